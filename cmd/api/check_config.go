@@ -0,0 +1,24 @@
+package main
+
+import (
+	"github.com/larissamartinsss/simple-banking-api/infra/database"
+)
+
+// checkConfig validates the configuration without starting the server, for deploy pre-checks
+// With connect it also opens the database, which creates the file when it does not exist yet
+func checkConfig(config Config, connect bool) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if !connect {
+		return nil
+	}
+
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: config.DatabasePath,
+	})
+	if err != nil {
+		return err
+	}
+	return db.Close()
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckConfig(t *testing.T) {
+	t.Run("valid configuration", func(t *testing.T) {
+		config := validConfig(t)
+
+		require.NoError(t, checkConfig(config, false))
+
+		_, err := os.Stat(config.DatabasePath)
+		assert.True(t, os.IsNotExist(err), "The database is not touched without connect")
+	})
+
+	t.Run("valid configuration with database", func(t *testing.T) {
+		config := validConfig(t)
+
+		require.NoError(t, checkConfig(config, true))
+
+		_, err := os.Stat(config.DatabasePath)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		config := validConfig(t)
+		config.ServerAddress = "localhost"
+
+		assert.ErrorContains(t, checkConfig(config, true), "server address")
+	})
+
+	t.Run("database path that cannot be created", func(t *testing.T) {
+		config := validConfig(t)
+		blocker := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(blocker, nil, 0o644))
+		config.DatabasePath = filepath.Join(blocker, "banking.db")
+
+		assert.Error(t, checkConfig(config, true))
+	})
+}
@@ -1,13 +1,116 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"net"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
 )
 
+// maxAllowedPageSize is the upper bound accepted for the configured page size
+const maxAllowedPageSize = 1000
+
+// minExportSigningSecretLength is the shortest export signing secret accepted, the size of an HMAC-SHA256 key
+const minExportSigningSecretLength = 32
+
 // Config holds application configuration
 type Config struct {
 	ServerAddress string
 	DatabasePath  string
+
+	// HTTP server timeouts; ShutdownTimeout is how long in-flight requests may finish on shutdown
+	ServerReadTimeout  time.Duration
+	ServerWriteTimeout time.Duration
+	ServerIdleTimeout  time.Duration
+	ShutdownTimeout    time.Duration
+
+	// Pagination bounds for list endpoints
+	DefaultPageSize int64
+	MaxPageSize     int64
+
+	// LargePageWarningThreshold adds a Warning header to pages with more items than this (0 disables)
+	LargePageWarningThreshold int64
+
+	// WALCheckpointInterval truncates the SQLite write-ahead log on this schedule (0 disables)
+	WALCheckpointInterval time.Duration
+
+	// WALSizeWarningBytes reports readiness as degraded while the write-ahead log is larger (0 disables)
+	WALSizeWarningBytes int64
+
+	// MaxQueryLength caps the raw URL query string in bytes
+	MaxQueryLength int64
+
+	// MaxResponseBytes caps the JSON response bodies; a larger one is replaced by a 500 (0 disables)
+	MaxResponseBytes int64
+
+	// IdempotencyTTL replays successful responses for an Idempotency-Key during this period (0 keeps them forever)
+	IdempotencyTTL time.Duration
+
+	// IdempotencyFailureGracePeriod replays server errors for an Idempotency-Key during this period (0 disables)
+	IdempotencyFailureGracePeriod time.Duration
+
+	// IdempotencyReuseWindow rejects an Idempotency-Key first used longer ago than this with 409 (0 disables)
+	IdempotencyReuseWindow time.Duration
+
+	// DuplicateTransactionWindow rejects identical transactions created within this window unless forced (0 disables)
+	DuplicateTransactionWindow time.Duration
+
+	// Installment limits for purchases with installments (a zero minimum amount disables that check)
+	MaxInstallments      int64
+	MinInstallmentAmount float64
+
+	// ReverificationAfterDays blocks transactions on never verified accounts older than this many days (0 disables)
+	ReverificationAfterDays int64
+
+	// EnforceCreditLimit rejects debits that would take the balance below the account's credit limit
+	EnforceCreditLimit bool
+
+	// DefaultCreditLimit is the credit limit every new account gets
+	DefaultCreditLimit float64
+
+	// MaxRowsPerRequest caps the transactions (installments included) a single request may create
+	MaxRowsPerRequest int64
+
+	// MaxConcurrentBatches caps the batch requests (imports) served at once; more get 429
+	MaxConcurrentBatches int64
+
+	// RateLimitRPS allows each client IP this many requests per second; more get 429 (0 disables)
+	RateLimitRPS int64
+
+	// RateLimitBurst is the number of requests a client IP may send at once (0 uses RateLimitRPS)
+	RateLimitBurst int64
+
+	// AdminToken protects the admin endpoints (empty disables them)
+	AdminToken string
+
+	// ExportSigningSecret signs transaction exports with HMAC-SHA256 so recipients can verify them (empty disables)
+	ExportSigningSecret string
+
+	// TierPermissions maps account tiers to allowed operation types, e.g. "basic:1,2,4;premium:1,2,3,4"
+	TierPermissions string
+
+	// DocumentNumberPrefixes restricts account creation to document numbers with one of these comma-separated
+	// prefixes, e.g. "000,999" for sandbox test ranges (empty allows every document number)
+	DocumentNumberPrefixes string
+
+	// SandboxMode marks a test environment; sandbox-only features such as magic amounts stay off without it
+	SandboxMode bool
+
+	// SandboxMagicAmounts maps amounts to simulated transaction outcomes, e.g. "13.37:decline,66.60:unavailable"
+	// Only applied in SandboxMode
+	SandboxMagicAmounts string
+
+	// Locale selects the language of the seeded operation type descriptions ("en" or "pt")
+	Locale string
+
+	// envProblems are the environment variables LoadConfig could not parse, reported by Validate
+	envProblems []error
 }
 
 // LoadConfig loads configuration from environment variables with defaults
@@ -22,8 +125,410 @@ func LoadConfig() Config {
 		databasePath = "./data/banking.db"
 	}
 
-	return Config{
-		ServerAddress: serverAddress,
-		DatabasePath:  databasePath,
+	locale := os.Getenv("LOCALE")
+	if locale == "" {
+		locale = domain.DefaultLocale
+	}
+
+	env := &envReader{}
+	config := Config{
+		ServerAddress:       serverAddress,
+		DatabasePath:        databasePath,
+		ServerReadTimeout:   env.Duration("SERVER_READ_TIMEOUT", 15*time.Second),
+		ServerWriteTimeout:  env.Duration("SERVER_WRITE_TIMEOUT", 15*time.Second),
+		ServerIdleTimeout:   env.Duration("SERVER_IDLE_TIMEOUT", 60*time.Second),
+		ShutdownTimeout:     env.Duration("SHUTDOWN_TIMEOUT", 30*time.Second),
+		DefaultPageSize:     env.Int64("DEFAULT_PAGE_SIZE", domain.DefaultPageSize),
+		MaxPageSize:         env.Int64("MAX_PAGE_SIZE", domain.MaxPageSize),
+		MaxQueryLength:      env.Int64("MAX_QUERY_LENGTH", 8*1024),
+		MaxInstallments:     env.Int64("MAX_INSTALLMENTS", domain.DefaultMaxInstallments),
+		MaxRowsPerRequest:   env.Int64("MAX_ROWS_PER_REQUEST", domain.DefaultMaxRowsPerRequest),
+		AdminToken:          os.Getenv("ADMIN_TOKEN"),
+		ExportSigningSecret: os.Getenv("EXPORT_SIGNING_SECRET"),
+		TierPermissions:     os.Getenv("TIER_PERMISSIONS"),
+		Locale:              locale,
+
+		IdempotencyFailureGracePeriod: env.Duration("IDEMPOTENCY_FAILURE_GRACE_PERIOD", 0),
+		DuplicateTransactionWindow:    env.Duration("DUPLICATE_TRANSACTION_WINDOW", 0),
+		MinInstallmentAmount:          env.Float64("MIN_INSTALLMENT_AMOUNT", 0),
+		LargePageWarningThreshold:     env.Int64("LARGE_PAGE_WARNING_THRESHOLD", 0),
+		ReverificationAfterDays:       env.Int64("REVERIFICATION_AFTER_DAYS", 0),
+		MaxConcurrentBatches:          env.Int64("MAX_CONCURRENT_BATCHES", 1),
+		WALCheckpointInterval:         env.Duration("WAL_CHECKPOINT_INTERVAL", 5*time.Minute),
+		WALSizeWarningBytes:           env.Int64("WAL_SIZE_WARNING_BYTES", 64*1024*1024),
+		IdempotencyTTL:                env.Duration("IDEMPOTENCY_TTL", 24*time.Hour),
+		DocumentNumberPrefixes:        os.Getenv("DOCUMENT_NUMBER_PREFIXES"),
+		EnforceCreditLimit:            env.Bool("ENFORCE_CREDIT_LIMIT", false),
+		DefaultCreditLimit:            env.Float64("DEFAULT_CREDIT_LIMIT", 0),
+		IdempotencyReuseWindow:        env.Duration("IDEMPOTENCY_REUSE_WINDOW", 0),
+		MaxResponseBytes:              env.Int64("MAX_RESPONSE_BYTES", 10*1024*1024),
+		RateLimitRPS:                  env.Int64("RATE_LIMIT_RPS", 50),
+		RateLimitBurst:                env.Int64("RATE_LIMIT_BURST", 100),
+		SandboxMode:                   env.Bool("SANDBOX_MODE", false),
+		SandboxMagicAmounts:           os.Getenv("SANDBOX_MAGIC_AMOUNTS"),
+	}
+	config.envProblems = env.problems
+
+	return config
+}
+
+// Validate checks the whole configuration and reports every problem found at once
+func (c Config) Validate() error {
+	// A value that cannot be parsed would otherwise run with the default unnoticed
+	problems := append([]error(nil), c.envProblems...)
+
+	if err := validateServerAddress(c.ServerAddress); err != nil {
+		problems = append(problems, err)
+	}
+
+	if err := validateDatabasePath(c.DatabasePath); err != nil {
+		problems = append(problems, err)
+	}
+
+	timeouts := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"server read timeout", c.ServerReadTimeout},
+		{"server write timeout", c.ServerWriteTimeout},
+		{"server idle timeout", c.ServerIdleTimeout},
+		{"shutdown timeout", c.ShutdownTimeout},
+	}
+	for _, timeout := range timeouts {
+		if timeout.value <= 0 {
+			problems = append(problems, fmt.Errorf("%s must be positive, got %s", timeout.name, timeout.value))
+		}
+	}
+
+	if c.DefaultPageSize <= 0 {
+		problems = append(problems, fmt.Errorf("default page size must be positive, got %d", c.DefaultPageSize))
+	}
+	if c.MaxPageSize < c.DefaultPageSize {
+		problems = append(problems, fmt.Errorf("max page size (%d) must not be lower than default page size (%d)", c.MaxPageSize, c.DefaultPageSize))
+	}
+	if c.MaxPageSize > maxAllowedPageSize {
+		problems = append(problems, fmt.Errorf("max page size must not exceed %d, got %d", maxAllowedPageSize, c.MaxPageSize))
+	}
+
+	if c.LargePageWarningThreshold < 0 {
+		problems = append(problems, fmt.Errorf("large page warning threshold must not be negative, got %d", c.LargePageWarningThreshold))
+	}
+
+	if c.WALCheckpointInterval < 0 {
+		problems = append(problems, fmt.Errorf("WAL checkpoint interval must not be negative, got %s", c.WALCheckpointInterval))
+	}
+
+	if c.IdempotencyTTL < 0 {
+		problems = append(problems, fmt.Errorf("idempotency TTL must not be negative, got %s", c.IdempotencyTTL))
+	}
+	if c.IdempotencyFailureGracePeriod < 0 {
+		problems = append(problems, fmt.Errorf("idempotency failure grace period must not be negative, got %s", c.IdempotencyFailureGracePeriod))
+	}
+	if c.IdempotencyReuseWindow < 0 {
+		problems = append(problems, fmt.Errorf("idempotency reuse window must not be negative, got %s", c.IdempotencyReuseWindow))
+	}
+	// Keys are deleted once the TTL passes, so a longer window would never get to reject them
+	if c.IdempotencyTTL > 0 && c.IdempotencyReuseWindow > c.IdempotencyTTL {
+		problems = append(problems, fmt.Errorf("idempotency reuse window must not exceed the idempotency TTL %s, got %s", c.IdempotencyTTL, c.IdempotencyReuseWindow))
+	}
+
+	if c.MaxResponseBytes < 0 {
+		problems = append(problems, fmt.Errorf("max response bytes must not be negative, got %d", c.MaxResponseBytes))
+	}
+
+	if c.DuplicateTransactionWindow < 0 {
+		problems = append(problems, fmt.Errorf("duplicate transaction window must not be negative, got %s", c.DuplicateTransactionWindow))
+	}
+
+	if c.MaxInstallments < 1 || c.MaxInstallments > domain.MaxInstallmentsCeiling {
+		problems = append(problems, fmt.Errorf("max installments must be between 1 and %d, got %d", domain.MaxInstallmentsCeiling, c.MaxInstallments))
+	}
+	if c.MaxRowsPerRequest < c.MaxInstallments {
+		problems = append(problems, fmt.Errorf("max rows per request (%d) must not be lower than max installments (%d)", c.MaxRowsPerRequest, c.MaxInstallments))
+	}
+	if c.MinInstallmentAmount < 0 {
+		problems = append(problems, fmt.Errorf("min installment amount must not be negative, got %g", c.MinInstallmentAmount))
+	}
+
+	if c.DefaultCreditLimit < 0 {
+		problems = append(problems, fmt.Errorf("default credit limit must not be negative, got %g", c.DefaultCreditLimit))
+	}
+
+	if c.ReverificationAfterDays < 0 {
+		problems = append(problems, fmt.Errorf("reverification after days must not be negative, got %d", c.ReverificationAfterDays))
+	}
+
+	if c.MaxQueryLength <= 0 {
+		problems = append(problems, fmt.Errorf("max query length must be positive, got %d", c.MaxQueryLength))
+	}
+
+	if c.WALSizeWarningBytes < 0 {
+		problems = append(problems, fmt.Errorf("WAL size warning bytes must not be negative, got %d", c.WALSizeWarningBytes))
+	}
+
+	if c.MaxConcurrentBatches <= 0 {
+		problems = append(problems, fmt.Errorf("max concurrent batches must be positive, got %d", c.MaxConcurrentBatches))
+	}
+
+	if c.ExportSigningSecret != "" && len(c.ExportSigningSecret) < minExportSigningSecretLength {
+		problems = append(problems, fmt.Errorf("export signing secret must be at least %d bytes, got %d", minExportSigningSecretLength, len(c.ExportSigningSecret)))
+	}
+
+	if c.RateLimitRPS < 0 {
+		problems = append(problems, fmt.Errorf("rate limit RPS must not be negative, got %d", c.RateLimitRPS))
+	}
+	if c.RateLimitBurst < 0 {
+		problems = append(problems, fmt.Errorf("rate limit burst must not be negative, got %d", c.RateLimitBurst))
+	}
+
+	if _, err := c.OperationPermissions(); err != nil {
+		problems = append(problems, err)
 	}
+
+	if _, err := c.DocumentNumberAllowlist(); err != nil {
+		problems = append(problems, err)
+	}
+
+	if _, err := c.MagicAmounts(); err != nil {
+		problems = append(problems, err)
+	}
+
+	if _, err := domain.OperationTypes(c.Locale); err != nil {
+		problems = append(problems, fmt.Errorf("locale %q is not supported (use %q or %q)", c.Locale, domain.LocaleEnglish, domain.LocalePortuguese))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(problems...))
+	}
+
+	return nil
+}
+
+// Pagination returns the page size limits for list endpoints
+func (c Config) Pagination() domain.PaginationDefaults {
+	return domain.PaginationDefaults{
+		DefaultLimit: c.DefaultPageSize,
+		MaxLimit:     c.MaxPageSize,
+		WarnAbove:    c.LargePageWarningThreshold,
+	}
+}
+
+// InstallmentPolicy returns the installment limits for purchases with installments
+func (c Config) InstallmentPolicy() domain.InstallmentPolicy {
+	return domain.InstallmentPolicy{
+		MaxInstallments:      c.MaxInstallments,
+		MinInstallmentAmount: c.MinInstallmentAmount,
+	}
+}
+
+// ReverificationPolicy returns the age after which never verified accounts may no longer transact
+func (c Config) ReverificationPolicy() domain.ReverificationPolicy {
+	return domain.ReverificationPolicy{
+		MaxUnverifiedAge: time.Duration(c.ReverificationAfterDays) * 24 * time.Hour,
+	}
+}
+
+// OperationPermissions parses TierPermissions into the per-tier allowed operation types
+// Tiers that are not listed keep access to every operation type
+func (c Config) OperationPermissions() (domain.OperationPermissions, error) {
+	permissions := domain.OperationPermissions{}
+	if strings.TrimSpace(c.TierPermissions) == "" {
+		return permissions, nil
+	}
+
+	for _, entry := range strings.Split(c.TierPermissions, ";") {
+		tier, ids, found := strings.Cut(strings.TrimSpace(entry), ":")
+		tier = strings.TrimSpace(tier)
+		if !found || tier == "" {
+			return nil, fmt.Errorf("tier permissions entry %q must have the form tier:id,id", entry)
+		}
+
+		allowed := []int64{}
+		for _, rawID := range strings.Split(ids, ",") {
+			rawID = strings.TrimSpace(rawID)
+			if rawID == "" {
+				continue
+			}
+			id, err := strconv.ParseInt(rawID, 10, 64)
+			if err != nil || id <= 0 {
+				return nil, fmt.Errorf("tier permissions for %q has invalid operation type %q", tier, rawID)
+			}
+			allowed = append(allowed, id)
+		}
+		permissions[tier] = allowed
+	}
+
+	return permissions, nil
+}
+
+// MagicAmounts parses SandboxMagicAmounts into the amounts that trigger simulated outcomes
+// Outside SandboxMode none are returned, and configuring some is an error so they are never silently expected
+func (c Config) MagicAmounts() (domain.MagicAmounts, error) {
+	if strings.TrimSpace(c.SandboxMagicAmounts) == "" {
+		return nil, nil
+	}
+	if !c.SandboxMode {
+		return nil, errors.New("sandbox magic amounts require SANDBOX_MODE to be enabled")
+	}
+
+	amounts := domain.MagicAmounts{}
+	for _, entry := range strings.Split(c.SandboxMagicAmounts, ",") {
+		rawAmount, name, found := strings.Cut(strings.TrimSpace(entry), ":")
+		if !found {
+			return nil, fmt.Errorf("sandbox magic amount entry %q must have the form amount:outcome", entry)
+		}
+
+		amount, err := strconv.ParseFloat(strings.TrimSpace(rawAmount), 64)
+		if err != nil || amount <= 0 || domain.ValidateAmountPrecision(amount) != nil {
+			return nil, fmt.Errorf("sandbox magic amount %q must be a positive amount with at most two decimal places", rawAmount)
+		}
+
+		outcome, ok := domain.SimulatedOutcomes[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("sandbox magic amount %q has unknown outcome %q (use one of %s)", rawAmount, name, strings.Join(domain.SimulatedOutcomeNames(), ", "))
+		}
+		amounts.Add(amount, outcome)
+	}
+
+	return amounts, nil
+}
+
+// validateServerAddress checks the address has the host:port form with a valid port
+func validateServerAddress(address string) error {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("server address %q is not valid: %w", address, err)
+	}
+
+	portNumber, err := strconv.Atoi(port)
+	if err != nil || portNumber < 0 || portNumber > 65535 {
+		return fmt.Errorf("server address %q has an invalid port", address)
+	}
+
+	return nil
+}
+
+// validateDatabasePath checks the database directory (or its nearest existing parent) is writable
+func validateDatabasePath(path string) error {
+	if path == "" {
+		return errors.New("database path is required")
+	}
+
+	dir := filepath.Dir(path)
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("database directory %q is not a directory", dir)
+			}
+			break
+		}
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("database directory %q is not accessible: %w", dir, err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return fmt.Errorf("database directory %q has no existing parent", filepath.Dir(path))
+		}
+		dir = parent
+	}
+
+	probe, err := os.CreateTemp(dir, ".write-check-*")
+	if err != nil {
+		return fmt.Errorf("database directory %q is not writable: %w", dir, err)
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	return nil
+}
+
+// envReader reads typed environment variables, falling back to the default when unset
+// A value that cannot be parsed also falls back, and is recorded in problems for Validate to report
+type envReader struct {
+	problems []error
+}
+
+// Int64 reads an integer environment variable
+func (e *envReader) Int64(key string, defaultValue int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		e.problems = append(e.problems, fmt.Errorf("%s must be an integer, got %q", key, value))
+		return defaultValue
+	}
+
+	return parsed
+}
+
+// Float64 reads a decimal environment variable
+func (e *envReader) Float64(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		e.problems = append(e.problems, fmt.Errorf("%s must be a number, got %q", key, value))
+		return defaultValue
+	}
+
+	return parsed
+}
+
+// Bool reads a boolean environment variable (e.g. "true", "1")
+func (e *envReader) Bool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		e.problems = append(e.problems, fmt.Errorf("%s must be a boolean (true or false), got %q", key, value))
+		return defaultValue
+	}
+
+	return parsed
+}
+
+// Duration reads a duration environment variable (e.g. "5s")
+func (e *envReader) Duration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		e.problems = append(e.problems, fmt.Errorf("%s must be a duration such as 30s or 5m, got %q", key, value))
+		return defaultValue
+	}
+
+	return parsed
+}
+
+// DocumentNumberAllowlist parses DocumentNumberPrefixes into the prefixes accepted for new accounts
+// Every prefix must be digits only, since no document number could start with anything else
+func (c Config) DocumentNumberAllowlist() (domain.DocumentNumberAllowlist, error) {
+	if strings.TrimSpace(c.DocumentNumberPrefixes) == "" {
+		return nil, nil
+	}
+
+	var allowlist domain.DocumentNumberAllowlist
+	for _, raw := range strings.Split(c.DocumentNumberPrefixes, ",") {
+		prefix := strings.TrimSpace(raw)
+		if prefix == "" || strings.Trim(prefix, "0123456789") != "" {
+			return nil, fmt.Errorf("document number prefix %q must contain only digits", raw)
+		}
+		allowlist = append(allowlist, prefix)
+	}
+
+	return allowlist, nil
 }
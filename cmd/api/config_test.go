@@ -0,0 +1,509 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validConfig(t *testing.T) Config {
+	return Config{
+		ServerAddress:      ":8080",
+		DatabasePath:       filepath.Join(t.TempDir(), "data", "banking.db"),
+		ServerReadTimeout:  15 * time.Second,
+		ServerWriteTimeout: 15 * time.Second,
+		ServerIdleTimeout:  60 * time.Second,
+		ShutdownTimeout:    30 * time.Second,
+		DefaultPageSize:    50,
+		MaxPageSize:        100,
+		MaxQueryLength:     8192,
+		MaxInstallments:    12,
+		MaxRowsPerRequest:  500,
+		Locale:             "en",
+
+		MaxConcurrentBatches: 1,
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		modify       func(*testing.T, *Config)
+		wantErr      bool
+		wantProblems []string
+	}{
+		{
+			name:    "valid configuration",
+			modify:  func(t *testing.T, c *Config) {},
+			wantErr: false,
+		},
+		{
+			name: "invalid server address",
+			modify: func(t *testing.T, c *Config) {
+				c.ServerAddress = "localhost"
+			},
+			wantErr:      true,
+			wantProblems: []string{`server address "localhost" is not valid`},
+		},
+		{
+			name: "invalid server port",
+			modify: func(t *testing.T, c *Config) {
+				c.ServerAddress = ":99999"
+			},
+			wantErr:      true,
+			wantProblems: []string{`server address ":99999" has an invalid port`},
+		},
+		{
+			name: "database directory is a file",
+			modify: func(t *testing.T, c *Config) {
+				file := filepath.Join(t.TempDir(), "not-a-dir")
+				require.NoError(t, os.WriteFile(file, []byte("x"), 0644))
+				c.DatabasePath = filepath.Join(file, "banking.db")
+			},
+			wantErr:      true,
+			wantProblems: []string{"is not a directory"},
+		},
+		{
+			name: "multiple problems are aggregated",
+			modify: func(t *testing.T, c *Config) {
+				c.ServerAddress = "no-port"
+				c.ServerReadTimeout = 0
+				c.ShutdownTimeout = -time.Second
+				c.DefaultPageSize = 200
+				c.MaxPageSize = 5000
+			},
+			wantErr: true,
+			wantProblems: []string{
+				`server address "no-port" is not valid`,
+				"server read timeout must be positive, got 0s",
+				"shutdown timeout must be positive, got -1s",
+				"max page size must not exceed 1000, got 5000",
+			},
+		},
+		{
+			name: "malformed tier permissions",
+			modify: func(t *testing.T, c *Config) {
+				c.TierPermissions = "basic:1,0"
+			},
+			wantErr:      true,
+			wantProblems: []string{`tier permissions for "basic" has invalid operation type "0"`},
+		},
+		{
+			name: "non-numeric document number prefix",
+			modify: func(t *testing.T, c *Config) {
+				c.DocumentNumberPrefixes = "000,9x"
+			},
+			wantErr:      true,
+			wantProblems: []string{`document number prefix "9x" must contain only digits`},
+		},
+		{
+			name: "negative idempotency TTL",
+			modify: func(t *testing.T, c *Config) {
+				c.IdempotencyTTL = -time.Hour
+			},
+			wantErr:      true,
+			wantProblems: []string{"idempotency TTL must not be negative, got -1h0m0s"},
+		},
+		{
+			name: "negative idempotency failure grace period",
+			modify: func(t *testing.T, c *Config) {
+				c.IdempotencyFailureGracePeriod = -time.Second
+			},
+			wantErr:      true,
+			wantProblems: []string{"idempotency failure grace period must not be negative, got -1s"},
+		},
+		{
+			name: "negative idempotency reuse window",
+			modify: func(t *testing.T, c *Config) {
+				c.IdempotencyReuseWindow = -time.Minute
+			},
+			wantErr:      true,
+			wantProblems: []string{"idempotency reuse window must not be negative, got -1m0s"},
+		},
+		{
+			name: "idempotency reuse window longer than the TTL",
+			modify: func(t *testing.T, c *Config) {
+				c.IdempotencyTTL = time.Hour
+				c.IdempotencyReuseWindow = 2 * time.Hour
+			},
+			wantErr:      true,
+			wantProblems: []string{"idempotency reuse window must not exceed the idempotency TTL 1h0m0s, got 2h0m0s"},
+		},
+		{
+			name: "idempotency reuse window as long as the TTL",
+			modify: func(t *testing.T, c *Config) {
+				c.IdempotencyTTL = time.Hour
+				c.IdempotencyReuseWindow = time.Hour
+			},
+		},
+		{
+			name: "idempotency reuse window with keys kept forever",
+			modify: func(t *testing.T, c *Config) {
+				c.IdempotencyTTL = 0
+				c.IdempotencyReuseWindow = 2 * time.Hour
+			},
+		},
+		{
+			name: "negative max response bytes",
+			modify: func(t *testing.T, c *Config) {
+				c.MaxResponseBytes = -1
+			},
+			wantErr:      true,
+			wantProblems: []string{"max response bytes must not be negative, got -1"},
+		},
+		{
+			name: "magic amounts outside sandbox mode",
+			modify: func(t *testing.T, c *Config) {
+				c.SandboxMagicAmounts = "13.37:decline"
+			},
+			wantErr:      true,
+			wantProblems: []string{"sandbox magic amounts require SANDBOX_MODE to be enabled"},
+		},
+		{
+			name: "magic amount with unknown outcome",
+			modify: func(t *testing.T, c *Config) {
+				c.SandboxMode = true
+				c.SandboxMagicAmounts = "13.37:explode"
+			},
+			wantErr:      true,
+			wantProblems: []string{`sandbox magic amount "13.37" has unknown outcome "explode" (use one of balance_changed, decline, insufficient_funds, unavailable)`},
+		},
+		{
+			name: "magic amount with too many decimals",
+			modify: func(t *testing.T, c *Config) {
+				c.SandboxMode = true
+				c.SandboxMagicAmounts = "13.375:decline"
+			},
+			wantErr:      true,
+			wantProblems: []string{`sandbox magic amount "13.375" must be a positive amount with at most two decimal places`},
+		},
+		{
+			name: "short export signing secret",
+			modify: func(t *testing.T, c *Config) {
+				c.ExportSigningSecret = "too-short"
+			},
+			wantErr:      true,
+			wantProblems: []string{"export signing secret must be at least 32 bytes, got 9"},
+		},
+		{
+			name: "negative rate limit",
+			modify: func(t *testing.T, c *Config) {
+				c.RateLimitRPS = -1
+				c.RateLimitBurst = -5
+			},
+			wantErr: true,
+			wantProblems: []string{
+				"rate limit RPS must not be negative, got -1",
+				"rate limit burst must not be negative, got -5",
+			},
+		},
+		{
+			name: "max installments below one",
+			modify: func(t *testing.T, c *Config) {
+				c.MaxInstallments = 0
+			},
+			wantErr:      true,
+			wantProblems: []string{"max installments must be between 1 and 48, got 0"},
+		},
+		{
+			name: "max installments above the ceiling",
+			modify: func(t *testing.T, c *Config) {
+				c.MaxInstallments = 60
+				c.MaxRowsPerRequest = 500
+			},
+			wantErr:      true,
+			wantProblems: []string{"max installments must be between 1 and 48, got 60"},
+		},
+		{
+			name: "max rows per request below max installments",
+			modify: func(t *testing.T, c *Config) {
+				c.MaxRowsPerRequest = 6
+			},
+			wantErr:      true,
+			wantProblems: []string{"max rows per request (6) must not be lower than max installments (12)"},
+		},
+		{
+			name: "negative min installment amount",
+			modify: func(t *testing.T, c *Config) {
+				c.MinInstallmentAmount = -1.5
+			},
+			wantErr:      true,
+			wantProblems: []string{"min installment amount must not be negative, got -1.5"},
+		},
+		{
+			name: "negative default credit limit",
+			modify: func(t *testing.T, c *Config) {
+				c.DefaultCreditLimit = -100
+			},
+			wantErr:      true,
+			wantProblems: []string{"default credit limit must not be negative, got -100"},
+		},
+		{
+			name: "negative duplicate transaction window",
+			modify: func(t *testing.T, c *Config) {
+				c.DuplicateTransactionWindow = -time.Second
+			},
+			wantErr:      true,
+			wantProblems: []string{"duplicate transaction window must not be negative, got -1s"},
+		},
+		{
+			name: "non-positive max query length",
+			modify: func(t *testing.T, c *Config) {
+				c.MaxQueryLength = 0
+			},
+			wantErr:      true,
+			wantProblems: []string{"max query length must be positive, got 0"},
+		},
+		{
+			name: "negative WAL size warning",
+			modify: func(t *testing.T, c *Config) {
+				c.WALSizeWarningBytes = -1
+			},
+			wantErr:      true,
+			wantProblems: []string{"WAL size warning bytes must not be negative, got -1"},
+		},
+		{
+			name: "non-positive max concurrent batches",
+			modify: func(t *testing.T, c *Config) {
+				c.MaxConcurrentBatches = 0
+			},
+			wantErr:      true,
+			wantProblems: []string{"max concurrent batches must be positive, got 0"},
+		},
+		{
+			name: "unsupported locale",
+			modify: func(t *testing.T, c *Config) {
+				c.Locale = "fr"
+			},
+			wantErr:      true,
+			wantProblems: []string{`locale "fr" is not supported`},
+		},
+		{
+			name: "max page size lower than default",
+			modify: func(t *testing.T, c *Config) {
+				c.DefaultPageSize = 50
+				c.MaxPageSize = 10
+			},
+			wantErr:      true,
+			wantProblems: []string{"max page size (10) must not be lower than default page size (50)"},
+		},
+		{
+			name: "negative WAL checkpoint interval",
+			modify: func(t *testing.T, c *Config) {
+				c.WALCheckpointInterval = -time.Minute
+			},
+			wantErr:      true,
+			wantProblems: []string{"WAL checkpoint interval must not be negative, got -1m0s"},
+		},
+		{
+			name: "negative large page warning threshold",
+			modify: func(t *testing.T, c *Config) {
+				c.LargePageWarningThreshold = -1
+			},
+			wantErr:      true,
+			wantProblems: []string{"large page warning threshold must not be negative, got -1"},
+		},
+		{
+			name: "negative reverification age",
+			modify: func(t *testing.T, c *Config) {
+				c.ReverificationAfterDays = -1
+			},
+			wantErr:      true,
+			wantProblems: []string{"reverification after days must not be negative, got -1"},
+		},
+		{
+			name: "non-positive default page size",
+			modify: func(t *testing.T, c *Config) {
+				c.DefaultPageSize = 0
+			},
+			wantErr:      true,
+			wantProblems: []string{"default page size must be positive, got 0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validConfig(t)
+			tt.modify(t, &config)
+
+			err := config.Validate()
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid configuration")
+			for _, problem := range tt.wantProblems {
+				assert.Contains(t, err.Error(), problem)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	t.Setenv("SERVER_ADDRESS", "")
+	t.Setenv("DATABASE_PATH", "")
+	t.Setenv("DEFAULT_PAGE_SIZE", "")
+	t.Setenv("MAX_PAGE_SIZE", "")
+	t.Setenv("LARGE_PAGE_WARNING_THRESHOLD", "")
+	t.Setenv("WAL_CHECKPOINT_INTERVAL", "")
+	t.Setenv("LOCALE", "")
+	t.Setenv("MAX_QUERY_LENGTH", "")
+	t.Setenv("IDEMPOTENCY_FAILURE_GRACE_PERIOD", "")
+	t.Setenv("DUPLICATE_TRANSACTION_WINDOW", "")
+	t.Setenv("MAX_INSTALLMENTS", "")
+	t.Setenv("MAX_ROWS_PER_REQUEST", "")
+	t.Setenv("MIN_INSTALLMENT_AMOUNT", "")
+	t.Setenv("REVERIFICATION_AFTER_DAYS", "")
+	t.Setenv("MAX_CONCURRENT_BATCHES", "")
+	t.Setenv("WAL_SIZE_WARNING_BYTES", "")
+	t.Setenv("IDEMPOTENCY_TTL", "")
+	t.Setenv("IDEMPOTENCY_REUSE_WINDOW", "")
+	t.Setenv("MAX_RESPONSE_BYTES", "")
+	t.Setenv("RATE_LIMIT_RPS", "")
+	t.Setenv("RATE_LIMIT_BURST", "")
+	t.Setenv("SANDBOX_MODE", "")
+	t.Setenv("EXPORT_SIGNING_SECRET", "")
+	t.Setenv("SANDBOX_MAGIC_AMOUNTS", "")
+	t.Setenv("DOCUMENT_NUMBER_PREFIXES", "")
+	t.Setenv("ENFORCE_CREDIT_LIMIT", "")
+	t.Setenv("SERVER_READ_TIMEOUT", "")
+	t.Setenv("SERVER_WRITE_TIMEOUT", "")
+	t.Setenv("SERVER_IDLE_TIMEOUT", "")
+	t.Setenv("SHUTDOWN_TIMEOUT", "")
+
+	config := LoadConfig()
+
+	assert.Equal(t, ":8080", config.ServerAddress)
+	assert.Equal(t, "./data/banking.db", config.DatabasePath)
+	assert.Equal(t, 15*time.Second, config.ServerReadTimeout)
+	assert.Equal(t, 15*time.Second, config.ServerWriteTimeout)
+	assert.Equal(t, 60*time.Second, config.ServerIdleTimeout)
+	assert.Equal(t, 30*time.Second, config.ShutdownTimeout)
+	assert.Equal(t, int64(50), config.DefaultPageSize)
+	assert.Equal(t, int64(100), config.MaxPageSize)
+	assert.Zero(t, config.LargePageWarningThreshold, "Large page warning is disabled by default")
+	assert.Equal(t, 5*time.Minute, config.WALCheckpointInterval)
+	assert.Equal(t, int64(64*1024*1024), config.WALSizeWarningBytes)
+	assert.Equal(t, int64(8192), config.MaxQueryLength)
+	assert.Equal(t, int64(10*1024*1024), config.MaxResponseBytes)
+	assert.Equal(t, int64(50), config.RateLimitRPS)
+	assert.Equal(t, int64(100), config.RateLimitBurst)
+	assert.False(t, config.SandboxMode, "Sandbox mode is off by default")
+	assert.Empty(t, config.SandboxMagicAmounts)
+	assert.Empty(t, config.ExportSigningSecret, "Exports are unsigned by default")
+	assert.Equal(t, 24*time.Hour, config.IdempotencyTTL)
+	assert.Zero(t, config.IdempotencyFailureGracePeriod, "Failure grace period is disabled by default")
+	assert.Zero(t, config.IdempotencyReuseWindow, "Reuse window is disabled by default")
+	assert.Zero(t, config.DuplicateTransactionWindow, "Duplicate check is disabled by default")
+	assert.Equal(t, int64(12), config.MaxInstallments)
+	assert.Equal(t, int64(500), config.MaxRowsPerRequest)
+	assert.Zero(t, config.MinInstallmentAmount, "No minimum installment amount by default")
+	assert.Zero(t, config.ReverificationAfterDays, "Reverification rule is disabled by default")
+	assert.Zero(t, config.ReverificationPolicy().MaxUnverifiedAge)
+	assert.Equal(t, int64(1), config.MaxConcurrentBatches, "One batch at a time by default")
+	assert.Equal(t, "en", config.Locale)
+	assert.Empty(t, config.DocumentNumberPrefixes, "Every document number is allowed by default")
+	assert.False(t, config.EnforceCreditLimit, "Credit limit is not enforced by default")
+	assert.Zero(t, config.DefaultCreditLimit, "New accounts have no credit by default")
+}
+
+func TestLoadConfig_ServerTimeouts(t *testing.T) {
+	t.Setenv("SERVER_READ_TIMEOUT", "2m")
+	t.Setenv("SERVER_WRITE_TIMEOUT", "90s")
+	t.Setenv("SERVER_IDLE_TIMEOUT", "5m30s")
+	t.Setenv("SHUTDOWN_TIMEOUT", "45s")
+
+	config := LoadConfig()
+
+	assert.Equal(t, 2*time.Minute, config.ServerReadTimeout)
+	assert.Equal(t, 90*time.Second, config.ServerWriteTimeout)
+	assert.Equal(t, 5*time.Minute+30*time.Second, config.ServerIdleTimeout)
+	assert.Equal(t, 45*time.Second, config.ShutdownTimeout)
+
+	// Values that are not durations fail validation instead of running with the defaults
+	t.Setenv("DATABASE_PATH", filepath.Join(t.TempDir(), "banking.db"))
+	t.Setenv("SERVER_READ_TIMEOUT", "30")
+	t.Setenv("SHUTDOWN_TIMEOUT", "soon")
+
+	config = LoadConfig()
+
+	err := config.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `SERVER_READ_TIMEOUT must be a duration such as 30s or 5m, got "30"`)
+	assert.Contains(t, err.Error(), `SHUTDOWN_TIMEOUT must be a duration such as 30s or 5m, got "soon"`)
+}
+
+func TestLoadConfig_UnparsableValuesFailValidation(t *testing.T) {
+	t.Setenv("DATABASE_PATH", filepath.Join(t.TempDir(), "banking.db"))
+	t.Setenv("MAX_PAGE_SIZE", "abc")
+	t.Setenv("RATE_LIMIT_RPS", "x")
+	t.Setenv("MIN_INSTALLMENT_AMOUNT", "ten")
+	t.Setenv("ENFORCE_CREDIT_LIMIT", "yes please")
+
+	config := LoadConfig()
+
+	// The defaults stand in until Validate stops startup
+	assert.Equal(t, int64(100), config.MaxPageSize)
+	assert.Equal(t, int64(50), config.RateLimitRPS)
+
+	err := config.Validate()
+	require.Error(t, err)
+	for _, problem := range []string{
+		`MAX_PAGE_SIZE must be an integer, got "abc"`,
+		`RATE_LIMIT_RPS must be an integer, got "x"`,
+		`MIN_INSTALLMENT_AMOUNT must be a number, got "ten"`,
+		`ENFORCE_CREDIT_LIMIT must be a boolean (true or false), got "yes please"`,
+	} {
+		assert.Contains(t, err.Error(), problem)
+	}
+}
+
+func TestConfig_OperationPermissions(t *testing.T) {
+	config := Config{TierPermissions: "basic: 1, 2, 4; premium:1,2,3,4"}
+
+	permissions, err := config.OperationPermissions()
+
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 4}, permissions["basic"])
+	assert.Equal(t, []int64{1, 2, 3, 4}, permissions["premium"])
+	assert.False(t, permissions.Allows("basic", 3))
+	assert.True(t, permissions.Allows("standard", 3), "Unlisted tiers allow everything")
+}
+
+func TestConfig_DocumentNumberAllowlist(t *testing.T) {
+	config := Config{DocumentNumberPrefixes: " 000, 999 "}
+
+	allowlist, err := config.DocumentNumberAllowlist()
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.DocumentNumberAllowlist{"000", "999"}, allowlist)
+	assert.True(t, allowlist.Allows("00012345678"))
+	assert.False(t, allowlist.Allows("12345678900"))
+
+	_, err = Config{DocumentNumberPrefixes: "000,,999"}.DocumentNumberAllowlist()
+	assert.Error(t, err, "Empty prefixes would allow everything")
+}
+
+func TestConfig_MagicAmounts(t *testing.T) {
+	config := Config{SandboxMode: true, SandboxMagicAmounts: " 13.37:decline, 66.60 : unavailable "}
+
+	amounts, err := config.MagicAmounts()
+
+	require.NoError(t, err)
+	assert.Len(t, amounts, 2)
+	assert.ErrorIs(t, amounts.Outcome(13.37), domain.ErrTransactionDeclined)
+	assert.ErrorIs(t, amounts.Outcome(66.6), domain.ErrServiceUnavailable)
+	assert.NoError(t, amounts.Outcome(10))
+
+	amounts, err = Config{}.MagicAmounts()
+	require.NoError(t, err)
+	assert.Empty(t, amounts, "Nothing is simulated unless configured")
+}
@@ -1,27 +1,47 @@
 package main
 
 import (
-	"log"
+	"flag"
+	"log/slog"
 	"os"
+
+	// Embed the IANA time zone database; the runtime image has none
+	_ "time/tzdata"
 )
 
 func main() {
+	checkConfigOnly := flag.Bool("check-config", false, "validate the configuration and exit with 0 when it is valid, 1 otherwise")
+	checkDatabase := flag.Bool("check-db", false, "with -check-config, also connect to the database")
+	flag.Parse()
+
+	// Structured JSON logs; as the default logger it also receives the standard log package's output
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	slog.SetDefault(logger)
+
 	// Load configuration
 	config := LoadConfig()
 
-	// Create logger
-	logger := log.New(os.Stdout, "[SIMPLE-BANKING-API] ", log.LstdFlags|log.Lshortfile)
-	logger.Println("Starting Simple Banking API...")
+	if *checkConfigOnly {
+		if err := checkConfig(config, *checkDatabase); err != nil {
+			logger.Error("Invalid configuration", "error", err)
+			os.Exit(1)
+		}
+		logger.Info("Configuration is valid")
+		return
+	}
+
+	logger.Info("Starting Simple Banking API")
 
 	// Initialize application
 	app, err := NewApplication(config)
 	if err != nil {
-		logger.Fatalf("Failed to initialize application: %v", err)
+		logger.Error("Failed to initialize application", "error", err)
+		os.Exit(1)
 	}
-	defer app.Shutdown()
 
-	// Start server (blocks until shutdown signal)
+	// Start server (blocks until shutdown signal, then drains requests and closes the database)
 	if err := app.Start(); err != nil {
-		logger.Fatalf("Server error: %v", err)
+		logger.Error("Server error", "error", err)
+		os.Exit(1)
 	}
 }
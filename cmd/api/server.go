@@ -3,36 +3,87 @@ package main
 import (
 	"context"
 	"database/sql"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
-	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/health"
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/metrics"
 	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/accounts"
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/balances"
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/idempotency"
 	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/operationtype"
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqltx"
 	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/transactions"
 
 	"github.com/larissamartinsss/simple-banking-api/infra/database"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
 	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
 	"github.com/larissamartinsss/simple-banking-api/internal/server"
 	"github.com/larissamartinsss/simple-banking-api/internal/server/handlers"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// availableEndpoints lists the routes logged at startup
+var availableEndpoints = []string{
+	"POST /v1/accounts",
+	"GET /v1/accounts?limit=&offset=",
+	"GET /v1/accounts/{accountId}",
+	"DELETE /v1/accounts/{accountId}",
+	"POST /v1/transactions",
+	"POST /v1/transactions/reverse-by-key",
+	"POST /v1/transactions/{transactionId}/reversal",
+	"POST /v1/transactions/import",
+	"GET /v1/accounts/{accountId}/transactions?from=&to=&operation_type_id=&order=&cursor=",
+	"GET /v1/accounts/{accountId}/transactions.jsonl",
+	"GET /v1/accounts/{accountId}/transactions.ofx",
+	"GET /v1/accounts/{accountId}/transactions/export",
+	"GET /v1/accounts/{accountId}/statement?start=&end=&tz=",
+	"GET /v1/accounts/{accountId}/statement?year=",
+	"GET /v1/accounts/{accountId}/daily?start=&end=&fill_gaps=",
+	"GET /v1/accounts/{accountId}/activity-range",
+	"GET /v1/accounts/{accountId}/trend?days=",
+	"GET /v1/accounts/{accountId}/balance?include=direction",
+	"GET /v1/accounts/{accountId}/balance?as_of=",
+	"GET /v1/accounts/{accountId}/can-debit?amount=",
+	"GET /v1/operation-types",
+	"GET /v1/operation-types/directions",
+	"GET /v1/admin/accounts/count",
+	"DELETE /v1/admin/accounts/{accountId}",
+	"GET /v1/admin/idempotency/{key}",
+	"GET /v1/admin/transactions?limit=&offset=",
+	"GET /v1/admin/transactions/recent",
+	"POST /v1/admin/recompute-balances",
+	"GET /health",
+	"GET /health/ready",
+	"GET /metrics",
+}
+
 // Application holds all application dependencies
 type Application struct {
 	config Config
-	logger *log.Logger
+	logger *slog.Logger
 	db     *sql.DB
 	server *server.Server
+
+	metricsRegistry *prometheus.Registry
 }
 
 // NewApplication creates and initializes a new application instance
 func NewApplication(config Config) (*Application, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
 	app := &Application{
 		config: config,
-		logger: log.New(os.Stdout, "[SIMPLE-BANKING-API] ", log.LstdFlags|log.Lshortfile),
+		logger: slog.Default(),
+
+		metricsRegistry: prometheus.NewRegistry(),
 	}
 
 	if err := app.initializeDatabase(); err != nil {
@@ -48,7 +99,7 @@ func NewApplication(config Config) (*Application, error) {
 
 // initializeDatabase connects to database, runs migrations and seeds data
 func (app *Application) initializeDatabase() error {
-	app.logger.Println("Connecting to database...")
+	app.logger.Info("Connecting to database")
 	db, err := database.NewConnection(database.Config{
 		DatabasePath: app.config.DatabasePath,
 	})
@@ -56,15 +107,15 @@ func (app *Application) initializeDatabase() error {
 		return err
 	}
 	app.db = db
-	app.logger.Println("Database connected successfully")
+	app.logger.Info("Database connected")
 
 	// Run migrations
-	app.logger.Println("Running database migrations...")
+	app.logger.Info("Running database migrations")
 	ctx := context.Background()
 	if err := database.RunMigrations(ctx, app.db); err != nil {
 		return err
 	}
-	app.logger.Println("Migrations completed successfully")
+	app.logger.Info("Migrations completed")
 
 	return nil
 }
@@ -75,104 +126,335 @@ func (app *Application) initializeDependencies() error {
 
 	// Initialize repositories (Adapters Layer)
 	accountRepo := accounts.NewAccountRepository(app.db)
-	operationTypeRepo := operationtype.NewOperationTypeRepository(app.db)
+	operationTypeRepo := operationtype.NewOperationTypeRepository(app.db, operationtype.WithLocale(app.config.Locale))
 	transactionRepo := transactions.NewTransactionRepository(app.db)
+	balanceRepo := balances.NewAccountBalanceRepository(app.db)
+	idempotencyRepo := idempotency.NewIdempotencyRepository(app.db)
+	// Repositories query through the transaction it binds to a context, so their calls join it
+	txProvider := sqltx.NewProvider(app.db)
 
 	// Seed operation types
-	app.logger.Println("Seeding operation types...")
+	app.logger.Info("Seeding operation types")
 	if err := operationTypeRepo.Seed(ctx); err != nil {
 		return err
 	}
 
+	// Initialize metrics (Adapters Layer)
+	transactionMetrics := metrics.NewTransactionMetrics(app.metricsRegistry)
+	idempotencyMetrics := metrics.NewIdempotencyMetrics(app.metricsRegistry)
+	httpMetrics := metrics.NewHTTPMetrics(app.metricsRegistry)
+
+	// Already checked by Config.Validate
+	operationPermissions, err := app.config.OperationPermissions()
+	if err != nil {
+		return err
+	}
+	if err := checkOperationPermissions(ctx, operationTypeRepo, operationPermissions); err != nil {
+		return err
+	}
+
+	// Already checked by Config.Validate
+	documentNumberAllowlist, err := app.config.DocumentNumberAllowlist()
+	if err != nil {
+		return err
+	}
+
+	// Already checked by Config.Validate
+	magicAmounts, err := app.config.MagicAmounts()
+	if err != nil {
+		return err
+	}
+
+	// Already checked by Config.Validate
+	operationTypes, err := domain.OperationTypes(app.config.Locale)
+	if err != nil {
+		return err
+	}
+
 	// Initialize processors (Business Logic Layer)
-	createAccountProcessor := processors.NewCreateAccountProcessor(accountRepo)
+	createAccountProcessor := processors.NewCreateAccountProcessor(
+		accountRepo,
+		processors.WithDocumentNumberAllowlist(documentNumberAllowlist),
+		processors.WithDefaultCreditLimit(app.config.DefaultCreditLimit),
+	)
 	getAccountProcessor := processors.NewGetAccountProcessor(accountRepo)
+	listAccountsProcessor := processors.NewListAccountsProcessor(accountRepo)
+	closeAccountProcessor := processors.NewCloseAccountProcessor(transactionRepo, accountRepo, txProvider)
 	createTransactionProcessor := processors.NewCreateTransactionProcessor(
 		transactionRepo,
 		accountRepo,
 		operationTypeRepo,
+		processors.WithTransactionMetrics(transactionMetrics),
+		processors.WithOperationPermissions(operationPermissions),
+		processors.WithDuplicateWindow(app.config.DuplicateTransactionWindow),
+		processors.WithInstallmentPolicy(app.config.InstallmentPolicy()),
+		processors.WithMaxRowsPerRequest(app.config.MaxRowsPerRequest),
+		processors.WithReverificationPolicy(app.config.ReverificationPolicy()),
+		processors.WithCreditLimitEnforcement(app.config.EnforceCreditLimit),
+		processors.WithMagicAmounts(magicAmounts),
 	)
 	getTransactionsProcessor := processors.NewGetTransactionsProcessor(
 		transactionRepo,
 		accountRepo,
+		processors.WithPaginationDefaults(app.config.Pagination()),
+	)
+	getRecentTransactionsProcessor := processors.NewGetRecentTransactionsProcessor(transactionRepo)
+	listTransactionsProcessor := processors.NewListTransactionsProcessor(transactionRepo)
+	countAccountsProcessor := processors.NewCountAccountsProcessor(accountRepo)
+	getIdempotencyKeyProcessor := processors.NewGetIdempotencyKeyProcessor(idempotencyRepo)
+	reverseTransactionProcessor := processors.NewReverseTransactionProcessor(
+		transactionRepo,
+		accountRepo,
+		txProvider,
+		processors.WithReversalCreditLimitEnforcement(app.config.EnforceCreditLimit),
 	)
+	importTransactionsProcessor := processors.NewImportTransactionsProcessor(createTransactionProcessor, transactionRepo)
+	exportTransactionsProcessor := processors.NewExportTransactionsProcessor(transactionRepo, accountRepo)
+	getStatementProcessor := processors.NewGetStatementProcessor(transactionRepo, accountRepo)
+	getMonthlyStatementProcessor := processors.NewGetMonthlyStatementProcessor(transactionRepo, accountRepo)
+	listOperationTypesProcessor := processors.NewListOperationTypesProcessor(operationTypeRepo)
+	recomputeBalancesProcessor := processors.NewRecomputeBalancesProcessor(balanceRepo)
+	canDebitProcessor := processors.NewCanDebitProcessor(transactionRepo, accountRepo)
+	getAccountBalanceProcessor := processors.NewGetAccountBalanceProcessor(transactionRepo, accountRepo)
+	getDailyTotalsProcessor := processors.NewGetDailyTotalsProcessor(transactionRepo, accountRepo)
+	getActivityRangeProcessor := processors.NewGetActivityRangeProcessor(transactionRepo, accountRepo)
+	getBalanceTrendProcessor := processors.NewGetBalanceTrendProcessor(transactionRepo, accountRepo)
+	readinessProcessor := processors.NewReadinessProcessor(
+		health.NewDatabaseCheck(app.db),
+		health.NewMigrationCheck(app.db, database.LatestVersion()),
+		health.NewSeedCheck(app.db, int64(len(operationTypes))),
+		health.NewWALSizeCheck(app.config.DatabasePath, app.config.WALSizeWarningBytes),
+	)
+
+	// Warm the operation types cache right after seeding
+	if err := listOperationTypesProcessor.Refresh(ctx); err != nil {
+		return err
+	}
 
 	// Initialize handlers (HTTP Layer)
 	createAccountHandler := handlers.NewCreateAccountHandler(createAccountProcessor)
 	getAccountHandler := handlers.NewGetAccountHandler(getAccountProcessor)
+	listAccountsHandler := handlers.NewListAccountsHandler(listAccountsProcessor, app.config.Pagination())
+	closeAccountHandler := handlers.NewCloseAccountHandler(closeAccountProcessor)
+	forceCloseAccountHandler := handlers.NewForceCloseAccountHandler(closeAccountProcessor)
 	createTransactionHandler := handlers.NewCreateTransactionHandler(createTransactionProcessor)
-	getTransactionsHandler := handlers.NewGetTransactionsHandler(getTransactionsProcessor)
+	getTransactionsHandler := handlers.NewGetTransactionsHandler(
+		getTransactionsProcessor,
+		handlers.WithPagination(app.config.Pagination()),
+	)
+	reverseTransactionHandler := handlers.NewReverseTransactionHandler(reverseTransactionProcessor)
+	reverseTransactionByIDHandler := handlers.NewReverseTransactionByIDHandler(reverseTransactionProcessor)
+	importTransactionsHandler := handlers.NewImportTransactionsHandler(importTransactionsProcessor)
+	getRecentTransactionsHandler := handlers.NewGetRecentTransactionsHandler(getRecentTransactionsProcessor)
+	listTransactionsHandler := handlers.NewListTransactionsHandler(listTransactionsProcessor, app.config.Pagination())
+	countAccountsHandler := handlers.NewCountAccountsHandler(countAccountsProcessor)
+	getIdempotencyKeyHandler := handlers.NewGetIdempotencyKeyHandler(getIdempotencyKeyProcessor)
+	exportSigner := handlers.WithExportSigner(handlers.NewExportSigner(app.config.ExportSigningSecret))
+	exportTransactionsJSONLHandler := handlers.NewExportTransactionsJSONLHandler(exportTransactionsProcessor, exportSigner)
+	exportTransactionsOFXHandler := handlers.NewExportTransactionsOFXHandler(exportTransactionsProcessor, exportSigner)
+	exportTransactionsCSVHandler := handlers.NewExportTransactionsCSVHandler(exportTransactionsProcessor, listOperationTypesProcessor, exportSigner)
+	getStatementHandler := handlers.NewGetStatementHandler(getStatementProcessor, getMonthlyStatementProcessor)
+	listOperationTypesHandler := handlers.NewListOperationTypesHandler(listOperationTypesProcessor)
+	operationTypeDirectionsHandler := handlers.NewOperationTypeDirectionsHandler(listOperationTypesProcessor)
+	recomputeBalancesHandler := handlers.NewRecomputeBalancesHandler(recomputeBalancesProcessor)
+	canDebitHandler := handlers.NewCanDebitHandler(canDebitProcessor)
+	getAccountBalanceHandler := handlers.NewGetAccountBalanceHandler(getAccountBalanceProcessor)
+	getDailyTotalsHandler := handlers.NewGetDailyTotalsHandler(getDailyTotalsProcessor)
+	getActivityRangeHandler := handlers.NewGetActivityRangeHandler(getActivityRangeProcessor)
+	getBalanceTrendHandler := handlers.NewGetBalanceTrendHandler(getBalanceTrendProcessor)
+	readinessHandler := handlers.NewReadinessHandler(readinessProcessor)
 
 	// Initialize server (Router)
 	app.server = server.NewServer(
-		createAccountHandler,
-		getAccountHandler,
-		createTransactionHandler,
-		getTransactionsHandler,
+		server.Config{
+			AdminToken:     app.config.AdminToken,
+			MaxQueryLength: int(app.config.MaxQueryLength),
+
+			MaxConcurrentBatches: int(app.config.MaxConcurrentBatches),
+			MaxResponseBytes:     app.config.MaxResponseBytes,
+			RateLimitRPS:         int(app.config.RateLimitRPS),
+			RateLimitBurst:       int(app.config.RateLimitBurst),
+
+			IdempotencyStore:              idempotencyRepo,
+			IdempotencyTTL:                app.config.IdempotencyTTL,
+			IdempotencyFailureGracePeriod: app.config.IdempotencyFailureGracePeriod,
+			IdempotencyReuseWindow:        app.config.IdempotencyReuseWindow,
+			IdempotencyMetrics:            idempotencyMetrics,
+			HTTPMetrics:                   httpMetrics,
+			TxProvider:                    txProvider,
+
+			Logger: app.logger,
+		},
+		server.Handlers{
+			CreateAccount:           createAccountHandler,
+			GetAccount:              getAccountHandler,
+			ListAccounts:            listAccountsHandler,
+			CloseAccount:            closeAccountHandler,
+			ForceCloseAccount:       forceCloseAccountHandler,
+			CreateTransaction:       createTransactionHandler,
+			GetTransactions:         getTransactionsHandler,
+			ExportTransactionsJSONL: exportTransactionsJSONLHandler,
+			ExportTransactionsOFX:   exportTransactionsOFXHandler,
+			ExportTransactionsCSV:   exportTransactionsCSVHandler,
+			GetRecentTransactions:   getRecentTransactionsHandler,
+			GetStatement:            getStatementHandler,
+			ListOperationTypes:      listOperationTypesHandler,
+			OperationTypeDirections: operationTypeDirectionsHandler,
+			RecomputeBalances:       recomputeBalancesHandler,
+			CanDebit:                canDebitHandler,
+			GetAccountBalance:       getAccountBalanceHandler,
+			GetDailyTotals:          getDailyTotalsHandler,
+			GetActivityRange:        getActivityRangeHandler,
+			GetBalanceTrend:         getBalanceTrendHandler,
+			ListTransactions:        listTransactionsHandler,
+			CountAccounts:           countAccountsHandler,
+			GetIdempotencyKey:       getIdempotencyKeyHandler,
+			ReverseTransaction:      reverseTransactionHandler,
+			ReverseTransactionByID:  reverseTransactionByIDHandler,
+			Readiness:               readinessHandler,
+			ImportTransactions:      importTransactionsHandler,
+			Metrics:                 promhttp.HandlerFor(app.metricsRegistry, promhttp.HandlerOpts{}),
+		},
 	)
 
 	return nil
 }
 
+// checkOperationPermissions makes sure every operation type the tiers are allowed is a seeded one
+// Config.Validate only knows the IDs are positive, the operation types live in the database
+func checkOperationPermissions(ctx context.Context, operationTypeRepo ports.OperationTypeRepository, permissions domain.OperationPermissions) error {
+	for tier, allowed := range permissions {
+		for _, id := range allowed {
+			operationType, err := operationTypeRepo.FindByID(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to check tier permissions for %q: %w", tier, err)
+			}
+			if operationType == nil {
+				return fmt.Errorf("tier permissions for %q has unknown operation type %d", tier, id)
+			}
+		}
+	}
+	return nil
+}
+
 // Start starts the HTTP server and handles graceful shutdown
+// Resources such as the database are released only after in-flight requests have drained
 func (app *Application) Start() error {
 	// Create HTTP server with timeouts
 	httpServer := &http.Server{
 		Addr:         app.config.ServerAddress,
 		Handler:      app.server.GetRouter(),
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		ReadTimeout:  app.config.ServerReadTimeout,
+		WriteTimeout: app.config.ServerWriteTimeout,
+		IdleTimeout:  app.config.ServerIdleTimeout,
 	}
 
+	// Context cancelled on interrupt signal
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	return app.run(ctx, httpServer, func() error {
+		app.logger.Info("Server starting", "address", app.config.ServerAddress, "endpoints", availableEndpoints)
+
+		return httpServer.ListenAndServe()
+	})
+}
+
+// run serves until ctx is cancelled or the listener fails, then shuts down in order:
+// stop accepting connections, drain in-flight requests, and only then close the database
+func (app *Application) run(ctx context.Context, httpServer *http.Server, serve func() error) error {
+	// Always release resources once the server is no longer serving
+	defer app.Shutdown()
+
+	// Stopped before Shutdown runs, so no checkpoint races the database close
+	stopCheckpoints := app.startCheckpoints()
+	defer stopCheckpoints()
+	stopJanitor := app.startIdempotencyJanitor()
+	defer stopJanitor()
+
 	// Channel to listen for errors coming from the listener
 	serverErrors := make(chan error, 1)
 
 	// Start server in a goroutine
 	go func() {
-		app.logger.Printf("🌐 Server starting on %s", app.config.ServerAddress)
-		app.logger.Println("📋 Available endpoints:")
-		app.logger.Println("   POST   /v1/accounts")
-		app.logger.Println("   GET    /v1/accounts/{accountId}")
-		app.logger.Println("   POST   /v1/transactions")
-		app.logger.Println("   GET    /v1/accounts/{accountId}/transactions")
-		app.logger.Println("   GET    /health")
-		app.logger.Println("")
-		app.logger.Println("✨ Server is ready to accept requests!")
-
-		serverErrors <- httpServer.ListenAndServe()
+		serverErrors <- serve()
 	}()
 
-	// Channel to listen for interrupt signal
-	shutdown := make(chan os.Signal, 1)
-	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
-
 	// Block until we receive a signal or server error
 	select {
 	case err := <-serverErrors:
 		if err != nil && err != http.ErrServerClosed {
 			return err
 		}
-	case <-shutdown:
-		app.logger.Println("🛑 Shutting down server...")
+	case <-ctx.Done():
+		app.logger.Info("Shutting down server")
 
-		// Graceful shutdown with timeout
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		// Graceful shutdown with timeout: stops accepting and waits for in-flight requests
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), app.config.ShutdownTimeout)
 		defer cancel()
 
-		if err := httpServer.Shutdown(ctx); err != nil {
-			app.logger.Printf("❌ Could not gracefully shutdown: %v", err)
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			app.logger.Error("Could not gracefully shut down, closing remaining connections", "error", err)
+
+			// Drop the connections still in flight, cancelling their request contexts,
+			// before the deferred Shutdown closes the database under them
+			if closeErr := httpServer.Close(); closeErr != nil {
+				app.logger.Error("Could not close remaining connections", "error", closeErr)
+			}
 			return err
 		}
 	}
 
-	app.logger.Println("✅ Server exited gracefully")
+	app.logger.Info("Server exited gracefully")
 	return nil
 }
 
+// startCheckpoints runs the periodic WAL checkpoint in the background and returns a function
+// that stops it and waits for an in-progress checkpoint to finish
+func (app *Application) startCheckpoints() func() {
+	if app.config.WALCheckpointInterval <= 0 || app.db == nil {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		database.RunCheckpoints(ctx, app.db, app.config.WALCheckpointInterval, app.logger)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// startIdempotencyJanitor purges expired idempotency keys in the background and returns a function
+// that stops it and waits for an in-progress purge to finish
+func (app *Application) startIdempotencyJanitor() func() {
+	if app.db == nil {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		idempotency.RunJanitor(ctx, idempotency.NewIdempotencyRepository(app.db), idempotency.DefaultJanitorInterval, app.logger)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
 // Shutdown closes all application resources
+// It must only run after the HTTP server has finished draining requests
 func (app *Application) Shutdown() {
 	if app.db != nil {
+		app.logger.Info("Closing database connection")
 		database.Close(app.db)
+		app.db = nil
 	}
 }
@@ -0,0 +1,482 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/infra/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplication_Run_DrainsRequestsBeforeClosingDatabase(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "shutdown.db"),
+	})
+	require.NoError(t, err)
+
+	app := &Application{
+		config: Config{ShutdownTimeout: 5 * time.Second},
+		logger: slog.New(slog.DiscardHandler),
+		db:     db,
+	}
+
+	requestStarted := make(chan struct{})
+	queryErr := make(chan error, 1)
+
+	// Handler that is still in flight when shutdown begins and then hits the database
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requestStarted)
+		time.Sleep(100 * time.Millisecond)
+
+		var one int
+		err := db.QueryRowContext(context.Background(), "SELECT 1").Scan(&one)
+		queryErr <- err
+		w.WriteHeader(http.StatusOK)
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	httpServer := &http.Server{Handler: handler}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- app.run(ctx, httpServer, func() error {
+			return httpServer.Serve(listener)
+		})
+	}()
+
+	// Fire a request and trigger shutdown while it is in flight
+	responseStatus := make(chan int, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/")
+		if err != nil {
+			responseStatus <- 0
+			return
+		}
+		resp.Body.Close()
+		responseStatus <- resp.StatusCode
+	}()
+
+	<-requestStarted
+	cancel()
+
+	require.NoError(t, <-runErr)
+	assert.NoError(t, <-queryErr, "In-flight request should complete its DB query")
+	assert.Equal(t, http.StatusOK, <-responseStatus)
+
+	// Database is closed only after the server has drained
+	assert.Nil(t, app.db)
+	assert.Error(t, db.Ping(), "Database should be closed after shutdown")
+}
+
+func TestApplication_Run_ClosesConnectionsWhenDrainTimesOut(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "shutdown-timeout.db"),
+	})
+	require.NoError(t, err)
+
+	app := &Application{
+		config: Config{ShutdownTimeout: 50 * time.Millisecond},
+		logger: slog.New(slog.DiscardHandler),
+		db:     db,
+	}
+
+	requestStarted := make(chan struct{})
+	requestCancelled := make(chan bool, 1)
+
+	// Handler that outlives the shutdown timeout and only stops once its connection is dropped
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requestStarted)
+		select {
+		case <-r.Context().Done():
+			requestCancelled <- true
+		case <-time.After(5 * time.Second):
+			requestCancelled <- false
+		}
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	httpServer := &http.Server{Handler: handler}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- app.run(ctx, httpServer, func() error {
+			return httpServer.Serve(listener)
+		})
+	}()
+
+	clientErr := make(chan error, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/")
+		if err == nil {
+			resp.Body.Close()
+		}
+		clientErr <- err
+	}()
+
+	<-requestStarted
+	cancel()
+
+	assert.ErrorIs(t, <-runErr, context.DeadlineExceeded)
+	assert.True(t, <-requestCancelled, "Lingering request should be cancelled when the drain times out")
+	assert.Error(t, <-clientErr, "Lingering connection should be closed")
+	assert.Nil(t, app.db)
+	assert.Error(t, db.Ping(), "Database should be closed after shutdown")
+}
+
+func TestApplication_Run_ClosesDatabaseOnServeError(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "serve-error.db"),
+	})
+	require.NoError(t, err)
+
+	app := &Application{
+		logger: slog.New(slog.DiscardHandler),
+		db:     db,
+	}
+
+	err = app.run(context.Background(), &http.Server{}, func() error {
+		return assert.AnError
+	})
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Error(t, db.Ping(), "Database should be closed when serving fails")
+}
+
+func TestApplication_Run_StopsCheckpointsBeforeClosingDatabase(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "checkpoints.db"),
+	})
+	require.NoError(t, err)
+
+	app := &Application{
+		config: Config{WALCheckpointInterval: time.Millisecond},
+		logger: slog.New(slog.DiscardHandler),
+		db:     db,
+	}
+
+	err = app.run(context.Background(), &http.Server{}, func() error {
+		// Let a few checkpoints run while serving
+		time.Sleep(20 * time.Millisecond)
+		return assert.AnError
+	})
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, app.db)
+	assert.Error(t, db.Ping(), "Database should be closed after the checkpoints stopped")
+}
+
+func TestApplication_ReadinessReportsUnreachableDatabase(t *testing.T) {
+	app, err := NewApplication(validConfig(t))
+	require.NoError(t, err)
+	t.Cleanup(app.Shutdown)
+	router := app.server.GetRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	require.NoError(t, app.db.Close())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var report struct {
+		Status string `json:"status"`
+		Checks []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+		} `json:"checks"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, "unhealthy", report.Status)
+	require.NotEmpty(t, report.Checks)
+	assert.Equal(t, "database", report.Checks[0].Name)
+	assert.Equal(t, "unhealthy", report.Checks[0].Status)
+
+	// Liveness stays cheap and does not touch the database
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestApplication_RejectsPermissionsForUnknownOperationTypes(t *testing.T) {
+	config := validConfig(t)
+	config.TierPermissions = "basic:1,9"
+
+	_, err := NewApplication(config)
+
+	assert.EqualError(t, err, `tier permissions for "basic" has unknown operation type 9`)
+}
+
+func TestApplication_ReplayedImportDoesNotDuplicateRows(t *testing.T) {
+	app, err := NewApplication(validConfig(t))
+	require.NoError(t, err)
+	t.Cleanup(app.Shutdown)
+	router := app.server.GetRouter()
+
+	createAccount := httptest.NewRequest(http.MethodPost, "/v1/accounts", strings.NewReader(`{"document_number":"12345678909"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createAccount)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	// Partial success: the second line fails and must be reported the same way on replay
+	body := `{"account_id":1,"operation_type_id":4,"amount":100}` + "\n" +
+		`{"account_id":1,"operation_type_id":1,"amount":"oops"}` + "\n" +
+		`{"account_id":1,"operation_type_id":1,"amount":30}` + "\n"
+
+	var responses []string
+	for range 2 {
+		req := httptest.NewRequest(http.MethodPost, "/v1/transactions/import", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		req.Header.Set("Idempotency-Key", "import-batch-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		responses = append(responses, w.Body.String())
+	}
+
+	assert.JSONEq(t, responses[0], responses[1], "Replay should return the original batch result")
+	assert.Contains(t, responses[0], `"succeeded":2`)
+
+	var count int
+	require.NoError(t, app.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE account_id = 1").Scan(&count))
+	assert.Equal(t, 2, count, "Replay should not insert the batch again")
+}
+
+func TestApplication_CursorPagesThroughTransactionsInBothOrders(t *testing.T) {
+	app, err := NewApplication(validConfig(t))
+	require.NoError(t, err)
+	t.Cleanup(app.Shutdown)
+	router := app.server.GetRouter()
+
+	createAccount := httptest.NewRequest(http.MethodPost, "/v1/accounts", strings.NewReader(`{"document_number":"12345678909"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createAccount)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	body := strings.Repeat(`{"account_id":1,"operation_type_id":4,"amount":10}`+"\n", 5)
+	req := httptest.NewRequest(http.MethodPost, "/v1/transactions/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	req.Header.Set("Idempotency-Key", "cursor-seed")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	tests := []struct {
+		order   string
+		wantIDs []int64
+	}{
+		{order: "asc", wantIDs: []int64{1, 2, 3, 4, 5}},
+		{order: "desc", wantIDs: []int64{5, 4, 3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			var seen []int64
+			url := "/v1/accounts/1/transactions?limit=2&sort=event_date&order=" + tt.order
+			for pages := 0; url != ""; pages++ {
+				require.Less(t, pages, 5, "Paging should end")
+
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+				require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+				var page struct {
+					Transactions []struct {
+						ID int64 `json:"transaction_id"`
+					} `json:"transactions"`
+					NextCursor string `json:"next_cursor"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+				for _, tx := range page.Transactions {
+					seen = append(seen, tx.ID)
+				}
+
+				url = ""
+				if page.NextCursor != "" {
+					url = "/v1/accounts/1/transactions?limit=2&sort=event_date&order=" + tt.order + "&cursor=" + page.NextCursor
+				}
+			}
+
+			assert.Equal(t, tt.wantIDs, seen)
+		})
+	}
+}
+
+func TestApplication_ClosedAccountIsNotFound(t *testing.T) {
+	app, err := NewApplication(validConfig(t))
+	require.NoError(t, err)
+	t.Cleanup(app.Shutdown)
+	router := app.server.GetRouter()
+
+	serve := func(method, url, body, idempotencyKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		if idempotencyKey != "" {
+			req.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(http.MethodPost, "/v1/accounts", `{"document_number":"12345678909"}`, "")
+	require.Equal(t, http.StatusCreated, w.Code)
+	w = serve(http.MethodPost, "/v1/transactions", `{"account_id":1,"operation_type_id":4,"amount":100}`, "close-credit")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = serve(http.MethodDelete, "/v1/accounts/1", "", "")
+	assert.Equal(t, http.StatusConflict, w.Code, "An account with funds is not closed")
+
+	w = serve(http.MethodPost, "/v1/transactions", `{"account_id":1,"operation_type_id":1,"amount":100}`, "close-purchase")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = serve(http.MethodDelete, "/v1/accounts/1", "", "")
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	assert.Empty(t, w.Body.String())
+
+	w = serve(http.MethodGet, "/v1/accounts/1", "", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serve(http.MethodPost, "/v1/transactions", `{"account_id":1,"operation_type_id":4,"amount":10}`, "after-close")
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "account is closed")
+
+	w = serve(http.MethodGet, "/v1/accounts/1/transactions", "", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "account is closed", "Listing a closed account is told apart from an unknown one")
+
+	w = serve(http.MethodGet, "/v1/accounts/2/transactions", "", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "account with id 2 not found")
+
+	w = serve(http.MethodDelete, "/v1/accounts/1", "", "")
+	assert.Equal(t, http.StatusNotFound, w.Code, "A closed account is closed once")
+
+	w = serve(http.MethodPost, "/v1/accounts", `{"document_number":"12345678909"}`, "")
+	assert.Equal(t, http.StatusConflict, w.Code, "The document number of a closed account stays taken")
+
+	var count int
+	require.NoError(t, app.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE account_id = 1").Scan(&count))
+	assert.Equal(t, 2, count, "The transaction history is kept")
+}
+
+func TestApplication_ConcurrentReversalsReverseOnce(t *testing.T) {
+	app, err := NewApplication(validConfig(t))
+	require.NoError(t, err)
+	t.Cleanup(app.Shutdown)
+	router := app.server.GetRouter()
+
+	serve := func(method, url, body, idempotencyKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		if idempotencyKey != "" {
+			req.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(http.MethodPost, "/v1/accounts", `{"document_number":"12345678909"}`, "")
+	require.Equal(t, http.StatusCreated, w.Code)
+	w = serve(http.MethodPost, "/v1/transactions", `{"account_id":1,"operation_type_id":4,"amount":100}`, "reversal-credit")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	// Each request checks for a reversal and inserts one in its own transaction, so only one gets through
+	const requests = 5
+	codes := make(chan int, requests)
+	var wg sync.WaitGroup
+	for i := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes <- serve(http.MethodPost, "/v1/transactions/1/reversal", "", fmt.Sprintf("reversal-%d", i)).Code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	counts := map[int]int{}
+	for code := range codes {
+		counts[code]++
+	}
+	assert.Equal(t, map[int]int{http.StatusCreated: 1, http.StatusConflict: requests - 1}, counts)
+
+	var reversals int
+	require.NoError(t, app.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE reversal_of = 1").Scan(&reversals))
+	assert.Equal(t, 1, reversals)
+
+	// The committed response was recorded for its Idempotency-Key and is replayed
+	for i := range requests {
+		w := serve(http.MethodPost, "/v1/transactions/1/reversal", "", fmt.Sprintf("reversal-%d", i))
+		if w.Code == http.StatusCreated {
+			assert.Contains(t, w.Body.String(), `"reversed_transaction_id":1`)
+		}
+	}
+	require.NoError(t, app.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE reversal_of = 1").Scan(&reversals))
+	assert.Equal(t, 1, reversals, "Replays do not reverse again")
+}
+
+func TestApplication_ReversalsUndoDischarges(t *testing.T) {
+	app, err := NewApplication(validConfig(t))
+	require.NoError(t, err)
+	t.Cleanup(app.Shutdown)
+	router := app.server.GetRouter()
+
+	serve := func(method, url, body, idempotencyKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	balances := func() map[int64]float64 {
+		rows, err := app.db.Query("SELECT id, balance FROM transactions")
+		require.NoError(t, err)
+		defer rows.Close()
+		result := map[int64]float64{}
+		for rows.Next() {
+			var id int64
+			var balance float64
+			require.NoError(t, rows.Scan(&id, &balance))
+			result[id] = balance
+		}
+		require.NoError(t, rows.Err())
+		return result
+	}
+
+	w := serve(http.MethodPost, "/v1/accounts", `{"document_number":"12345678909"}`, "account")
+	require.Equal(t, http.StatusCreated, w.Code)
+	w = serve(http.MethodPost, "/v1/transactions", `{"account_id":1,"operation_type_id":1,"amount":50}`, "purchase")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	w = serve(http.MethodPost, "/v1/transactions", `{"account_id":1,"operation_type_id":4,"amount":80}`, "credit")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	require.Equal(t, map[int64]float64{1: 0, 2: 30}, balances(), "The credit paid the purchase")
+
+	// Taking the credit back makes what it paid owed again
+	w = serve(http.MethodPost, "/v1/transactions/2/reversal", "", "reverse-credit")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, map[int64]float64{1: 0, 2: 0, 3: -50}, balances())
+
+	// Cancelling the purchase frees what was paid of it, which settles that debt
+	w = serve(http.MethodPost, "/v1/transactions/1/reversal", "", "reverse-purchase")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, map[int64]float64{1: 0, 2: 0, 3: 0, 4: 0}, balances())
+}
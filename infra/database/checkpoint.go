@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// CheckpointResult is the outcome of a WAL checkpoint as reported by SQLite
+// Busy is set when readers or writers prevented the checkpoint from completing
+type CheckpointResult struct {
+	Busy               bool
+	LogFrames          int64
+	CheckpointedFrames int64
+}
+
+// Checkpoint copies the write-ahead log into the database file and truncates the -wal file
+func Checkpoint(ctx context.Context, db *sql.DB) (CheckpointResult, error) {
+	var busy int64
+	var result CheckpointResult
+
+	err := db.QueryRowContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)").Scan(&busy, &result.LogFrames, &result.CheckpointedFrames)
+	if err != nil {
+		return CheckpointResult{}, fmt.Errorf("failed to checkpoint WAL: %w", err)
+	}
+	result.Busy = busy != 0
+
+	return result, nil
+}
+
+// RunCheckpoints checkpoints the WAL every interval until ctx is cancelled, bounding the -wal file size
+// under sustained writes; SQLite's automatic checkpoints reuse the file but never shrink it
+func RunCheckpoints(ctx context.Context, db *sql.DB, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := Checkpoint(ctx, db)
+			switch {
+			case err != nil:
+				if ctx.Err() == nil {
+					logger.Error("WAL checkpoint failed", "error", err)
+				}
+			case result.Busy:
+				logger.Warn("WAL checkpoint incomplete (database busy)", "checkpointed_frames", result.CheckpointedFrames, "log_frames", result.LogFrames)
+			case result.LogFrames > 0:
+				logger.Info("WAL checkpoint", "checkpointed_frames", result.CheckpointedFrames)
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// walSize returns the size of the database's -wal file
+func walSize(t *testing.T, path string) int64 {
+	info, err := os.Stat(path + "-wal")
+	require.NoError(t, err)
+	return info.Size()
+}
+
+func TestRunCheckpoints_TruncatesWAL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal.db")
+	db, err := NewConnection(Config{DatabasePath: path})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, RunMigrations(ctx, db))
+	for i := 0; i < 200; i++ {
+		_, err := db.ExecContext(ctx, "INSERT INTO accounts (document_number) VALUES (?)", 10000000000+i)
+		require.NoError(t, err)
+	}
+	require.Positive(t, walSize(t, path), "Writes should grow the WAL")
+
+	checkpointCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		RunCheckpoints(checkpointCtx, db, 10*time.Millisecond, slog.New(slog.DiscardHandler))
+	}()
+
+	assert.Eventually(t, func() bool {
+		return walSize(t, path) == 0
+	}, 2*time.Second, 10*time.Millisecond, "A checkpoint should truncate the WAL")
+
+	cancel()
+	<-done
+
+	var count int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM accounts").Scan(&count))
+	assert.Equal(t, 200, count, "Checkpointed rows stay readable")
+}
+
+func TestCheckpoint_ReportsFrames(t *testing.T) {
+	db, err := NewConnection(Config{DatabasePath: filepath.Join(t.TempDir(), "frames.db")})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, RunMigrations(ctx, db))
+
+	result, err := Checkpoint(ctx, db)
+
+	require.NoError(t, err)
+	assert.False(t, result.Busy)
+	assert.Equal(t, result.LogFrames, result.CheckpointedFrames, "Every frame is checkpointed when the database is idle")
+}
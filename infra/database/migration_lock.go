@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The migration lock is a single row in schema_migrations_lock: whoever inserts it holds the lock
+const (
+	createMigrationLockTableSQL = `
+		CREATE TABLE IF NOT EXISTS schema_migrations_lock (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			owner TEXT NOT NULL,
+			acquired_at DATETIME NOT NULL
+		)
+	`
+	acquireMigrationLockSQL    = `INSERT OR IGNORE INTO schema_migrations_lock (id, owner, acquired_at) VALUES (1, ?, ?)`
+	releaseMigrationLockSQL    = `DELETE FROM schema_migrations_lock WHERE id = 1 AND owner = ?`
+	breakStaleMigrationLockSQL = `DELETE FROM schema_migrations_lock WHERE id = 1 AND acquired_at < ?`
+)
+
+// ErrMigrationLockTimeout is returned when another instance holds the migration lock for longer than MigrationLockTimeout
+var ErrMigrationLockTimeout = errors.New("timed out waiting for the migration lock")
+
+var (
+	// MigrationLockTimeout is how long RunMigrations waits for another instance to finish migrating
+	MigrationLockTimeout = 2 * time.Minute
+
+	// MigrationLockStaleAfter breaks a lock held for longer than this, left behind by an instance that crashed mid-migration
+	MigrationLockStaleAfter = 10 * time.Minute
+
+	// migrationLockPollInterval is how often a waiting instance retries the lock
+	migrationLockPollInterval = 100 * time.Millisecond
+)
+
+// acquireMigrationLock waits until this instance holds the migration lock and returns the function releasing it
+func acquireMigrationLock(ctx context.Context, db *sql.DB) (func() error, error) {
+	if _, err := db.ExecContext(ctx, createMigrationLockTableSQL); err != nil {
+		return nil, fmt.Errorf("failed to create migration lock table: %w", err)
+	}
+
+	owner, err := newLockOwner()
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(MigrationLockTimeout)
+	waiting := false
+	for {
+		now := time.Now().UTC()
+		if _, err := db.ExecContext(ctx, breakStaleMigrationLockSQL, now.Add(-MigrationLockStaleAfter)); err != nil {
+			return nil, fmt.Errorf("failed to break stale migration lock: %w", err)
+		}
+
+		result, err := db.ExecContext(ctx, acquireMigrationLockSQL, owner, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		if acquired, err := result.RowsAffected(); err != nil {
+			return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+		} else if acquired == 1 {
+			break
+		}
+
+		if now.After(deadline) {
+			return nil, ErrMigrationLockTimeout
+		}
+		if !waiting {
+			fmt.Println("⏳ Waiting for another instance to finish migrations")
+			waiting = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(migrationLockPollInterval):
+		}
+	}
+
+	release := func() error {
+		// Released with a fresh context so a cancelled startup does not leave the lock behind
+		if _, err := db.ExecContext(context.Background(), releaseMigrationLockSQL, owner); err != nil {
+			return fmt.Errorf("failed to release migration lock: %w", err)
+		}
+		return nil
+	}
+
+	return release, nil
+}
+
+// newLockOwner returns a random token identifying this instance's hold on the lock
+func newLockOwner() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("failed to generate migration lock owner: %w", err)
+	}
+	return hex.EncodeToString(token), nil
+}
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunMigrations_ConcurrentReplicas(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "replicas.db")
+	ctx := context.Background()
+
+	// Each replica has its own connection to the same file
+	const replicas = 4
+	dbs := make([]*sql.DB, 0, replicas)
+	errs := make(chan error, replicas)
+	var wg sync.WaitGroup
+	for i := 0; i < replicas; i++ {
+		db, err := NewConnection(Config{DatabasePath: path})
+		require.NoError(t, err)
+		dbs = append(dbs, db)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- RunMigrations(ctx, db)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for _, db := range dbs {
+		defer db.Close()
+	}
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	db, err := NewConnection(Config{DatabasePath: path})
+	require.NoError(t, err)
+	defer db.Close()
+
+	var applied, distinct, latest int64
+	require.NoError(t, db.QueryRowContext(ctx,
+		"SELECT COUNT(*), COUNT(DISTINCT version), MAX(version) FROM schema_migrations",
+	).Scan(&applied, &distinct, &latest))
+	assert.Equal(t, int64(len(GetMigrations())), applied, "Each migration is recorded once")
+	assert.Equal(t, applied, distinct)
+	assert.Equal(t, LatestVersion(), latest)
+
+	var integrity string
+	require.NoError(t, db.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&integrity))
+	assert.Equal(t, "ok", integrity)
+
+	var locks int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations_lock").Scan(&locks))
+	assert.Zero(t, locks, "The lock is released")
+}
+
+func TestRunMigrations_WaitsForLockHolder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "waiting.db")
+	ctx := context.Background()
+
+	holder, err := NewConnection(Config{DatabasePath: path})
+	require.NoError(t, err)
+	defer holder.Close()
+	release, err := acquireMigrationLock(ctx, holder)
+	require.NoError(t, err)
+
+	waiter, err := NewConnection(Config{DatabasePath: path})
+	require.NoError(t, err)
+	defer waiter.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- RunMigrations(ctx, waiter)
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("RunMigrations finished while another instance held the lock: %v", err)
+	case <-time.After(3 * migrationLockPollInterval):
+	}
+
+	require.NoError(t, release())
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunMigrations did not take the released lock")
+	}
+}
+
+func TestRunMigrations_LockTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timeout.db")
+	ctx := context.Background()
+
+	holder, err := NewConnection(Config{DatabasePath: path})
+	require.NoError(t, err)
+	defer holder.Close()
+	_, err = acquireMigrationLock(ctx, holder)
+	require.NoError(t, err)
+
+	timeout := MigrationLockTimeout
+	MigrationLockTimeout = 3 * migrationLockPollInterval
+	defer func() { MigrationLockTimeout = timeout }()
+
+	err = RunMigrations(ctx, holder)
+
+	assert.ErrorIs(t, err, ErrMigrationLockTimeout)
+}
+
+func TestRunMigrations_BreaksStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stale.db")
+	ctx := context.Background()
+
+	db, err := NewConnection(Config{DatabasePath: path})
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Left behind by an instance that crashed mid-migration
+	_, err = db.ExecContext(ctx, createMigrationLockTableSQL)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, acquireMigrationLockSQL, "crashed", time.Now().UTC().Add(-MigrationLockStaleAfter-time.Minute))
+	require.NoError(t, err)
+
+	require.NoError(t, RunMigrations(ctx, db))
+
+	var latest int64
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT MAX(version) FROM schema_migrations").Scan(&latest))
+	assert.Equal(t, LatestVersion(), latest)
+}
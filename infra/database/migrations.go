@@ -61,9 +61,115 @@ func GetMigrations() []Migration {
 				);
 			`,
 		},
+		{
+			Version:     2,
+			Description: "Add tier to accounts",
+			SQL: `
+				ALTER TABLE accounts ADD COLUMN tier TEXT NOT NULL DEFAULT 'standard';
+			`,
+		},
+		{
+			Version:     3,
+			Description: "Create account balances cache",
+			SQL: `
+				-- Balance of each account as summed from its transactions; rebuilt by the admin recompute endpoint
+				CREATE TABLE IF NOT EXISTS account_balances (
+					account_id INTEGER PRIMARY KEY,
+					balance REAL NOT NULL DEFAULT 0,
+					updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (account_id) REFERENCES accounts(id)
+				);
+			`,
+		},
+		{
+			Version:     4,
+			Description: "Add credit limit to accounts",
+			SQL: `
+				ALTER TABLE accounts ADD COLUMN credit_limit REAL NOT NULL DEFAULT 0;
+			`,
+		},
+		{
+			Version:     5,
+			Description: "Store idempotency keys and reversals on transactions",
+			SQL: `
+				-- Idempotency-Key the transaction was created with (NULL for rows created without one)
+				ALTER TABLE transactions ADD COLUMN idempotency_key TEXT;
+				-- Transaction a reversal cancels; each transaction is reversed at most once
+				ALTER TABLE transactions ADD COLUMN reversal_of INTEGER;
+				CREATE INDEX IF NOT EXISTS idx_transactions_idempotency_key ON transactions(account_id, idempotency_key);
+				CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_reversal_of ON transactions(reversal_of) WHERE reversal_of IS NOT NULL;
+			`,
+		},
+		{
+			Version:     6,
+			Description: "Track the outstanding balance of each transaction",
+			SQL: `
+				-- What is left of the amount: debts are paid down by later credits, credits keep what they did not pay
+				ALTER TABLE transactions ADD COLUMN balance REAL NOT NULL DEFAULT 0;
+				UPDATE transactions SET balance = amount;
+				CREATE INDEX IF NOT EXISTS idx_transactions_outstanding ON transactions(account_id, event_date) WHERE balance < 0;
+			`,
+		},
+		{
+			Version:     7,
+			Description: "Add verification date to accounts",
+			SQL: `
+				ALTER TABLE accounts ADD COLUMN verified_at DATETIME;
+			`,
+		},
+		{
+			Version:     8,
+			Description: "Persist idempotency keys",
+			SQL: `
+				-- One row per Idempotency-Key: 'processing' while the first request runs, then 'completed' with its response
+				-- expires_at is only set for replayed failures, which may be retried once it has passed
+				CREATE TABLE IF NOT EXISTS idempotency_keys (
+					key TEXT PRIMARY KEY,
+					status TEXT NOT NULL,
+					response_status INTEGER,
+					response_body BLOB,
+					created_at DATETIME NOT NULL,
+					expires_at DATETIME
+				);
+			`,
+		},
+		{
+			Version:     9,
+			Description: "Remember the request body of each idempotency key",
+			SQL: `
+				-- SHA-256 of the first request's body, so a key reused for a different request is rejected
+				ALTER TABLE idempotency_keys ADD COLUMN request_hash TEXT;
+			`,
+		},
+		{
+			Version:     10,
+			Description: "Link the installments of a split purchase",
+			SQL: `
+				-- First installment of the purchase this row belongs to (NULL for the first one and unsplit rows)
+				ALTER TABLE transactions ADD COLUMN installment_of INTEGER;
+				CREATE INDEX IF NOT EXISTS idx_transactions_installment_of ON transactions(installment_of) WHERE installment_of IS NOT NULL;
+			`,
+		},
+		{
+			Version:     11,
+			Description: "Store the direction of each operation type",
+			SQL: `
+				-- Credits are stored as positive amounts, every other type as negative; new types default to debits
+				ALTER TABLE operation_types ADD COLUMN is_credit BOOLEAN NOT NULL DEFAULT 0;
+				UPDATE operation_types SET is_credit = 1 WHERE id = 4;
+			`,
+		},
+		{
+			Version:     12,
+			Description: "Soft-delete accounts",
+			SQL: `
+				-- When the account was closed; closed accounts keep their row and transactions but are no longer found
+				ALTER TABLE accounts ADD COLUMN deleted_at DATETIME;
+			`,
+		},
 		// EXAMPLE: How to add a new column in the future:
 		// {
-		// 	Version:     2,
+		// 	Version:     13,
 		// 	Description: "Add merchant_id to transactions",
 		// 	SQL: `
 		// 		ALTER TABLE transactions ADD COLUMN merchant_id INTEGER;
@@ -73,8 +179,31 @@ func GetMigrations() []Migration {
 	}
 }
 
+// LatestVersion returns the version of the last migration, which a fully migrated database has applied
+func LatestVersion() int64 {
+	migrations := GetMigrations()
+	return migrations[len(migrations)-1].Version
+}
+
 // RunMigrations executes all pending migrations
-func RunMigrations(ctx context.Context, db *sql.DB) error {
+// It holds the migration lock while doing so, so replicas starting together against the same database
+// apply each migration once: the others wait for the lock and then find everything applied
+func RunMigrations(ctx context.Context, db *sql.DB) (err error) {
+	release, err := acquireMigrationLock(ctx, db)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if releaseErr := release(); releaseErr != nil && err == nil {
+			err = releaseErr
+		}
+	}()
+
+	return applyMigrations(ctx, db)
+}
+
+// applyMigrations executes the migrations not yet recorded in schema_migrations
+func applyMigrations(ctx context.Context, db *sql.DB) error {
 	migrations := GetMigrations()
 
 	for _, migration := range migrations {
@@ -0,0 +1,101 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// SQL queries - Readiness
+const (
+	latestMigrationVersionSQL = `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`
+	countOperationTypesSQL    = `SELECT COUNT(*) FROM operation_types`
+)
+
+// databasePingTimeout bounds the database ping, so a stuck connection fails readiness instead of hanging the probe
+var databasePingTimeout = 2 * time.Second
+
+// checkFunc adapts a function to the ports.HealthChecker interface
+type checkFunc func(ctx context.Context) domain.HealthCheck
+
+func (f checkFunc) Check(ctx context.Context) domain.HealthCheck {
+	return f(ctx)
+}
+
+// result builds a named check outcome
+func result(name string, status domain.HealthStatus, detail string) domain.HealthCheck {
+	return domain.HealthCheck{Name: name, Status: status, Detail: detail}
+}
+
+// NewDatabaseCheck reports the database unhealthy when it cannot be pinged within databasePingTimeout
+func NewDatabaseCheck(db *sql.DB) ports.HealthChecker {
+	return checkFunc(func(ctx context.Context) domain.HealthCheck {
+		ctx, cancel := context.WithTimeout(ctx, databasePingTimeout)
+		defer cancel()
+
+		if err := db.PingContext(ctx); err != nil {
+			return result("database", domain.HealthStatusUnhealthy, err.Error())
+		}
+		return result("database", domain.HealthStatusHealthy, "")
+	})
+}
+
+// NewMigrationCheck reports the schema unhealthy until the latest migration version has been applied
+func NewMigrationCheck(db *sql.DB, latestVersion int64) ports.HealthChecker {
+	return checkFunc(func(ctx context.Context) domain.HealthCheck {
+		var version int64
+		if err := db.QueryRowContext(ctx, latestMigrationVersionSQL).Scan(&version); err != nil {
+			return result("migrations", domain.HealthStatusUnhealthy, fmt.Sprintf("failed to read schema version: %v", err))
+		}
+
+		detail := fmt.Sprintf("version %d of %d", version, latestVersion)
+		if version < latestVersion {
+			return result("migrations", domain.HealthStatusUnhealthy, detail)
+		}
+		return result("migrations", domain.HealthStatusHealthy, detail)
+	})
+}
+
+// NewSeedCheck reports the reference data unhealthy while fewer than the expected operation types are seeded
+func NewSeedCheck(db *sql.DB, expectedOperationTypes int64) ports.HealthChecker {
+	return checkFunc(func(ctx context.Context) domain.HealthCheck {
+		var count int64
+		if err := db.QueryRowContext(ctx, countOperationTypesSQL).Scan(&count); err != nil {
+			return result("seed", domain.HealthStatusUnhealthy, fmt.Sprintf("failed to count operation types: %v", err))
+		}
+
+		detail := fmt.Sprintf("%d of %d operation types", count, expectedOperationTypes)
+		if count < expectedOperationTypes {
+			return result("seed", domain.HealthStatusUnhealthy, detail)
+		}
+		return result("seed", domain.HealthStatusHealthy, detail)
+	})
+}
+
+// NewWALSizeCheck reports the service degraded while the write-ahead log of the database at databasePath
+// is larger than maxBytes, usually because checkpoints cannot keep up; a non-positive maxBytes only reports the size
+func NewWALSizeCheck(databasePath string, maxBytes int64) ports.HealthChecker {
+	return checkFunc(func(ctx context.Context) domain.HealthCheck {
+		var size int64
+		info, err := os.Stat(databasePath + "-wal")
+		switch {
+		case err == nil:
+			size = info.Size()
+		case !errors.Is(err, fs.ErrNotExist):
+			return result("wal", domain.HealthStatusDegraded, fmt.Sprintf("failed to read WAL size: %v", err))
+		}
+
+		detail := fmt.Sprintf("%d bytes", size)
+		if maxBytes > 0 && size > maxBytes {
+			return result("wal", domain.HealthStatusDegraded, fmt.Sprintf("%s, above %d", detail, maxBytes))
+		}
+		return result("wal", domain.HealthStatusHealthy, detail)
+	})
+}
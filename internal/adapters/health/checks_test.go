@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/infra/database"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecks_MigratedAndSeededDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ready.db")
+	db, err := database.NewConnection(database.Config{DatabasePath: path})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	// Before migrating, the schema and reference data are missing
+	assert.Equal(t, domain.HealthStatusHealthy, NewDatabaseCheck(db).Check(ctx).Status)
+	assert.Equal(t, domain.HealthStatusUnhealthy, NewMigrationCheck(db, database.LatestVersion()).Check(ctx).Status)
+	assert.Equal(t, domain.HealthStatusUnhealthy, NewSeedCheck(db, 4).Check(ctx).Status)
+
+	require.NoError(t, database.RunMigrations(ctx, db))
+	assert.Equal(t, domain.HealthStatusHealthy, NewMigrationCheck(db, database.LatestVersion()).Check(ctx).Status)
+	assert.Equal(t, domain.HealthStatusUnhealthy, NewMigrationCheck(db, database.LatestVersion()+1).Check(ctx).Status)
+
+	seed := NewSeedCheck(db, 4).Check(ctx)
+	assert.Equal(t, domain.HealthStatusUnhealthy, seed.Status)
+	assert.Equal(t, "0 of 4 operation types", seed.Detail)
+
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (1, 'a'), (2, 'b'), (3, 'c'), (4, 'd')")
+	require.NoError(t, err)
+	assert.Equal(t, domain.HealthStatusHealthy, NewSeedCheck(db, 4).Check(ctx).Status)
+
+	// Closed connections fail the ping
+	require.NoError(t, db.Close())
+	assert.Equal(t, domain.HealthStatusUnhealthy, NewDatabaseCheck(db).Check(ctx).Status)
+}
+
+func TestDatabaseCheck_TimesOut(t *testing.T) {
+	db, err := database.NewConnection(database.Config{DatabasePath: filepath.Join(t.TempDir(), "busy.db")})
+	require.NoError(t, err)
+	defer db.Close()
+
+	timeout := databasePingTimeout
+	databasePingTimeout = 50 * time.Millisecond
+	defer func() { databasePingTimeout = timeout }()
+
+	// Hold the only connection so the ping has to wait for it
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	start := time.Now()
+	check := NewDatabaseCheck(db).Check(ctx)
+
+	assert.Equal(t, domain.HealthStatusUnhealthy, check.Status)
+	assert.Contains(t, check.Detail, "deadline exceeded")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestWALSizeCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal.db")
+	ctx := context.Background()
+
+	missing := NewWALSizeCheck(path, 10).Check(ctx)
+	assert.Equal(t, domain.HealthStatusHealthy, missing.Status, "No WAL file means nothing to checkpoint")
+	assert.Equal(t, "0 bytes", missing.Detail)
+
+	require.NoError(t, os.WriteFile(path+"-wal", make([]byte, 20), 0o644))
+
+	large := NewWALSizeCheck(path, 10).Check(ctx)
+	assert.Equal(t, domain.HealthStatusDegraded, large.Status)
+	assert.Equal(t, "20 bytes, above 10", large.Detail)
+
+	assert.Equal(t, domain.HealthStatusHealthy, NewWALSizeCheck(path, 20).Check(ctx).Status)
+	assert.Equal(t, domain.HealthStatusHealthy, NewWALSizeCheck(path, 0).Check(ctx).Status, "Zero disables the limit")
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// methodLabels keeps label cardinality bounded to the standard HTTP methods
+var methodLabels = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// HTTPMetrics implements the ports.HTTPMetrics interface with Prometheus collectors
+type HTTPMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewHTTPMetrics creates the HTTP request collectors and registers them
+func NewHTTPMetrics(registerer prometheus.Registerer) ports.HTTPMetrics {
+	m := &HTTPMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests served, by method, route pattern and status.",
+		}, []string{"method", "path", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Time taken to serve HTTP requests, by method and route pattern.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "path"}),
+	}
+
+	registerer.MustRegister(m.requests, m.duration)
+
+	return m
+}
+
+// RequestServed increments the request counter and observes the duration
+func (m *HTTPMetrics) RequestServed(method, route string, status int, duration time.Duration) {
+	method = methodLabel(method)
+
+	m.requests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.duration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+func methodLabel(method string) string {
+	if methodLabels[method] {
+		return method
+	}
+	return "OTHER"
+}
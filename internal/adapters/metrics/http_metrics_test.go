@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPMetrics_RequestServed(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := NewHTTPMetrics(registry).(*HTTPMetrics)
+
+	m.RequestServed(http.MethodGet, "/v1/accounts/{accountId}", http.StatusOK, 20*time.Millisecond)
+	m.RequestServed(http.MethodGet, "/v1/accounts/{accountId}", http.StatusOK, 30*time.Millisecond)
+	m.RequestServed(http.MethodGet, "/v1/accounts/{accountId}", http.StatusNotFound, 10*time.Millisecond)
+	m.RequestServed("BREW", "unmatched", http.StatusMethodNotAllowed, time.Millisecond)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.requests.WithLabelValues("GET", "/v1/accounts/{accountId}", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues("GET", "/v1/accounts/{accountId}", "404")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues("OTHER", "unmatched", "405")), "Unknown methods share one label")
+	assert.Equal(t, 3, testutil.CollectAndCount(m.requests))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.duration), "Durations are not split by status")
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// IdempotencyMetrics implements the ports.IdempotencyMetrics interface with Prometheus counters
+type IdempotencyMetrics struct {
+	hits   prometheus.Counter
+	misses prometheus.Counter
+}
+
+// NewIdempotencyMetrics creates the idempotency counters and registers them
+func NewIdempotencyMetrics(registerer prometheus.Registerer) ports.IdempotencyMetrics {
+	m := &IdempotencyMetrics{
+		hits: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "idempotency_hits_total",
+			Help: "Total number of requests answered from a stored response for their Idempotency-Key.",
+		}),
+		misses: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "idempotency_misses_total",
+			Help: "Total number of requests with an Idempotency-Key that were processed.",
+		}),
+	}
+
+	registerer.MustRegister(m.hits, m.misses)
+
+	return m
+}
+
+// Hit increments the hit counter
+func (m *IdempotencyMetrics) Hit() {
+	m.hits.Inc()
+}
+
+// Miss increments the miss counter
+func (m *IdempotencyMetrics) Miss() {
+	m.misses.Inc()
+}
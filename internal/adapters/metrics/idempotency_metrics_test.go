@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := NewIdempotencyMetrics(registry).(*IdempotencyMetrics)
+
+	m.Miss()
+	m.Hit()
+	m.Hit()
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.hits))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.misses))
+	assert.Equal(t, 2, testutil.CollectAndCount(registry, "idempotency_hits_total", "idempotency_misses_total"))
+}
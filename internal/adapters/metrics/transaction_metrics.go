@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"math"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// operationTypeLabels keeps label cardinality bounded to the known operation types
+var operationTypeLabels = map[int64]string{
+	domain.OperationTypePurchase:                 "purchase",
+	domain.OperationTypePurchaseWithInstallments: "purchase_with_installments",
+	domain.OperationTypeWithdrawal:               "withdrawal",
+	domain.OperationTypeCreditVoucher:            "credit_voucher",
+}
+
+// TransactionMetrics implements the ports.TransactionMetrics interface with Prometheus collectors
+type TransactionMetrics struct {
+	created *prometheus.CounterVec
+	amount  *prometheus.HistogramVec
+}
+
+// NewTransactionMetrics creates the transaction collectors and registers them
+func NewTransactionMetrics(registerer prometheus.Registerer) ports.TransactionMetrics {
+	m := &TransactionMetrics{
+		created: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "transactions_created_total",
+			Help: "Total number of transactions created, by operation type.",
+		}, []string{"operation_type"}),
+		amount: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "transaction_amount",
+			Help:    "Absolute amount of created transactions, by operation type and direction.",
+			Buckets: []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000},
+		}, []string{"operation_type", "direction"}),
+	}
+
+	registerer.MustRegister(m.created, m.amount)
+
+	return m
+}
+
+// TransactionCreated increments the counter and observes the amount for the operation type
+func (m *TransactionMetrics) TransactionCreated(operationType *domain.OperationType, amount float64) {
+	label := operationTypeLabel(operationType)
+
+	m.created.WithLabelValues(label).Inc()
+	m.amount.WithLabelValues(label, directionLabel(operationType)).Observe(math.Abs(amount))
+}
+
+func operationTypeLabel(operationType *domain.OperationType) string {
+	if operationType == nil {
+		return "unknown"
+	}
+	if label, ok := operationTypeLabels[operationType.ID]; ok {
+		return label
+	}
+	return "unknown"
+}
+
+func directionLabel(operationType *domain.OperationType) string {
+	if operationType == nil {
+		return "unknown"
+	}
+	return operationType.Direction()
+}
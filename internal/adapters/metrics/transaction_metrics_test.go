@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionMetrics_TransactionCreated(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := NewTransactionMetrics(registry).(*TransactionMetrics)
+
+	withdrawal := &domain.OperationType{ID: domain.OperationTypeWithdrawal, Description: "Withdrawal"}
+	creditVoucher := &domain.OperationType{ID: domain.OperationTypeCreditVoucher, Description: "Credit Voucher", IsCredit: true}
+
+	m.TransactionCreated(withdrawal, -50.0)
+	m.TransactionCreated(withdrawal, -25.0)
+	m.TransactionCreated(creditVoucher, 100.0)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.created.WithLabelValues("withdrawal")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.created.WithLabelValues("credit_voucher")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.created.WithLabelValues("purchase")))
+
+	// Histogram series are labeled by type and direction
+	assert.Equal(t, 2, testutil.CollectAndCount(m.amount, "transaction_amount"))
+}
+
+func TestTransactionMetrics_UnknownOperationTypeIsBounded(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := NewTransactionMetrics(registry).(*TransactionMetrics)
+
+	m.TransactionCreated(&domain.OperationType{ID: 99}, 10.0)
+	m.TransactionCreated(nil, 10.0)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.created.WithLabelValues("unknown")))
+}
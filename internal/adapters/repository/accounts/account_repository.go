@@ -5,6 +5,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqlerr"
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqltime"
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqltx"
 	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
 	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
 )
@@ -18,28 +21,32 @@ func NewAccountRepository(db *sql.DB) ports.AccountRepository {
 	return &AccountRepository{db: db}
 }
 
+// conn returns the database transaction bound to ctx, or the database outside of one
+func (r *AccountRepository) conn(ctx context.Context) sqltx.Querier {
+	return sqltx.Conn(ctx, r.db)
+}
+
 func (r *AccountRepository) Create(ctx context.Context, account *domain.Account) (*domain.Account, error) {
-	var result domain.Account
+	tier := account.Tier
+	if tier == "" {
+		tier = domain.DefaultAccountTier
+	}
 
-	err := r.db.QueryRowContext(ctx, createAccountSQL, account.DocumentNumber).
-		Scan(&result.ID, &result.DocumentNumber, &result.CreatedAt)
+	result, err := scanAccount(r.conn(ctx).QueryRowContext(ctx, createAccountSQL, account.DocumentNumber, tier, account.CreditLimit))
 
 	if err != nil {
-		// Check for unique constraint violation
-		if err.Error() == "UNIQUE constraint failed: accounts.document_number" {
+		// document_number is the only unique column; closed accounts keep theirs
+		if sqlerr.IsUniqueViolation(err) {
 			return nil, errors.New("account with this document number already exists")
 		}
-		return nil, fmt.Errorf("failed to create account: %w", err)
+		return nil, fmt.Errorf("failed to create account: %w", sqlerr.Translate(err))
 	}
 
-	return &result, nil
+	return result, nil
 }
 
 func (r *AccountRepository) FindByID(ctx context.Context, id int64) (*domain.Account, error) {
-	var account domain.Account
-
-	err := r.db.QueryRowContext(ctx, findAccountByIDSQL, id).
-		Scan(&account.ID, &account.DocumentNumber, &account.CreatedAt)
+	account, err := scanAccount(r.conn(ctx).QueryRowContext(ctx, findAccountByIDSQL, id))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -48,14 +55,41 @@ func (r *AccountRepository) FindByID(ctx context.Context, id int64) (*domain.Acc
 		return nil, fmt.Errorf("failed to find account: %w", err)
 	}
 
-	return &account, nil
+	return account, nil
 }
 
-func (r *AccountRepository) FindByDocumentNumber(ctx context.Context, documentNumber string) (*domain.Account, error) {
-	var account domain.Account
+// Exists checks for the account without scanning its columns
+func (r *AccountRepository) Exists(ctx context.Context, id int64) (bool, error) {
+	var found int
+	err := r.conn(ctx).QueryRowContext(ctx, accountExistsSQL, id).Scan(&found)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check account existence: %w", err)
+	}
+
+	return true, nil
+}
 
-	err := r.db.QueryRowContext(ctx, findAccountByDocumentNumberSQL, documentNumber).
-		Scan(&account.ID, &account.DocumentNumber, &account.CreatedAt)
+// IsClosed looks for a soft-deleted row, the only one the other lookups leave out
+func (r *AccountRepository) IsClosed(ctx context.Context, id int64) (bool, error) {
+	var found int
+	err := r.conn(ctx).QueryRowContext(ctx, accountClosedSQL, id).Scan(&found)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check whether account is closed: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *AccountRepository) FindByDocumentNumber(ctx context.Context, documentNumber string) (*domain.Account, error) {
+	account, err := scanAccount(r.conn(ctx).QueryRowContext(ctx, findAccountByDocumentNumberSQL, documentNumber))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -64,24 +98,74 @@ func (r *AccountRepository) FindByDocumentNumber(ctx context.Context, documentNu
 		return nil, fmt.Errorf("failed to find account: %w", err)
 	}
 
-	return &account, nil
+	return account, nil
+}
+
+// SoftDelete sets deleted_at, unless the account is missing or already deleted
+func (r *AccountRepository) SoftDelete(ctx context.Context, id int64) (bool, error) {
+	result, err := r.conn(ctx).ExecContext(ctx, softDeleteAccountSQL, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete account: %w", sqlerr.Translate(err))
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete account: %w", err)
+	}
+
+	return affected > 0, nil
 }
 
 func (r *AccountRepository) GetAll(ctx context.Context) ([]*domain.Account, error) {
-	rows, err := r.db.QueryContext(ctx, getAllAccountsSQL)
+	rows, err := r.conn(ctx).QueryContext(ctx, getAllAccountsSQL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get accounts: %w", err)
 	}
 	defer rows.Close()
 
+	return scanAccounts(rows)
+}
+
+func (r *AccountRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.conn(ctx).QueryRowContext(ctx, countAccountsSQL).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count accounts: %w", sqlerr.Translate(err))
+	}
+	return count, nil
+}
+
+func (r *AccountRepository) GetAllPaginated(ctx context.Context, limit int64, offset int64) ([]*domain.Account, int64, error) {
+	var total int64
+
+	err := r.conn(ctx).QueryRowContext(ctx, countAccountsSQL).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count accounts: %w", err)
+	}
+
+	rows, err := r.conn(ctx).QueryContext(ctx, getAllAccountsPaginatedSQL, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get paginated accounts: %w", err)
+	}
+	defer rows.Close()
+
+	accounts, err := scanAccounts(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return accounts, total, nil
+}
+
+// scanAccounts scans every remaining row into an account
+func scanAccounts(rows *sql.Rows) ([]*domain.Account, error) {
 	var accounts []*domain.Account
 
 	for rows.Next() {
-		var account domain.Account
-		if err := rows.Scan(&account.ID, &account.DocumentNumber, &account.CreatedAt); err != nil {
+		account, err := scanAccount(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan account: %w", err)
 		}
-		accounts = append(accounts, &account)
+		accounts = append(accounts, account)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -90,3 +174,20 @@ func (r *AccountRepository) GetAll(ctx context.Context) ([]*domain.Account, erro
 
 	return accounts, nil
 }
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanAccount is a helper to scan a single account row
+// When adding new columns, just update this method!
+func scanAccount(row rowScanner) (*domain.Account, error) {
+	var account domain.Account
+
+	if err := row.Scan(&account.ID, &account.DocumentNumber, &account.Tier, &account.CreditLimit, sqltime.UTC(&account.CreatedAt), sqltime.NullUTC(&account.VerifiedAt)); err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
@@ -3,10 +3,13 @@ package accounts
 import (
 	"context"
 	"database/sql"
+	"math"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/larissamartinsss/simple-banking-api/infra/database"
 	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -32,9 +35,31 @@ func TestCreate(t *testing.T) {
 			account: &domain.Account{DocumentNumber: "12345678900"},
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				mock.ExpectQuery("INSERT INTO accounts").
-					WithArgs("12345678900").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "created_at"}).
-						AddRow(1, "12345678900", time.Now()))
+					WithArgs("12345678900", "standard", 0.0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "tier", "credit_limit", "created_at", "verified_at"}).
+						AddRow(1, "12345678900", "standard", 0.0, time.Now(), nil))
+			},
+			wantErr: false,
+		},
+		{
+			name:    "creation with explicit tier",
+			account: &domain.Account{DocumentNumber: "12345678900", Tier: "basic"},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO accounts").
+					WithArgs("12345678900", "basic", 0.0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "tier", "credit_limit", "created_at", "verified_at"}).
+						AddRow(1, "12345678900", "basic", 0.0, time.Now(), nil))
+			},
+			wantErr: false,
+		},
+		{
+			name:    "creation with credit limit",
+			account: &domain.Account{DocumentNumber: "12345678900", CreditLimit: 500},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO accounts").
+					WithArgs("12345678900", "standard", 500.0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "tier", "credit_limit", "created_at", "verified_at"}).
+						AddRow(1, "12345678900", "standard", 500.0, time.Now(), nil))
 			},
 			wantErr: false,
 		},
@@ -43,7 +68,7 @@ func TestCreate(t *testing.T) {
 			account: &domain.Account{DocumentNumber: "12345678900"},
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				mock.ExpectQuery("INSERT INTO accounts").
-					WithArgs("12345678900").
+					WithArgs("12345678900", "standard", 0.0).
 					WillReturnError(sql.ErrConnDone)
 			},
 			wantErr:     true,
@@ -90,8 +115,8 @@ func TestFindByID(t *testing.T) {
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				mock.ExpectQuery("SELECT (.+) FROM accounts WHERE id").
 					WithArgs(int64(1)).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "created_at"}).
-						AddRow(1, "12345678900", time.Now()))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "tier", "credit_limit", "created_at", "verified_at"}).
+						AddRow(1, "12345678900", "standard", 0.0, time.Now(), nil))
 			},
 			wantFound: true,
 		},
@@ -141,8 +166,8 @@ func TestFindByDocumentNumber(t *testing.T) {
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				mock.ExpectQuery("SELECT (.+) FROM accounts WHERE document_number").
 					WithArgs("12345678900").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "created_at"}).
-						AddRow(1, "12345678900", time.Now()))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "tier", "credit_limit", "created_at", "verified_at"}).
+						AddRow(1, "12345678900", "standard", 0.0, time.Now(), nil))
 			},
 			wantFound: true,
 		},
@@ -189,7 +214,7 @@ func TestGetAll(t *testing.T) {
 			name: "empty",
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				mock.ExpectQuery("SELECT (.+) FROM accounts").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "created_at"}))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "tier", "credit_limit", "created_at", "verified_at"}))
 			},
 			wantCount: 0,
 		},
@@ -198,10 +223,10 @@ func TestGetAll(t *testing.T) {
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				now := time.Now()
 				mock.ExpectQuery("SELECT (.+) FROM accounts").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "created_at"}).
-						AddRow(1, "11111111111", now).
-						AddRow(2, "22222222222", now).
-						AddRow(3, "33333333333", now))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "tier", "credit_limit", "created_at", "verified_at"}).
+						AddRow(1, "11111111111", "standard", 0.0, now, nil).
+						AddRow(2, "22222222222", "standard", 0.0, now, nil).
+						AddRow(3, "33333333333", "standard", 0.0, now, nil))
 			},
 			wantCount: 3,
 		},
@@ -222,3 +247,253 @@ func TestGetAll(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAllPaginated(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT COUNT(.+) FROM accounts").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery("SELECT (.+) FROM accounts (.+) LIMIT").
+		WithArgs(int64(2), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "tier", "credit_limit", "created_at", "verified_at"}).
+			AddRow(3, "33333333333", "standard", 0.0, now, nil).
+			AddRow(2, "22222222222", "standard", 0.0, now, nil))
+
+	results, total, err := repo.GetAllPaginated(context.Background(), 2, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	require.Len(t, results, 2)
+	assert.Equal(t, int64(3), results[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExists(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        int64
+		mockSetup func(sqlmock.Sqlmock)
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name: "existing account",
+			id:   1,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT 1 FROM accounts WHERE id = (.+) LIMIT 1").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+			},
+			want: true,
+		},
+		{
+			name: "missing account",
+			id:   999,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT 1 FROM accounts WHERE id = (.+) LIMIT 1").
+					WithArgs(int64(999)).
+					WillReturnError(sql.ErrNoRows)
+			},
+			want: false,
+		},
+		{
+			name: "database error",
+			id:   1,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT 1 FROM accounts WHERE id = (.+) LIMIT 1").
+					WithArgs(int64(1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, repo := setupMock(t)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			exists, err := repo.Exists(context.Background(), tt.id)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "failed to check account existence")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, exists)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFindByID_VerifiedAt(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM accounts WHERE id").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "tier", "credit_limit", "created_at", "verified_at"}).
+			AddRow(1, "12345678900", "standard", 0.0, "2024-01-01 10:00:00", "2025-03-01 09:30:00"))
+
+	result, err := repo.FindByID(context.Background(), 1)
+
+	require.NoError(t, err)
+	require.NotNil(t, result.VerifiedAt)
+	assert.Equal(t, time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC), *result.VerifiedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindByID_IDAboveMaxInt32(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "large-id.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+
+	id := int64(math.MaxInt32) + 10
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (?, '12345678900')", id)
+	require.NoError(t, err)
+
+	repo := NewAccountRepository(db)
+
+	// Truncated to 32 bits the ID would wrap to a negative number and find nothing
+	result, err := repo.FindByID(ctx, id)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, id, result.ID)
+	assert.Equal(t, "12345678900", result.DocumentNumber)
+}
+
+func TestCount(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "count.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	repo := NewAccountRepository(db)
+
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count, "Empty database")
+
+	for _, documentNumber := range []string{"11111111111", "22222222222", "33333333333"} {
+		_, err := repo.Create(ctx, &domain.Account{DocumentNumber: documentNumber})
+		require.NoError(t, err)
+	}
+
+	count, err = repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestCount_Error(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT COUNT(.+) FROM accounts").WillReturnError(sql.ErrConnDone)
+
+	_, err := repo.Count(context.Background())
+
+	assert.ErrorContains(t, err, "failed to count accounts")
+}
+
+func TestSoftDelete(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "soft_delete.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	repo := NewAccountRepository(db)
+
+	deleted, err := repo.Create(ctx, &domain.Account{DocumentNumber: "11111111111"})
+	require.NoError(t, err)
+	kept, err := repo.Create(ctx, &domain.Account{DocumentNumber: "22222222222"})
+	require.NoError(t, err)
+
+	ok, err := repo.SoftDelete(ctx, deleted.ID)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = repo.SoftDelete(ctx, deleted.ID)
+	require.NoError(t, err)
+	assert.False(t, ok, "An account is deleted once")
+
+	ok, err = repo.SoftDelete(ctx, 999)
+	require.NoError(t, err)
+	assert.False(t, ok, "Unknown accounts cannot be deleted")
+
+	account, err := repo.FindByID(ctx, deleted.ID)
+	require.NoError(t, err)
+	assert.Nil(t, account)
+
+	account, err = repo.FindByDocumentNumber(ctx, "11111111111")
+	require.NoError(t, err)
+	assert.Nil(t, account)
+
+	exists, err := repo.Exists(ctx, deleted.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	closed, err := repo.IsClosed(ctx, deleted.ID)
+	require.NoError(t, err)
+	assert.True(t, closed, "A deleted account is told apart from one that never existed")
+
+	closed, err = repo.IsClosed(ctx, kept.ID)
+	require.NoError(t, err)
+	assert.False(t, closed)
+
+	closed, err = repo.IsClosed(ctx, 999)
+	require.NoError(t, err)
+	assert.False(t, closed, "Unknown accounts are not closed")
+
+	accounts, total, err := repo.GetAllPaginated(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, accounts, 1)
+	assert.Equal(t, kept.ID, accounts[0].ID)
+
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// The row is kept, so the history of the account is not lost
+	var deletedAt sql.NullString
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT deleted_at FROM accounts WHERE id = ?", deleted.ID).Scan(&deletedAt))
+	assert.True(t, deletedAt.Valid)
+}
+
+func TestCreate_DuplicateDocumentNumber(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "duplicate.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	repo := NewAccountRepository(db)
+
+	closed, err := repo.Create(ctx, &domain.Account{DocumentNumber: "11111111111"})
+	require.NoError(t, err)
+	_, err = repo.SoftDelete(ctx, closed.ID)
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, &domain.Account{DocumentNumber: "11111111111"})
+
+	assert.EqualError(t, err, "account with this document number already exists")
+}
@@ -3,26 +3,61 @@ package accounts
 // SQL queries - Accounts
 const (
 	createAccountSQL = `
-		INSERT INTO accounts (document_number, created_at)
-		VALUES (?, CURRENT_TIMESTAMP)
-		RETURNING id, document_number, created_at
+		INSERT INTO accounts (document_number, tier, credit_limit, created_at)
+		VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+		RETURNING id, document_number, tier, credit_limit, created_at, verified_at
 	`
 
 	findAccountByIDSQL = `
-		SELECT id, document_number, created_at
+		SELECT id, document_number, tier, credit_limit, created_at, verified_at
 		FROM accounts
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	accountExistsSQL = `
+		SELECT 1
+		FROM accounts
+		WHERE id = ? AND deleted_at IS NULL
+		LIMIT 1
+	`
+
+	accountClosedSQL = `
+		SELECT 1
+		FROM accounts
+		WHERE id = ? AND deleted_at IS NOT NULL
+		LIMIT 1
 	`
 
 	findAccountByDocumentNumberSQL = `
-		SELECT id, document_number, created_at
+		SELECT id, document_number, tier, credit_limit, created_at, verified_at
 		FROM accounts
-		WHERE document_number = ?
+		WHERE document_number = ? AND deleted_at IS NULL
 	`
 
 	getAllAccountsSQL = `
-		SELECT id, document_number, created_at
+		SELECT id, document_number, tier, credit_limit, created_at, verified_at
 		FROM accounts
+		WHERE deleted_at IS NULL
 		ORDER BY created_at DESC
 	`
+
+	countAccountsSQL = `
+		SELECT COUNT(*)
+		FROM accounts
+		WHERE deleted_at IS NULL
+	`
+
+	getAllAccountsPaginatedSQL = `
+		SELECT id, document_number, tier, credit_limit, created_at, verified_at
+		FROM accounts
+		WHERE deleted_at IS NULL
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	softDeleteAccountSQL = `
+		UPDATE accounts
+		SET deleted_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND deleted_at IS NULL
+	`
 )
@@ -0,0 +1,52 @@
+package balances
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqlerr"
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqltx"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// AccountBalanceRepository implements the ports.AccountBalanceRepository interface
+type AccountBalanceRepository struct {
+	db *sql.DB
+}
+
+func NewAccountBalanceRepository(db *sql.DB) ports.AccountBalanceRepository {
+	return &AccountBalanceRepository{db: db}
+}
+
+// conn returns the database transaction bound to ctx, or the database outside of one
+func (r *AccountBalanceRepository) conn(ctx context.Context) sqltx.Querier {
+	return sqltx.Conn(ctx, r.db)
+}
+
+func (r *AccountBalanceRepository) RecomputeBatch(ctx context.Context, afterAccountID int64, limit int64) (int64, int64, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, recomputeBalancesBatchSQL, afterAccountID, limit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to recompute balances: %w", sqlerr.Translate(err))
+	}
+	defer rows.Close()
+
+	lastAccountID := afterAccountID
+	var processed int64
+	for rows.Next() {
+		var accountID int64
+		if err := rows.Scan(&accountID); err != nil {
+			return 0, 0, fmt.Errorf("failed to scan recomputed account: %w", err)
+		}
+		if accountID > lastAccountID {
+			lastAccountID = accountID
+		}
+		processed++
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, 0, fmt.Errorf("error iterating recomputed accounts: %w", sqlerr.Translate(err))
+	}
+
+	return lastAccountID, processed, nil
+}
@@ -0,0 +1,125 @@
+package balances
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/larissamartinsss/simple-banking-api/infra/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDatabase(t *testing.T) *sql.DB {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "balances.db"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (1, 'Normal Purchase'), (4, 'Credit Voucher')")
+	require.NoError(t, err)
+
+	return db
+}
+
+// summedBalances returns the balance of every account summed straight from the transaction log
+func summedBalances(t *testing.T, db *sql.DB) map[int64]float64 {
+	rows, err := db.Query(`
+		SELECT a.id, ROUND(COALESCE(SUM(t.amount), 0), 2)
+		FROM accounts a LEFT JOIN transactions t ON t.account_id = a.id
+		GROUP BY a.id`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	balances := map[int64]float64{}
+	for rows.Next() {
+		var id int64
+		var balance float64
+		require.NoError(t, rows.Scan(&id, &balance))
+		balances[id] = balance
+	}
+	require.NoError(t, rows.Err())
+	return balances
+}
+
+func cachedBalances(t *testing.T, db *sql.DB) map[int64]float64 {
+	rows, err := db.Query("SELECT account_id, balance FROM account_balances")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	balances := map[int64]float64{}
+	for rows.Next() {
+		var id int64
+		var balance float64
+		require.NoError(t, rows.Scan(&id, &balance))
+		balances[id] = balance
+	}
+	require.NoError(t, rows.Err())
+	return balances
+}
+
+func TestRecomputeBatch_MatchesSummedLog(t *testing.T) {
+	db := setupDatabase(t)
+	ctx := context.Background()
+
+	_, err := db.ExecContext(ctx, `INSERT INTO accounts (id, document_number) VALUES
+		(1, '11111111111'), (2, '22222222222'), (3, '33333333333'), (5, '55555555555'), (8, '88888888888')`)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `INSERT INTO transactions (account_id, operation_type_id, amount) VALUES
+		(1, 1, -50.0), (1, 4, 100.0), (1, 1, -0.1), (1, 1, -0.2),
+		(2, 1, -23.5),
+		(5, 4, 60.0), (5, 4, 0.3)`)
+	require.NoError(t, err)
+
+	// A stale value from before a data fix must be overwritten
+	_, err = db.ExecContext(ctx, "INSERT INTO account_balances (account_id, balance) VALUES (1, 999.0)")
+	require.NoError(t, err)
+
+	repo := NewAccountBalanceRepository(db)
+
+	last, processed, err := repo.RecomputeBatch(ctx, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), last)
+	assert.Equal(t, int64(2), processed)
+
+	last, processed, err = repo.RecomputeBatch(ctx, last, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), last)
+	assert.Equal(t, int64(2), processed)
+
+	last, processed, err = repo.RecomputeBatch(ctx, last, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), last)
+	assert.Equal(t, int64(1), processed)
+
+	_, processed, err = repo.RecomputeBatch(ctx, last, 2)
+	require.NoError(t, err)
+	assert.Zero(t, processed, "No accounts left after the last one")
+
+	expected := summedBalances(t, db)
+	assert.Equal(t, expected, cachedBalances(t, db))
+	assert.Equal(t, 49.7, expected[1])
+	assert.Equal(t, 0.0, expected[3], "Accounts without transactions have a zero balance")
+}
+
+func TestRecomputeBatch_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("INSERT INTO account_balances").
+		WithArgs(int64(10), int64(100)).
+		WillReturnError(sql.ErrConnDone)
+
+	repo := NewAccountBalanceRepository(db)
+	_, _, err = repo.RecomputeBatch(context.Background(), 10, 100)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to recompute balances")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
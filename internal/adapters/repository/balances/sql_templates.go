@@ -0,0 +1,21 @@
+package balances
+
+// SQL queries - Account balances
+const (
+	// Each batch is summed and written in a single statement, so it never observes a half-applied transaction
+	// and only holds the write lock briefly while the API keeps serving
+	recomputeBalancesBatchSQL = `
+		INSERT INTO account_balances (account_id, balance, updated_at)
+		SELECT a.id,
+			ROUND(COALESCE((SELECT SUM(t.amount) FROM transactions t WHERE t.account_id = a.id), 0), 2),
+			CURRENT_TIMESTAMP
+		FROM accounts a
+		WHERE a.id > ?
+		ORDER BY a.id
+		LIMIT ?
+		ON CONFLICT(account_id) DO UPDATE SET
+			balance = excluded.balance,
+			updated_at = excluded.updated_at
+		RETURNING account_id
+	`
+)
@@ -0,0 +1,145 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqlerr"
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqltime"
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqltx"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// IdempotencyRepository implements the ports.IdempotencyStore interface
+type IdempotencyRepository struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+func NewIdempotencyRepository(db *sql.DB) ports.IdempotencyStore {
+	return &IdempotencyRepository{db: db, now: time.Now}
+}
+
+// conn returns the database transaction bound to ctx, or the database outside of one
+func (r *IdempotencyRepository) conn(ctx context.Context) sqltx.Querier {
+	return sqltx.Conn(ctx, r.db)
+}
+
+func (r *IdempotencyRepository) Get(ctx context.Context, key string) (*domain.IdempotencyRecord, error) {
+	var (
+		record         domain.IdempotencyRecord
+		status         string
+		requestHash    sql.NullString
+		responseStatus sql.NullInt64
+	)
+	err := r.conn(ctx).QueryRowContext(ctx, getIdempotencyKeySQL, key).Scan(
+		&record.Key,
+		&status,
+		&requestHash,
+		&responseStatus,
+		&record.ResponseBody,
+		sqltime.UTC(&record.CreatedAt),
+		sqltime.NullUTC(&record.ExpiresAt),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", sqlerr.Translate(err))
+	}
+
+	record.Status = domain.IdempotencyStatus(status)
+	record.RequestHash = requestHash.String
+	record.ResponseStatus = int(responseStatus.Int64)
+	return &record, nil
+}
+
+func (r *IdempotencyRepository) GetMetadata(ctx context.Context, key string) (*domain.IdempotencyRecord, error) {
+	var (
+		record         domain.IdempotencyRecord
+		status         string
+		responseStatus sql.NullInt64
+	)
+	err := r.conn(ctx).QueryRowContext(ctx, getIdempotencyKeyMetadataSQL, key).Scan(
+		&record.Key,
+		&status,
+		&responseStatus,
+		sqltime.UTC(&record.CreatedAt),
+		sqltime.NullUTC(&record.ExpiresAt),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", sqlerr.Translate(err))
+	}
+
+	record.Status = domain.IdempotencyStatus(status)
+	record.ResponseStatus = int(responseStatus.Int64)
+	return &record, nil
+}
+
+func (r *IdempotencyRepository) SetProcessing(ctx context.Context, key string) (bool, error) {
+	now := r.now()
+	result, err := r.conn(ctx).ExecContext(ctx, claimIdempotencyKeySQL,
+		key,
+		sqltime.Format(now),
+		sqltime.Format(now.Add(-domain.IdempotencyStaleProcessingAfter)),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim idempotency key: %w", sqlerr.Translate(err))
+	}
+
+	claimed, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+	return claimed > 0, nil
+}
+
+func (r *IdempotencyRepository) SetResult(ctx context.Context, record *domain.IdempotencyRecord) error {
+	var expires any
+	if record.ExpiresAt != nil {
+		expires = sqltime.Format(*record.ExpiresAt)
+	}
+
+	_, err := r.conn(ctx).ExecContext(ctx, completeIdempotencyKeySQL,
+		record.RequestHash,
+		record.ResponseStatus,
+		record.ResponseBody,
+		expires,
+		record.Key,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", sqlerr.Translate(err))
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) Delete(ctx context.Context, key string) error {
+	_, err := r.conn(ctx).ExecContext(ctx, deleteIdempotencyKeySQL, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", sqlerr.Translate(err))
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.conn(ctx).ExecContext(ctx, deleteExpiredIdempotencyKeysSQL,
+		sqltime.Format(before),
+		sqltime.Format(before.Add(-domain.IdempotencyStaleProcessingAfter)),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", sqlerr.Translate(err))
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,234 @@
+package idempotency
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/infra/database"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRepository(t *testing.T) *IdempotencyRepository {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "idempotency.db"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	require.NoError(t, database.RunMigrations(context.Background(), db))
+
+	return NewIdempotencyRepository(db).(*IdempotencyRepository)
+}
+
+func TestIdempotencyRepository_Lifecycle(t *testing.T) {
+	repo := setupRepository(t)
+	ctx := context.Background()
+
+	record, err := repo.Get(ctx, "key-1")
+	require.NoError(t, err)
+	assert.Nil(t, record, "Unknown keys have no record")
+
+	claimed, err := repo.SetProcessing(ctx, "key-1")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = repo.SetProcessing(ctx, "key-1")
+	require.NoError(t, err)
+	assert.False(t, claimed, "A key being processed cannot be claimed again")
+
+	record, err = repo.Get(ctx, "key-1")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, domain.IdempotencyStatusProcessing, record.Status)
+
+	require.NoError(t, repo.SetResult(ctx, &domain.IdempotencyRecord{
+		Key:            "key-1",
+		RequestHash:    "f00d",
+		ResponseStatus: 201,
+		ResponseBody:   []byte(`{"transaction_id":1}`),
+	}))
+
+	record, err = repo.Get(ctx, "key-1")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, domain.IdempotencyStatusCompleted, record.Status)
+	assert.Equal(t, "f00d", record.RequestHash)
+	assert.Equal(t, 201, record.ResponseStatus)
+	assert.Equal(t, []byte(`{"transaction_id":1}`), record.ResponseBody)
+	assert.Nil(t, record.ExpiresAt)
+
+	claimed, err = repo.SetProcessing(ctx, "key-1")
+	require.NoError(t, err)
+	assert.False(t, claimed, "A completed key cannot be claimed again")
+
+	require.NoError(t, repo.Delete(ctx, "key-1"))
+
+	claimed, err = repo.SetProcessing(ctx, "key-1")
+	require.NoError(t, err)
+	assert.True(t, claimed, "A deleted key can be claimed again")
+}
+
+func TestIdempotencyRepository_SetResultWithExpiry(t *testing.T) {
+	repo := setupRepository(t)
+	ctx := context.Background()
+	expiresAt := time.Date(2025, 11, 16, 14, 37, 3, 0, time.UTC)
+
+	_, err := repo.SetProcessing(ctx, "failed-key")
+	require.NoError(t, err)
+	require.NoError(t, repo.SetResult(ctx, &domain.IdempotencyRecord{
+		Key:            "failed-key",
+		ResponseStatus: 500,
+		ResponseBody:   []byte(`{"error":"boom"}`),
+		ExpiresAt:      &expiresAt,
+	}))
+
+	record, err := repo.Get(ctx, "failed-key")
+	require.NoError(t, err)
+	require.NotNil(t, record.ExpiresAt)
+	assert.Equal(t, expiresAt, *record.ExpiresAt)
+	assert.True(t, record.Expired(expiresAt))
+}
+
+func TestIdempotencyRepository_ReclaimsStaleProcessingKey(t *testing.T) {
+	repo := setupRepository(t)
+	ctx := context.Background()
+	start := time.Date(2025, 11, 16, 14, 0, 0, 0, time.UTC)
+
+	repo.now = func() time.Time { return start }
+	claimed, err := repo.SetProcessing(ctx, "abandoned-key")
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	repo.now = func() time.Time { return start.Add(domain.IdempotencyStaleProcessingAfter - time.Second) }
+	claimed, err = repo.SetProcessing(ctx, "abandoned-key")
+	require.NoError(t, err)
+	assert.False(t, claimed, "A key still within the processing window is kept")
+
+	repo.now = func() time.Time { return start.Add(domain.IdempotencyStaleProcessingAfter + time.Second) }
+	claimed, err = repo.SetProcessing(ctx, "abandoned-key")
+	require.NoError(t, err)
+	assert.True(t, claimed, "A key left processing by a crash is taken over")
+}
+
+func TestIdempotencyRepository_DeleteExpired(t *testing.T) {
+	repo := setupRepository(t)
+	ctx := context.Background()
+	now := time.Date(2025, 11, 16, 14, 0, 0, 0, time.UTC)
+	expired := now.Add(-time.Minute)
+	live := now.Add(time.Hour)
+
+	for key, expiresAt := range map[string]*time.Time{"expired": &expired, "live": &live, "forever": nil} {
+		_, err := repo.SetProcessing(ctx, key)
+		require.NoError(t, err)
+		require.NoError(t, repo.SetResult(ctx, &domain.IdempotencyRecord{Key: key, ResponseStatus: 201, ExpiresAt: expiresAt}))
+	}
+	repo.now = func() time.Time { return now.Add(-time.Minute) }
+	_, err := repo.SetProcessing(ctx, "processing")
+	require.NoError(t, err)
+	repo.now = func() time.Time { return now.Add(-domain.IdempotencyStaleProcessingAfter - time.Minute) }
+	_, err = repo.SetProcessing(ctx, "abandoned")
+	require.NoError(t, err)
+
+	deleted, err := repo.DeleteExpired(ctx, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted, "Expired responses and abandoned keys are deleted")
+	for key, wantKept := range map[string]bool{"expired": false, "live": true, "forever": true, "processing": true, "abandoned": false} {
+		record, err := repo.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, wantKept, record != nil, key)
+	}
+}
+
+func TestRunJanitor_DeletesExpiredKeys(t *testing.T) {
+	repo := setupRepository(t)
+	ctx := context.Background()
+	expired := time.Now().Add(-time.Minute)
+
+	_, err := repo.SetProcessing(ctx, "expired")
+	require.NoError(t, err)
+	require.NoError(t, repo.SetResult(ctx, &domain.IdempotencyRecord{Key: "expired", ResponseStatus: 201, ExpiresAt: &expired}))
+
+	janitorCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		RunJanitor(janitorCtx, repo, 5*time.Millisecond, slog.New(slog.DiscardHandler))
+	}()
+
+	assert.Eventually(t, func() bool {
+		record, err := repo.Get(ctx, "expired")
+		return err == nil && record == nil
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func TestIdempotencyRepository_GetMetadata(t *testing.T) {
+	repo := setupRepository(t)
+	ctx := context.Background()
+
+	record, err := repo.GetMetadata(ctx, "key-1")
+	require.NoError(t, err)
+	assert.Nil(t, record, "Unknown keys have no metadata")
+
+	claimed, err := repo.SetProcessing(ctx, "key-1")
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	require.NoError(t, repo.SetResult(ctx, &domain.IdempotencyRecord{
+		Key:            "key-1",
+		RequestHash:    "f00d",
+		ResponseStatus: 201,
+		ResponseBody:   []byte(`{"transaction_id":1}`),
+		ExpiresAt:      &expires,
+	}))
+
+	record, err = repo.GetMetadata(ctx, "key-1")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, "key-1", record.Key)
+	assert.Equal(t, domain.IdempotencyStatusCompleted, record.Status)
+	assert.Equal(t, 201, record.ResponseStatus)
+	assert.False(t, record.CreatedAt.IsZero())
+	require.NotNil(t, record.ExpiresAt)
+	assert.True(t, expires.Equal(*record.ExpiresAt))
+	assert.Nil(t, record.ResponseBody, "The stored response is not read")
+}
+
+func TestIdempotencyRepository_KeysAreCaseSensitive(t *testing.T) {
+	repo := setupRepository(t)
+	ctx := context.Background()
+
+	claimed, err := repo.SetProcessing(ctx, "key-abc")
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	claimed, err = repo.SetProcessing(ctx, "KEY-ABC")
+	require.NoError(t, err)
+	assert.True(t, claimed, "A key differing only in case is another key")
+
+	require.NoError(t, repo.SetResult(ctx, &domain.IdempotencyRecord{
+		Key:            "KEY-ABC",
+		RequestHash:    "f00d",
+		ResponseStatus: 201,
+		ResponseBody:   []byte(`{"transaction_id":2}`),
+	}))
+
+	record, err := repo.Get(ctx, "key-abc")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, "key-abc", record.Key)
+	assert.Equal(t, domain.IdempotencyStatusProcessing, record.Status, "Completing one key leaves the other alone")
+
+	record, err = repo.Get(ctx, "Key-Abc")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+}
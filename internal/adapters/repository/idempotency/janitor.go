@@ -0,0 +1,37 @@
+package idempotency
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// DefaultJanitorInterval is how often expired idempotency keys are purged
+const DefaultJanitorInterval = 10 * time.Minute
+
+// RunJanitor deletes the expired idempotency keys every interval until ctx is cancelled,
+// so the table does not grow with every key ever used
+// Expired keys are already ignored when a request arrives; this only reclaims their space
+func RunJanitor(ctx context.Context, store ports.IdempotencyStore, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := store.DeleteExpired(ctx, time.Now())
+			switch {
+			case err != nil:
+				if ctx.Err() == nil {
+					logger.Error("Idempotency key cleanup failed", "error", err)
+				}
+			case deleted > 0:
+				logger.Info("Idempotency key cleanup", "deleted", deleted)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package idempotency
+
+// SQL queries - Idempotency keys
+const (
+	getIdempotencyKeySQL = `
+		SELECT key, status, request_hash, response_status, response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE key = ?
+	`
+
+	getIdempotencyKeyMetadataSQL = `
+		SELECT key, status, response_status, created_at, expires_at
+		FROM idempotency_keys
+		WHERE key = ?
+	`
+
+	// A key left processing since before the stale cutoff (e.g. by a crashed replica) is taken over,
+	// otherwise an existing key is left untouched and no row changes
+	claimIdempotencyKeySQL = `
+		INSERT INTO idempotency_keys (key, status, created_at)
+		VALUES (?, 'processing', ?)
+		ON CONFLICT(key) DO UPDATE SET
+			created_at = excluded.created_at
+		WHERE idempotency_keys.status = 'processing' AND idempotency_keys.created_at < ?
+	`
+
+	completeIdempotencyKeySQL = `
+		UPDATE idempotency_keys
+		SET status = 'completed', request_hash = ?, response_status = ?, response_body = ?, expires_at = ?
+		WHERE key = ?
+	`
+
+	// Keys left processing since before the stale cutoff are abandoned, so they go too
+	deleteExpiredIdempotencyKeysSQL = `
+		DELETE FROM idempotency_keys
+		WHERE (expires_at IS NOT NULL AND expires_at <= ?)
+			OR (status = 'processing' AND created_at <= ?)
+	`
+
+	deleteIdempotencyKeySQL = `
+		DELETE FROM idempotency_keys
+		WHERE key = ?
+	`
+)
@@ -4,26 +4,54 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqltime"
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqltx"
 	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
 	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
 )
 
 // OperationTypeRepository implements the ports.OperationTypeRepository interface
 type OperationTypeRepository struct {
-	db *sql.DB
+	db     *sql.DB
+	locale string
+}
+
+// Option configures optional behavior of the OperationTypeRepository
+type Option func(*OperationTypeRepository)
+
+// WithLocale selects the language of the descriptions written by Seed
+func WithLocale(locale string) Option {
+	return func(r *OperationTypeRepository) {
+		r.locale = locale
+	}
 }
 
 // NewOperationTypeRepository creates a new operation type repository
-func NewOperationTypeRepository(db *sql.DB) ports.OperationTypeRepository {
-	return &OperationTypeRepository{db: db}
+func NewOperationTypeRepository(db *sql.DB, opts ...Option) ports.OperationTypeRepository {
+	r := &OperationTypeRepository{
+		db:     db,
+		locale: domain.DefaultLocale,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// conn returns the database transaction bound to ctx, or the database outside of one
+func (r *OperationTypeRepository) conn(ctx context.Context) sqltx.Querier {
+	return sqltx.Conn(ctx, r.db)
 }
 
 // FindByID retrieves an operation type by its ID
 func (r *OperationTypeRepository) FindByID(ctx context.Context, id int64) (*domain.OperationType, error) {
 	var opType domain.OperationType
 
-	err := r.db.QueryRowContext(ctx, findOperationTypeByIDSQL, id).
-		Scan(&opType.ID, &opType.Description, &opType.CreatedAt)
+	err := r.conn(ctx).QueryRowContext(ctx, findOperationTypeByIDSQL, id).
+		Scan(&opType.ID, &opType.Description, &opType.IsCredit, sqltime.UTC(&opType.CreatedAt))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -37,7 +65,7 @@ func (r *OperationTypeRepository) FindByID(ctx context.Context, id int64) (*doma
 
 // GetAll retrieves all operation types
 func (r *OperationTypeRepository) GetAll(ctx context.Context) ([]*domain.OperationType, error) {
-	rows, err := r.db.QueryContext(ctx, getAllOperationTypesSQL)
+	rows, err := r.conn(ctx).QueryContext(ctx, getAllOperationTypesSQL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get operation types: %w", err)
 	}
@@ -47,7 +75,7 @@ func (r *OperationTypeRepository) GetAll(ctx context.Context) ([]*domain.Operati
 
 	for rows.Next() {
 		var opType domain.OperationType
-		if err := rows.Scan(&opType.ID, &opType.Description, &opType.CreatedAt); err != nil {
+		if err := rows.Scan(&opType.ID, &opType.Description, &opType.IsCredit, sqltime.UTC(&opType.CreatedAt)); err != nil {
 			return nil, fmt.Errorf("failed to scan operation type: %w", err)
 		}
 		operationTypes = append(operationTypes, &opType)
@@ -61,19 +89,15 @@ func (r *OperationTypeRepository) GetAll(ctx context.Context) ([]*domain.Operati
 }
 
 // Seed initializes the database with the predefined operation types
+// Descriptions follow the configured locale and are rewritten when the locale changes
 func (r *OperationTypeRepository) Seed(ctx context.Context) error {
-	operationTypes := []struct {
-		ID          int64
-		Description string
-	}{
-		{domain.OperationTypePurchase, "Normal Purchase"},
-		{domain.OperationTypePurchaseWithInstallments, "Purchase with installments"},
-		{domain.OperationTypeWithdrawal, "Withdrawal"},
-		{domain.OperationTypeCreditVoucher, "Credit Voucher"},
+	operationTypes, err := domain.OperationTypes(r.locale)
+	if err != nil {
+		return fmt.Errorf("failed to seed operation types: %w", err)
 	}
 
 	for _, ot := range operationTypes {
-		_, err := r.db.ExecContext(ctx, insertOperationTypeSQL, ot.ID, ot.Description)
+		_, err := r.conn(ctx).ExecContext(ctx, insertOperationTypeSQL, ot.ID, ot.Description, ot.IsCredit)
 		if err != nil {
 			return fmt.Errorf("failed to seed operation type %d: %w", ot.ID, err)
 		}
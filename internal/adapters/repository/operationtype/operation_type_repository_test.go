@@ -3,10 +3,13 @@ package operationtype
 import (
 	"context"
 	"database/sql"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/larissamartinsss/simple-banking-api/infra/database"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -20,11 +23,12 @@ func setupMock(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *OperationTypeRepository
 
 func TestFindByID(t *testing.T) {
 	tests := []struct {
-		name      string
-		id        int64
-		mockSetup func(sqlmock.Sqlmock)
-		wantFound bool
-		wantDesc  string
+		name       string
+		id         int64
+		mockSetup  func(sqlmock.Sqlmock)
+		wantFound  bool
+		wantDesc   string
+		wantCredit bool
 	}{
 		{
 			name: "purchase",
@@ -32,8 +36,8 @@ func TestFindByID(t *testing.T) {
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				mock.ExpectQuery("SELECT (.+) FROM operation_types WHERE id").
 					WithArgs(int64(1)).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "description", "created_at"}).
-						AddRow(1, "COMPRA A VISTA", time.Now()))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "description", "is_credit", "created_at"}).
+						AddRow(1, "COMPRA A VISTA", false, time.Now()))
 			},
 			wantFound: true,
 			wantDesc:  "COMPRA A VISTA",
@@ -44,11 +48,12 @@ func TestFindByID(t *testing.T) {
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				mock.ExpectQuery("SELECT (.+) FROM operation_types WHERE id").
 					WithArgs(int64(4)).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "description", "created_at"}).
-						AddRow(4, "PAGAMENTO", time.Now()))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "description", "is_credit", "created_at"}).
+						AddRow(4, "PAGAMENTO", true, time.Now()))
 			},
-			wantFound: true,
-			wantDesc:  "PAGAMENTO",
+			wantFound:  true,
+			wantDesc:   "PAGAMENTO",
+			wantCredit: true,
 		},
 		{
 			name: "not found",
@@ -76,6 +81,7 @@ func TestFindByID(t *testing.T) {
 				assert.NotNil(t, result)
 				assert.Equal(t, tt.id, result.ID)
 				assert.Equal(t, tt.wantDesc, result.Description)
+				assert.Equal(t, tt.wantCredit, result.IsCredit)
 			} else {
 				assert.Nil(t, result)
 			}
@@ -90,11 +96,11 @@ func TestGetAll(t *testing.T) {
 
 	now := time.Now()
 	mock.ExpectQuery("SELECT (.+) FROM operation_types").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "description", "created_at"}).
-			AddRow(1, "COMPRA A VISTA", now).
-			AddRow(2, "COMPRA PARCELADA", now).
-			AddRow(3, "SAQUE", now).
-			AddRow(4, "PAGAMENTO", now))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "description", "is_credit", "created_at"}).
+			AddRow(1, "COMPRA A VISTA", false, now).
+			AddRow(2, "COMPRA PARCELADA", false, now).
+			AddRow(3, "SAQUE", false, now).
+			AddRow(4, "PAGAMENTO", true, now))
 
 	results, err := repo.GetAll(context.Background())
 
@@ -102,6 +108,93 @@ func TestGetAll(t *testing.T) {
 	assert.Len(t, results, 4)
 	assert.Equal(t, "COMPRA A VISTA", results[0].Description)
 	assert.Equal(t, int64(1), results[0].ID)
+	assert.False(t, results[0].IsCredit)
 	assert.Equal(t, "PAGAMENTO", results[3].Description)
+	assert.True(t, results[3].IsCredit)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindByID_ClassifiesTypeAddedAfterSeed(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "operation_types.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	repo := NewOperationTypeRepository(db)
+	require.NoError(t, repo.Seed(ctx))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description, is_credit) VALUES (5, 'Cashback', 1)")
+	require.NoError(t, err)
+
+	cashback, err := repo.FindByID(ctx, 5)
+	require.NoError(t, err)
+	require.NotNil(t, cashback)
+	assert.True(t, cashback.IsCreditOperation())
+
+	withdrawal, err := repo.FindByID(ctx, domain.OperationTypeWithdrawal)
+	require.NoError(t, err)
+	require.NotNil(t, withdrawal)
+	assert.True(t, withdrawal.IsDebitOperation())
+
+	creditVoucher, err := repo.FindByID(ctx, domain.OperationTypeCreditVoucher)
+	require.NoError(t, err)
+	require.NotNil(t, creditVoucher)
+	assert.True(t, creditVoucher.IsCreditOperation())
+}
+
+func TestSeed(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected []string
+	}{
+		{
+			name:     "default locale seeds english descriptions",
+			expected: []string{"Normal Purchase", "Purchase with installments", "Withdrawal", "Credit Voucher"},
+		},
+		{
+			name:     "english locale",
+			opts:     []Option{WithLocale("en")},
+			expected: []string{"Normal Purchase", "Purchase with installments", "Withdrawal", "Credit Voucher"},
+		},
+		{
+			name:     "portuguese locale",
+			opts:     []Option{WithLocale("pt")},
+			expected: []string{"COMPRA A VISTA", "COMPRA PARCELADA", "SAQUE", "PAGAMENTO"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			for i, description := range tt.expected {
+				mock.ExpectExec("INSERT INTO operation_types").
+					WithArgs(int64(i+1), description, i+1 == 4).
+					WillReturnResult(sqlmock.NewResult(int64(i+1), 1))
+			}
+
+			repo := NewOperationTypeRepository(db, tt.opts...)
+
+			require.NoError(t, repo.Seed(context.Background()))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSeed_UnsupportedLocale(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewOperationTypeRepository(db, WithLocale("fr"))
+
+	err = repo.Seed(context.Background())
+
+	assert.ErrorIs(t, err, domain.ErrUnsupportedLocale)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
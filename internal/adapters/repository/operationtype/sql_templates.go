@@ -3,19 +3,20 @@ package operationtype
 // SQL queries - OperationTypes
 const (
 	findOperationTypeByIDSQL = `
-		SELECT id, description, created_at
+		SELECT id, description, is_credit, created_at
 		FROM operation_types
 		WHERE id = ?
 	`
 
 	getAllOperationTypesSQL = `
-		SELECT id, description, created_at
+		SELECT id, description, is_credit, created_at
 		FROM operation_types
 		ORDER BY id
 	`
 
 	insertOperationTypeSQL = `
-		INSERT OR IGNORE INTO operation_types (id, description, created_at)
-		VALUES (?, ?, CURRENT_TIMESTAMP)
+		INSERT INTO operation_types (id, description, is_credit, created_at)
+		VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(id) DO UPDATE SET description = excluded.description, is_credit = excluded.is_credit
 	`
 )
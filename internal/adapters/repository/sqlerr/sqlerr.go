@@ -0,0 +1,44 @@
+package sqlerr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// IsBusy reports whether err means SQLite gave up waiting for a lock, i.e. busy_timeout was exceeded
+func IsBusy(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+
+	// Extended result codes (e.g. SQLITE_BUSY_SNAPSHOT) keep the primary code in the low byte
+	switch sqliteErr.Code() & 0xff {
+	case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsUniqueViolation reports whether err means a write failed on a UNIQUE constraint
+func IsUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	return sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
+
+// Translate marks busy errors with domain.ErrServiceUnavailable so callers can ask clients to back off
+// Any other error is returned unchanged
+func Translate(err error) error {
+	if IsBusy(err) {
+		return fmt.Errorf("%w: %w", domain.ErrServiceUnavailable, err)
+	}
+	return err
+}
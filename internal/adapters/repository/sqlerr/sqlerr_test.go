@@ -0,0 +1,90 @@
+package sqlerr
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+// lockedDatabase returns a connection whose writes fail because another connection holds the write lock
+func lockedDatabase(t *testing.T) *sql.DB {
+	path := filepath.Join(t.TempDir(), "busy.db")
+
+	holder, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { holder.Close() })
+
+	_, err = holder.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY)")
+	require.NoError(t, err)
+
+	lock, err := holder.Conn(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { lock.Close() })
+	_, err = lock.ExecContext(context.Background(), "BEGIN IMMEDIATE")
+	require.NoError(t, err)
+	t.Cleanup(func() { lock.ExecContext(context.Background(), "ROLLBACK") })
+
+	writer, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { writer.Close() })
+	writer.SetMaxOpenConns(1)
+	_, err = writer.Exec("PRAGMA busy_timeout = 10")
+	require.NoError(t, err)
+
+	return writer
+}
+
+func TestIsBusy(t *testing.T) {
+	db := lockedDatabase(t)
+
+	_, err := db.Exec("INSERT INTO items (id) VALUES (1)")
+	require.Error(t, err)
+
+	assert.True(t, IsBusy(err))
+	assert.True(t, IsBusy(errors.Join(errors.New("failed to create"), err)), "Wrapped busy errors are detected")
+	assert.False(t, IsBusy(sql.ErrNoRows))
+	assert.False(t, IsBusy(nil))
+}
+
+func TestTranslate(t *testing.T) {
+	db := lockedDatabase(t)
+
+	_, busyErr := db.Exec("INSERT INTO items (id) VALUES (1)")
+	require.Error(t, busyErr)
+
+	err := Translate(busyErr)
+	assert.ErrorIs(t, err, domain.ErrServiceUnavailable)
+	assert.ErrorIs(t, err, busyErr, "The driver error is kept")
+
+	assert.Equal(t, sql.ErrNoRows, Translate(sql.ErrNoRows))
+	assert.NoError(t, Translate(nil))
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "unique.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT UNIQUE, size INTEGER NOT NULL)")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO items (name, size) VALUES ('a', 1)")
+	require.NoError(t, err)
+
+	_, uniqueErr := db.Exec("INSERT INTO items (name, size) VALUES ('a', 2)")
+	require.Error(t, uniqueErr)
+	assert.True(t, IsUniqueViolation(uniqueErr))
+	assert.True(t, IsUniqueViolation(errors.Join(errors.New("failed to create"), uniqueErr)), "Wrapped violations are detected")
+
+	_, notNullErr := db.Exec("INSERT INTO items (name) VALUES ('b')")
+	require.Error(t, notNullErr)
+	assert.False(t, IsUniqueViolation(notNullErr), "Other constraints are not unique violations")
+	assert.False(t, IsUniqueViolation(sql.ErrNoRows))
+	assert.False(t, IsUniqueViolation(nil))
+}
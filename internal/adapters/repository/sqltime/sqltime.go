@@ -0,0 +1,94 @@
+package sqltime
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Layout is the UTC format used to store timestamps
+// It extends SQLite's CURRENT_TIMESTAMP format ("2006-01-02 15:04:05") with fractional seconds,
+// so values written by Go and by SQLite sort and compare consistently
+const Layout = "2006-01-02 15:04:05.999999999"
+
+// parseLayouts lists the formats a timestamp column may hold, most common first
+var parseLayouts = []string{
+	Layout,
+	"2006-01-02 15:04:05.999999999-07:00",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// Format renders t as a UTC timestamp for storage
+func Format(t time.Time) string {
+	return t.UTC().Format(Layout)
+}
+
+// Parse reads a stored timestamp as UTC
+// Values without an offset (such as CURRENT_TIMESTAMP) are interpreted as UTC
+func Parse(value string) (time.Time, error) {
+	for _, layout := range parseLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported timestamp format %q", value)
+}
+
+// UTC returns a sql.Scanner that stores the scanned column into dst as UTC
+func UTC(dst *time.Time) sql.Scanner {
+	return &utcScanner{dst: dst}
+}
+
+// NullUTC returns a sql.Scanner for a nullable timestamp column: NULL leaves *dst nil, anything else is stored as UTC
+func NullUTC(dst **time.Time) sql.Scanner {
+	return &nullUTCScanner{dst: dst}
+}
+
+type nullUTCScanner struct {
+	dst **time.Time
+}
+
+func (s *nullUTCScanner) Scan(src any) error {
+	if src == nil {
+		*s.dst = nil
+		return nil
+	}
+
+	var t time.Time
+	if err := (&utcScanner{dst: &t}).Scan(src); err != nil {
+		return err
+	}
+	*s.dst = &t
+	return nil
+}
+
+type utcScanner struct {
+	dst *time.Time
+}
+
+func (s *utcScanner) Scan(src any) error {
+	switch v := src.(type) {
+	case time.Time:
+		*s.dst = v.UTC()
+	case string:
+		t, err := Parse(v)
+		if err != nil {
+			return err
+		}
+		*s.dst = t
+	case []byte:
+		t, err := Parse(string(v))
+		if err != nil {
+			return err
+		}
+		*s.dst = t
+	case nil:
+		*s.dst = time.Time{}
+	default:
+		return fmt.Errorf("cannot scan %T into time.Time", src)
+	}
+	return nil
+}
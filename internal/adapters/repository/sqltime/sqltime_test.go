@@ -0,0 +1,71 @@
+package sqltime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUTC_Scan(t *testing.T) {
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+	want := time.Date(2025, 11, 16, 14, 37, 3, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		src  any
+		want time.Time
+	}{
+		{name: "sqlite CURRENT_TIMESTAMP string", src: "2025-11-16 14:37:03", want: want},
+		{name: "sqlite CURRENT_TIMESTAMP bytes", src: []byte("2025-11-16 14:37:03"), want: want},
+		{name: "stored layout with fraction", src: "2025-11-16 14:37:03.5", want: want.Add(500 * time.Millisecond)},
+		{name: "RFC3339 with offset", src: "2025-11-16T11:37:03-03:00", want: want},
+		{name: "driver time in another zone", src: want.In(saoPaulo), want: want},
+		{name: "null", src: nil, want: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got time.Time
+
+			require.NoError(t, UTC(&got).Scan(tt.src))
+
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+			if !got.IsZero() {
+				assert.Equal(t, time.UTC, got.Location())
+			}
+		})
+	}
+}
+
+func TestUTC_Scan_InvalidValue(t *testing.T) {
+	var got time.Time
+
+	assert.Error(t, UTC(&got).Scan("yesterday"))
+	assert.Error(t, UTC(&got).Scan(42))
+}
+
+func TestFormat_RoundTrip(t *testing.T) {
+	original := time.Date(2025, 11, 16, 11, 37, 3, 123456789, time.FixedZone("BRT", -3*60*60))
+
+	stored := Format(original)
+	parsed, err := Parse(stored)
+
+	require.NoError(t, err)
+	assert.Equal(t, "2025-11-16 14:37:03.123456789", stored)
+	assert.True(t, original.Equal(parsed))
+}
+
+func TestNullUTC_Scan(t *testing.T) {
+	var got *time.Time
+
+	require.NoError(t, NullUTC(&got).Scan(nil))
+	assert.Nil(t, got)
+
+	require.NoError(t, NullUTC(&got).Scan("2025-11-16 14:37:03"))
+	require.NotNil(t, got)
+	assert.Equal(t, time.Date(2025, 11, 16, 14, 37, 3, 0, time.UTC), *got)
+
+	assert.Error(t, NullUTC(&got).Scan("yesterday"))
+}
@@ -0,0 +1,91 @@
+package sqltx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqlerr"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// ErrTransactionInProgress is returned by Begin when the context already carries a transaction
+var ErrTransactionInProgress = errors.New("a database transaction is already bound to the context")
+
+// Querier is satisfied by both *sql.DB and *sql.Tx
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type contextKey struct{}
+
+// Provider implements the ports.TxProvider interface
+type Provider struct {
+	db *sql.DB
+}
+
+func NewProvider(db *sql.DB) ports.TxProvider {
+	return &Provider{db: db}
+}
+
+func (p *Provider) Begin(ctx context.Context) (context.Context, ports.Tx, error) {
+	if FromContext(ctx) != nil {
+		return nil, nil, ErrTransactionInProgress
+	}
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", sqlerr.Translate(err))
+	}
+
+	return context.WithValue(ctx, contextKey{}, tx), tx, nil
+}
+
+func (p *Provider) FromContext(ctx context.Context) ports.Tx {
+	// A nil *sql.Tx must not become a non-nil interface
+	if tx := FromContext(ctx); tx != nil {
+		return tx
+	}
+	return nil
+}
+
+// FromContext returns the transaction bound to ctx by Provider.Begin, or nil when there is none
+func FromContext(ctx context.Context) *sql.Tx {
+	tx, _ := ctx.Value(contextKey{}).(*sql.Tx)
+	return tx
+}
+
+// Conn returns the transaction bound to ctx, or db outside of one
+// Repositories query through it so their calls join the transaction of the request
+func Conn(ctx context.Context, db *sql.DB) Querier {
+	if tx := FromContext(ctx); tx != nil {
+		return tx
+	}
+	return db
+}
+
+// InTx runs fn in the transaction bound to ctx, leaving its commit to whoever began it
+// Outside of one, fn runs in a new transaction of db that is committed when fn succeeds
+func InTx(ctx context.Context, db *sql.DB, fn func(q Querier) error) error {
+	if tx := FromContext(ctx); tx != nil {
+		return fn(tx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", sqlerr.Translate(err))
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", sqlerr.Translate(err))
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package sqltx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/infra/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDB(t *testing.T) *sql.DB {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "sqltx.db"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec("CREATE TABLE items (name TEXT NOT NULL)")
+	require.NoError(t, err)
+	return db
+}
+
+func countItems(t *testing.T, db *sql.DB) int {
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM items").Scan(&count))
+	return count
+}
+
+func TestProvider_CommitAndRollback(t *testing.T) {
+	db := setupDB(t)
+	provider := NewProvider(db)
+
+	ctx, tx, err := provider.Begin(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, tx, provider.FromContext(ctx))
+	_, err = Conn(ctx, db).ExecContext(ctx, "INSERT INTO items (name) VALUES ('rolled back')")
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback())
+	assert.Equal(t, 0, countItems(t, db))
+
+	ctx, tx, err = provider.Begin(context.Background())
+	require.NoError(t, err)
+	_, err = Conn(ctx, db).ExecContext(ctx, "INSERT INTO items (name) VALUES ('committed')")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	assert.Equal(t, 1, countItems(t, db))
+}
+
+func TestProvider_BeginTwice(t *testing.T) {
+	provider := NewProvider(setupDB(t))
+
+	ctx, tx, err := provider.Begin(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	_, _, err = provider.Begin(ctx)
+	assert.ErrorIs(t, err, ErrTransactionInProgress)
+}
+
+func TestProvider_FromContextWithoutTransaction(t *testing.T) {
+	provider := NewProvider(setupDB(t))
+
+	assert.Nil(t, provider.FromContext(context.Background()))
+}
+
+func TestInTx(t *testing.T) {
+	insert := func(ctx context.Context) func(q Querier) error {
+		return func(q Querier) error {
+			_, err := q.ExecContext(ctx, "INSERT INTO items (name) VALUES ('item')")
+			return err
+		}
+	}
+
+	t.Run("commits its own transaction", func(t *testing.T) {
+		db := setupDB(t)
+
+		require.NoError(t, InTx(context.Background(), db, insert(context.Background())))
+		assert.Equal(t, 1, countItems(t, db))
+	})
+
+	t.Run("rolls back its own transaction on error", func(t *testing.T) {
+		db := setupDB(t)
+		ctx := context.Background()
+
+		err := InTx(ctx, db, func(q Querier) error {
+			require.NoError(t, insert(ctx)(q))
+			return errors.New("failed")
+		})
+
+		assert.EqualError(t, err, "failed")
+		assert.Equal(t, 0, countItems(t, db))
+	})
+
+	t.Run("joins the transaction of the context", func(t *testing.T) {
+		db := setupDB(t)
+		ctx, tx, err := NewProvider(db).Begin(context.Background())
+		require.NoError(t, err)
+
+		require.NoError(t, InTx(ctx, db, insert(ctx)))
+		require.NoError(t, tx.Rollback())
+
+		assert.Equal(t, 0, countItems(t, db), "The outer rollback undoes the joined work")
+	})
+}
@@ -0,0 +1,155 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/infra/database"
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqltime"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+)
+
+// benchmarkTransactionCount is the number of transactions seeded for the benchmark account
+const benchmarkTransactionCount = 20000
+
+// benchmarkPageSize matches the default page size of the transactions endpoint
+const benchmarkPageSize = 50
+
+// findByAccountIDKeysetSQL is the proposed keyset (cursor) variant: it seeks past the last row
+// of the previous page instead of skipping OFFSET rows, so its cost does not grow with depth
+const findByAccountIDKeysetSQL = `SELECT id, account_id, operation_type_id, amount, event_date
+	FROM transactions
+	WHERE account_id = ? AND (event_date, id) < (?, ?)
+	ORDER BY event_date DESC, id DESC
+	LIMIT ?`
+
+// seedBenchmarkDB creates a migrated SQLite database holding n transactions for account 1
+func seedBenchmarkDB(b *testing.B, n int) *sql.DB {
+	b.Helper()
+
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(b.TempDir(), "pagination.db"),
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { db.Close() })
+
+	ctx := context.Background()
+	if err := database.RunMigrations(ctx, db); err != nil {
+		b.Fatal(err)
+	}
+
+	// Index supporting the keyset ordering; the offset query uses it as well
+	fixtures := []string{
+		"CREATE INDEX IF NOT EXISTS idx_transactions_account_event ON transactions(account_id, event_date, id)",
+		"INSERT INTO operation_types (id, description) VALUES (1, 'Normal Purchase')",
+		"INSERT INTO accounts (id, document_number) VALUES (1, '12345678900')",
+	}
+	for _, fixture := range fixtures {
+		if _, err := db.ExecContext(ctx, fixture); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	stmt, err := tx.PrepareContext(ctx, "INSERT INTO transactions (account_id, operation_type_id, amount, event_date) VALUES (1, 1, ?, ?)")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		if _, err := stmt.ExecContext(ctx, -float64(i%500+1), sqltime.Format(base.Add(time.Duration(i)*time.Minute))); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	stmt.Close()
+	if err := tx.Commit(); err != nil {
+		b.Fatal(err)
+	}
+
+	return db
+}
+
+// keysetCursor returns the last row of the page preceding offset, which keyset pagination seeks past
+func keysetCursor(b *testing.B, db *sql.DB, offset int) (time.Time, int64) {
+	b.Helper()
+
+	var eventDate time.Time
+	var id int64
+	err := db.QueryRow(
+		"SELECT event_date, id FROM transactions WHERE account_id = 1 ORDER BY event_date DESC, id DESC LIMIT 1 OFFSET ?",
+		offset-1,
+	).Scan(sqltime.UTC(&eventDate), &id)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return eventDate, id
+}
+
+func findByAccountIDKeyset(ctx context.Context, db *sql.DB, accountID int64, afterDate time.Time, afterID int64, limit int64) ([]*domain.Transaction, error) {
+	rows, err := db.QueryContext(ctx, findByAccountIDKeysetSQL, accountID, sqltime.Format(afterDate), afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	repo := &TransactionRepository{db: db}
+	return repo.scanTransactions(rows)
+}
+
+// BenchmarkPagination compares offset pagination with the keyset variant at increasing depths
+// The offset cases go through FindByAccountIDPaginated, including its COUNT query, as the endpoint does
+// Run with: go test ./internal/adapters/repository/transactions -run '^$' -bench Pagination
+func BenchmarkPagination(b *testing.B) {
+	db := seedBenchmarkDB(b, benchmarkTransactionCount)
+	repo := NewTransactionRepository(db)
+	ctx := context.Background()
+
+	offsets := []int{0, 1000, 5000, 10000, benchmarkTransactionCount - benchmarkPageSize}
+
+	for _, offset := range offsets {
+		b.Run(fmt.Sprintf("offset/%d", offset), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				page, _, err := repo.FindByAccountIDPaginated(ctx, 1, benchmarkPageSize, int64(offset))
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(page) != benchmarkPageSize {
+					b.Fatalf("expected %d rows, got %d", benchmarkPageSize, len(page))
+				}
+			}
+		})
+	}
+
+	for _, offset := range offsets {
+		b.Run(fmt.Sprintf("keyset/%d", offset), func(b *testing.B) {
+			// The first page has no cursor; start just after the newest row
+			afterDate, afterID := time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC), int64(1<<62)
+			if offset > 0 {
+				afterDate, afterID = keysetCursor(b, db, offset)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				page, err := findByAccountIDKeyset(ctx, db, 1, afterDate, afterID, benchmarkPageSize)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(page) != benchmarkPageSize {
+					b.Fatalf("expected %d rows, got %d", benchmarkPageSize, len(page))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package transactions
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqltime"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+)
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTransaction scans the id, account_id, operation_type_id, amount and event_date columns, then extra
+// The required columns are read as nullable, so a NULL left by a bad migration or corruption is reported
+// as domain.ErrCorruptTransaction naming the record and the columns, instead of a driver conversion error
+func scanTransaction(row rowScanner, extra ...any) (*domain.Transaction, error) {
+	var (
+		id, accountID, operationTypeID sql.NullInt64
+		amount                         sql.NullFloat64
+		eventDate                      *time.Time
+	)
+
+	dest := append([]any{&id, &accountID, &operationTypeID, &amount, sqltime.NullUTC(&eventDate)}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	var nulls []string
+	for _, column := range []struct {
+		name  string
+		valid bool
+	}{
+		{"id", id.Valid},
+		{"account_id", accountID.Valid},
+		{"operation_type_id", operationTypeID.Valid},
+		{"amount", amount.Valid},
+		{"event_date", eventDate != nil},
+	} {
+		if !column.valid {
+			nulls = append(nulls, column.name)
+		}
+	}
+	if len(nulls) > 0 {
+		recordID := "NULL"
+		if id.Valid {
+			recordID = fmt.Sprint(id.Int64)
+		}
+		err := fmt.Errorf("%w id=%s: NULL in required columns %s", domain.ErrCorruptTransaction, recordID, strings.Join(nulls, ", "))
+		log.Print(err)
+		return nil, err
+	}
+
+	return &domain.Transaction{
+		ID:              id.Int64,
+		AccountID:       accountID.Int64,
+		OperationTypeID: operationTypeID.Int64,
+		Amount:          amount.Float64,
+		EventDate:       *eventDate,
+	}, nil
+}
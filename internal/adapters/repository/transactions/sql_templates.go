@@ -3,19 +3,79 @@ package transactions
 // SQL queries - Transactions
 const (
 	createTransactionSQL = `
-		INSERT INTO transactions (account_id, operation_type_id, amount, event_date, created_at)
-		VALUES (?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		INSERT INTO transactions (account_id, operation_type_id, amount, event_date, idempotency_key, reversal_of, installment_of, balance, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
 		RETURNING id, account_id, operation_type_id, amount, event_date
 	`
 
+	// The balance check and the insert run as a single statement, so concurrent writers cannot interleave
+	// Balances are compared rounded to cents to avoid floating point drift
+	createTransactionIfBalanceSQL = `
+		INSERT INTO transactions (account_id, operation_type_id, amount, event_date, idempotency_key, reversal_of, installment_of, balance, created_at)
+		SELECT ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP
+		WHERE (
+			SELECT ROUND(COALESCE(SUM(amount), 0), 2)
+			FROM transactions
+			WHERE account_id = ?
+		) = ROUND(?, 2)
+		RETURNING id, account_id, operation_type_id, amount, event_date
+	`
+
+	// created_at is set by SQLite, so the window is measured against its own clock
+	findRecentDuplicateTransactionSQL = `
+		SELECT id, account_id, operation_type_id, amount, event_date
+		FROM transactions
+		WHERE account_id = ?
+			AND operation_type_id = ?
+			AND ROUND(amount, 2) = ROUND(?, 2)
+			AND created_at >= datetime('now', ?)
+		ORDER BY id DESC
+		LIMIT 1
+	`
+
 	// Simple query - easy to extend with JOINs later
 	// Example: SELECT t.*, m.name as merchant_name FROM transactions t LEFT JOIN merchants m ON t.merchant_id = m.id
 	findTransactionByIDSQL = `
-		SELECT id, account_id, operation_type_id, amount, event_date
+		SELECT id, account_id, operation_type_id, amount, event_date, balance
 		FROM transactions
 		WHERE id = ?
 	`
 
+	// The earliest row wins, should a key ever have been stored on more than one transaction
+	findTransactionByIdempotencyKeySQL = `
+		SELECT id, account_id, operation_type_id, amount, event_date, balance
+		FROM transactions
+		WHERE account_id = ? AND idempotency_key = ?
+		ORDER BY id ASC
+		LIMIT 1
+	`
+
+	findOutstandingByAccountIDSQL = `
+		SELECT id, account_id, operation_type_id, amount, event_date, balance
+		FROM transactions
+		WHERE account_id = ? AND balance < 0
+		ORDER BY event_date ASC, id ASC
+	`
+
+	// Applied only while the balance is still the one the discharge was computed from
+	dischargeTransactionSQL = `
+		UPDATE transactions
+		SET balance = ?
+		WHERE id = ? AND ROUND(balance, 2) = ROUND(?, 2)
+	`
+
+	findReversalSQL = `
+		SELECT id, account_id, operation_type_id, amount, event_date, balance
+		FROM transactions
+		WHERE reversal_of = ?
+	`
+
+	findReversedSQL = `
+		SELECT id, account_id, operation_type_id, amount, event_date, balance
+		FROM transactions
+		WHERE id = (SELECT reversal_of FROM transactions WHERE id = ?)
+	`
+
 	findTransactionsByAccountIDSQL = `
 		SELECT id, account_id, operation_type_id, amount, event_date
 		FROM transactions
@@ -23,11 +83,84 @@ const (
 		ORDER BY event_date DESC
 	`
 
-	findByAccountIDPaginatedSQL = `SELECT id, account_id, operation_type_id, amount, event_date
+	// The account listings carry the operation type description; LEFT JOIN keeps a transaction
+	// whose type row is missing, with an empty description
+	findByAccountIDPaginatedSQL = `SELECT t.id, t.account_id, t.operation_type_id, t.amount, t.event_date, ot.description
+		FROM transactions t
+		LEFT JOIN operation_types ot ON ot.id = t.operation_type_id
+		WHERE t.account_id = ?
+		ORDER BY t.event_date DESC, t.id DESC
+		LIMIT ? OFFSET ?`
+
+	sumTransactionsByAccountIDSQL = `
+		SELECT COALESCE(SUM(amount), 0)
 		FROM transactions
 		WHERE account_id = ?
-		ORDER BY event_date DESC
-		LIMIT ? OFFSET ?`
+	`
+
+	sumTransactionsByDirectionSQL = `
+		SELECT
+			COALESCE(SUM(CASE WHEN amount < 0 THEN amount ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN amount > 0 THEN amount ELSE 0 END), 0)
+		FROM transactions
+		WHERE account_id = ?
+	`
+
+	sumTransactionsBeforeSQL = `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM transactions
+		WHERE account_id = ? AND event_date < ?
+	`
+
+	sumTransactionsAsOfSQL = `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM transactions
+		WHERE account_id = ? AND event_date <= ?
+	`
+
+	findTransactionsBetweenSQL = `
+		SELECT id, account_id, operation_type_id, amount, event_date
+		FROM transactions
+		WHERE account_id = ? AND event_date >= ? AND event_date <= ?
+		ORDER BY event_date ASC, id ASC
+	`
+
+	// date() truncates the stored UTC timestamp to its day
+	dailyTotalsBetweenSQL = `
+		SELECT date(event_date) AS day, COUNT(*), COALESCE(SUM(amount), 0)
+		FROM transactions
+		WHERE account_id = ? AND event_date >= ? AND event_date <= ?
+		GROUP BY day
+		ORDER BY day ASC
+	`
+
+	// strftime() truncates the stored UTC timestamp to its month; debits are the negative amounts
+	// On PostgreSQL the month is to_char(event_date, 'YYYY-MM') and the sums SUM(amount) FILTER (WHERE amount < 0)
+	monthlyTotalsBetweenSQL = `
+		SELECT strftime('%Y-%m', event_date) AS month,
+			COALESCE(SUM(CASE WHEN amount < 0 THEN amount ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN amount > 0 THEN amount ELSE 0 END), 0)
+		FROM transactions
+		WHERE account_id = ? AND event_date >= ? AND event_date <= ?
+		GROUP BY month
+		ORDER BY month ASC
+	`
+
+	// The window sums the daily totals in day order, giving the running total at the end of each day
+	runningTotalsBetweenSQL = `
+		SELECT date(event_date) AS day, SUM(SUM(amount)) OVER (ORDER BY date(event_date))
+		FROM transactions
+		WHERE account_id = ? AND event_date >= ? AND event_date <= ?
+		GROUP BY day
+		ORDER BY day ASC
+	`
+
+	// MIN and MAX are NULL when the account has no transactions
+	activityRangeSQL = `
+		SELECT MIN(event_date), MAX(event_date), COUNT(*)
+		FROM transactions
+		WHERE account_id = ?
+	`
 
 	countTransactionsByAccountIDSQL = `
 		SELECT COUNT(*)
@@ -35,9 +168,44 @@ const (
 		WHERE account_id = ?
 	`
 
+	// %s is the account and event date filter built by accountTransactionsFilter
+	countFilteredTransactionsSQL = `
+		SELECT COUNT(*)
+		FROM transactions t
+		WHERE %s
+	`
+
+	// %[2]s is the sort direction, ASC or DESC; id breaks event date ties so keyset cursors are stable
+	findFilteredPaginatedSQL = `SELECT t.id, t.account_id, t.operation_type_id, t.amount, t.event_date, ot.description
+		FROM transactions t
+		LEFT JOIN operation_types ot ON ot.id = t.operation_type_id
+		WHERE %[1]s
+		ORDER BY t.event_date %[2]s, t.id %[2]s
+		LIMIT ? OFFSET ?`
+
 	getAllTransactionsSQL = `
 		SELECT id, account_id, operation_type_id, amount, event_date
 		FROM transactions
 		ORDER BY event_date DESC
 	`
+
+	countAllTransactionsSQL = `
+		SELECT COUNT(*)
+		FROM transactions
+	`
+
+	findAllPaginatedSQL = `
+		SELECT id, account_id, operation_type_id, amount, event_date
+		FROM transactions
+		ORDER BY event_date DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	findRecentTransactionsSQL = `
+		SELECT t.id, t.account_id, t.operation_type_id, t.amount, t.event_date, a.document_number
+		FROM transactions t
+		INNER JOIN accounts a ON a.id = t.account_id
+		ORDER BY t.event_date DESC, t.id DESC
+		LIMIT ?
+	`
 )
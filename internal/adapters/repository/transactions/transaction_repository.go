@@ -4,7 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqlerr"
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqltime"
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqltx"
 	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
 	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
 )
@@ -18,54 +22,210 @@ func NewTransactionRepository(db *sql.DB) ports.TransactionRepository {
 	return &TransactionRepository{db: db}
 }
 
+// conn returns the database transaction bound to ctx, or the database outside of one
+func (r *TransactionRepository) conn(ctx context.Context) sqltx.Querier {
+	return sqltx.Conn(ctx, r.db)
+}
+
 func (r *TransactionRepository) Create(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error) {
-	var result domain.Transaction
+	return insertTransaction(ctx, r.conn(ctx), transaction, nil)
+}
 
-	err := r.db.QueryRowContext(
-		ctx,
-		createTransactionSQL,
+func (r *TransactionRepository) CreateIfBalance(ctx context.Context, transaction *domain.Transaction, expectedBalance float64) (*domain.Transaction, error) {
+	return insertTransaction(ctx, r.conn(ctx), transaction, &expectedBalance)
+}
+
+func (r *TransactionRepository) CreateWithDischarge(ctx context.Context, transaction *domain.Transaction, discharges []domain.Discharge, expectedBalance *float64) (*domain.Transaction, error) {
+	var created *domain.Transaction
+	err := sqltx.InTx(ctx, r.db, func(q sqltx.Querier) error {
+		for _, discharge := range discharges {
+			result, err := q.ExecContext(ctx, dischargeTransactionSQL, discharge.Balance, discharge.TransactionID, discharge.Previous)
+			if err != nil {
+				return fmt.Errorf("failed to discharge transaction %d: %w", discharge.TransactionID, sqlerr.Translate(err))
+			}
+			updated, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("failed to discharge transaction %d: %w", discharge.TransactionID, err)
+			}
+			// Another credit paid this debt down since it was read
+			if updated == 0 {
+				return domain.ErrBalanceChanged
+			}
+		}
+
+		var err error
+		created, err = insertTransaction(ctx, q, transaction, expectedBalance)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (r *TransactionRepository) CreateBatch(ctx context.Context, transactions []*domain.Transaction) ([]*domain.Transaction, error) {
+	created := make([]*domain.Transaction, 0, len(transactions))
+	err := sqltx.InTx(ctx, r.db, func(q sqltx.Querier) error {
+		for _, transaction := range transactions {
+			result, err := insertTransaction(ctx, q, transaction, nil)
+			if err != nil {
+				return err
+			}
+			created = append(created, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (r *TransactionRepository) CreateInstallments(ctx context.Context, installments []*domain.Transaction, expectedBalance *float64) ([]*domain.Transaction, error) {
+	created := make([]*domain.Transaction, 0, len(installments))
+	err := sqltx.InTx(ctx, r.db, func(q sqltx.Querier) error {
+		for i, installment := range installments {
+			// The balance is checked once, before the first installment changes it
+			check := expectedBalance
+			if i > 0 {
+				check = nil
+				linked := *installment
+				linked.InstallmentOf = created[0].ID
+				installment = &linked
+			}
+
+			result, err := insertTransaction(ctx, q, installment, check)
+			if err != nil {
+				return err
+			}
+			result.InstallmentOf = installment.InstallmentOf
+			created = append(created, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// rowQuerier is satisfied by both *sql.DB and *sql.Tx
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// insertTransaction creates the transaction, conditionally on the account balance when expectedBalance is set
+func insertTransaction(ctx context.Context, q rowQuerier, transaction *domain.Transaction, expectedBalance *float64) (*domain.Transaction, error) {
+	query := createTransactionSQL
+	args := []any{
 		transaction.AccountID,
 		transaction.OperationTypeID,
 		transaction.Amount,
-	).Scan(
-		&result.ID,
-		&result.AccountID,
-		&result.OperationTypeID,
-		&result.Amount,
-		&result.EventDate,
-	)
+		sqltime.Format(transaction.EventDate),
+		sql.NullString{String: transaction.IdempotencyKey, Valid: transaction.IdempotencyKey != ""},
+		sql.NullInt64{Int64: transaction.ReversalOf, Valid: transaction.ReversalOf != 0},
+		sql.NullInt64{Int64: transaction.InstallmentOf, Valid: transaction.InstallmentOf != 0},
+		transaction.Balance,
+	}
+	if expectedBalance != nil {
+		query = createTransactionIfBalanceSQL
+		args = append(args, transaction.AccountID, *expectedBalance)
+	}
 
+	result, err := scanTransaction(q.QueryRowContext(ctx, query, args...))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create transaction: %w", err)
+		// No row inserted means the balance did not match the expectation
+		if expectedBalance != nil && err == sql.ErrNoRows {
+			return nil, domain.ErrBalanceChanged
+		}
+		return nil, fmt.Errorf("failed to create transaction: %w", sqlerr.Translate(err))
 	}
 
-	return &result, nil
+	result.Balance = transaction.Balance
+	return result, nil
 }
 
 func (r *TransactionRepository) FindByID(ctx context.Context, id int64) (*domain.Transaction, error) {
-	var transaction domain.Transaction
+	return r.findOne(ctx, findTransactionByIDSQL, id)
+}
+
+func (r *TransactionRepository) FindByIdempotencyKey(ctx context.Context, accountID int64, key string) (*domain.Transaction, error) {
+	return r.findOne(ctx, findTransactionByIdempotencyKeySQL, accountID, key)
+}
+
+func (r *TransactionRepository) FindOutstandingByAccountID(ctx context.Context, accountID int64) ([]*domain.Transaction, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, findOutstandingByAccountIDSQL, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find outstanding transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var transactions []*domain.Transaction
+	for rows.Next() {
+		var balance float64
+		transaction, err := scanTransaction(rows, &balance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		transaction.Balance = balance
+		transactions = append(transactions, transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transactions: %w", err)
+	}
+
+	return transactions, nil
+}
 
-	err := r.db.QueryRowContext(ctx, findTransactionByIDSQL, id).
-		Scan(
-			&transaction.ID,
-			&transaction.AccountID,
-			&transaction.OperationTypeID,
-			&transaction.Amount,
-			&transaction.EventDate,
-		)
+func (r *TransactionRepository) FindReversal(ctx context.Context, transactionID int64) (*domain.Transaction, error) {
+	return r.findOne(ctx, findReversalSQL, transactionID)
+}
 
+func (r *TransactionRepository) FindReversed(ctx context.Context, transactionID int64) (*domain.Transaction, error) {
+	return r.findOne(ctx, findReversedSQL, transactionID)
+}
+
+// findOne scans the single transaction returned by query, or nil when there is none
+// findOne loads a single transaction with its balance, so it can be reversed
+func (r *TransactionRepository) findOne(ctx context.Context, query string, args ...any) (*domain.Transaction, error) {
+	var balance float64
+	transaction, err := scanTransaction(r.conn(ctx).QueryRowContext(ctx, query, args...), &balance)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // Not found
 		}
 		return nil, fmt.Errorf("failed to find transaction: %w", err)
 	}
+	transaction.Balance = balance
+
+	return transaction, nil
+}
+
+func (r *TransactionRepository) FindRecentDuplicate(ctx context.Context, transaction *domain.Transaction, window time.Duration) (*domain.Transaction, error) {
+	duplicate, err := scanTransaction(r.conn(ctx).QueryRowContext(
+		ctx,
+		findRecentDuplicateTransactionSQL,
+		transaction.AccountID,
+		transaction.OperationTypeID,
+		transaction.Amount,
+		fmt.Sprintf("-%.3f seconds", window.Seconds()),
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No duplicate
+		}
+		return nil, fmt.Errorf("failed to find duplicate transaction: %w", err)
+	}
 
-	return &transaction, nil
+	return duplicate, nil
 }
 
 func (r *TransactionRepository) FindByAccountID(ctx context.Context, accountID int64) ([]*domain.Transaction, error) {
-	rows, err := r.db.QueryContext(ctx, findTransactionsByAccountIDSQL, accountID)
+	rows, err := r.conn(ctx).QueryContext(ctx, findTransactionsByAccountIDSQL, accountID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
@@ -74,8 +234,32 @@ func (r *TransactionRepository) FindByAccountID(ctx context.Context, accountID i
 	return r.scanTransactions(rows)
 }
 
+func (r *TransactionRepository) StreamByAccountID(ctx context.Context, accountID int64, fn func(*domain.Transaction) error) error {
+	rows, err := r.conn(ctx).QueryContext(ctx, findTransactionsByAccountIDSQL, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to get transactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		if err := fn(transaction); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating transactions: %w", err)
+	}
+
+	return nil
+}
+
 func (r *TransactionRepository) GetAll(ctx context.Context) ([]*domain.Transaction, error) {
-	rows, err := r.db.QueryContext(ctx, getAllTransactionsSQL)
+	rows, err := r.conn(ctx).QueryContext(ctx, getAllTransactionsSQL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
@@ -90,17 +274,11 @@ func (r *TransactionRepository) scanTransactions(rows *sql.Rows) ([]*domain.Tran
 	var transactions []*domain.Transaction
 
 	for rows.Next() {
-		var transaction domain.Transaction
-		if err := rows.Scan(
-			&transaction.ID,
-			&transaction.AccountID,
-			&transaction.OperationTypeID,
-			&transaction.Amount,
-			&transaction.EventDate,
-		); err != nil {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan transaction: %w", err)
 		}
-		transactions = append(transactions, &transaction)
+		transactions = append(transactions, transaction)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -110,15 +288,110 @@ func (r *TransactionRepository) scanTransactions(rows *sql.Rows) ([]*domain.Tran
 	return transactions, nil
 }
 
-func (r *TransactionRepository) FindByAccountIDPaginated(ctx context.Context, accountID int64, limit int64, offset int64) ([]*domain.Transaction, int64, error) {
+// scanAccountTransactions scans rows of transaction columns followed by the operation type description
+func (r *TransactionRepository) scanAccountTransactions(rows *sql.Rows) ([]*domain.AccountTransaction, error) {
+	var transactions []*domain.AccountTransaction
+
+	for rows.Next() {
+		var description sql.NullString
+		transaction, err := scanTransaction(rows, &description)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		transactions = append(transactions, &domain.AccountTransaction{Transaction: *transaction, OperationTypeDescription: description.String})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transactions: %w", err)
+	}
+
+	return transactions, nil
+}
+
+func (r *TransactionRepository) FindByAccountIDPaginated(ctx context.Context, accountID int64, limit int64, offset int64) ([]*domain.AccountTransaction, int64, error) {
+	var total int64
+
+	err := r.conn(ctx).QueryRowContext(ctx, countTransactionsByAccountIDSQL, accountID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count transactions: %w", err)
+	}
+
+	rows, err := r.conn(ctx).QueryContext(ctx, findByAccountIDPaginatedSQL, accountID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get paginated transactions: %w", err)
+	}
+	defer rows.Close()
+
+	transactions, err := r.scanAccountTransactions(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return transactions, total, nil
+}
+
+func (r *TransactionRepository) FindByAccountIDPaginatedFiltered(ctx context.Context, accountID int64, filter domain.TransactionFilter, limit int64, offset int64) ([]*domain.AccountTransaction, int64, error) {
+	where, args := accountTransactionsFilter(accountID, filter)
+
+	var total int64
+	err := r.conn(ctx).QueryRowContext(ctx, fmt.Sprintf(countFilteredTransactionsSQL, where), args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count transactions: %w", err)
+	}
+
+	// The total covers the whole listing, so the cursor only narrows the page query
+	direction, comparison := "DESC", "<"
+	if filter.Order == domain.SortAscending {
+		direction, comparison = "ASC", ">"
+	}
+	if filter.After != nil {
+		where += fmt.Sprintf(" AND (t.event_date, t.id) %s (?, ?)", comparison)
+		args = append(args, sqltime.Format(filter.After.EventDate), filter.After.ID)
+	}
+
+	rows, err := r.conn(ctx).QueryContext(ctx, fmt.Sprintf(findFilteredPaginatedSQL, where, direction), append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get paginated transactions: %w", err)
+	}
+	defer rows.Close()
+
+	transactions, err := r.scanAccountTransactions(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return transactions, total, nil
+}
+
+// accountTransactionsFilter returns the WHERE clause selecting the account's transactions that match
+// the set parts of filter, with its arguments; the order and cursor are applied by the caller
+func accountTransactionsFilter(accountID int64, filter domain.TransactionFilter) (string, []any) {
+	where := "t.account_id = ?"
+	args := []any{accountID}
+	if filter.From != nil {
+		where += " AND t.event_date >= ?"
+		args = append(args, sqltime.Format(*filter.From))
+	}
+	if filter.To != nil {
+		where += " AND t.event_date <= ?"
+		args = append(args, sqltime.Format(*filter.To))
+	}
+	if filter.OperationTypeID != 0 {
+		where += " AND t.operation_type_id = ?"
+		args = append(args, filter.OperationTypeID)
+	}
+	return where, args
+}
+
+func (r *TransactionRepository) FindAllPaginated(ctx context.Context, limit int64, offset int64) ([]*domain.Transaction, int64, error) {
 	var total int64
 
-	err := r.db.QueryRowContext(ctx, countTransactionsByAccountIDSQL, accountID).Scan(&total)
+	err := r.conn(ctx).QueryRowContext(ctx, countAllTransactionsSQL).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count transactions: %w", err)
 	}
 
-	rows, err := r.db.QueryContext(ctx, findByAccountIDPaginatedSQL, accountID, limit, offset)
+	rows, err := r.conn(ctx).QueryContext(ctx, findAllPaginatedSQL, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get paginated transactions: %w", err)
 	}
@@ -131,3 +404,166 @@ func (r *TransactionRepository) FindByAccountIDPaginated(ctx context.Context, ac
 
 	return transactions, total, nil
 }
+
+func (r *TransactionRepository) SumByAccountID(ctx context.Context, accountID int64) (float64, error) {
+	var sum float64
+	err := r.conn(ctx).QueryRowContext(ctx, sumTransactionsByAccountIDSQL, accountID).Scan(&sum)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum transactions: %w", err)
+	}
+
+	return sum, nil
+}
+
+func (r *TransactionRepository) SumByDirection(ctx context.Context, accountID int64) (float64, float64, error) {
+	var debit, credit float64
+	err := r.conn(ctx).QueryRowContext(ctx, sumTransactionsByDirectionSQL, accountID).Scan(&debit, &credit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to sum transactions: %w", err)
+	}
+
+	return debit, credit, nil
+}
+
+func (r *TransactionRepository) SumBefore(ctx context.Context, accountID int64, before time.Time) (float64, error) {
+	var sum float64
+	err := r.conn(ctx).QueryRowContext(ctx, sumTransactionsBeforeSQL, accountID, sqltime.Format(before)).Scan(&sum)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum transactions: %w", err)
+	}
+
+	return sum, nil
+}
+
+func (r *TransactionRepository) SumAsOf(ctx context.Context, accountID int64, asOf time.Time) (float64, error) {
+	var sum float64
+	err := r.conn(ctx).QueryRowContext(ctx, sumTransactionsAsOfSQL, accountID, sqltime.Format(asOf)).Scan(&sum)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum transactions: %w", err)
+	}
+
+	return sum, nil
+}
+
+func (r *TransactionRepository) FindByAccountIDBetween(ctx context.Context, accountID int64, start, end time.Time) ([]*domain.Transaction, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, findTransactionsBetweenSQL, accountID, sqltime.Format(start), sqltime.Format(end))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanTransactions(rows)
+}
+
+func (r *TransactionRepository) DailyTotalsBetween(ctx context.Context, accountID int64, start, end time.Time) ([]*domain.DailyTotal, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, dailyTotalsBetweenSQL, accountID, sqltime.Format(start), sqltime.Format(end))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily totals: %w", err)
+	}
+	defer rows.Close()
+
+	var days []*domain.DailyTotal
+
+	for rows.Next() {
+		var day domain.DailyTotal
+		if err := rows.Scan(&day.Date, &day.Count, &day.NetAmount); err != nil {
+			return nil, fmt.Errorf("failed to scan daily total: %w", err)
+		}
+		day.NetAmount = domain.RoundToCents(day.NetAmount)
+		days = append(days, &day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating daily totals: %w", err)
+	}
+
+	return days, nil
+}
+
+func (r *TransactionRepository) MonthlyTotalsBetween(ctx context.Context, accountID int64, start, end time.Time) ([]*domain.MonthlyTotal, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, monthlyTotalsBetweenSQL, accountID, sqltime.Format(start), sqltime.Format(end))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly totals: %w", err)
+	}
+	defer rows.Close()
+
+	var months []*domain.MonthlyTotal
+
+	for rows.Next() {
+		var month domain.MonthlyTotal
+		if err := rows.Scan(&month.Month, &month.Debits, &month.Credits); err != nil {
+			return nil, fmt.Errorf("failed to scan monthly total: %w", err)
+		}
+		months = append(months, &month)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating monthly totals: %w", err)
+	}
+
+	return months, nil
+}
+
+func (r *TransactionRepository) ActivityRange(ctx context.Context, accountID int64) (*domain.ActivityRange, error) {
+	var activity domain.ActivityRange
+	err := r.conn(ctx).QueryRowContext(ctx, activityRangeSQL, accountID).Scan(
+		sqltime.NullUTC(&activity.FirstTransactionAt),
+		sqltime.NullUTC(&activity.LastTransactionAt),
+		&activity.TotalCount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity range: %w", err)
+	}
+
+	return &activity, nil
+}
+
+func (r *TransactionRepository) RunningTotalsBetween(ctx context.Context, accountID int64, start, end time.Time) ([]*domain.DailyBalance, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, runningTotalsBetweenSQL, accountID, sqltime.Format(start), sqltime.Format(end))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running totals: %w", err)
+	}
+	defer rows.Close()
+
+	var days []*domain.DailyBalance
+
+	for rows.Next() {
+		var day domain.DailyBalance
+		if err := rows.Scan(&day.Date, &day.Balance); err != nil {
+			return nil, fmt.Errorf("failed to scan running total: %w", err)
+		}
+		day.Balance = domain.RoundToCents(day.Balance)
+		days = append(days, &day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating running totals: %w", err)
+	}
+
+	return days, nil
+}
+
+func (r *TransactionRepository) FindRecent(ctx context.Context, limit int64) ([]*domain.RecentTransaction, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, findRecentTransactionsSQL, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var transactions []*domain.RecentTransaction
+
+	for rows.Next() {
+		var documentNumber string
+		transaction, err := scanTransaction(rows, &documentNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recent transaction: %w", err)
+		}
+		transactions = append(transactions, &domain.RecentTransaction{Transaction: *transaction, DocumentNumber: documentNumber})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recent transactions: %w", err)
+	}
+
+	return transactions, nil
+}
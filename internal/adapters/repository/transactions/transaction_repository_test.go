@@ -3,10 +3,16 @@ package transactions
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/larissamartinsss/simple-banking-api/infra/database"
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/repository/sqltx"
 	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,10 +30,10 @@ func TestCreate(t *testing.T) {
 	defer db.Close()
 
 	now := time.Now()
-	input := &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: -50.0}
+	input := &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: -50.0, Balance: -50.0}
 
 	mock.ExpectQuery("INSERT INTO transactions").
-		WithArgs(int64(1), int64(1), -50.0).
+		WithArgs(int64(1), int64(1), -50.0, sqlmock.AnyArg(), nil, nil, nil, -50.0).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date"}).
 			AddRow(1, 1, 1, -50.0, now))
 
@@ -58,14 +64,15 @@ func TestFindByID(t *testing.T) {
 	now := time.Now()
 	mock.ExpectQuery("SELECT (.+) FROM transactions WHERE id").
 		WithArgs(int64(1)).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date"}).
-			AddRow(1, 1, 1, -50.0, now))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date", "balance"}).
+			AddRow(1, 1, 1, -50.0, now, -20.0))
 
 	result, err := repo.FindByID(context.Background(), 1)
 
 	require.NoError(t, err)
 	assert.Equal(t, int64(1), result.ID)
 	assert.Equal(t, -50.0, result.Amount)
+	assert.Equal(t, -20.0, result.Balance)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -84,6 +91,40 @@ func TestFindByID_NotFound(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestFindByID_NullRequiredColumn(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM transactions WHERE id").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date", "balance"}).
+			AddRow(7, 1, nil, nil, time.Now(), 0.0))
+
+	result, err := repo.FindByID(context.Background(), 7)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrCorruptTransaction)
+	assert.EqualError(t, err, "failed to find transaction: corrupt transaction record id=7: NULL in required columns operation_type_id, amount")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindByAccountID_NullEventDate(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM transactions WHERE account_id").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date"}).
+			AddRow(1, 1, 1, -50.0, time.Now()).
+			AddRow(2, 1, 4, 100.0, nil))
+
+	results, err := repo.FindByAccountID(context.Background(), 1)
+
+	assert.Nil(t, results)
+	assert.ErrorIs(t, err, domain.ErrCorruptTransaction)
+	assert.Contains(t, err.Error(), "corrupt transaction record id=2: NULL in required columns event_date")
+}
+
 func TestFindByAccountID(t *testing.T) {
 	db, mock, repo := setupMock(t)
 	defer db.Close()
@@ -116,20 +157,57 @@ func TestFindByAccountIDPaginated(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
 
 	// Mock paginated query
-	mock.ExpectQuery("SELECT (.+) FROM transactions WHERE account_id (.+) ORDER BY").
+	mock.ExpectQuery("SELECT (.+) FROM transactions t LEFT JOIN operation_types ot (.+) WHERE t.account_id (.+) ORDER BY").
 		WithArgs(int64(1), int64(2), int64(0)).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date"}).
-			AddRow(1, 1, 1, -50.0, now).
-			AddRow(2, 1, 4, 100.0, now))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date", "description"}).
+			AddRow(1, 1, 1, -50.0, now, "Normal Purchase").
+			AddRow(2, 1, 4, 100.0, now, "Credit Voucher"))
 
 	results, total, err := repo.FindByAccountIDPaginated(context.Background(), 1, 2, 0)
 
 	require.NoError(t, err)
 	assert.Equal(t, int64(5), total)
-	assert.Len(t, results, 2)
+	require.Len(t, results, 2)
+	assert.Equal(t, "Normal Purchase", results[0].OperationTypeDescription)
+	assert.Equal(t, int64(4), results[1].OperationTypeID)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestFindByAccountIDPaginated_OperationTypeDescription(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "descriptions.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (3, 'Withdrawal'), (4, 'Credit Voucher')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	_, err = repo.Create(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 4, Amount: 100, EventDate: base})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 3, Amount: -20, EventDate: base.Add(time.Hour)})
+	require.NoError(t, err)
+
+	results, _, err := repo.FindByAccountIDPaginated(ctx, 1, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, int64(3), results[0].OperationTypeID)
+	assert.Equal(t, "Withdrawal", results[0].OperationTypeDescription)
+	assert.Equal(t, int64(4), results[1].OperationTypeID)
+	assert.Equal(t, "Credit Voucher", results[1].OperationTypeDescription)
+
+	filtered, _, err := repo.FindByAccountIDPaginatedFiltered(ctx, 1, domain.TransactionFilter{OperationTypeID: 3}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "Withdrawal", filtered[0].OperationTypeDescription)
+}
+
 func TestFindByAccountIDPaginated_CountError(t *testing.T) {
 	db, mock, repo := setupMock(t)
 	defer db.Close()
@@ -157,3 +235,1112 @@ func TestGetAll(t *testing.T) {
 	assert.Len(t, results, 1)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestFindRecent(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	newer := time.Now()
+	older := newer.Add(-time.Hour)
+
+	mock.ExpectQuery(`SELECT (.+) FROM transactions t INNER JOIN accounts a ON a.id = t.account_id ORDER BY t.event_date DESC, t.id DESC LIMIT`).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date", "document_number"}).
+			AddRow(7, 2, 4, 100.0, newer, "98765432100").
+			AddRow(6, 1, 1, -50.0, older, "12345678900"))
+
+	results, err := repo.FindRecent(context.Background(), 2)
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, int64(7), results[0].ID)
+	assert.Equal(t, "98765432100", results[0].DocumentNumber)
+	assert.Equal(t, int64(6), results[1].ID)
+	assert.Equal(t, "12345678900", results[1].DocumentNumber)
+	assert.True(t, results[0].EventDate.After(results[1].EventDate), "Newest transaction should come first")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindRecent_Error(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM transactions t").WillReturnError(sql.ErrConnDone)
+
+	_, err := repo.FindRecent(context.Background(), 10)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get recent transactions")
+}
+
+func TestStreamByAccountID(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT (.+) FROM transactions WHERE account_id").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date"}).
+			AddRow(1, 1, 1, -50.0, now).
+			AddRow(2, 1, 4, 100.0, now))
+
+	var streamed []int64
+	err := repo.StreamByAccountID(context.Background(), 1, func(tx *domain.Transaction) error {
+		streamed = append(streamed, tx.ID)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, streamed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStreamByAccountID_StopsOnCallbackError(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT (.+) FROM transactions WHERE account_id").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date"}).
+			AddRow(1, 1, 1, -50.0, now).
+			AddRow(2, 1, 4, 100.0, now))
+
+	calls := 0
+	err := repo.StreamByAccountID(context.Background(), 1, func(tx *domain.Transaction) error {
+		calls++
+		return sql.ErrConnDone
+	})
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCreateIfBalance(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "balance matches",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO transactions (.+) SELECT (.+) WHERE").
+					WithArgs(int64(1), int64(4), 25.0, sqlmock.AnyArg(), nil, nil, nil, 25.0, int64(1), 100.0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date"}).
+						AddRow(7, 1, 4, 25.0, time.Now()))
+			},
+		},
+		{
+			name: "balance changed",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO transactions (.+) SELECT (.+) WHERE").
+					WithArgs(int64(1), int64(4), 25.0, sqlmock.AnyArg(), nil, nil, nil, 25.0, int64(1), 100.0).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: domain.ErrBalanceChanged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, repo := setupMock(t)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			result, err := repo.CreateIfBalance(context.Background(), &domain.Transaction{
+				AccountID:       1,
+				OperationTypeID: 4,
+				Amount:          25.0,
+				EventDate:       time.Now(),
+				Balance:         25.0,
+			}, 100.0)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(7), result.ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCreateIfBalance_Concurrent(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "cas.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (4, 'Credit Voucher')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+
+	// Every writer expects the initial zero balance; only one may win
+	const writers = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.CreateIfBalance(ctx, &domain.Transaction{
+				AccountID:       1,
+				OperationTypeID: 4,
+				Amount:          10.0,
+				EventDate:       time.Now(),
+			}, 0)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded, conflicted := 0, 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.Is(err, domain.ErrBalanceChanged):
+			conflicted++
+		default:
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, writers-1, conflicted)
+
+	// The next write must expect the updated balance
+	_, err = repo.CreateIfBalance(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 4, Amount: 5.0, EventDate: time.Now()}, 10.0)
+	assert.NoError(t, err)
+}
+
+func TestEventDate_ConsistentJSONRegardlessOfInsertionPath(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "timestamps.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (4, 'Credit Voucher')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+
+	// Inserted by SQLite's CURRENT_TIMESTAMP default
+	_, err = db.ExecContext(ctx, "INSERT INTO transactions (id, account_id, operation_type_id, amount) VALUES (1, 1, 4, 10.0)")
+	require.NoError(t, err)
+
+	// Inserted through the repository with a Go time in a non-UTC zone
+	created, err := repo.Create(ctx, &domain.Transaction{
+		AccountID:       1,
+		OperationTypeID: 4,
+		Amount:          10.0,
+		EventDate:       time.Now().In(time.FixedZone("BRT", -3*60*60)),
+	})
+	require.NoError(t, err)
+
+	fromDefault, err := repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	fromRepo, err := repo.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+
+	for _, tx := range []*domain.Transaction{fromDefault, fromRepo, created} {
+		assert.Equal(t, time.UTC, tx.EventDate.Location())
+
+		body, err := json.Marshal(tx)
+		require.NoError(t, err)
+
+		var decoded struct {
+			EventDate string `json:"event_date"`
+		}
+		require.NoError(t, json.Unmarshal(body, &decoded))
+		assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`, decoded.EventDate)
+
+		_, err = time.Parse(time.RFC3339Nano, decoded.EventDate)
+		assert.NoError(t, err)
+	}
+}
+
+func TestSumBefore(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	before := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT COALESCE\\(SUM\\(amount\\), 0\\) FROM transactions WHERE account_id = (.+) AND event_date <").
+		WithArgs(int64(1), "2025-01-01 00:00:00").
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(-42.5))
+
+	sum, err := repo.SumBefore(context.Background(), 1, before)
+
+	require.NoError(t, err)
+	assert.Equal(t, -42.5, sum)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSumAsOf(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "as_of.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (1, 'Normal Purchase'), (4, 'Credit Voucher')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900'), (2, '98765432100')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+
+	first := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	second := time.Date(2025, 2, 10, 9, 0, 0, 0, time.UTC)
+	for _, tx := range []*domain.Transaction{
+		{AccountID: 1, OperationTypeID: domain.OperationTypeCreditVoucher, Amount: 100.0, EventDate: first},
+		{AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -30.0, EventDate: second},
+		{AccountID: 2, OperationTypeID: domain.OperationTypeCreditVoucher, Amount: 999.0, EventDate: first},
+	} {
+		_, err := repo.Create(ctx, tx)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name string
+		asOf time.Time
+		want float64
+	}{
+		{name: "before every transaction", asOf: first.Add(-time.Second), want: 0},
+		{name: "at a transaction includes it", asOf: first, want: 100.0},
+		{name: "between transactions", asOf: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), want: 100.0},
+		{name: "after every transaction", asOf: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), want: 70.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sum, err := repo.SumAsOf(ctx, 1, tt.asOf)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sum)
+		})
+	}
+}
+
+func TestFindByAccountIDBetween(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC)
+	mock.ExpectQuery("SELECT (.+) FROM transactions WHERE account_id = (.+) AND event_date >= (.+) AND event_date <= (.+) ORDER BY event_date ASC").
+		WithArgs(int64(1), "2025-01-01 00:00:00", "2025-01-31 23:59:59").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date"}).
+			AddRow(1, 1, 4, 100.0, "2025-01-05 10:00:00").
+			AddRow(2, 1, 1, -30.0, "2025-01-20 12:00:00"))
+
+	results, err := repo.FindByAccountIDBetween(context.Background(), 1, start, end)
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, int64(1), results[0].ID)
+	assert.Equal(t, time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC), results[0].EventDate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindRecentDuplicate(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "duplicates.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (1, 'Normal Purchase'), (4, 'Credit Voucher')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+
+	existing, err := repo.Create(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: -50.0, EventDate: time.Now()})
+	require.NoError(t, err)
+
+	// An older identical transaction outside the window must be ignored
+	_, err = db.ExecContext(ctx, `INSERT INTO transactions (account_id, operation_type_id, amount, event_date, created_at)
+		VALUES (1, 4, 20.0, datetime('now', '-1 hour'), datetime('now', '-1 hour'))`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		transaction *domain.Transaction
+		wantID      int64
+	}{
+		{name: "identical within window", transaction: &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: -50.0}, wantID: existing.ID},
+		{name: "different amount", transaction: &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: -50.01}},
+		{name: "different operation type", transaction: &domain.Transaction{AccountID: 1, OperationTypeID: 4, Amount: -50.0}},
+		{name: "identical outside window", transaction: &domain.Transaction{AccountID: 1, OperationTypeID: 4, Amount: 20.0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			duplicate, err := repo.FindRecentDuplicate(ctx, tt.transaction, time.Minute)
+			require.NoError(t, err)
+
+			if tt.wantID == 0 {
+				assert.Nil(t, duplicate)
+				return
+			}
+			require.NotNil(t, duplicate)
+			assert.Equal(t, tt.wantID, duplicate.ID)
+		})
+	}
+}
+
+func TestFindRecentDuplicate_Error(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM transactions").
+		WithArgs(int64(1), int64(1), -50.0, "-5.000 seconds").
+		WillReturnError(sql.ErrConnDone)
+
+	_, err := repo.FindRecentDuplicate(context.Background(), &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: -50.0}, 5*time.Second)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to find duplicate transaction")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate_BusyDatabaseIsServiceUnavailable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "busy.db")
+	db, err := database.NewConnection(database.Config{DatabasePath: path})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (4, 'Credit Voucher')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900')")
+	require.NoError(t, err)
+
+	// Another process holds the write lock for longer than the busy timeout
+	holder, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	defer holder.Close()
+	lock, err := holder.Conn(ctx)
+	require.NoError(t, err)
+	defer lock.Close()
+	_, err = lock.ExecContext(ctx, "BEGIN IMMEDIATE")
+	require.NoError(t, err)
+	defer lock.ExecContext(ctx, "ROLLBACK")
+
+	_, err = db.ExecContext(ctx, "PRAGMA busy_timeout = 10")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+	_, err = repo.Create(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 4, Amount: 10.0, EventDate: time.Now()})
+
+	assert.ErrorIs(t, err, domain.ErrServiceUnavailable)
+}
+
+func TestSumByAccountID(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT COALESCE\\(SUM\\(amount\\), 0\\) FROM transactions WHERE account_id = \\?").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(57.25))
+
+	sum, err := repo.SumByAccountID(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 57.25, sum)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDailyTotalsBetween(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "daily.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (1, 'Normal Purchase'), (4, 'Credit Voucher')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900'), (2, '98765432100')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+
+	for _, tx := range []*domain.Transaction{
+		{AccountID: 1, OperationTypeID: 4, Amount: 100.0, EventDate: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 1, Amount: -30.1, EventDate: time.Date(2025, 1, 1, 23, 59, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 1, Amount: -0.2, EventDate: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 1, Amount: -0.1, EventDate: time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 1, Amount: -5.0, EventDate: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{AccountID: 2, OperationTypeID: 4, Amount: 999.0, EventDate: time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)},
+	} {
+		_, err := repo.Create(ctx, tx)
+		require.NoError(t, err)
+	}
+
+	days, err := repo.DailyTotalsBetween(ctx, 1,
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.DailyTotal{
+		{Date: "2025-01-01", Count: 2, NetAmount: 69.9},
+		{Date: "2025-01-03", Count: 2, NetAmount: -0.3},
+	}, days, "Other accounts and days outside the range are excluded")
+}
+
+func TestMonthlyTotalsBetween(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "monthly.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (1, 'Normal Purchase'), (4, 'Credit Voucher')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900'), (2, '98765432100')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+
+	for _, tx := range []*domain.Transaction{
+		{AccountID: 1, OperationTypeID: 4, Amount: 50.0, EventDate: time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 4, Amount: 100.0, EventDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 1, Amount: -30.0, EventDate: time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 1, Amount: -5.5, EventDate: time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 4, Amount: 2.0, EventDate: time.Date(2025, 3, 16, 12, 0, 0, 0, time.UTC)},
+		{AccountID: 2, OperationTypeID: 4, Amount: 999.0, EventDate: time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)},
+	} {
+		_, err := repo.Create(ctx, tx)
+		require.NoError(t, err)
+	}
+
+	months, err := repo.MonthlyTotalsBetween(ctx, 1,
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.MonthlyTotal{
+		{Month: "2025-01", Debits: -30.0, Credits: 100.0},
+		{Month: "2025-03", Debits: -5.5, Credits: 2.0},
+	}, months, "Other accounts and months outside the range are excluded")
+}
+
+func TestRunningTotalsBetween(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "running.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (1, 'Normal Purchase'), (4, 'Credit Voucher')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900'), (2, '98765432100')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+
+	for _, tx := range []*domain.Transaction{
+		{AccountID: 1, OperationTypeID: 4, Amount: 500.0, EventDate: time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 4, Amount: 100.0, EventDate: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 1, Amount: -30.1, EventDate: time.Date(2025, 1, 1, 23, 59, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 1, Amount: -0.2, EventDate: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 1, Amount: -0.1, EventDate: time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 1, Amount: -5.0, EventDate: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{AccountID: 2, OperationTypeID: 4, Amount: 999.0, EventDate: time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)},
+	} {
+		_, err := repo.Create(ctx, tx)
+		require.NoError(t, err)
+	}
+
+	days, err := repo.RunningTotalsBetween(ctx, 1,
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.DailyBalance{
+		{Date: "2025-01-01", Balance: 69.9},
+		{Date: "2025-01-03", Balance: 69.6},
+	}, days, "Totals run from the start of the range; other accounts and days outside it are excluded")
+}
+
+func TestActivityRange(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "activity.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (1, 'Normal Purchase'), (4, 'Credit Voucher')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900'), (2, '98765432100')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+
+	// Inserted out of order, so the range comes from the event dates rather than the IDs
+	for _, tx := range []*domain.Transaction{
+		{AccountID: 1, OperationTypeID: 1, Amount: -30.1, EventDate: time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 4, Amount: 100.0, EventDate: time.Date(2025, 2, 1, 18, 30, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 1, Amount: -5.0, EventDate: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)},
+	} {
+		_, err := repo.Create(ctx, tx)
+		require.NoError(t, err)
+	}
+
+	t.Run("active account", func(t *testing.T) {
+		activity, err := repo.ActivityRange(ctx, 1)
+
+		require.NoError(t, err)
+		require.NotNil(t, activity.FirstTransactionAt)
+		require.NotNil(t, activity.LastTransactionAt)
+		assert.Equal(t, time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC), *activity.FirstTransactionAt)
+		assert.Equal(t, time.Date(2025, 2, 1, 18, 30, 0, 0, time.UTC), *activity.LastTransactionAt)
+		assert.Equal(t, int64(3), activity.TotalCount)
+	})
+
+	t.Run("account without transactions", func(t *testing.T) {
+		activity, err := repo.ActivityRange(ctx, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, &domain.ActivityRange{}, activity)
+	})
+}
+
+func TestSumByDirection(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+)CASE WHEN amount < 0(.+)CASE WHEN amount > 0(.+)FROM transactions WHERE account_id = \\?").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"debit", "credit"}).AddRow(-80.5, 200.0))
+
+	debit, credit, err := repo.SumByDirection(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, -80.5, debit)
+	assert.Equal(t, 200.0, credit)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindAllPaginated(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM transactions").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery("SELECT (.+) FROM transactions ORDER BY event_date DESC, id DESC LIMIT \\? OFFSET \\?").
+		WithArgs(int64(2), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date"}).
+			AddRow(3, 2, 4, 100.0, "2025-01-03 10:00:00").
+			AddRow(2, 1, 1, -30.0, "2025-01-02 12:00:00"))
+
+	results, total, err := repo.FindAllPaginated(context.Background(), 2, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	require.Len(t, results, 2)
+	assert.Equal(t, int64(3), results[0].ID)
+	assert.Equal(t, int64(2), results[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_JoinsContextTransaction(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "context-tx.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (1, 'Normal Purchase')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678909')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+	txCtx, tx, err := sqltx.NewProvider(db).Begin(ctx)
+	require.NoError(t, err)
+
+	created, err := repo.Create(txCtx, &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: -50.0, EventDate: time.Now()})
+	require.NoError(t, err)
+	_, err = repo.CreateBatch(txCtx, []*domain.Transaction{
+		{AccountID: 1, OperationTypeID: 1, Amount: -10.0, EventDate: time.Now()},
+	})
+	require.NoError(t, err)
+
+	found, err := repo.FindByID(txCtx, created.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, found, "Reads within the transaction see its writes")
+
+	require.NoError(t, tx.Rollback())
+
+	transactions, err := repo.FindByAccountID(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, transactions, "Rolling back the context's transaction undoes every call made with it")
+}
+
+func TestFindByIdempotencyKey_AndReversal(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "reversals.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (1, 'Normal Purchase')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900'), (2, '98765432100')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+
+	original, err := repo.Create(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: -50.0, EventDate: time.Now(), IdempotencyKey: "key-1"})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: -10.0, EventDate: time.Now()})
+	require.NoError(t, err)
+
+	found, err := repo.FindByIdempotencyKey(ctx, 1, "key-1")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, original.ID, found.ID)
+
+	found, err = repo.FindByIdempotencyKey(ctx, 2, "key-1")
+	require.NoError(t, err)
+	assert.Nil(t, found, "Keys are scoped to their account")
+
+	found, err = repo.FindByIdempotencyKey(ctx, 1, "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	reversal, err := repo.FindReversal(ctx, original.ID)
+	require.NoError(t, err)
+	assert.Nil(t, reversal)
+
+	created, err := repo.Create(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: 50.0, EventDate: time.Now(), ReversalOf: original.ID})
+	require.NoError(t, err)
+
+	reversal, err = repo.FindReversal(ctx, original.ID)
+	require.NoError(t, err)
+	require.NotNil(t, reversal)
+	assert.Equal(t, created.ID, reversal.ID)
+
+	reversed, err := repo.FindReversed(ctx, created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, reversed)
+	assert.Equal(t, original.ID, reversed.ID)
+
+	reversed, err = repo.FindReversed(ctx, original.ID)
+	require.NoError(t, err)
+	assert.Nil(t, reversed, "The original is not a reversal")
+
+	// A transaction is reversed at most once
+	_, err = repo.Create(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: 50.0, EventDate: time.Now(), ReversalOf: original.ID})
+	assert.Error(t, err)
+}
+
+func TestCreateWithDischarge(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "discharge.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (1, 'Normal Purchase'), (4, 'Credit Voucher')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900'), (2, '98765432100')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 10, 0, 0, 0, time.UTC) }
+	newer, err := repo.Create(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: -30.0, Balance: -30.0, EventDate: day(2)})
+	require.NoError(t, err)
+	older, err := repo.Create(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: -50.0, Balance: -50.0, EventDate: day(1)})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 4, Amount: 10.0, Balance: 10.0, EventDate: day(1)})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &domain.Transaction{AccountID: 2, OperationTypeID: 1, Amount: -5.0, Balance: -5.0, EventDate: day(1)})
+	require.NoError(t, err)
+
+	outstanding, err := repo.FindOutstandingByAccountID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, outstanding, 2)
+	assert.Equal(t, older.ID, outstanding[0].ID, "Oldest debt comes first")
+	assert.Equal(t, -50.0, outstanding[0].Balance)
+	assert.Equal(t, newer.ID, outstanding[1].ID)
+
+	credit := &domain.Transaction{AccountID: 1, OperationTypeID: 4, Amount: 60.0, EventDate: day(3)}
+	discharges, leftover := domain.DischargeDebts(credit.Amount, outstanding)
+	credit.Balance = leftover
+
+	created, err := repo.CreateWithDischarge(ctx, credit, discharges, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, created.Balance)
+
+	outstanding, err = repo.FindOutstandingByAccountID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, outstanding, 1, "The older debt is fully paid")
+	assert.Equal(t, newer.ID, outstanding[0].ID)
+	assert.Equal(t, -20.0, outstanding[0].Balance)
+
+	// Replaying the same discharges finds the balances changed and creates nothing
+	_, err = repo.CreateWithDischarge(ctx, credit, discharges, nil)
+	assert.ErrorIs(t, err, domain.ErrBalanceChanged)
+
+	var count int64
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM transactions WHERE account_id = 1").Scan(&count))
+	assert.Equal(t, int64(4), count)
+
+	// A mismatching expected balance rolls the discharges back
+	expected := 999.0
+	_, err = repo.CreateWithDischarge(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 4, Amount: 5.0, EventDate: day(4)},
+		[]domain.Discharge{{TransactionID: newer.ID, Previous: -20.0, Balance: -15.0}}, &expected)
+	assert.ErrorIs(t, err, domain.ErrBalanceChanged)
+
+	outstanding, err = repo.FindOutstandingByAccountID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, outstanding, 1)
+	assert.Equal(t, -20.0, outstanding[0].Balance)
+}
+
+func TestCreateWithDischarge_FailureLeavesNoPartialChanges(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "discharge-failure.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (1, 'Normal Purchase'), (4, 'Credit Voucher')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678909')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+
+	first, err := repo.Create(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: -50.0, Balance: -50.0, EventDate: time.Now()})
+	require.NoError(t, err)
+	second, err := repo.Create(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: -30.0, Balance: -30.0, EventDate: time.Now()})
+	require.NoError(t, err)
+
+	assertUnchanged := func(t *testing.T) {
+		outstanding, err := repo.FindOutstandingByAccountID(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, outstanding, 2)
+		assert.Equal(t, -50.0, outstanding[0].Balance)
+		assert.Equal(t, -30.0, outstanding[1].Balance)
+
+		var count int64
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM transactions").Scan(&count))
+		assert.Equal(t, int64(2), count)
+	}
+
+	t.Run("last discharge is stale", func(t *testing.T) {
+		_, err := repo.CreateWithDischarge(ctx,
+			&domain.Transaction{AccountID: 1, OperationTypeID: 4, Amount: 80.0, EventDate: time.Now()},
+			[]domain.Discharge{
+				{TransactionID: first.ID, Previous: -50.0, Balance: 0},
+				{TransactionID: second.ID, Previous: -20.0, Balance: 0},
+			}, nil)
+
+		assert.ErrorIs(t, err, domain.ErrBalanceChanged)
+		assertUnchanged(t)
+	})
+
+	t.Run("insert fails after every discharge", func(t *testing.T) {
+		// The operation type does not exist, so the insert breaks the foreign key
+		_, err := repo.CreateWithDischarge(ctx,
+			&domain.Transaction{AccountID: 1, OperationTypeID: 99, Amount: 80.0, EventDate: time.Now()},
+			[]domain.Discharge{
+				{TransactionID: first.ID, Previous: -50.0, Balance: 0},
+				{TransactionID: second.ID, Previous: -30.0, Balance: 0},
+			}, nil)
+
+		assert.ErrorContains(t, err, "failed to create transaction")
+		assertUnchanged(t)
+	})
+}
+
+func TestCreateWithDischarge_RollsBackOnUpdateError(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE transactions SET balance").
+		WithArgs(0.0, int64(1), -50.0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE transactions SET balance").
+		WithArgs(0.0, int64(2), -30.0).
+		WillReturnError(errors.New("disk I/O error"))
+	mock.ExpectRollback()
+
+	_, err := repo.CreateWithDischarge(context.Background(),
+		&domain.Transaction{AccountID: 1, OperationTypeID: 4, Amount: 80.0, EventDate: time.Now()},
+		[]domain.Discharge{
+			{TransactionID: 1, Previous: -50.0, Balance: 0},
+			{TransactionID: 2, Previous: -30.0, Balance: 0},
+		}, nil)
+
+	assert.ErrorContains(t, err, "failed to discharge transaction 2")
+	assert.NoError(t, mock.ExpectationsWereMet(), "The first update is rolled back, never committed")
+}
+
+func TestCreateBatch(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "batch.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (1, 'Normal Purchase')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+	eventDate := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	created, err := repo.CreateBatch(ctx, []*domain.Transaction{
+		{AccountID: 1, OperationTypeID: 1, Amount: -10.0, Balance: -10.0, EventDate: eventDate},
+		{AccountID: 1, OperationTypeID: 1, Amount: -20.0, Balance: -20.0, EventDate: eventDate},
+	})
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	assert.NotZero(t, created[0].ID)
+	assert.Equal(t, -20.0, created[1].Amount)
+
+	// A row violating a constraint rolls the whole batch back
+	_, err = repo.CreateBatch(ctx, []*domain.Transaction{
+		{AccountID: 1, OperationTypeID: 1, Amount: -30.0, Balance: -30.0, EventDate: eventDate},
+		{AccountID: 99, OperationTypeID: 1, Amount: -40.0, Balance: -40.0, EventDate: eventDate},
+	})
+	assert.Error(t, err)
+
+	var count int64
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM transactions").Scan(&count))
+	assert.Equal(t, int64(2), count)
+}
+
+func TestCreateInstallments(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "installments.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (2, 'Purchase with installments')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+	purchase := &domain.Transaction{
+		AccountID:       1,
+		OperationTypeID: 2,
+		Amount:          -100.0,
+		Balance:         -100.0,
+		EventDate:       time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC),
+		IdempotencyKey:  "purchase-1",
+	}
+
+	created, err := repo.CreateInstallments(ctx, domain.SplitInstallments(purchase, 3), nil)
+	require.NoError(t, err)
+	require.Len(t, created, 3)
+	assert.Zero(t, created[0].InstallmentOf)
+	assert.Equal(t, created[0].ID, created[1].InstallmentOf)
+	assert.Equal(t, created[0].ID, created[2].InstallmentOf)
+
+	var linked int64
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM transactions WHERE installment_of = ?", created[0].ID).Scan(&linked))
+	assert.Equal(t, int64(2), linked)
+
+	balance, err := repo.SumByAccountID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, -100.0, balance, "Installments add up to the purchase")
+
+	// A mismatching expected balance creates none of the installments
+	expected := 0.0
+	_, err = repo.CreateInstallments(ctx, domain.SplitInstallments(purchase, 2), &expected)
+	assert.ErrorIs(t, err, domain.ErrBalanceChanged)
+
+	var count int64
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM transactions").Scan(&count))
+	assert.Equal(t, int64(3), count)
+}
+
+func TestFindByAccountIDPaginatedFiltered(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "filtered.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (1, 'Normal Purchase'), (4, 'Credit voucher')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900'), (2, '98765432100')")
+	require.NoError(t, err)
+
+	repo := NewTransactionRepository(db)
+	for _, tx := range []*domain.Transaction{
+		{AccountID: 1, OperationTypeID: 4, Amount: 10, EventDate: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 4, Amount: 20, EventDate: time.Date(2025, 2, 5, 10, 0, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 4, Amount: 30, EventDate: time.Date(2025, 3, 5, 10, 0, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 1, Amount: -5, EventDate: time.Date(2025, 2, 6, 10, 0, 0, 0, time.UTC)},
+		{AccountID: 1, OperationTypeID: 1, Amount: -7, EventDate: time.Date(2025, 3, 6, 10, 0, 0, 0, time.UTC)},
+		{AccountID: 2, OperationTypeID: 4, Amount: 40, EventDate: time.Date(2025, 2, 5, 10, 0, 0, 0, time.UTC)},
+	} {
+		_, err := repo.Create(ctx, tx)
+		require.NoError(t, err)
+	}
+
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 28, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		filter      domain.TransactionFilter
+		limit       int64
+		wantAmounts []float64
+		wantTotal   int64
+	}{
+		{name: "both bounds", filter: domain.TransactionFilter{From: &from, To: &to}, limit: 10, wantAmounts: []float64{-5, 20}, wantTotal: 2},
+		{name: "from only", filter: domain.TransactionFilter{From: &from}, limit: 10, wantAmounts: []float64{-7, 30, -5, 20}, wantTotal: 4},
+		{name: "to only", filter: domain.TransactionFilter{To: &to}, limit: 10, wantAmounts: []float64{-5, 20, 10}, wantTotal: 3},
+		{name: "no filter", limit: 10, wantAmounts: []float64{-7, 30, -5, 20, 10}, wantTotal: 5},
+		{name: "credit vouchers only", filter: domain.TransactionFilter{OperationTypeID: domain.OperationTypeCreditVoucher}, limit: 10, wantAmounts: []float64{30, 20, 10}, wantTotal: 3},
+		{name: "total counts the filtered rows beyond the page", filter: domain.TransactionFilter{OperationTypeID: domain.OperationTypeCreditVoucher}, limit: 2, wantAmounts: []float64{30, 20}, wantTotal: 3},
+		{name: "type and dates combined", filter: domain.TransactionFilter{From: &from, OperationTypeID: domain.OperationTypePurchase}, limit: 10, wantAmounts: []float64{-7, -5}, wantTotal: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, total, err := repo.FindByAccountIDPaginatedFiltered(ctx, 1, tt.filter, tt.limit, 0)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, total)
+			amounts := make([]float64, 0, len(results))
+			for _, tx := range results {
+				amounts = append(amounts, tx.Amount)
+			}
+			assert.Equal(t, tt.wantAmounts, amounts)
+		})
+	}
+}
+
+func TestFindByAccountIDPaginatedFiltered_CountError(t *testing.T) {
+	db, mock, repo := setupMock(t)
+	defer db.Close()
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM transactions t WHERE t.account_id = (.+) AND t.event_date >=").
+		WithArgs(int64(1), "2025-01-01 00:00:00").
+		WillReturnError(errors.New("database error"))
+
+	_, _, err := repo.FindByAccountIDPaginatedFiltered(context.Background(), 1, domain.TransactionFilter{From: &from}, 10, 0)
+
+	assert.ErrorContains(t, err, "failed to count transactions")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindByAccountIDPaginatedFiltered_CursorPagesThroughBothOrders(t *testing.T) {
+	db, err := database.NewConnection(database.Config{
+		DatabasePath: filepath.Join(t.TempDir(), "cursor.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(t, database.RunMigrations(ctx, db))
+	_, err = db.ExecContext(ctx, "INSERT INTO operation_types (id, description) VALUES (4, 'Credit voucher')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO accounts (id, document_number) VALUES (1, '12345678900')")
+	require.NoError(t, err)
+
+	// Seven transactions over four days; several share an event date so only the id orders them
+	repo := NewTransactionRepository(db)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var ids []int64
+	for _, day := range []int{0, 1, 1, 1, 2, 3, 3} {
+		created, err := repo.Create(ctx, &domain.Transaction{AccountID: 1, OperationTypeID: 4, Amount: 1, EventDate: base.AddDate(0, 0, day)})
+		require.NoError(t, err)
+		ids = append(ids, created.ID)
+	}
+
+	reversed := make([]int64, len(ids))
+	for i, id := range ids {
+		reversed[len(ids)-1-i] = id
+	}
+
+	tests := []struct {
+		order   domain.SortOrder
+		wantIDs []int64
+	}{
+		{order: domain.SortAscending, wantIDs: ids},
+		{order: domain.SortDescending, wantIDs: reversed},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			filter := domain.TransactionFilter{Order: tt.order}
+			var seen []int64
+			for page := 0; page < len(ids); page++ {
+				results, total, err := repo.FindByAccountIDPaginatedFiltered(ctx, 1, filter, 2, 0)
+				require.NoError(t, err)
+				assert.Equal(t, int64(len(ids)), total, "The total ignores the cursor")
+				if len(results) == 0 {
+					break
+				}
+				for _, tx := range results {
+					seen = append(seen, tx.ID)
+				}
+				filter.After = domain.NewTransactionCursor(&results[len(results)-1].Transaction, tt.order)
+			}
+
+			assert.Equal(t, tt.wantIDs, seen, "Every transaction appears once, in order")
+		})
+	}
+}
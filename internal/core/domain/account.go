@@ -2,20 +2,37 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
+	"strings"
 	"time"
 )
 
 // Account errors
 var (
-	ErrInvalidAccountID = errors.New("account_id must be greater than 0")
+	ErrInvalidAccountID              = errors.New("account_id must be greater than 0")
+	ErrAccountNotFound               = errors.New("account not found")
+	ErrAccountClosed                 = errors.New("account is closed")
+	ErrAccountReverificationRequired = errors.New("account must be verified again before it can transact")
+	ErrAccountHasBalance             = errors.New("cannot close account with nonzero balance")
+	ErrDocumentNumberNotAllowed      = errors.New("document_number is not in an allowed range for this environment")
 )
 
+// DefaultAccountTier is assigned to accounts created without an explicit tier
+const DefaultAccountTier = "standard"
+
+// accountTierPattern restricts tiers to short lowercase identifiers
+var accountTierPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
 // Account represents a customer account
+// VerifiedAt is when the account holder last passed identity verification (nil if never)
 type Account struct {
-	ID             int64     `json:"account_id"`
-	DocumentNumber string    `json:"document_number"`
-	CreatedAt      time.Time `json:"created_at"`
+	ID             int64      `json:"account_id"`
+	DocumentNumber string     `json:"document_number"`
+	Tier           string     `json:"tier"`
+	CreditLimit    float64    `json:"credit_limit"`
+	CreatedAt      time.Time  `json:"created_at"`
+	VerifiedAt     *time.Time `json:"verified_at,omitempty"`
 }
 
 // Validate checks if the account data is valid
@@ -24,8 +41,12 @@ func (a *Account) Validate() error {
 		return errors.New("document_number is required")
 	}
 
-	if len(a.DocumentNumber) < 11 || len(a.DocumentNumber) > 14 {
-		return errors.New("document_number must have between 11 and 14 characters")
+	// Only a CPF (11 digits) or a CNPJ (14 digits) can ever be valid
+	switch n := len(a.DocumentNumber); {
+	case n == 12 || n == 13:
+		return fmt.Errorf("document_number with %d characters is neither a CPF (11) nor a CNPJ (14)", n)
+	case n != 11 && n != 14:
+		return errors.New("document_number must have 11 or 14 characters")
 	}
 
 	// Validate that document_number contains only digits
@@ -37,12 +58,85 @@ func (a *Account) Validate() error {
 		return errors.New("document_number must contain only digits")
 	}
 
+	if err := ValidateDocument(a.DocumentNumber); err != nil {
+		return err
+	}
+
+	if a.Tier != "" && !accountTierPattern.MatchString(a.Tier) {
+		return errors.New("tier must be a lowercase identifier of up to 32 characters")
+	}
+
+	if a.CreditLimit < 0 {
+		return errors.New("credit_limit must not be negative")
+	}
+
+	return nil
+}
+
+// DocumentNumberAllowlist restricts account creation to document numbers starting with one of its prefixes,
+// e.g. test ranges in a sandbox; an empty allowlist allows every document number
+type DocumentNumberAllowlist []string
+
+// Allows reports whether an account may be created with the document number
+func (l DocumentNumberAllowlist) Allows(documentNumber string) bool {
+	if len(l) == 0 {
+		return true
+	}
+
+	for _, prefix := range l {
+		if strings.HasPrefix(documentNumber, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AvailableFunds returns how much the account may still debit given its current balance
+// The credit limit extends the funds below zero, so a negative balance still leaves limit+balance available
+func (a *Account) AvailableFunds(balance float64) float64 {
+	return RoundToCents(balance + a.CreditLimit)
+}
+
+// ReverificationPolicy blocks transactions on accounts older than MaxUnverifiedAge that were never verified
+// A zero MaxUnverifiedAge disables the rule
+type ReverificationPolicy struct {
+	MaxUnverifiedAge time.Duration
+}
+
+// Check reports ErrAccountReverificationRequired when the policy forbids the account to transact at now
+func (p ReverificationPolicy) Check(account *Account, now time.Time) error {
+	if p.MaxUnverifiedAge <= 0 || account.VerifiedAt != nil {
+		return nil
+	}
+	if now.Sub(account.CreatedAt) > p.MaxUnverifiedAge {
+		return ErrAccountReverificationRequired
+	}
 	return nil
 }
 
+// CloseAccountRequest represents the request to close (soft-delete) an account
+// Force is an admin override that closes the account even when its balance is not zero
+type CloseAccountRequest struct {
+	AccountID int64 `json:"account_id"`
+	Force     bool  `json:"force"`
+}
+
+// CheckClose reports ErrAccountHasBalance when an account with the given balance may not be closed,
+// so closing never orphans funds or debts; balances are compared in cents and force skips the check
+func (r CloseAccountRequest) CheckClose(balance float64) error {
+	if r.Force || RoundToCents(balance) == 0 {
+		return nil
+	}
+	return ErrAccountHasBalance
+}
+
 // CreateAccountRequest represents the request to create an account
+// Simple rules are declared in `validate` tags; Account.Validate covers the rest
+// The credit limit is not the client's to choose: new accounts get the configured default
 type CreateAccountRequest struct {
-	DocumentNumber string `json:"document_number"`
+	DocumentNumber string `json:"document_number" validate:"required,len=11|14,numeric"`
+	Tier           string `json:"tier,omitempty"`
 }
 
 // CreateAccountResponse represents the response after creating an account
@@ -59,3 +153,41 @@ type GetAccountRequest struct {
 type GetAccountResponse struct {
 	Account *Account `json:"account"`
 }
+
+// ListAccountsRequest represents a page of the account listing
+type ListAccountsRequest struct {
+	Limit  int64 `json:"limit"`
+	Offset int64 `json:"offset"`
+}
+
+// ListAccountsResponse is one page of accounts, with the same pagination metadata as transactions
+type ListAccountsResponse struct {
+	Accounts   []*Account         `json:"accounts"`
+	Pagination PaginationMetadata `json:"pagination"`
+}
+
+// CountAccountsResponse is the number of accounts, for admin dashboards
+type CountAccountsResponse struct {
+	Count int64 `json:"count"`
+}
+
+// GetAccountBalanceRequest represents the request to get an account with its computed balances
+// IncludeDirection adds the money-out (net debit) and money-in (net credit) split of the balance
+// AsOf, when set, computes the balance from the transactions dated up to it instead of all of them
+type GetAccountBalanceRequest struct {
+	AccountID        int64      `json:"account_id"`
+	IncludeDirection bool       `json:"include_direction"`
+	AsOf             *time.Time `json:"as_of,omitempty"`
+}
+
+// GetAccountBalanceResponse is the account plus its current balance and the funds available under its credit limit
+// AvailableBalance is CurrentBalance + CreditLimit; when included, NetDebit + NetCredit equals CurrentBalance
+// AsOf echoes the requested point in time, whose balance CurrentBalance then is
+type GetAccountBalanceResponse struct {
+	*Account
+	CurrentBalance   float64    `json:"current_balance"`
+	AvailableBalance float64    `json:"available_balance"`
+	NetDebit         *float64   `json:"net_debit,omitempty"`
+	NetCredit        *float64   `json:"net_credit,omitempty"`
+	AsOf             *time.Time `json:"as_of,omitempty"`
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccount_Validate_DocumentNumberLength(t *testing.T) {
+	tests := []struct {
+		name           string
+		documentNumber string
+		wantErr        string
+	}{
+		{name: "11 digits (CPF)", documentNumber: "12345678909"},
+		{name: "12 digits", documentNumber: "123456789001", wantErr: "document_number with 12 characters is neither a CPF (11) nor a CNPJ (14)"},
+		{name: "13 digits", documentNumber: "1234567890012", wantErr: "document_number with 13 characters is neither a CPF (11) nor a CNPJ (14)"},
+		{name: "14 digits (CNPJ)", documentNumber: "12345678000195"},
+		{name: "15 digits", documentNumber: "123456780001901", wantErr: "document_number must have 11 or 14 characters"},
+		{name: "10 digits", documentNumber: "1234567890", wantErr: "document_number must have 11 or 14 characters"},
+		{name: "11 characters with a letter", documentNumber: "1234567890a", wantErr: "document_number must contain only digits"},
+		{name: "CPF with wrong check digits", documentNumber: "12345678900", wantErr: "invalid CPF"},
+		{name: "CNPJ with wrong check digits", documentNumber: "12345678000190", wantErr: "invalid CNPJ"},
+		{name: "CPF of zeros", documentNumber: "00000000000", wantErr: "invalid CPF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := &Account{DocumentNumber: tt.documentNumber}
+
+			err := account.Validate()
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAccount_Validate_CreditLimit(t *testing.T) {
+	assert.NoError(t, (&Account{DocumentNumber: "12345678909", CreditLimit: 500}).Validate())
+	assert.EqualError(t, (&Account{DocumentNumber: "12345678909", CreditLimit: -1}).Validate(), "credit_limit must not be negative")
+}
+
+func TestReverificationPolicy_Check(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	verifiedAt := now.AddDate(0, -1, 0)
+
+	tests := []struct {
+		name    string
+		policy  ReverificationPolicy
+		account *Account
+		wantErr error
+	}{
+		{
+			name:    "disabled rule allows old unverified accounts",
+			policy:  ReverificationPolicy{},
+			account: &Account{CreatedAt: now.AddDate(-5, 0, 0)},
+		},
+		{
+			name:    "recent unverified account",
+			policy:  ReverificationPolicy{MaxUnverifiedAge: 90 * 24 * time.Hour},
+			account: &Account{CreatedAt: now.AddDate(0, 0, -30)},
+		},
+		{
+			name:    "old unverified account",
+			policy:  ReverificationPolicy{MaxUnverifiedAge: 90 * 24 * time.Hour},
+			account: &Account{CreatedAt: now.AddDate(0, 0, -91)},
+			wantErr: ErrAccountReverificationRequired,
+		},
+		{
+			name:    "old verified account",
+			policy:  ReverificationPolicy{MaxUnverifiedAge: 90 * 24 * time.Hour},
+			account: &Account{CreatedAt: now.AddDate(0, 0, -91), VerifiedAt: &verifiedAt},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.account, now)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCloseAccountRequest_CheckClose(t *testing.T) {
+	tests := []struct {
+		name    string
+		force   bool
+		balance float64
+		wantErr error
+	}{
+		{name: "zero balance closes", balance: 0},
+		{name: "sub-cent residue counts as zero", balance: 0.001},
+		{name: "positive balance is refused", balance: 10.5, wantErr: ErrAccountHasBalance},
+		{name: "outstanding debt is refused", balance: -0.01, wantErr: ErrAccountHasBalance},
+		{name: "forced close ignores the balance", force: true, balance: 10.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := CloseAccountRequest{AccountID: 1, Force: tt.force}
+
+			assert.Equal(t, tt.wantErr, req.CheckClose(tt.balance))
+		})
+	}
+}
+
+func TestDocumentNumberAllowlist_Allows(t *testing.T) {
+	assert.True(t, DocumentNumberAllowlist(nil).Allows("12345678900"), "An empty allowlist allows everything")
+
+	allowlist := DocumentNumberAllowlist{"000", "999"}
+	assert.True(t, allowlist.Allows("00012345678"))
+	assert.True(t, allowlist.Allows("99912345678901"))
+	assert.False(t, allowlist.Allows("12345678900"))
+	assert.False(t, allowlist.Allows("00"), "Document number shorter than the prefix")
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// ActivityRange spans an account's transactions by event date
+// FirstTransactionAt and LastTransactionAt are nil when the account has no transactions
+type ActivityRange struct {
+	FirstTransactionAt *time.Time `json:"first_transaction_at"`
+	LastTransactionAt  *time.Time `json:"last_transaction_at"`
+	TotalCount         int64      `json:"total_count"`
+}
+
+// GetActivityRangeRequest asks for the activity range of an account
+type GetActivityRangeRequest struct {
+	AccountID int64 `json:"account_id"`
+}
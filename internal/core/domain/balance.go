@@ -0,0 +1,43 @@
+package domain
+
+import "math"
+
+// Batch sizes for recomputing the account balances cache
+const (
+	DefaultRecomputeBatchSize = 500
+	MaxRecomputeBatchSize     = 5000
+)
+
+// RecomputeBalancesRequest rebuilds the cached balances of every account with ID greater than AfterAccountID
+// AfterAccountID resumes an interrupted run; 0 starts from the first account
+type RecomputeBalancesRequest struct {
+	AfterAccountID int64 `json:"after_account_id"`
+	BatchSize      int64 `json:"batch_size"`
+}
+
+// RecomputeBalancesResponse reports the progress of a recompute run
+// LastAccountID is the cursor to resume from if the run is interrupted
+type RecomputeBalancesResponse struct {
+	AccountsProcessed int64 `json:"accounts_processed"`
+	Batches           int64 `json:"batches"`
+	LastAccountID     int64 `json:"last_account_id"`
+}
+
+// CanDebitRequest asks whether an account can afford a debit of Amount (a positive value)
+type CanDebitRequest struct {
+	AccountID int64   `json:"account_id"`
+	Amount    float64 `json:"amount"`
+}
+
+// CanDebitResponse previews the overdraft rule for a debit without creating it
+type CanDebitResponse struct {
+	AccountID int64   `json:"account_id"`
+	Amount    float64 `json:"amount"`
+	Allowed   bool    `json:"allowed"`
+	Available float64 `json:"available"`
+}
+
+// CanDebit reports whether a debit of amount fits in the available funds; values are compared in cents
+func CanDebit(available, amount float64) bool {
+	return math.Round(math.Abs(amount)*100) <= math.Round(available*100)
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// DailyDateLayout is the format of DailyTotal.Date
+const DailyDateLayout = "2006-01-02"
+
+// MaxGapFillDays bounds how many days a gap-filled daily series may span
+const MaxGapFillDays = 366
+
+// Number of days in a balance trend when none is given and the most allowed
+const (
+	DefaultTrendDays = 30
+	MaxTrendDays     = 365
+)
+
+// Daily totals errors
+var (
+	ErrGapFillRangeTooLarge = errors.New("fill_gaps supports ranges of up to 366 days")
+)
+
+// DailyTotal is the number of transactions and their net amount on one UTC day
+type DailyTotal struct {
+	Date      string  `json:"date"`
+	Count     int64   `json:"count"`
+	NetAmount float64 `json:"net_amount"`
+}
+
+// GetDailyTotalsRequest groups an account's transactions by day within an optional [Start, End] range
+// FillGaps adds zero entries for days without activity
+type GetDailyTotalsRequest struct {
+	AccountID int64      `json:"account_id"`
+	Start     *time.Time `json:"start,omitempty"`
+	End       *time.Time `json:"end,omitempty"`
+	FillGaps  bool       `json:"fill_gaps"`
+}
+
+// GetDailyTotalsResponse lists the account's daily totals, oldest day first
+type GetDailyTotalsResponse struct {
+	AccountID int64         `json:"account_id"`
+	Days      []*DailyTotal `json:"days"`
+}
+
+// FillDailyGaps returns the days from first through last (inclusive), taking totals from days
+// and adding zero entries for the missing ones; days must be sorted by date
+func FillDailyGaps(days []*DailyTotal, first, last time.Time) []*DailyTotal {
+	byDate := make(map[string]*DailyTotal, len(days))
+	for _, day := range days {
+		byDate[day.Date] = day
+	}
+
+	var filled []*DailyTotal
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		date := d.Format(DailyDateLayout)
+		if day, ok := byDate[date]; ok {
+			filled = append(filled, day)
+			continue
+		}
+		filled = append(filled, &DailyTotal{Date: date})
+	}
+	return filled
+}
+
+// DailyBalance is an account's balance at the end of one UTC day
+type DailyBalance struct {
+	Date    string  `json:"date"`
+	Balance float64 `json:"balance"`
+}
+
+// GetBalanceTrendRequest asks for the end-of-day balances of the Days UTC days up to and including AsOf's day
+type GetBalanceTrendRequest struct {
+	AccountID int64     `json:"account_id"`
+	Days      int64     `json:"days"`
+	AsOf      time.Time `json:"as_of"`
+}
+
+// GetBalanceTrendResponse lists one end-of-day balance per day, oldest day first
+type GetBalanceTrendResponse struct {
+	AccountID int64           `json:"account_id"`
+	Days      []*DailyBalance `json:"days"`
+}
+
+// NormalizeTrendDays applies DefaultTrendDays to a missing (non-positive) number of days and caps it at MaxTrendDays
+func NormalizeTrendDays(days int64) int64 {
+	if days <= 0 {
+		return DefaultTrendDays
+	}
+	if days > MaxTrendDays {
+		return MaxTrendDays
+	}
+	return days
+}
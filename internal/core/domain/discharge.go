@@ -0,0 +1,62 @@
+package domain
+
+import "math"
+
+// Discharge records how a credit paid down one outstanding debt
+// Previous is the debt's balance as read, so the update can be applied only if nobody changed it meanwhile
+type Discharge struct {
+	TransactionID int64
+	Previous      float64
+	Balance       float64
+}
+
+// DischargeDebts applies a credit amount to the outstanding debts in the given order (oldest first)
+// It returns the resulting balance changes and what is left of the credit once every debt is paid
+func DischargeDebts(credit float64, debts []*Transaction) ([]Discharge, float64) {
+	remaining := RoundToCents(credit)
+
+	var discharges []Discharge
+	for _, debt := range debts {
+		if remaining <= 0 {
+			break
+		}
+		if debt.Balance >= 0 {
+			continue
+		}
+
+		paid := math.Min(remaining, -debt.Balance)
+		discharges = append(discharges, Discharge{
+			TransactionID: debt.ID,
+			Previous:      debt.Balance,
+			Balance:       RoundToCents(debt.Balance + paid),
+		})
+		remaining = RoundToCents(remaining - paid)
+	}
+
+	return discharges, remaining
+}
+
+// ReversalDischarges returns the balance changes that undo original and the balance of its reversal
+// Reversing a debt cancels what is left of it, and what credits had already paid of it pays down the other
+// outstanding debts, oldest first. Reversing a credit takes back what is left of it, and what it had paid of
+// debts is owed again, on the reversal itself
+func ReversalDischarges(original *Transaction, outstanding []*Transaction) ([]Discharge, float64) {
+	var discharges []Discharge
+	if RoundToCents(original.Balance) != 0 {
+		discharges = append(discharges, Discharge{TransactionID: original.ID, Previous: original.Balance, Balance: 0})
+	}
+
+	amount := RoundToCents(-original.Amount)
+	if amount < 0 {
+		return discharges, RoundToCents(amount + original.Balance)
+	}
+
+	others := make([]*Transaction, 0, len(outstanding))
+	for _, debt := range outstanding {
+		if debt.ID != original.ID {
+			others = append(others, debt)
+		}
+	}
+	paid, leftover := DischargeDebts(amount+original.Balance, others)
+	return append(discharges, paid...), leftover
+}
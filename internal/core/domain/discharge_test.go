@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDischargeDebts(t *testing.T) {
+	debts := func() []*Transaction {
+		return []*Transaction{
+			{ID: 1, Amount: -50.0, Balance: -50.0},
+			{ID: 2, Amount: -23.5, Balance: -10.1},
+			{ID: 3, Amount: -0.3, Balance: -0.3},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		credit         float64
+		debts          []*Transaction
+		wantDischarges []Discharge
+		wantLeftover   float64
+	}{
+		{
+			name:   "partial discharge of the oldest debt",
+			credit: 20.0,
+			debts:  debts(),
+			wantDischarges: []Discharge{
+				{TransactionID: 1, Previous: -50.0, Balance: -30.0},
+			},
+		},
+		{
+			name:   "partial discharge across debts",
+			credit: 55.0,
+			debts:  debts(),
+			wantDischarges: []Discharge{
+				{TransactionID: 1, Previous: -50.0, Balance: 0},
+				{TransactionID: 2, Previous: -10.1, Balance: -5.1},
+			},
+		},
+		{
+			name:   "exact discharge",
+			credit: 60.4,
+			debts:  debts(),
+			wantDischarges: []Discharge{
+				{TransactionID: 1, Previous: -50.0, Balance: 0},
+				{TransactionID: 2, Previous: -10.1, Balance: 0},
+				{TransactionID: 3, Previous: -0.3, Balance: 0},
+			},
+		},
+		{
+			name:   "overpayment",
+			credit: 100.0,
+			debts:  debts(),
+			wantDischarges: []Discharge{
+				{TransactionID: 1, Previous: -50.0, Balance: 0},
+				{TransactionID: 2, Previous: -10.1, Balance: 0},
+				{TransactionID: 3, Previous: -0.3, Balance: 0},
+			},
+			wantLeftover: 39.6,
+		},
+		{
+			name:         "no debts",
+			credit:       25.0,
+			wantLeftover: 25.0,
+		},
+		{
+			name:   "settled debts are skipped",
+			credit: 5.0,
+			debts: []*Transaction{
+				{ID: 1, Amount: -50.0, Balance: 0},
+				{ID: 2, Amount: -10.0, Balance: -10.0},
+			},
+			wantDischarges: []Discharge{
+				{TransactionID: 2, Previous: -10.0, Balance: -5.0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discharges, leftover := DischargeDebts(tt.credit, tt.debts)
+
+			assert.Equal(t, tt.wantDischarges, discharges)
+			assert.Equal(t, tt.wantLeftover, leftover)
+		})
+	}
+}
+
+func TestReversalDischarges(t *testing.T) {
+	outstanding := func() []*Transaction {
+		return []*Transaction{
+			{ID: 1, Amount: -50.0, Balance: -50.0},
+			{ID: 2, Amount: -20.0, Balance: -20.0},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		original       *Transaction
+		outstanding    []*Transaction
+		wantDischarges []Discharge
+		wantBalance    float64
+	}{
+		{
+			name:        "unpaid debt is cancelled",
+			original:    &Transaction{ID: 1, Amount: -50.0, Balance: -50.0},
+			outstanding: outstanding(),
+			wantDischarges: []Discharge{
+				{TransactionID: 1, Previous: -50.0, Balance: 0},
+			},
+		},
+		{
+			name:        "what was paid of a debt pays the other debts",
+			original:    &Transaction{ID: 1, Amount: -50.0, Balance: -20.0},
+			outstanding: []*Transaction{{ID: 1, Amount: -50.0, Balance: -20.0}, {ID: 2, Amount: -20.0, Balance: -20.0}},
+			wantDischarges: []Discharge{
+				{TransactionID: 1, Previous: -20.0, Balance: 0},
+				{TransactionID: 2, Previous: -20.0, Balance: 0},
+			},
+			wantBalance: 10.0,
+		},
+		{
+			name:        "paid debt frees its whole amount",
+			original:    &Transaction{ID: 3, Amount: -30.0, Balance: 0},
+			outstanding: outstanding(),
+			wantDischarges: []Discharge{
+				{TransactionID: 1, Previous: -50.0, Balance: -20.0},
+			},
+		},
+		{
+			name:        "unused credit is taken back",
+			original:    &Transaction{ID: 4, Amount: 80.0, Balance: 80.0},
+			outstanding: outstanding(),
+			wantDischarges: []Discharge{
+				{TransactionID: 4, Previous: 80.0, Balance: 0},
+			},
+		},
+		{
+			name:        "what a credit paid is owed again on the reversal",
+			original:    &Transaction{ID: 4, Amount: 80.0, Balance: 30.0},
+			outstanding: outstanding(),
+			wantDischarges: []Discharge{
+				{TransactionID: 4, Previous: 30.0, Balance: 0},
+			},
+			wantBalance: -50.0,
+		},
+		{
+			name:        "fully used credit",
+			original:    &Transaction{ID: 4, Amount: 80.0, Balance: 0},
+			outstanding: outstanding(),
+			wantBalance: -80.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discharges, balance := ReversalDischarges(tt.original, tt.outstanding)
+
+			assert.Equal(t, tt.wantDischarges, discharges)
+			assert.Equal(t, tt.wantBalance, balance)
+		})
+	}
+}
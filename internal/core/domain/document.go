@@ -0,0 +1,62 @@
+package domain
+
+import "errors"
+
+// Document number errors
+var (
+	ErrInvalidCPF  = errors.New("invalid CPF")
+	ErrInvalidCNPJ = errors.New("invalid CNPJ")
+)
+
+// cnpjWeights are the weights of the CNPJ check digits; the first digit uses all but the leading 6
+var cnpjWeights = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// ValidateDocument checks the check digits of a CPF (11 digits) or a CNPJ (14 digits)
+// Documents made of a single repeated digit, such as 00000000000, pass the arithmetic but are never issued
+func ValidateDocument(document string) error {
+	switch len(document) {
+	case 11:
+		if !validDocumentDigits(document, func(position, length int) int { return length + 1 - position }) {
+			return ErrInvalidCPF
+		}
+	case 14:
+		if !validDocumentDigits(document, func(position, length int) int { return cnpjWeights[position+13-length] }) {
+			return ErrInvalidCNPJ
+		}
+	default:
+		return errors.New("document_number must have 11 or 14 characters")
+	}
+	return nil
+}
+
+// validDocumentDigits checks the two trailing check digits of document, each computed modulo 11 over the
+// digits before it with weight(position, length), where length is the number of digits weighted
+func validDocumentDigits(document string, weight func(position, length int) int) bool {
+	digits := make([]int, len(document))
+	repeated := true
+	for i := range document {
+		if document[i] < '0' || document[i] > '9' {
+			return false
+		}
+		digits[i] = int(document[i] - '0')
+		repeated = repeated && digits[i] == digits[0]
+	}
+	if repeated {
+		return false
+	}
+
+	for length := len(digits) - 2; length < len(digits); length++ {
+		sum := 0
+		for position := 0; position < length; position++ {
+			sum += digits[position] * weight(position, length)
+		}
+		check := 11 - sum%11
+		if check >= 10 {
+			check = 0
+		}
+		if digits[length] != check {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		wantErr  error
+	}{
+		{name: "valid CPF", document: "52998224725"},
+		{name: "valid CPF with zero check digit", document: "12345678909"},
+		{name: "valid CPF with zero first check digit", document: "98765432100"},
+		{name: "CPF with wrong first check digit", document: "52998224735", wantErr: ErrInvalidCPF},
+		{name: "CPF with wrong second check digit", document: "52998224726", wantErr: ErrInvalidCPF},
+		{name: "CPF of zeros", document: "00000000000", wantErr: ErrInvalidCPF},
+		{name: "CPF of a repeated digit", document: "11111111111", wantErr: ErrInvalidCPF},
+		{name: "valid CNPJ", document: "11222333000181"},
+		{name: "another valid CNPJ", document: "11444777000161"},
+		{name: "CNPJ with wrong first check digit", document: "11222333000191", wantErr: ErrInvalidCNPJ},
+		{name: "CNPJ with wrong second check digit", document: "11222333000182", wantErr: ErrInvalidCNPJ},
+		{name: "CNPJ of zeros", document: "00000000000000", wantErr: ErrInvalidCNPJ},
+		{name: "CNPJ with a letter", document: "1122233300018a", wantErr: ErrInvalidCNPJ},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDocument(tt.document)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	assert.EqualError(t, ValidateDocument("1234567890"), "document_number must have 11 or 14 characters")
+}
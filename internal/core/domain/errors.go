@@ -0,0 +1,12 @@
+package domain
+
+import "errors"
+
+// Infrastructure errors
+var (
+	// ErrServiceUnavailable means the storage is saturated and the request may succeed if retried later
+	ErrServiceUnavailable = errors.New("service temporarily unavailable")
+
+	// ErrCorruptTransaction means a stored transaction lacks required data and cannot be read
+	ErrCorruptTransaction = errors.New("corrupt transaction record")
+)
@@ -0,0 +1,54 @@
+package domain
+
+// HealthStatus is the state reported by a readiness check
+type HealthStatus string
+
+// Readiness states, from best to worst
+// A degraded service still accepts traffic; an unhealthy one should be taken out of rotation
+const (
+	HealthStatusHealthy   HealthStatus = "healthy"
+	HealthStatusDegraded  HealthStatus = "degraded"
+	HealthStatusUnhealthy HealthStatus = "unhealthy"
+)
+
+// healthStatusesBySeverity lists the states from best to worst
+var healthStatusesBySeverity = []HealthStatus{HealthStatusHealthy, HealthStatusDegraded, HealthStatusUnhealthy}
+
+// severity orders the states so the worst one can be picked; unknown states count as unhealthy
+func (s HealthStatus) severity() int {
+	for i, status := range healthStatusesBySeverity {
+		if s == status {
+			return i
+		}
+	}
+	return len(healthStatusesBySeverity) - 1
+}
+
+// HealthCheck is the outcome of one readiness check
+type HealthCheck struct {
+	Name   string       `json:"name"`
+	Status HealthStatus `json:"status"`
+	Detail string       `json:"detail,omitempty"`
+}
+
+// ReadinessReport lists every readiness check with the overall status, the worst of them
+type ReadinessReport struct {
+	Status HealthStatus  `json:"status"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+// NewReadinessReport summarizes the checks; with no checks the service is healthy
+func NewReadinessReport(checks []HealthCheck) *ReadinessReport {
+	report := &ReadinessReport{Status: HealthStatusHealthy, Checks: checks}
+	if report.Checks == nil {
+		report.Checks = []HealthCheck{}
+	}
+
+	for _, check := range checks {
+		if severity := check.Status.severity(); severity > report.Status.severity() {
+			report.Status = healthStatusesBySeverity[severity]
+		}
+	}
+
+	return report
+}
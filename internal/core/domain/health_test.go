@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReadinessReport(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     []HealthCheck
+		wantStatus HealthStatus
+	}{
+		{name: "no checks", checks: nil, wantStatus: HealthStatusHealthy},
+		{
+			name:       "all healthy",
+			checks:     []HealthCheck{{Name: "database", Status: HealthStatusHealthy}, {Name: "wal", Status: HealthStatusHealthy}},
+			wantStatus: HealthStatusHealthy,
+		},
+		{
+			name:       "one degraded",
+			checks:     []HealthCheck{{Name: "database", Status: HealthStatusHealthy}, {Name: "wal", Status: HealthStatusDegraded}},
+			wantStatus: HealthStatusDegraded,
+		},
+		{
+			name:       "unhealthy outweighs degraded",
+			checks:     []HealthCheck{{Name: "database", Status: HealthStatusUnhealthy}, {Name: "wal", Status: HealthStatusDegraded}},
+			wantStatus: HealthStatusUnhealthy,
+		},
+		{
+			name:       "unknown status counts as unhealthy",
+			checks:     []HealthCheck{{Name: "database", Status: "broken"}},
+			wantStatus: HealthStatusUnhealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewReadinessReport(tt.checks)
+
+			assert.Equal(t, tt.wantStatus, report.Status)
+			assert.NotNil(t, report.Checks)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+// IdempotencyStatus is the state of a request made with an Idempotency-Key
+type IdempotencyStatus string
+
+const (
+	// IdempotencyStatusProcessing marks a key whose first request is still running
+	IdempotencyStatusProcessing IdempotencyStatus = "processing"
+	// IdempotencyStatusCompleted marks a key whose response is stored for replay
+	IdempotencyStatusCompleted IdempotencyStatus = "completed"
+)
+
+// IdempotencyStaleProcessingAfter is how long a key may stay processing before another request may take it over
+// It is well above the request timeout, so only keys abandoned by a crash or a killed request are reclaimed
+const IdempotencyStaleProcessingAfter = 5 * time.Minute
+
+// IdempotencyRecord is the stored outcome of the first request made with an Idempotency-Key
+// RequestHash identifies the first request by its method, target and body (empty when unknown); ExpiresAt is when the response
+// stops being replayed (nil never expires)
+type IdempotencyRecord struct {
+	Key            string
+	Status         IdempotencyStatus
+	RequestHash    string
+	ResponseStatus int
+	ResponseBody   []byte
+	CreatedAt      time.Time
+	ExpiresAt      *time.Time
+}
+
+// Expired reports whether a stored response may no longer be replayed at now
+func (r *IdempotencyRecord) Expired(now time.Time) bool {
+	return r.ExpiresAt != nil && !now.Before(*r.ExpiresAt)
+}
+
+// StaleProcessing reports whether the key is still processing at now long after its request started,
+// so the request holding it is presumed gone
+func (r *IdempotencyRecord) StaleProcessing(now time.Time) bool {
+	return r.Status == IdempotencyStatusProcessing && !now.Before(r.CreatedAt.Add(IdempotencyStaleProcessingAfter))
+}
+
+// MatchesRequest reports whether a request with the given hash may be answered with this record
+// Records without a hash (stored before hashes were kept) match any request
+func (r *IdempotencyRecord) MatchesRequest(requestHash string) bool {
+	return r.RequestHash == "" || r.RequestHash == requestHash
+}
+
+// IdempotencyKeyStatus describes a stored Idempotency-Key for debugging stuck retries
+// It never carries the stored response body; Cached is false for unknown and expired keys
+type IdempotencyKeyStatus struct {
+	Key            string            `json:"key"`
+	Cached         bool              `json:"cached"`
+	Status         IdempotencyStatus `json:"status,omitempty"`
+	ResponseStatus int               `json:"response_status,omitempty"`
+	AgeSeconds     int64             `json:"age_seconds,omitempty"`
+	Expired        bool              `json:"expired,omitempty"`
+	ExpiresAt      *time.Time        `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyRecord_MatchesRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		storedHash  string
+		requestHash string
+		want        bool
+	}{
+		{name: "same body", storedHash: "abc", requestHash: "abc", want: true},
+		{name: "different body", storedHash: "abc", requestHash: "def", want: false},
+		{name: "no stored hash", storedHash: "", requestHash: "def", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := &IdempotencyRecord{RequestHash: tt.storedHash}
+			assert.Equal(t, tt.want, record.MatchesRequest(tt.requestHash))
+		})
+	}
+}
+
+func TestIdempotencyRecord_Expired(t *testing.T) {
+	now := time.Date(2025, 11, 16, 14, 0, 0, 0, time.UTC)
+	later := now.Add(time.Second)
+
+	assert.False(t, (&IdempotencyRecord{}).Expired(now), "No expiry never expires")
+	assert.False(t, (&IdempotencyRecord{ExpiresAt: &later}).Expired(now))
+	assert.True(t, (&IdempotencyRecord{ExpiresAt: &now}).Expired(now))
+}
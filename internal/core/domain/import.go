@@ -0,0 +1,35 @@
+package domain
+
+// DefaultImportBatchSize is how many imported transactions are written per database transaction
+const DefaultImportBatchSize = 100
+
+// ImportLine is one line of a transaction import, numbered from 1
+// Err is set instead of Request when the line could not be read as a valid transaction request
+type ImportLine struct {
+	Number  int64
+	Request CreateTransactionRequest
+	Err     error
+}
+
+// ImportFailure reports why a line of an import was not stored
+type ImportFailure struct {
+	Line  int64  `json:"line"`
+	Error string `json:"error"`
+}
+
+// ImportTransactionsResponse summarizes a transaction import
+type ImportTransactionsResponse struct {
+	Processed int64           `json:"processed"`
+	Succeeded int64           `json:"succeeded"`
+	Failed    []ImportFailure `json:"failed"`
+}
+
+// NewImportTransactionsResponse returns an empty summary
+func NewImportTransactionsResponse() *ImportTransactionsResponse {
+	return &ImportTransactionsResponse{Failed: []ImportFailure{}}
+}
+
+// Fail records the line as failed with the given error
+func (r *ImportTransactionsResponse) Fail(line int64, err error) {
+	r.Failed = append(r.Failed, ImportFailure{Line: line, Error: err.Error()})
+}
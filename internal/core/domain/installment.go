@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// DefaultMaxInstallments is the default limit for splitting a purchase
+const DefaultMaxInstallments = 12
+
+// MaxInstallmentsCeiling is the most installments a purchase can ever be split into, whatever the policy
+const MaxInstallmentsCeiling = 48
+
+// DefaultMaxRowsPerRequest is the default limit for transaction rows a single request may create
+const DefaultMaxRowsPerRequest = 500
+
+// Installment errors
+var (
+	ErrInstallmentsNotAllowed  = errors.New("installments are only allowed for purchases with installments")
+	ErrTooManyInstallments     = errors.New("installments exceed the maximum allowed")
+	ErrInstallmentBelowMinimum = errors.New("installment amount is below the minimum allowed")
+	ErrTooManyRowsPerRequest   = errors.New("request would create more transactions than allowed")
+)
+
+// InstallmentPolicy bounds how an interest-free purchase may be split
+// MinInstallmentAmount of 0 disables the per-installment minimum
+type InstallmentPolicy struct {
+	MaxInstallments      int64
+	MinInstallmentAmount float64
+}
+
+// DefaultInstallmentPolicy allows up to DefaultMaxInstallments with no minimum installment amount
+func DefaultInstallmentPolicy() InstallmentPolicy {
+	return InstallmentPolicy{MaxInstallments: DefaultMaxInstallments}
+}
+
+// Validate checks that splitting amount into the given number of installments respects the policy
+// and MaxInstallmentsCeiling
+// Installments are interest-free, so each one is the amount divided evenly; amounts are compared in cents
+func (p InstallmentPolicy) Validate(amount float64, installments int64) error {
+	if installments > p.MaxInstallments || installments > MaxInstallmentsCeiling {
+		return ErrTooManyInstallments
+	}
+
+	totalCents := int64(math.Round(math.Abs(amount) * 100))
+	minCents := int64(math.Round(p.MinInstallmentAmount * 100))
+	if totalCents < minCents*installments {
+		return ErrInstallmentBelowMinimum
+	}
+
+	return nil
+}
+
+// InstallmentCount returns the number of installments requested, 1 when not split
+func (r CreateTransactionRequest) InstallmentCount() int64 {
+	if r.Installments == nil {
+		return 1
+	}
+	return *r.Installments
+}
+
+// RowsForRequests returns how many transaction rows the requests create once split into installments
+func RowsForRequests(requests ...CreateTransactionRequest) int64 {
+	var rows int64
+	for _, req := range requests {
+		rows += req.InstallmentCount()
+	}
+	return rows
+}
+
+// SplitInstallments divides a purchase into installments of equal amounts, one month apart from its event date
+// Amounts are split in cents; the cents that do not divide evenly go to the first installment
+// A single installment returns the purchase itself
+func SplitInstallments(purchase *Transaction, installments int64) []*Transaction {
+	if installments <= 1 {
+		return []*Transaction{purchase}
+	}
+
+	totalCents := int64(math.Round(purchase.Amount * 100))
+	partCents := totalCents / installments
+	remainderCents := totalCents % installments
+
+	parts := make([]*Transaction, installments)
+	for i := range parts {
+		cents := partCents
+		if i == 0 {
+			cents += remainderCents
+		}
+		amount := float64(cents) / 100
+
+		part := *purchase
+		part.Amount = amount
+		part.Balance = amount
+		part.EventDate = addMonths(purchase.EventDate, i)
+		// The Idempotency-Key identifies the purchase, so only its first installment carries it
+		if i > 0 {
+			part.IdempotencyKey = ""
+		}
+		parts[i] = &part
+	}
+
+	return parts
+}
+
+// addMonths returns t moved the given number of months ahead, keeping the day of month when it exists
+// and clamping to the last day otherwise (Jan 31 + 1 month is Feb 28 or 29)
+func addMonths(t time.Time, months int) time.Time {
+	shifted := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := shifted.AddDate(0, 1, -1).Day()
+	return shifted.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallmentPolicy_Validate(t *testing.T) {
+	policy := InstallmentPolicy{MaxInstallments: 12, MinInstallmentAmount: 10.0}
+
+	tests := []struct {
+		name         string
+		amount       float64
+		installments int64
+		wantErr      error
+	}{
+		{name: "single installment", amount: 10.0, installments: 1},
+		{name: "at the maximum installment count", amount: 120.0, installments: 12},
+		{name: "above the maximum installment count", amount: 1300.0, installments: 13, wantErr: ErrTooManyInstallments},
+		{name: "installment exactly at the minimum", amount: 100.0, installments: 10},
+		{name: "installment one cent below the minimum", amount: 99.99, installments: 10, wantErr: ErrInstallmentBelowMinimum},
+		{name: "negative amounts use their magnitude", amount: -100.0, installments: 10},
+		{name: "tiny purchase split too far", amount: 15.0, installments: 12, wantErr: ErrInstallmentBelowMinimum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, policy.Validate(tt.amount, tt.installments))
+		})
+	}
+}
+
+func TestDefaultInstallmentPolicy(t *testing.T) {
+	policy := DefaultInstallmentPolicy()
+
+	assert.NoError(t, policy.Validate(0.12, 12), "No minimum by default")
+	assert.ErrorIs(t, policy.Validate(1000.0, 13), ErrTooManyInstallments)
+}
+
+func TestInstallmentPolicy_ValidateCeiling(t *testing.T) {
+	policy := InstallmentPolicy{MaxInstallments: 100}
+
+	assert.NoError(t, policy.Validate(480.0, MaxInstallmentsCeiling))
+	assert.ErrorIs(t, policy.Validate(490.0, MaxInstallmentsCeiling+1), ErrTooManyInstallments, "The ceiling applies whatever the policy")
+}
+
+func TestSplitInstallments(t *testing.T) {
+	purchase := &Transaction{AccountID: 1, Amount: -1000.0, EventDate: time.Date(2025, 11, 30, 9, 0, 0, 0, time.UTC)}
+
+	parts := SplitInstallments(purchase, 3)
+
+	if assert.Len(t, parts, 3) {
+		assert.Equal(t, -333.34, parts[0].Amount, "The remainder cent goes to the first installment")
+		assert.Equal(t, -333.33, parts[1].Amount)
+		assert.Equal(t, -333.33, parts[2].Amount)
+		assert.Equal(t, time.Date(2025, 12, 30, 9, 0, 0, 0, time.UTC), parts[1].EventDate)
+		assert.Equal(t, time.Date(2026, 1, 30, 9, 0, 0, 0, time.UTC), parts[2].EventDate, "Crosses the year boundary")
+	}
+	assert.Equal(t, -1000.0, purchase.Amount, "The purchase itself is left untouched")
+
+	assert.Equal(t, []*Transaction{purchase}, SplitInstallments(purchase, 1))
+}
+
+func TestAddMonths(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   time.Time
+		months int
+		want   time.Time
+	}{
+		{name: "same day next month", from: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), months: 1, want: time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "clamped to a shorter month", from: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), months: 1, want: time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{name: "clamped in a leap year", from: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), months: 1, want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "day kept after a short month", from: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), months: 2, want: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, addMonths(tt.from, tt.months))
+		})
+	}
+}
+
+func TestRowsForRequests(t *testing.T) {
+	installments := func(n int64) *int64 { return &n }
+
+	tests := []struct {
+		name     string
+		requests []CreateTransactionRequest
+		want     int64
+	}{
+		{name: "no requests", want: 0},
+		{name: "single request without installments", requests: []CreateTransactionRequest{{}}, want: 1},
+		{name: "single request with installments", requests: []CreateTransactionRequest{{Installments: installments(12)}}, want: 12},
+		{
+			name: "batch mixing installments and single transactions",
+			requests: []CreateTransactionRequest{
+				{Installments: installments(12)},
+				{},
+				{Installments: installments(3)},
+			},
+			want: 16,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RowsForRequests(tt.requests...))
+		})
+	}
+}
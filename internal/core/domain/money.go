@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Currency codes (ISO 4217) with display formats
+const (
+	CurrencyBRL = "BRL"
+	CurrencyUSD = "USD"
+)
+
+// amountFormat is how a currency writes amounts for people to read
+type amountFormat struct {
+	symbol             string
+	symbolSpace        bool
+	decimalSeparator   string
+	thousandsSeparator string
+}
+
+var amountFormats = map[string]amountFormat{
+	CurrencyBRL: {symbol: "R$", symbolSpace: true, decimalSeparator: ",", thousandsSeparator: "."},
+	CurrencyUSD: {symbol: "$", decimalSeparator: ".", thousandsSeparator: ","},
+}
+
+// FormatAmount renders an amount for display in statements and exports, rounded to cents:
+// "R$ 1.234,50" for BRL and "$1,234.50" for USD, with the minus sign before the symbol
+// Other currencies use their code and US separators, e.g. "EUR 1,234.50"
+// Machine-readable outputs (OFX, JSON) keep plain decimal amounts instead
+func FormatAmount(amount float64, currency string) string {
+	code := strings.ToUpper(currency)
+	format, ok := amountFormats[code]
+	if !ok {
+		format = amountFormat{symbol: code, symbolSpace: true, decimalSeparator: ".", thousandsSeparator: ","}
+	}
+
+	rounded := RoundToCents(amount)
+	sign := ""
+	if rounded < 0 {
+		sign = "-"
+		rounded = -rounded
+	}
+
+	digits := strconv.FormatFloat(rounded, 'f', 2, 64)
+	whole, cents := digits[:len(digits)-3], digits[len(digits)-2:]
+
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteString(format.thousandsSeparator)
+		}
+		grouped.WriteRune(digit)
+	}
+
+	symbol := format.symbol
+	if format.symbolSpace {
+		symbol += " "
+	}
+	return sign + symbol + grouped.String() + format.decimalSeparator + cents
+}
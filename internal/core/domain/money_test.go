@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		currency string
+		want     string
+	}{
+		{name: "BRL", amount: 50, currency: CurrencyBRL, want: "R$ 50,00"},
+		{name: "BRL cents", amount: 0.5, currency: CurrencyBRL, want: "R$ 0,50"},
+		{name: "BRL thousands", amount: 1234567.891, currency: CurrencyBRL, want: "R$ 1.234.567,89"},
+		{name: "BRL negative", amount: -50, currency: CurrencyBRL, want: "-R$ 50,00"},
+		{name: "BRL negative thousands", amount: -1234.5, currency: CurrencyBRL, want: "-R$ 1.234,50"},
+		{name: "USD", amount: 50, currency: CurrencyUSD, want: "$50.00"},
+		{name: "USD thousands", amount: 1234567.891, currency: CurrencyUSD, want: "$1,234,567.89"},
+		{name: "USD negative", amount: -23.456, currency: CurrencyUSD, want: "-$23.46"},
+		{name: "USD exact thousand", amount: 1000, currency: CurrencyUSD, want: "$1,000.00"},
+		{name: "lowercase code", amount: 10, currency: "brl", want: "R$ 10,00"},
+		{name: "rounds to zero without a sign", amount: -0.001, currency: CurrencyUSD, want: "$0.00"},
+		{name: "unknown currency uses its code", amount: -1234.5, currency: "EUR", want: "-EUR 1,234.50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatAmount(tt.amount, tt.currency))
+		})
+	}
+}
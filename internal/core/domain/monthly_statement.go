@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MonthlyDateLayout is the format of MonthlyTotal.Month and MonthlyStatement.Month
+const MonthlyDateLayout = "2006-01"
+
+// Years a monthly statement may be requested for
+const (
+	MinStatementYear = 1900
+	MaxStatementYear = 9999
+)
+
+// ErrInvalidStatementYear rejects monthly statements for years outside [MinStatementYear, MaxStatementYear]
+var ErrInvalidStatementYear = fmt.Errorf("year must be between %d and %d", MinStatementYear, MaxStatementYear)
+
+// MonthlyTotal is the sum of an account's negative (debit) and positive (credit) amounts in one UTC month
+type MonthlyTotal struct {
+	Month   string  `json:"month"`
+	Debits  float64 `json:"debits"`
+	Credits float64 `json:"credits"`
+}
+
+// GetMonthlyStatementRequest asks for the month-by-month summary of an account over one UTC calendar year
+type GetMonthlyStatementRequest struct {
+	AccountID int64 `json:"account_id"`
+	Year      int   `json:"year"`
+}
+
+// Validate reports ErrInvalidStatementYear for years that cannot be requested
+func (r GetMonthlyStatementRequest) Validate() error {
+	if r.Year < MinStatementYear || r.Year > MaxStatementYear {
+		return ErrInvalidStatementYear
+	}
+	return nil
+}
+
+// Period returns the first and last instants of the requested year, in UTC
+func (r GetMonthlyStatementRequest) Period() (time.Time, time.Time) {
+	start := time.Date(r.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(1, 0, 0).Add(-time.Nanosecond)
+}
+
+// MonthlyStatement summarizes one month: TotalDebits is at most zero, TotalCredits at least zero,
+// NetChange is their sum and EndingBalance the account's balance at the end of the month
+type MonthlyStatement struct {
+	Month         string  `json:"month"`
+	TotalDebits   float64 `json:"total_debits"`
+	TotalCredits  float64 `json:"total_credits"`
+	NetChange     float64 `json:"net_change"`
+	EndingBalance float64 `json:"ending_balance"`
+}
+
+// GetMonthlyStatementResponse lists every month of the year, January first, after the balance the year opened with
+type GetMonthlyStatementResponse struct {
+	AccountID      int64               `json:"account_id"`
+	Year           int                 `json:"year"`
+	OpeningBalance float64             `json:"opening_balance"`
+	Months         []*MonthlyStatement `json:"months"`
+}
+
+// BuildMonthlyStatement returns the twelve months of year, taking their sums from totals (months without
+// activity are zero) and carrying the balance forward from opening; totals of other years are ignored
+func BuildMonthlyStatement(year int, opening float64, totals []*MonthlyTotal) []*MonthlyStatement {
+	byMonth := make(map[string]*MonthlyTotal, len(totals))
+	for _, total := range totals {
+		byMonth[total.Month] = total
+	}
+
+	months := make([]*MonthlyStatement, 0, 12)
+	balance := RoundToCents(opening)
+	for month := time.January; month <= time.December; month++ {
+		key := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Format(MonthlyDateLayout)
+		statement := &MonthlyStatement{Month: key}
+		if total, ok := byMonth[key]; ok {
+			// Rounded in cents first so the rounded parts add up to the net change
+			statement.TotalDebits = RoundToCents(total.Debits)
+			statement.TotalCredits = RoundToCents(total.Credits)
+			statement.NetChange = RoundToCents(statement.TotalDebits + statement.TotalCredits)
+		}
+		balance = RoundToCents(balance + statement.NetChange)
+		statement.EndingBalance = balance
+		months = append(months, statement)
+	}
+
+	return months
+}
@@ -1,12 +1,18 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // OperationType represents the type of transaction operation
 type OperationType struct {
-	ID          int64     `json:"operation_type_id"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          int64  `json:"operation_type_id"`
+	Description string `json:"description"`
+	// IsCredit is stored with the type, so types added by later migrations are classified from data
+	IsCredit  bool      `json:"is_credit"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // Operation type constants
@@ -17,14 +23,105 @@ const (
 	OperationTypeCreditVoucher            = 4
 )
 
+// Supported locales for operation type descriptions
+const (
+	LocaleEnglish    = "en"
+	LocalePortuguese = "pt"
+	DefaultLocale    = LocaleEnglish
+)
+
+// ErrUnsupportedLocale is returned when no descriptions exist for a locale
+var ErrUnsupportedLocale = errors.New("unsupported locale")
+
+// operationTypeSeed is a predefined operation type: its direction and its description in every supported locale
+type operationTypeSeed struct {
+	id           int64
+	isCredit     bool
+	descriptions map[string]string
+}
+
+// operationTypeSeeds are only written by the seed; once stored, descriptions and directions are read from the rows,
+// so types added by later migrations are described and classified like these
+var operationTypeSeeds = []operationTypeSeed{
+	{id: OperationTypePurchase, descriptions: map[string]string{
+		LocaleEnglish:    "Normal Purchase",
+		LocalePortuguese: "COMPRA A VISTA",
+	}},
+	{id: OperationTypePurchaseWithInstallments, descriptions: map[string]string{
+		LocaleEnglish:    "Purchase with installments",
+		LocalePortuguese: "COMPRA PARCELADA",
+	}},
+	{id: OperationTypeWithdrawal, descriptions: map[string]string{
+		LocaleEnglish:    "Withdrawal",
+		LocalePortuguese: "SAQUE",
+	}},
+	{id: OperationTypeCreditVoucher, isCredit: true, descriptions: map[string]string{
+		LocaleEnglish:    "Credit Voucher",
+		LocalePortuguese: "PAGAMENTO",
+	}},
+}
+
+// OperationTypes returns the predefined operation types described in the given locale, ordered by ID
+func OperationTypes(locale string) ([]*OperationType, error) {
+	operationTypes := make([]*OperationType, 0, len(operationTypeSeeds))
+	for _, seed := range operationTypeSeeds {
+		description, ok := seed.descriptions[locale]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnsupportedLocale, locale)
+		}
+		operationTypes = append(operationTypes, &OperationType{
+			ID:          seed.id,
+			Description: description,
+			IsCredit:    seed.isCredit,
+		})
+	}
+
+	return operationTypes, nil
+}
+
+// OperationTypeDescriptions maps each operation type ID to its stored description
+func OperationTypeDescriptions(operationTypes []*OperationType) map[int64]string {
+	descriptions := make(map[int64]string, len(operationTypes))
+	for _, ot := range operationTypes {
+		descriptions[ot.ID] = ot.Description
+	}
+	return descriptions
+}
+
+// ListOperationTypesResponse lists every operation type
+type ListOperationTypesResponse struct {
+	OperationTypes []*OperationType `json:"operation_types"`
+}
+
+// Directions of an operation type, as labeled for clients
+const (
+	DirectionDebit  = "debit"
+	DirectionCredit = "credit"
+)
+
+// Direction labels the operation type as DirectionCredit or DirectionDebit
+func (ot *OperationType) Direction() string {
+	if ot.IsCreditOperation() {
+		return DirectionCredit
+	}
+	return DirectionDebit
+}
+
+// OperationTypeDirections maps each operation type ID to its direction
+func OperationTypeDirections(operationTypes []*OperationType) map[int64]string {
+	directions := make(map[int64]string, len(operationTypes))
+	for _, ot := range operationTypes {
+		directions[ot.ID] = ot.Direction()
+	}
+	return directions
+}
+
 // IsDebitOperation checks if the operation type should result in a negative amount
 func (ot *OperationType) IsDebitOperation() bool {
-	return ot.ID == OperationTypePurchase ||
-		ot.ID == OperationTypePurchaseWithInstallments ||
-		ot.ID == OperationTypeWithdrawal
+	return !ot.IsCredit
 }
 
 // IsCreditOperation checks if the operation type should result in a positive amount
 func (ot *OperationType) IsCreditOperation() bool {
-	return ot.ID == OperationTypeCreditVoucher
+	return ot.IsCredit
 }
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		locale   string
+		expected []string
+	}{
+		{name: "english", locale: LocaleEnglish, expected: []string{"Normal Purchase", "Purchase with installments", "Withdrawal", "Credit Voucher"}},
+		{name: "portuguese", locale: LocalePortuguese, expected: []string{"COMPRA A VISTA", "COMPRA PARCELADA", "SAQUE", "PAGAMENTO"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operationTypes, err := OperationTypes(tt.locale)
+
+			require.NoError(t, err)
+			require.Len(t, operationTypes, len(tt.expected))
+			for i, ot := range operationTypes {
+				assert.Equal(t, int64(i+1), ot.ID, "IDs are the same in every locale")
+				assert.Equal(t, tt.expected[i], ot.Description)
+			}
+			assert.True(t, operationTypes[0].IsDebitOperation())
+			assert.True(t, operationTypes[3].IsCreditOperation())
+		})
+	}
+}
+
+func TestOperationType_ClassifiedFromData(t *testing.T) {
+	cashback := &OperationType{ID: 5, Description: "Cashback", IsCredit: true}
+	fee := &OperationType{ID: 6, Description: "Fee"}
+
+	assert.True(t, cashback.IsCreditOperation())
+	assert.False(t, cashback.IsDebitOperation())
+	assert.True(t, fee.IsDebitOperation())
+	assert.False(t, fee.IsCreditOperation())
+
+	credit := &Transaction{Amount: -25}
+	require.NoError(t, credit.NormalizeAmount(cashback))
+	assert.Equal(t, 25.0, credit.Amount)
+
+	debit := &Transaction{Amount: 25}
+	require.NoError(t, debit.NormalizeAmount(fee))
+	assert.Equal(t, -25.0, debit.Amount)
+}
+
+func TestOperationTypeDirections(t *testing.T) {
+	operationTypes, err := OperationTypes(DefaultLocale)
+	require.NoError(t, err)
+
+	directions := OperationTypeDirections(operationTypes)
+
+	assert.Equal(t, map[int64]string{1: DirectionDebit, 2: DirectionDebit, 3: DirectionDebit, 4: DirectionCredit}, directions)
+	assert.Equal(t, map[int64]string{5: DirectionCredit}, OperationTypeDirections([]*OperationType{{ID: 5, IsCredit: true}}))
+	assert.Empty(t, OperationTypeDirections(nil))
+}
+
+func TestOperationTypes_UnsupportedLocale(t *testing.T) {
+	_, err := OperationTypes("fr")
+
+	assert.ErrorIs(t, err, ErrUnsupportedLocale)
+}
+
+func TestOperationTypeDescriptions(t *testing.T) {
+	operationTypes := []*OperationType{
+		{ID: OperationTypePurchase, Description: "COMPRA A VISTA"},
+		{ID: 5, Description: "Cashback", IsCredit: true},
+	}
+
+	descriptions := OperationTypeDescriptions(operationTypes)
+
+	assert.Equal(t, map[int64]string{1: "COMPRA A VISTA", 5: "Cashback"}, descriptions, "Descriptions come from the stored rows, whatever the ID")
+	assert.Empty(t, OperationTypeDescriptions(nil))
+}
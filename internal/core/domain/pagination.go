@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Default page sizes for paginated endpoints
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 100
+)
+
+// PaginationDefaults holds the page size applied when none is given and the largest page size allowed
+// WarnAbove flags pages larger than it as inefficient (0 disables the warning)
+type PaginationDefaults struct {
+	DefaultLimit int64
+	MaxLimit     int64
+	WarnAbove    int64
+}
+
+// IsLargePage reports whether a (normalized) limit exceeds the large page warning threshold
+func (d PaginationDefaults) IsLargePage(limit int64) bool {
+	return d.WarnAbove > 0 && limit > d.WarnAbove
+}
+
+// DefaultPagination returns the standard page size limits (50 by default, at most 100)
+func DefaultPagination() PaginationDefaults {
+	return PaginationDefaults{DefaultLimit: DefaultPageSize, MaxLimit: MaxPageSize}
+}
+
+// NormalizePagination applies the pagination clamping rules
+// A missing (non-positive) limit becomes the default, a limit above the maximum is capped at it,
+// and a negative offset starts from the beginning
+func NormalizePagination(limit, offset int64, defaults PaginationDefaults) (int64, int64) {
+	if limit <= 0 {
+		limit = defaults.DefaultLimit
+	}
+	if limit > defaults.MaxLimit {
+		limit = defaults.MaxLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return limit, offset
+}
+
+// NewPaginationMetadata describes a page of total results; an empty result still has one page
+func NewPaginationMetadata(total, limit, offset int64) PaginationMetadata {
+	pages := int64(1)
+	if limit > 0 && total > 0 {
+		pages = (total + limit - 1) / limit
+	}
+
+	return PaginationMetadata{
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+		Pages:  pages,
+	}
+}
+
+// SortOrder is the direction a transaction listing runs in by event date
+type SortOrder string
+
+// Supported sort orders; listings are newest first unless asked otherwise
+const (
+	SortDescending SortOrder = "desc"
+	SortAscending  SortOrder = "asc"
+)
+
+// Cursor pagination errors
+var (
+	ErrInvalidCursor       = errors.New("invalid cursor")
+	ErrCursorOrderMismatch = errors.New("cursor was issued for the other sort order")
+	ErrCursorWithOffset    = errors.New("cursor cannot be combined with offset")
+)
+
+// ParseSortOrder reads an order query value; empty means unspecified and is returned as is
+func ParseSortOrder(value string) (SortOrder, bool) {
+	switch order := SortOrder(value); order {
+	case "", SortDescending, SortAscending:
+		return order, true
+	default:
+		return "", false
+	}
+}
+
+// TransactionCursor marks the last transaction of a page; the next page continues after it in Order
+// Ties on EventDate are broken by ID, so every transaction has a distinct position in either order
+type TransactionCursor struct {
+	Order     SortOrder `json:"o"`
+	EventDate time.Time `json:"d"`
+	ID        int64     `json:"id"`
+}
+
+// NewTransactionCursor returns the cursor continuing after last in the given order
+func NewTransactionCursor(last *Transaction, order SortOrder) *TransactionCursor {
+	return &TransactionCursor{Order: order, EventDate: last.EventDate.UTC(), ID: last.ID}
+}
+
+// Encode returns the opaque form of the cursor handed to clients
+func (c *TransactionCursor) Encode() string {
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeTransactionCursor parses a cursor produced by Encode
+func DecodeTransactionCursor(value string) (*TransactionCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor TransactionCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if (cursor.Order != SortDescending && cursor.Order != SortAscending) || cursor.ID <= 0 || cursor.EventDate.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizePagination(t *testing.T) {
+	defaults := PaginationDefaults{DefaultLimit: 20, MaxLimit: 30}
+
+	tests := []struct {
+		name       string
+		limit      int64
+		offset     int64
+		wantLimit  int64
+		wantOffset int64
+	}{
+		{name: "values within bounds are kept", limit: 10, offset: 5, wantLimit: 10, wantOffset: 5},
+		{name: "missing limit uses the default", limit: 0, offset: 0, wantLimit: 20, wantOffset: 0},
+		{name: "negative limit uses the default", limit: -1, offset: 0, wantLimit: 20, wantOffset: 0},
+		{name: "limit at the maximum is kept", limit: 30, offset: 0, wantLimit: 30, wantOffset: 0},
+		{name: "limit above the maximum is capped", limit: 31, offset: 0, wantLimit: 30, wantOffset: 0},
+		{name: "negative offset starts from the beginning", limit: 10, offset: -5, wantLimit: 10, wantOffset: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, offset := NormalizePagination(tt.limit, tt.offset, defaults)
+
+			assert.Equal(t, tt.wantLimit, limit)
+			assert.Equal(t, tt.wantOffset, offset)
+		})
+	}
+}
+
+func TestDefaultPagination(t *testing.T) {
+	limit, offset := NormalizePagination(0, 0, DefaultPagination())
+	assert.Equal(t, int64(50), limit)
+	assert.Equal(t, int64(0), offset)
+
+	limit, _ = NormalizePagination(1000, 0, DefaultPagination())
+	assert.Equal(t, int64(100), limit)
+}
+
+func TestNewPaginationMetadata(t *testing.T) {
+	assert.Equal(t, PaginationMetadata{Total: 5, Limit: 2, Offset: 2, Pages: 3}, NewPaginationMetadata(5, 2, 2))
+	assert.Equal(t, PaginationMetadata{Total: 4, Limit: 2, Offset: 0, Pages: 2}, NewPaginationMetadata(4, 2, 0))
+	assert.Equal(t, int64(1), NewPaginationMetadata(0, 50, 0).Pages, "An empty result still has one page")
+}
+
+func TestParseSortOrder(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantOrder SortOrder
+		wantOK    bool
+	}{
+		{value: "", wantOrder: "", wantOK: true},
+		{value: "asc", wantOrder: SortAscending, wantOK: true},
+		{value: "desc", wantOrder: SortDescending, wantOK: true},
+		{value: "ASC", wantOK: false},
+		{value: "newest", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			order, ok := ParseSortOrder(tt.value)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantOrder, order)
+		})
+	}
+}
+
+func TestTransactionCursor_EncodeDecode(t *testing.T) {
+	last := &Transaction{ID: 42, EventDate: time.Date(2025, 3, 1, 9, 30, 0, 123, time.FixedZone("BRT", -3*60*60))}
+
+	encoded := NewTransactionCursor(last, SortAscending).Encode()
+	decoded, err := DecodeTransactionCursor(encoded)
+
+	require.NoError(t, err)
+	assert.Equal(t, SortAscending, decoded.Order)
+	assert.Equal(t, int64(42), decoded.ID)
+	assert.True(t, last.EventDate.Equal(decoded.EventDate))
+	assert.NotContains(t, encoded, "=", "Cursors are safe to put in a query string as is")
+}
+
+func TestDecodeTransactionCursor_Invalid(t *testing.T) {
+	encode := func(payload string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(payload))
+	}
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "not base64", value: "not a cursor!"},
+		{name: "not JSON", value: encode("desc|2025-01-01|1")},
+		{name: "unknown order", value: encode(`{"o":"up","d":"2025-01-01T00:00:00Z","id":1}`)},
+		{name: "missing id", value: encode(`{"o":"asc","d":"2025-01-01T00:00:00Z"}`)},
+		{name: "missing event date", value: encode(`{"o":"asc","id":1}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeTransactionCursor(tt.value)
+
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "errors"
+
+// Permission errors
+var (
+	ErrOperationNotPermitted = errors.New("operation type is not permitted for this account tier")
+)
+
+// OperationPermissions maps an account tier to the operation types it may perform
+// Tiers without an entry are allowed every operation type
+type OperationPermissions map[string][]int64
+
+// Allows reports whether accounts of the given tier may perform the operation type
+func (p OperationPermissions) Allows(tier string, operationTypeID int64) bool {
+	allowed, restricted := p[tier]
+	if !restricted {
+		return true
+	}
+
+	for _, id := range allowed {
+		if id == operationTypeID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationPermissions_Allows(t *testing.T) {
+	permissions := OperationPermissions{
+		"basic":  {OperationTypePurchase, OperationTypePurchaseWithInstallments, OperationTypeCreditVoucher},
+		"frozen": {},
+	}
+
+	tests := []struct {
+		name            string
+		tier            string
+		operationTypeID int64
+		want            bool
+	}{
+		{name: "basic tier may purchase", tier: "basic", operationTypeID: OperationTypePurchase, want: true},
+		{name: "basic tier may not withdraw", tier: "basic", operationTypeID: OperationTypeWithdrawal, want: false},
+		{name: "frozen tier may do nothing", tier: "frozen", operationTypeID: OperationTypeCreditVoucher, want: false},
+		{name: "default tier allows everything", tier: DefaultAccountTier, operationTypeID: OperationTypeWithdrawal, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, permissions.Allows(tt.tier, tt.operationTypeID))
+		})
+	}
+}
+
+func TestOperationPermissions_NilAllowsEverything(t *testing.T) {
+	var permissions OperationPermissions
+
+	assert.True(t, permissions.Allows("basic", OperationTypeWithdrawal))
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// ErrTransactionDeclined is the simulated decline triggered by a sandbox magic amount
+var ErrTransactionDeclined = errors.New("transaction declined")
+
+// SimulatedOutcomes names the outcomes a sandbox magic amount may trigger, each answered like the real error
+var SimulatedOutcomes = map[string]error{
+	"decline":            ErrTransactionDeclined,
+	"insufficient_funds": ErrInsufficientLimit,
+	"balance_changed":    ErrBalanceChanged,
+	"unavailable":        ErrServiceUnavailable,
+}
+
+// SimulatedOutcomeNames lists the names accepted in SimulatedOutcomes, sorted
+func SimulatedOutcomeNames() []string {
+	names := make([]string, 0, len(SimulatedOutcomes))
+	for name := range SimulatedOutcomes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MagicAmounts maps sandbox test amounts, in cents, to the error a transaction of that amount fails with
+// They let clients exercise failure paths without setting up real state; an empty map triggers nothing
+type MagicAmounts map[int64]error
+
+// Add makes transactions of amount (in either sign) fail with outcome
+func (m MagicAmounts) Add(amount float64, outcome error) {
+	m[amountCents(amount)] = outcome
+}
+
+// Outcome returns the simulated error for amount, or nil when it is not a magic amount
+func (m MagicAmounts) Outcome(amount float64) error {
+	if len(m) == 0 {
+		return nil
+	}
+	return m[amountCents(amount)]
+}
+
+// amountCents returns the absolute amount in cents, so 13.37 and -13.37 are the same magic amount
+func amountCents(amount float64) int64 {
+	return int64(math.Round(math.Abs(amount) * 100))
+}
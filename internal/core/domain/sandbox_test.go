@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMagicAmounts_Outcome(t *testing.T) {
+	amounts := MagicAmounts{}
+	amounts.Add(13.37, ErrTransactionDeclined)
+
+	assert.ErrorIs(t, amounts.Outcome(13.37), ErrTransactionDeclined)
+	assert.ErrorIs(t, amounts.Outcome(-13.37), ErrTransactionDeclined, "the sign does not matter")
+	assert.NoError(t, amounts.Outcome(13.36))
+	assert.NoError(t, amounts.Outcome(1337))
+
+	var none MagicAmounts
+	assert.NoError(t, none.Outcome(13.37))
+}
+
+func TestSimulatedOutcomeNames(t *testing.T) {
+	assert.Equal(t, []string{"balance_changed", "decline", "insufficient_funds", "unavailable"}, SimulatedOutcomeNames())
+}
@@ -2,48 +2,198 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // Transaction represents a financial transaction
+// IdempotencyKey, ReversalOf and InstallmentOf are only written on creation; they are not loaded by the read queries
+// Balance is what remains outstanding of the amount (see DischargeDebts); it is only loaded with outstanding debts
+// and by the lookups of a single transaction
 type Transaction struct {
 	ID              int64     `json:"transaction_id"`
 	AccountID       int64     `json:"account_id"`
 	OperationTypeID int64     `json:"operation_type_id"`
 	Amount          float64   `json:"amount"`
 	EventDate       time.Time `json:"event_date"`
+	IdempotencyKey  string    `json:"-"`
+	ReversalOf      int64     `json:"-"`
+	InstallmentOf   int64     `json:"-"`
+	Balance         float64   `json:"-"`
 }
 
 // CreateTransactionRequest represents the input for creating a transaction
+// EventDate is optional and allows backfilling; it defaults to the current time
+// ExpectedBalance is optional; when set the transaction is applied only if the account balance still matches it
+// Installments splits a purchase with installments into interest-free parts (defaults to 1)
+// Force skips the duplicate check for a transaction identical to a recent one
+// IdempotencyKey is taken from the Idempotency-Key header and stored with the transaction
 type CreateTransactionRequest struct {
-	AccountID       int64   `json:"account_id"`
-	OperationTypeID int64   `json:"operation_type_id"`
-	Amount          float64 `json:"amount"`
+	AccountID       int64      `json:"account_id" validate:"gt=0"`
+	OperationTypeID int64      `json:"operation_type_id" validate:"gt=0"`
+	Amount          float64    `json:"amount"`
+	EventDate       *time.Time `json:"event_date,omitempty"`
+	ExpectedBalance *float64   `json:"expected_balance,omitempty"`
+	Installments    *int64     `json:"installments,omitempty" validate:"gte=1"`
+	Force           bool       `json:"force,omitempty"`
+	IdempotencyKey  string     `json:"-"`
 }
 
 // CreateTransactionResponse represents the output after creating a transaction
+// A purchase split into installments is described by its first installment with the total amount;
+// TransactionIDs then lists every installment, in order
 type CreateTransactionResponse struct {
 	TransactionID   int64     `json:"transaction_id"`
 	AccountID       int64     `json:"account_id"`
 	OperationTypeID int64     `json:"operation_type_id"`
 	Amount          float64   `json:"amount"`
 	EventDate       time.Time `json:"event_date"`
+	TransactionIDs  []int64   `json:"transaction_ids,omitempty"`
 }
 
+// ReverseTransactionRequest identifies the transaction to reverse by the Idempotency-Key it was created with
+type ReverseTransactionRequest struct {
+	AccountID      int64  `json:"account_id" validate:"gt=0"`
+	IdempotencyKey string `json:"idempotency_key" validate:"required"`
+}
+
+// ReverseTransactionResponse is the reversal created for a transaction
+type ReverseTransactionResponse struct {
+	CreateTransactionResponse
+	ReversedTransactionID int64 `json:"reversed_transaction_id"`
+}
+
+// Reversal errors
+var (
+	ErrIdempotencyKeyNotFound     = errors.New("transaction not found for idempotency key")
+	ErrTransactionNotFound        = errors.New("transaction not found")
+	ErrTransactionAlreadyReversed = errors.New("transaction already reversed")
+	ErrReversalNotReversible      = errors.New("a reversal cannot be reversed")
+)
+
+// ErrInvalidDateRange is returned when a transaction listing's to bound is before its from bound
+var ErrInvalidDateRange = errors.New("to must not be before from")
+
 // GetTransactionsRequest represents the request to get transactions with pagination
+// When StrictPagination is set, an offset past the last result is an error instead of an empty page
+// From and To optionally restrict the event dates to an inclusive range; either side may be left open
+// A non-zero OperationTypeID keeps only transactions of that type
+// Order sorts by event date (newest first when empty); Cursor continues after a previous page instead of Offset
 type GetTransactionsRequest struct {
-	AccountID int64 `json:"account_id"`
-	Limit     int64 `json:"limit"`
-	Offset    int64 `json:"offset"`
+	AccountID        int64              `json:"account_id"`
+	Limit            int64              `json:"limit"`
+	Offset           int64              `json:"offset"`
+	StrictPagination bool               `json:"strict_pagination"`
+	From             *time.Time         `json:"from,omitempty"`
+	To               *time.Time         `json:"to,omitempty"`
+	OperationTypeID  int64              `json:"operation_type_id,omitempty"`
+	Order            SortOrder          `json:"order,omitempty"`
+	Cursor           *TransactionCursor `json:"-"`
+}
+
+// TransactionFilter narrows and orders an account's transaction listing
+// Its zero value matches every transaction, newest first; After seeks past a cursor in Order
+type TransactionFilter struct {
+	From            *time.Time
+	To              *time.Time
+	OperationTypeID int64
+	Order           SortOrder
+	After           *TransactionCursor
+}
+
+// Filter returns the listing filter the request asks for
+func (r GetTransactionsRequest) Filter() TransactionFilter {
+	return TransactionFilter{From: r.From, To: r.To, OperationTypeID: r.OperationTypeID, Order: r.Order, After: r.Cursor}
+}
+
+// IsZero reports whether the filter leaves the listing unrestricted in the default order
+func (f TransactionFilter) IsZero() bool {
+	return f.From == nil && f.To == nil && f.OperationTypeID == 0 && f.Order != SortAscending && f.After == nil
 }
 
 // GetTransactionsResponse represents the response with transactions and pagination info
+// NextCursor continues the listing after this page and is empty on the last page
 type GetTransactionsResponse struct {
+	Transactions []*AccountTransaction `json:"transactions"`
+	Pagination   PaginationMetadata    `json:"pagination"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
+}
+
+// AccountTransaction is a transaction of an account listing enriched with its operation type's description,
+// so clients do not need a second lookup
+type AccountTransaction struct {
+	Transaction
+	OperationTypeDescription string `json:"operation_type_description"`
+}
+
+// ListTransactionsRequest represents a page of the system-wide transaction listing
+type ListTransactionsRequest struct {
+	Limit  int64 `json:"limit"`
+	Offset int64 `json:"offset"`
+}
+
+// ListTransactionsResponse represents a page of the system-wide transaction listing
+type ListTransactionsResponse struct {
 	Transactions []*Transaction     `json:"transactions"`
 	Pagination   PaginationMetadata `json:"pagination"`
 }
 
+// ExportTransactionsRequest represents the request to stream all transactions of an account
+type ExportTransactionsRequest struct {
+	AccountID int64 `json:"account_id"`
+}
+
+// GetStatementRequest represents the request for an account statement over [Start, End]
+type GetStatementRequest struct {
+	AccountID int64     `json:"account_id"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+}
+
+// StatementResponse lists the transactions of a period between its opening and closing balances
+// OpeningBalance sums every transaction before Start; ClosingBalance sums every transaction through End
+type StatementResponse struct {
+	AccountID      int64          `json:"account_id"`
+	Start          time.Time      `json:"start"`
+	End            time.Time      `json:"end"`
+	OpeningBalance float64        `json:"opening_balance"`
+	ClosingBalance float64        `json:"closing_balance"`
+	Transactions   []*Transaction `json:"transactions"`
+}
+
+// Statement errors
+var (
+	ErrInvalidStatementPeriod = errors.New("end must not be before start")
+)
+
+// RoundToCents rounds an amount to two decimal places, never returning negative zero
+func RoundToCents(amount float64) float64 {
+	rounded := math.Round(amount*100) / 100
+	if rounded == 0 {
+		return 0
+	}
+	return rounded
+}
+
+// RecentTransaction is a transaction enriched with its account's document number
+type RecentTransaction struct {
+	Transaction
+	DocumentNumber string `json:"document_number"`
+}
+
+// GetRecentTransactionsRequest represents the request for the system-wide recent transactions feed
+type GetRecentTransactionsRequest struct {
+	Limit int64 `json:"limit"`
+}
+
+// GetRecentTransactionsResponse represents the most recent transactions across all accounts
+type GetRecentTransactionsResponse struct {
+	Transactions []*RecentTransaction `json:"transactions"`
+}
+
 // PaginationMetadata contains pagination information
 type PaginationMetadata struct {
 	Total  int64 `json:"total"`
@@ -52,32 +202,94 @@ type PaginationMetadata struct {
 	Pages  int64 `json:"pages"`
 }
 
+// Pagination errors
+var (
+	ErrOffsetExceedsTotal = errors.New("offset exceeds total results")
+)
+
+// Amount bounds shared by the HTTP layer and Transaction.Validate
+const (
+	MaxTransactionAmount = 1_000_000_000.0
+	AmountDecimalPlaces  = 2
+)
+
 // Validation errors
 var (
-	ErrInvalidOperationType = errors.New("operation_type_id must be between 1 and 4")
+	ErrInvalidOperationType = errors.New("operation_type_id is not a known operation type")
 	ErrZeroAmount           = errors.New("amount cannot be zero")
+	ErrAmountTooLarge       = fmt.Errorf("amount must not exceed %.0f in absolute value", MaxTransactionAmount)
+	ErrAmountTooPrecise     = fmt.Errorf("amount must not have more than %d decimal places", AmountDecimalPlaces)
+)
+
+// ValidateAmountMagnitude rejects amounts beyond MaxTransactionAmount (and non-finite values)
+func ValidateAmountMagnitude(amount float64) error {
+	if math.IsNaN(amount) || math.Abs(amount) > MaxTransactionAmount {
+		return ErrAmountTooLarge
+	}
+	return nil
+}
+
+// ValidateAmountPrecision rejects amounts with more than AmountDecimalPlaces decimals
+// The shortest decimal representation is inspected, so 0.1+0.2 style noise never reaches it from JSON input
+func ValidateAmountPrecision(amount float64) error {
+	text := strconv.FormatFloat(amount, 'f', -1, 64)
+	if dot := strings.IndexByte(text, '.'); dot >= 0 && len(text)-dot-1 > AmountDecimalPlaces {
+		return ErrAmountTooPrecise
+	}
+	return nil
+}
+
+// Ledger rule errors
+var (
+	ErrEventDateBeforeAccountCreation = errors.New("event_date cannot be earlier than the account creation date")
+	ErrBalanceChanged                 = errors.New("balance changed")
+	ErrPossibleDuplicateTransaction   = errors.New("possible duplicate transaction")
+	ErrInsufficientLimit              = errors.New("transaction exceeds the account's available credit limit")
 )
 
+// DuplicateTransactionError reports a transaction identical to one created moments before
+// It matches ErrPossibleDuplicateTransaction with errors.Is
+type DuplicateTransactionError struct {
+	ExistingTransactionID int64
+}
+
+func (e *DuplicateTransactionError) Error() string {
+	return ErrPossibleDuplicateTransaction.Error()
+}
+
+func (e *DuplicateTransactionError) Is(target error) bool {
+	return target == ErrPossibleDuplicateTransaction
+}
+
 // Validate checks if the transaction data is valid
 func (t *Transaction) Validate() error {
 	if t.AccountID <= 0 {
 		return errors.New("account_id must be greater than 0")
 	}
 
-	if t.OperationTypeID < 1 || t.OperationTypeID > 4 {
-		return errors.New("operation_type_id must be between 1 and 4")
+	// Whether the type exists is checked against the operation types repository
+	if t.OperationTypeID <= 0 {
+		return errors.New("operation_type_id must be greater than 0")
 	}
 
+	// Negative zero compares equal to zero, so -0 is rejected here as well
 	if t.Amount == 0 {
 		return errors.New("amount cannot be zero")
 	}
 
+	if err := ValidateAmountMagnitude(t.Amount); err != nil {
+		return err
+	}
+
+	if err := ValidateAmountPrecision(t.Amount); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // NormalizeAmount adjusts the amount sign based on the operation type
-// Debit operations (Purchase, Withdrawal) should be negative
-// Credit operations (Credit Voucher) should be positive
+// Credit operations (Credit Voucher) are positive and every other type is a debit, so negative
 func (t *Transaction) NormalizeAmount(operationType *OperationType) error {
 	if operationType == nil {
 		return errors.New("operation type cannot be nil")
@@ -85,10 +297,16 @@ func (t *Transaction) NormalizeAmount(operationType *OperationType) error {
 
 	absAmount := math.Abs(t.Amount)
 
-	if operationType.IsDebitOperation() {
-		t.Amount = -absAmount
-	} else if operationType.IsCreditOperation() {
+	// Never produce negative zero, which serializes as "-0" in JSON
+	if absAmount == 0 {
+		t.Amount = 0
+		return nil
+	}
+
+	if operationType.IsCredit {
 		t.Amount = absAmount
+	} else {
+		t.Amount = -absAmount
 	}
 
 	return nil
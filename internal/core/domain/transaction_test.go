@@ -0,0 +1,222 @@
+package domain
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransaction_Validate_ZeroAmounts(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  float64
+		wantErr bool
+	}{
+		{name: "zero", amount: 0, wantErr: true},
+		{name: "zero with decimals", amount: 0.0, wantErr: true},
+		{name: "negative zero", amount: math.Copysign(0, -1), wantErr: true},
+		{name: "smallest positive amount", amount: 0.01, wantErr: false},
+		{name: "smallest negative amount", amount: -0.01, wantErr: false},
+		{name: "regular amount", amount: 50.0, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := &Transaction{
+				AccountID:       1,
+				OperationTypeID: OperationTypePurchase,
+				Amount:          tt.amount,
+			}
+
+			err := transaction.Validate()
+
+			if tt.wantErr {
+				assert.EqualError(t, err, "amount cannot be zero")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTransaction_Validate_OperationType(t *testing.T) {
+	tests := []struct {
+		name            string
+		operationTypeID int64
+		wantErr         bool
+	}{
+		{name: "seeded type", operationTypeID: OperationTypeCreditVoucher},
+		{name: "type added after the seeded ones", operationTypeID: 5},
+		{name: "zero", operationTypeID: 0, wantErr: true},
+		{name: "negative", operationTypeID: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := &Transaction{AccountID: 1, OperationTypeID: tt.operationTypeID, Amount: 10}
+
+			err := transaction.Validate()
+
+			if tt.wantErr {
+				assert.EqualError(t, err, "operation_type_id must be greater than 0")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTransaction_NormalizeAmount_NeverProducesNegativeZero(t *testing.T) {
+	purchase := &OperationType{ID: OperationTypePurchase}
+	creditVoucher := &OperationType{ID: OperationTypeCreditVoucher, IsCredit: true}
+
+	tests := []struct {
+		name          string
+		amount        float64
+		operationType *OperationType
+		wantAmount    float64
+		wantJSON      string
+	}{
+		{
+			name:          "negative zero on debit",
+			amount:        math.Copysign(0, -1),
+			operationType: purchase,
+			wantAmount:    0,
+			wantJSON:      `"amount":0`,
+		},
+		{
+			name:          "zero on debit",
+			amount:        0,
+			operationType: purchase,
+			wantAmount:    0,
+			wantJSON:      `"amount":0`,
+		},
+		{
+			name:          "negative zero on credit",
+			amount:        math.Copysign(0, -1),
+			operationType: creditVoucher,
+			wantAmount:    0,
+			wantJSON:      `"amount":0`,
+		},
+		{
+			name:          "tiny epsilon on debit keeps its sign",
+			amount:        1e-9,
+			operationType: purchase,
+			wantAmount:    -1e-9,
+			wantJSON:      `"amount":-1e-9`,
+		},
+		{
+			name:          "tiny negative epsilon on credit becomes positive",
+			amount:        -1e-9,
+			operationType: creditVoucher,
+			wantAmount:    1e-9,
+			wantJSON:      `"amount":1e-9`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := &Transaction{Amount: tt.amount}
+
+			require.NoError(t, transaction.NormalizeAmount(tt.operationType))
+
+			assert.Equal(t, tt.wantAmount, transaction.Amount)
+			assert.False(t, math.Signbit(transaction.Amount) && transaction.Amount == 0, "Amount must not be negative zero")
+
+			body, err := json.Marshal(transaction)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), tt.wantJSON)
+			assert.NotContains(t, string(body), `"amount":-0`)
+		})
+	}
+}
+
+func TestValidateAmountMagnitude(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  float64
+		wantErr bool
+	}{
+		{name: "regular amount", amount: 50.0},
+		{name: "at the maximum", amount: MaxTransactionAmount},
+		{name: "negative at the maximum", amount: -MaxTransactionAmount},
+		{name: "above the maximum", amount: MaxTransactionAmount + 0.01, wantErr: true},
+		{name: "giant float", amount: 1e300, wantErr: true},
+		{name: "infinity", amount: math.Inf(1), wantErr: true},
+		{name: "NaN", amount: math.NaN(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAmountMagnitude(tt.amount)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrAmountTooLarge)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateAmountPrecision(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  float64
+		wantErr bool
+	}{
+		{name: "integer", amount: 50},
+		{name: "two decimals", amount: 10.55},
+		{name: "two decimals near the maximum", amount: 999999999.99},
+		{name: "three decimals", amount: 10.555, wantErr: true},
+		{name: "tiny fraction", amount: 1e-9, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAmountPrecision(tt.amount)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrAmountTooPrecise)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTransaction_Validate_Precision(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  float64
+		wantErr bool
+	}{
+		{name: "two decimals", amount: 10.55},
+		{name: "negative two decimals", amount: -10.55},
+		{name: "three decimals", amount: 10.555, wantErr: true},
+		{name: "tiny epsilon", amount: 1e-9, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := &Transaction{AccountID: 1, OperationTypeID: OperationTypePurchase, Amount: tt.amount}
+
+			err := transaction.Validate()
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrAmountTooPrecise)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTransaction_Validate_RejectsAmountAboveMaximum(t *testing.T) {
+	transaction := &Transaction{AccountID: 1, OperationTypeID: OperationTypePurchase, Amount: -2e9}
+
+	assert.ErrorIs(t, transaction.Validate(), ErrAmountTooLarge)
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+)
+
+// AccountBalanceRepository maintains the account balances cache derived from the transaction log
+type AccountBalanceRepository interface {
+	// RecomputeBatch rebuilds the cached balance of up to limit accounts with ID greater than afterAccountID, in ID order
+	// It returns the last account ID processed and how many accounts were updated (0 once every account is done)
+	RecomputeBatch(ctx context.Context, afterAccountID int64, limit int64) (lastAccountID int64, processed int64, err error)
+}
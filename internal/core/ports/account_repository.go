@@ -8,9 +8,21 @@ import (
 
 // AccountRepository defines the interface for account data operations
 // This is a port in hexagonal architecture - it defines WHAT we need without HOW
+// Soft-deleted accounts are left out of every lookup, listing and count
 type AccountRepository interface {
 	Create(ctx context.Context, account *domain.Account) (*domain.Account, error)
 	FindByID(ctx context.Context, id int64) (*domain.Account, error)
+	// Exists reports whether an account exists without loading it
+	Exists(ctx context.Context, id int64) (bool, error)
+	// IsClosed reports whether the account was soft-deleted; accounts that never existed are not closed
+	IsClosed(ctx context.Context, id int64) (bool, error)
 	FindByDocumentNumber(ctx context.Context, documentNumber string) (*domain.Account, error)
+	// SoftDelete marks the account deleted, keeping its row and transactions; it reports false when
+	// there was no account left to delete
+	SoftDelete(ctx context.Context, id int64) (bool, error)
 	GetAll(ctx context.Context) ([]*domain.Account, error)
+	// Count returns the number of accounts
+	Count(ctx context.Context) (int64, error)
+	// GetAllPaginated returns one page of accounts, newest first, and the total number of accounts
+	GetAllPaginated(ctx context.Context, limit int64, offset int64) ([]*domain.Account, int64, error)
 }
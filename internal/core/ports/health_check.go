@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+)
+
+// HealthChecker defines the interface for one readiness check of a dependency
+type HealthChecker interface {
+	// Check probes the dependency; failures are reported in the returned status, never as an error
+	Check(ctx context.Context) domain.HealthCheck
+}
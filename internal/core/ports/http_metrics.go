@@ -0,0 +1,9 @@
+package ports
+
+import "time"
+
+// HTTPMetrics defines the interface for recording served HTTP requests
+type HTTPMetrics interface {
+	// RequestServed records a request by method, route pattern (never the raw path) and status, with its duration
+	RequestServed(method, route string, status int, duration time.Duration)
+}
@@ -0,0 +1,9 @@
+package ports
+
+// IdempotencyMetrics defines the interface for recording how often idempotency keys deduplicate requests
+type IdempotencyMetrics interface {
+	// Hit records a request answered with the stored response of an earlier request with the same key
+	Hit()
+	// Miss records a request with an idempotency key that had to be processed
+	Miss()
+}
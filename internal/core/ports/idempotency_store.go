@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+)
+
+// IdempotencyStore defines the interface for persisting the responses of requests made with an Idempotency-Key,
+// so they are deduplicated across restarts and replicas
+type IdempotencyStore interface {
+	// Get returns the record of the key, or nil when there is none
+	Get(ctx context.Context, key string) (*domain.IdempotencyRecord, error)
+	// GetMetadata is Get without the stored response body, for inspecting a key without exposing its data
+	GetMetadata(ctx context.Context, key string) (*domain.IdempotencyRecord, error)
+	// SetProcessing atomically claims the key for a new request, reporting false when it already has a record
+	SetProcessing(ctx context.Context, key string) (bool, error)
+	// SetResult completes record.Key with its RequestHash, ResponseStatus, ResponseBody and ExpiresAt
+	// A nil ExpiresAt keeps the response forever
+	SetResult(ctx context.Context, record *domain.IdempotencyRecord) error
+	// Delete releases the key so the next request with it is processed again
+	Delete(ctx context.Context, key string) error
+	// DeleteExpired removes the responses that expired at or before the given time, and the keys left
+	// processing since domain.IdempotencyStaleProcessingAfter before it, returning how many
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,101 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAccountBalanceRepository is an autogenerated mock type for the AccountBalanceRepository type
+type MockAccountBalanceRepository struct {
+	mock.Mock
+}
+
+type MockAccountBalanceRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAccountBalanceRepository) EXPECT() *MockAccountBalanceRepository_Expecter {
+	return &MockAccountBalanceRepository_Expecter{mock: &_m.Mock}
+}
+
+// RecomputeBatch provides a mock function with given fields: ctx, afterAccountID, limit
+func (_m *MockAccountBalanceRepository) RecomputeBatch(ctx context.Context, afterAccountID int64, limit int64) (int64, int64, error) {
+	ret := _m.Called(ctx, afterAccountID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecomputeBatch")
+	}
+
+	var r0 int64
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (int64, int64, error)); ok {
+		return rf(ctx, afterAccountID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) int64); ok {
+		r0 = rf(ctx, afterAccountID, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) int64); ok {
+		r1 = rf(ctx, afterAccountID, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64, int64) error); ok {
+		r2 = rf(ctx, afterAccountID, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockAccountBalanceRepository_RecomputeBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecomputeBatch'
+type MockAccountBalanceRepository_RecomputeBatch_Call struct {
+	*mock.Call
+}
+
+// RecomputeBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterAccountID int64
+//   - limit int64
+func (_e *MockAccountBalanceRepository_Expecter) RecomputeBatch(ctx interface{}, afterAccountID interface{}, limit interface{}) *MockAccountBalanceRepository_RecomputeBatch_Call {
+	return &MockAccountBalanceRepository_RecomputeBatch_Call{Call: _e.mock.On("RecomputeBatch", ctx, afterAccountID, limit)}
+}
+
+func (_c *MockAccountBalanceRepository_RecomputeBatch_Call) Run(run func(ctx context.Context, afterAccountID int64, limit int64)) *MockAccountBalanceRepository_RecomputeBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAccountBalanceRepository_RecomputeBatch_Call) Return(lastAccountID int64, processed int64, err error) *MockAccountBalanceRepository_RecomputeBatch_Call {
+	_c.Call.Return(lastAccountID, processed, err)
+	return _c
+}
+
+func (_c *MockAccountBalanceRepository_RecomputeBatch_Call) RunAndReturn(run func(context.Context, int64, int64) (int64, int64, error)) *MockAccountBalanceRepository_RecomputeBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAccountBalanceRepository creates a new instance of MockAccountBalanceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAccountBalanceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAccountBalanceRepository {
+	mock := &MockAccountBalanceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -22,6 +22,62 @@ func (_m *MockAccountRepository) EXPECT() *MockAccountRepository_Expecter {
 	return &MockAccountRepository_Expecter{mock: &_m.Mock}
 }
 
+// Count provides a mock function with given fields: ctx
+func (_m *MockAccountRepository) Count(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type MockAccountRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAccountRepository_Expecter) Count(ctx interface{}) *MockAccountRepository_Count_Call {
+	return &MockAccountRepository_Count_Call{Call: _e.mock.On("Count", ctx)}
+}
+
+func (_c *MockAccountRepository_Count_Call) Run(run func(ctx context.Context)) *MockAccountRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_Count_Call) Return(_a0 int64, _a1 error) *MockAccountRepository_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountRepository_Count_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockAccountRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, account
 func (_m *MockAccountRepository) Create(ctx context.Context, account *domain.Account) (*domain.Account, error) {
 	ret := _m.Called(ctx, account)
@@ -81,6 +137,63 @@ func (_c *MockAccountRepository_Create_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// Exists provides a mock function with given fields: ctx, id
+func (_m *MockAccountRepository) Exists(ctx context.Context, id int64) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountRepository_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type MockAccountRepository_Exists_Call struct {
+	*mock.Call
+}
+
+// Exists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockAccountRepository_Expecter) Exists(ctx interface{}, id interface{}) *MockAccountRepository_Exists_Call {
+	return &MockAccountRepository_Exists_Call{Call: _e.mock.On("Exists", ctx, id)}
+}
+
+func (_c *MockAccountRepository_Exists_Call) Run(run func(ctx context.Context, id int64)) *MockAccountRepository_Exists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_Exists_Call) Return(_a0 bool, _a1 error) *MockAccountRepository_Exists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountRepository_Exists_Call) RunAndReturn(run func(context.Context, int64) (bool, error)) *MockAccountRepository_Exists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindByDocumentNumber provides a mock function with given fields: ctx, documentNumber
 func (_m *MockAccountRepository) FindByDocumentNumber(ctx context.Context, documentNumber string) (*domain.Account, error) {
 	ret := _m.Called(ctx, documentNumber)
@@ -257,6 +370,187 @@ func (_c *MockAccountRepository_GetAll_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// GetAllPaginated provides a mock function with given fields: ctx, limit, offset
+func (_m *MockAccountRepository) GetAllPaginated(ctx context.Context, limit int64, offset int64) ([]*domain.Account, int64, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllPaginated")
+	}
+
+	var r0 []*domain.Account
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) ([]*domain.Account, int64, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []*domain.Account); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) int64); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64, int64) error); ok {
+		r2 = rf(ctx, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockAccountRepository_GetAllPaginated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllPaginated'
+type MockAccountRepository_GetAllPaginated_Call struct {
+	*mock.Call
+}
+
+// GetAllPaginated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int64
+//   - offset int64
+func (_e *MockAccountRepository_Expecter) GetAllPaginated(ctx interface{}, limit interface{}, offset interface{}) *MockAccountRepository_GetAllPaginated_Call {
+	return &MockAccountRepository_GetAllPaginated_Call{Call: _e.mock.On("GetAllPaginated", ctx, limit, offset)}
+}
+
+func (_c *MockAccountRepository_GetAllPaginated_Call) Run(run func(ctx context.Context, limit int64, offset int64)) *MockAccountRepository_GetAllPaginated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_GetAllPaginated_Call) Return(_a0 []*domain.Account, _a1 int64, _a2 error) *MockAccountRepository_GetAllPaginated_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockAccountRepository_GetAllPaginated_Call) RunAndReturn(run func(context.Context, int64, int64) ([]*domain.Account, int64, error)) *MockAccountRepository_GetAllPaginated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsClosed provides a mock function with given fields: ctx, id
+func (_m *MockAccountRepository) IsClosed(ctx context.Context, id int64) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsClosed")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountRepository_IsClosed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsClosed'
+type MockAccountRepository_IsClosed_Call struct {
+	*mock.Call
+}
+
+// IsClosed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockAccountRepository_Expecter) IsClosed(ctx interface{}, id interface{}) *MockAccountRepository_IsClosed_Call {
+	return &MockAccountRepository_IsClosed_Call{Call: _e.mock.On("IsClosed", ctx, id)}
+}
+
+func (_c *MockAccountRepository_IsClosed_Call) Run(run func(ctx context.Context, id int64)) *MockAccountRepository_IsClosed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_IsClosed_Call) Return(_a0 bool, _a1 error) *MockAccountRepository_IsClosed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountRepository_IsClosed_Call) RunAndReturn(run func(context.Context, int64) (bool, error)) *MockAccountRepository_IsClosed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SoftDelete provides a mock function with given fields: ctx, id
+func (_m *MockAccountRepository) SoftDelete(ctx context.Context, id int64) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SoftDelete")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountRepository_SoftDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SoftDelete'
+type MockAccountRepository_SoftDelete_Call struct {
+	*mock.Call
+}
+
+// SoftDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockAccountRepository_Expecter) SoftDelete(ctx interface{}, id interface{}) *MockAccountRepository_SoftDelete_Call {
+	return &MockAccountRepository_SoftDelete_Call{Call: _e.mock.On("SoftDelete", ctx, id)}
+}
+
+func (_c *MockAccountRepository_SoftDelete_Call) Run(run func(ctx context.Context, id int64)) *MockAccountRepository_SoftDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_SoftDelete_Call) Return(_a0 bool, _a1 error) *MockAccountRepository_SoftDelete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountRepository_SoftDelete_Call) RunAndReturn(run func(context.Context, int64) (bool, error)) *MockAccountRepository_SoftDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockAccountRepository creates a new instance of MockAccountRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockAccountRepository(t interface {
@@ -0,0 +1,72 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockHTTPMetrics is an autogenerated mock type for the HTTPMetrics type
+type MockHTTPMetrics struct {
+	mock.Mock
+}
+
+type MockHTTPMetrics_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHTTPMetrics) EXPECT() *MockHTTPMetrics_Expecter {
+	return &MockHTTPMetrics_Expecter{mock: &_m.Mock}
+}
+
+// RequestServed provides a mock function with given fields: method, route, status, duration
+func (_m *MockHTTPMetrics) RequestServed(method string, route string, status int, duration time.Duration) {
+	_m.Called(method, route, status, duration)
+}
+
+// MockHTTPMetrics_RequestServed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestServed'
+type MockHTTPMetrics_RequestServed_Call struct {
+	*mock.Call
+}
+
+// RequestServed is a helper method to define mock.On call
+//   - method string
+//   - route string
+//   - status int
+//   - duration time.Duration
+func (_e *MockHTTPMetrics_Expecter) RequestServed(method interface{}, route interface{}, status interface{}, duration interface{}) *MockHTTPMetrics_RequestServed_Call {
+	return &MockHTTPMetrics_RequestServed_Call{Call: _e.mock.On("RequestServed", method, route, status, duration)}
+}
+
+func (_c *MockHTTPMetrics_RequestServed_Call) Run(run func(method string, route string, status int, duration time.Duration)) *MockHTTPMetrics_RequestServed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(int), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockHTTPMetrics_RequestServed_Call) Return() *MockHTTPMetrics_RequestServed_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockHTTPMetrics_RequestServed_Call) RunAndReturn(run func(string, string, int, time.Duration)) *MockHTTPMetrics_RequestServed_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockHTTPMetrics creates a new instance of MockHTTPMetrics. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHTTPMetrics(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHTTPMetrics {
+	mock := &MockHTTPMetrics{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
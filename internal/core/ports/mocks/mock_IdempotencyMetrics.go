@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockIdempotencyMetrics is an autogenerated mock type for the IdempotencyMetrics type
+type MockIdempotencyMetrics struct {
+	mock.Mock
+}
+
+type MockIdempotencyMetrics_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIdempotencyMetrics) EXPECT() *MockIdempotencyMetrics_Expecter {
+	return &MockIdempotencyMetrics_Expecter{mock: &_m.Mock}
+}
+
+// Hit provides a mock function with no fields
+func (_m *MockIdempotencyMetrics) Hit() {
+	_m.Called()
+}
+
+// MockIdempotencyMetrics_Hit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Hit'
+type MockIdempotencyMetrics_Hit_Call struct {
+	*mock.Call
+}
+
+// Hit is a helper method to define mock.On call
+func (_e *MockIdempotencyMetrics_Expecter) Hit() *MockIdempotencyMetrics_Hit_Call {
+	return &MockIdempotencyMetrics_Hit_Call{Call: _e.mock.On("Hit")}
+}
+
+func (_c *MockIdempotencyMetrics_Hit_Call) Run(run func()) *MockIdempotencyMetrics_Hit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyMetrics_Hit_Call) Return() *MockIdempotencyMetrics_Hit_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockIdempotencyMetrics_Hit_Call) RunAndReturn(run func()) *MockIdempotencyMetrics_Hit_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Miss provides a mock function with no fields
+func (_m *MockIdempotencyMetrics) Miss() {
+	_m.Called()
+}
+
+// MockIdempotencyMetrics_Miss_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Miss'
+type MockIdempotencyMetrics_Miss_Call struct {
+	*mock.Call
+}
+
+// Miss is a helper method to define mock.On call
+func (_e *MockIdempotencyMetrics_Expecter) Miss() *MockIdempotencyMetrics_Miss_Call {
+	return &MockIdempotencyMetrics_Miss_Call{Call: _e.mock.On("Miss")}
+}
+
+func (_c *MockIdempotencyMetrics_Miss_Call) Run(run func()) *MockIdempotencyMetrics_Miss_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyMetrics_Miss_Call) Return() *MockIdempotencyMetrics_Miss_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockIdempotencyMetrics_Miss_Call) RunAndReturn(run func()) *MockIdempotencyMetrics_Miss_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockIdempotencyMetrics creates a new instance of MockIdempotencyMetrics. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIdempotencyMetrics(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIdempotencyMetrics {
+	mock := &MockIdempotencyMetrics{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
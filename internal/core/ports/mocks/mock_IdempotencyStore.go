@@ -0,0 +1,365 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockIdempotencyStore is an autogenerated mock type for the IdempotencyStore type
+type MockIdempotencyStore struct {
+	mock.Mock
+}
+
+type MockIdempotencyStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIdempotencyStore) EXPECT() *MockIdempotencyStore_Expecter {
+	return &MockIdempotencyStore_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, key
+func (_m *MockIdempotencyStore) Delete(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIdempotencyStore_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockIdempotencyStore_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockIdempotencyStore_Expecter) Delete(ctx interface{}, key interface{}) *MockIdempotencyStore_Delete_Call {
+	return &MockIdempotencyStore_Delete_Call{Call: _e.mock.On("Delete", ctx, key)}
+}
+
+func (_c *MockIdempotencyStore_Delete_Call) Run(run func(ctx context.Context, key string)) *MockIdempotencyStore_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Delete_Call) Return(_a0 error) *MockIdempotencyStore_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Delete_Call) RunAndReturn(run func(context.Context, string) error) *MockIdempotencyStore_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpired provides a mock function with given fields: ctx, before
+func (_m *MockIdempotencyStore) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpired")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyStore_DeleteExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpired'
+type MockIdempotencyStore_DeleteExpired_Call struct {
+	*mock.Call
+}
+
+// DeleteExpired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockIdempotencyStore_Expecter) DeleteExpired(ctx interface{}, before interface{}) *MockIdempotencyStore_DeleteExpired_Call {
+	return &MockIdempotencyStore_DeleteExpired_Call{Call: _e.mock.On("DeleteExpired", ctx, before)}
+}
+
+func (_c *MockIdempotencyStore_DeleteExpired_Call) Run(run func(ctx context.Context, before time.Time)) *MockIdempotencyStore_DeleteExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_DeleteExpired_Call) Return(_a0 int64, _a1 error) *MockIdempotencyStore_DeleteExpired_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_DeleteExpired_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockIdempotencyStore_DeleteExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, key
+func (_m *MockIdempotencyStore) Get(ctx context.Context, key string) (*domain.IdempotencyRecord, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.IdempotencyRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.IdempotencyRecord, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.IdempotencyRecord); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IdempotencyRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyStore_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockIdempotencyStore_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockIdempotencyStore_Expecter) Get(ctx interface{}, key interface{}) *MockIdempotencyStore_Get_Call {
+	return &MockIdempotencyStore_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+}
+
+func (_c *MockIdempotencyStore_Get_Call) Run(run func(ctx context.Context, key string)) *MockIdempotencyStore_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Get_Call) Return(_a0 *domain.IdempotencyRecord, _a1 error) *MockIdempotencyStore_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Get_Call) RunAndReturn(run func(context.Context, string) (*domain.IdempotencyRecord, error)) *MockIdempotencyStore_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMetadata provides a mock function with given fields: ctx, key
+func (_m *MockIdempotencyStore) GetMetadata(ctx context.Context, key string) (*domain.IdempotencyRecord, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMetadata")
+	}
+
+	var r0 *domain.IdempotencyRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.IdempotencyRecord, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.IdempotencyRecord); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IdempotencyRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyStore_GetMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMetadata'
+type MockIdempotencyStore_GetMetadata_Call struct {
+	*mock.Call
+}
+
+// GetMetadata is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockIdempotencyStore_Expecter) GetMetadata(ctx interface{}, key interface{}) *MockIdempotencyStore_GetMetadata_Call {
+	return &MockIdempotencyStore_GetMetadata_Call{Call: _e.mock.On("GetMetadata", ctx, key)}
+}
+
+func (_c *MockIdempotencyStore_GetMetadata_Call) Run(run func(ctx context.Context, key string)) *MockIdempotencyStore_GetMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_GetMetadata_Call) Return(_a0 *domain.IdempotencyRecord, _a1 error) *MockIdempotencyStore_GetMetadata_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_GetMetadata_Call) RunAndReturn(run func(context.Context, string) (*domain.IdempotencyRecord, error)) *MockIdempotencyStore_GetMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetProcessing provides a mock function with given fields: ctx, key
+func (_m *MockIdempotencyStore) SetProcessing(ctx context.Context, key string) (bool, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetProcessing")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyStore_SetProcessing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetProcessing'
+type MockIdempotencyStore_SetProcessing_Call struct {
+	*mock.Call
+}
+
+// SetProcessing is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockIdempotencyStore_Expecter) SetProcessing(ctx interface{}, key interface{}) *MockIdempotencyStore_SetProcessing_Call {
+	return &MockIdempotencyStore_SetProcessing_Call{Call: _e.mock.On("SetProcessing", ctx, key)}
+}
+
+func (_c *MockIdempotencyStore_SetProcessing_Call) Run(run func(ctx context.Context, key string)) *MockIdempotencyStore_SetProcessing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_SetProcessing_Call) Return(_a0 bool, _a1 error) *MockIdempotencyStore_SetProcessing_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_SetProcessing_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockIdempotencyStore_SetProcessing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetResult provides a mock function with given fields: ctx, record
+func (_m *MockIdempotencyStore) SetResult(ctx context.Context, record *domain.IdempotencyRecord) error {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetResult")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.IdempotencyRecord) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIdempotencyStore_SetResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetResult'
+type MockIdempotencyStore_SetResult_Call struct {
+	*mock.Call
+}
+
+// SetResult is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record *domain.IdempotencyRecord
+func (_e *MockIdempotencyStore_Expecter) SetResult(ctx interface{}, record interface{}) *MockIdempotencyStore_SetResult_Call {
+	return &MockIdempotencyStore_SetResult_Call{Call: _e.mock.On("SetResult", ctx, record)}
+}
+
+func (_c *MockIdempotencyStore_SetResult_Call) Run(run func(ctx context.Context, record *domain.IdempotencyRecord)) *MockIdempotencyStore_SetResult_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.IdempotencyRecord))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_SetResult_Call) Return(_a0 error) *MockIdempotencyStore_SetResult_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_SetResult_Call) RunAndReturn(run func(context.Context, *domain.IdempotencyRecord) error) *MockIdempotencyStore_SetResult_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIdempotencyStore creates a new instance of MockIdempotencyStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIdempotencyStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIdempotencyStore {
+	mock := &MockIdempotencyStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,69 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTransactionMetrics is an autogenerated mock type for the TransactionMetrics type
+type MockTransactionMetrics struct {
+	mock.Mock
+}
+
+type MockTransactionMetrics_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTransactionMetrics) EXPECT() *MockTransactionMetrics_Expecter {
+	return &MockTransactionMetrics_Expecter{mock: &_m.Mock}
+}
+
+// TransactionCreated provides a mock function with given fields: operationType, amount
+func (_m *MockTransactionMetrics) TransactionCreated(operationType *domain.OperationType, amount float64) {
+	_m.Called(operationType, amount)
+}
+
+// MockTransactionMetrics_TransactionCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransactionCreated'
+type MockTransactionMetrics_TransactionCreated_Call struct {
+	*mock.Call
+}
+
+// TransactionCreated is a helper method to define mock.On call
+//   - operationType *domain.OperationType
+//   - amount float64
+func (_e *MockTransactionMetrics_Expecter) TransactionCreated(operationType interface{}, amount interface{}) *MockTransactionMetrics_TransactionCreated_Call {
+	return &MockTransactionMetrics_TransactionCreated_Call{Call: _e.mock.On("TransactionCreated", operationType, amount)}
+}
+
+func (_c *MockTransactionMetrics_TransactionCreated_Call) Run(run func(operationType *domain.OperationType, amount float64)) *MockTransactionMetrics_TransactionCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*domain.OperationType), args[1].(float64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionMetrics_TransactionCreated_Call) Return() *MockTransactionMetrics_TransactionCreated_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockTransactionMetrics_TransactionCreated_Call) RunAndReturn(run func(*domain.OperationType, float64)) *MockTransactionMetrics_TransactionCreated_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockTransactionMetrics creates a new instance of MockTransactionMetrics. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransactionMetrics(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransactionMetrics {
+	mock := &MockTransactionMetrics{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockTransactionRepository is an autogenerated mock type for the TransactionRepository type
@@ -22,6 +24,65 @@ func (_m *MockTransactionRepository) EXPECT() *MockTransactionRepository_Expecte
 	return &MockTransactionRepository_Expecter{mock: &_m.Mock}
 }
 
+// ActivityRange provides a mock function with given fields: ctx, accountID
+func (_m *MockTransactionRepository) ActivityRange(ctx context.Context, accountID int64) (*domain.ActivityRange, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActivityRange")
+	}
+
+	var r0 *domain.ActivityRange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*domain.ActivityRange, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.ActivityRange); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ActivityRange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_ActivityRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActivityRange'
+type MockTransactionRepository_ActivityRange_Call struct {
+	*mock.Call
+}
+
+// ActivityRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID int64
+func (_e *MockTransactionRepository_Expecter) ActivityRange(ctx interface{}, accountID interface{}) *MockTransactionRepository_ActivityRange_Call {
+	return &MockTransactionRepository_ActivityRange_Call{Call: _e.mock.On("ActivityRange", ctx, accountID)}
+}
+
+func (_c *MockTransactionRepository_ActivityRange_Call) Run(run func(ctx context.Context, accountID int64)) *MockTransactionRepository_ActivityRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_ActivityRange_Call) Return(_a0 *domain.ActivityRange, _a1 error) *MockTransactionRepository_ActivityRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_ActivityRange_Call) RunAndReturn(run func(context.Context, int64) (*domain.ActivityRange, error)) *MockTransactionRepository_ActivityRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, transaction
 func (_m *MockTransactionRepository) Create(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error) {
 	ret := _m.Called(ctx, transaction)
@@ -81,29 +142,29 @@ func (_c *MockTransactionRepository_Create_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
-// FindByAccountID provides a mock function with given fields: ctx, accountID
-func (_m *MockTransactionRepository) FindByAccountID(ctx context.Context, accountID int64) ([]*domain.Transaction, error) {
-	ret := _m.Called(ctx, accountID)
+// CreateBatch provides a mock function with given fields: ctx, transactions
+func (_m *MockTransactionRepository) CreateBatch(ctx context.Context, transactions []*domain.Transaction) ([]*domain.Transaction, error) {
+	ret := _m.Called(ctx, transactions)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindByAccountID")
+		panic("no return value specified for CreateBatch")
 	}
 
 	var r0 []*domain.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]*domain.Transaction, error)); ok {
-		return rf(ctx, accountID)
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Transaction) ([]*domain.Transaction, error)); ok {
+		return rf(ctx, transactions)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) []*domain.Transaction); ok {
-		r0 = rf(ctx, accountID)
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Transaction) []*domain.Transaction); ok {
+		r0 = rf(ctx, transactions)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, accountID)
+	if rf, ok := ret.Get(1).(func(context.Context, []*domain.Transaction) error); ok {
+		r1 = rf(ctx, transactions)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -111,126 +172,178 @@ func (_m *MockTransactionRepository) FindByAccountID(ctx context.Context, accoun
 	return r0, r1
 }
 
-// MockTransactionRepository_FindByAccountID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByAccountID'
-type MockTransactionRepository_FindByAccountID_Call struct {
+// MockTransactionRepository_CreateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatch'
+type MockTransactionRepository_CreateBatch_Call struct {
 	*mock.Call
 }
 
-// FindByAccountID is a helper method to define mock.On call
+// CreateBatch is a helper method to define mock.On call
 //   - ctx context.Context
-//   - accountID int64
-func (_e *MockTransactionRepository_Expecter) FindByAccountID(ctx interface{}, accountID interface{}) *MockTransactionRepository_FindByAccountID_Call {
-	return &MockTransactionRepository_FindByAccountID_Call{Call: _e.mock.On("FindByAccountID", ctx, accountID)}
+//   - transactions []*domain.Transaction
+func (_e *MockTransactionRepository_Expecter) CreateBatch(ctx interface{}, transactions interface{}) *MockTransactionRepository_CreateBatch_Call {
+	return &MockTransactionRepository_CreateBatch_Call{Call: _e.mock.On("CreateBatch", ctx, transactions)}
 }
 
-func (_c *MockTransactionRepository_FindByAccountID_Call) Run(run func(ctx context.Context, accountID int64)) *MockTransactionRepository_FindByAccountID_Call {
+func (_c *MockTransactionRepository_CreateBatch_Call) Run(run func(ctx context.Context, transactions []*domain.Transaction)) *MockTransactionRepository_CreateBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].([]*domain.Transaction))
 	})
 	return _c
 }
 
-func (_c *MockTransactionRepository_FindByAccountID_Call) Return(_a0 []*domain.Transaction, _a1 error) *MockTransactionRepository_FindByAccountID_Call {
+func (_c *MockTransactionRepository_CreateBatch_Call) Return(_a0 []*domain.Transaction, _a1 error) *MockTransactionRepository_CreateBatch_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockTransactionRepository_FindByAccountID_Call) RunAndReturn(run func(context.Context, int64) ([]*domain.Transaction, error)) *MockTransactionRepository_FindByAccountID_Call {
+func (_c *MockTransactionRepository_CreateBatch_Call) RunAndReturn(run func(context.Context, []*domain.Transaction) ([]*domain.Transaction, error)) *MockTransactionRepository_CreateBatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindByAccountIDPaginated provides a mock function with given fields: ctx, accountID, limit, offset
-func (_m *MockTransactionRepository) FindByAccountIDPaginated(ctx context.Context, accountID int64, limit int64, offset int64) ([]*domain.Transaction, int64, error) {
-	ret := _m.Called(ctx, accountID, limit, offset)
+// CreateIfBalance provides a mock function with given fields: ctx, transaction, expectedBalance
+func (_m *MockTransactionRepository) CreateIfBalance(ctx context.Context, transaction *domain.Transaction, expectedBalance float64) (*domain.Transaction, error) {
+	ret := _m.Called(ctx, transaction, expectedBalance)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindByAccountIDPaginated")
+		panic("no return value specified for CreateIfBalance")
 	}
 
-	var r0 []*domain.Transaction
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) ([]*domain.Transaction, int64, error)); ok {
-		return rf(ctx, accountID, limit, offset)
+	var r0 *domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Transaction, float64) (*domain.Transaction, error)); ok {
+		return rf(ctx, transaction, expectedBalance)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) []*domain.Transaction); ok {
-		r0 = rf(ctx, accountID, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Transaction, float64) *domain.Transaction); ok {
+		r0 = rf(ctx, transaction, expectedBalance)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*domain.Transaction)
+			r0 = ret.Get(0).(*domain.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) int64); ok {
-		r1 = rf(ctx, accountID, limit, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Transaction, float64) error); ok {
+		r1 = rf(ctx, transaction, expectedBalance)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r1 = ret.Error(1)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, int64, int64, int64) error); ok {
-		r2 = rf(ctx, accountID, limit, offset)
+	return r0, r1
+}
+
+// MockTransactionRepository_CreateIfBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIfBalance'
+type MockTransactionRepository_CreateIfBalance_Call struct {
+	*mock.Call
+}
+
+// CreateIfBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transaction *domain.Transaction
+//   - expectedBalance float64
+func (_e *MockTransactionRepository_Expecter) CreateIfBalance(ctx interface{}, transaction interface{}, expectedBalance interface{}) *MockTransactionRepository_CreateIfBalance_Call {
+	return &MockTransactionRepository_CreateIfBalance_Call{Call: _e.mock.On("CreateIfBalance", ctx, transaction, expectedBalance)}
+}
+
+func (_c *MockTransactionRepository_CreateIfBalance_Call) Run(run func(ctx context.Context, transaction *domain.Transaction, expectedBalance float64)) *MockTransactionRepository_CreateIfBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Transaction), args[2].(float64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_CreateIfBalance_Call) Return(_a0 *domain.Transaction, _a1 error) *MockTransactionRepository_CreateIfBalance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_CreateIfBalance_Call) RunAndReturn(run func(context.Context, *domain.Transaction, float64) (*domain.Transaction, error)) *MockTransactionRepository_CreateIfBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateInstallments provides a mock function with given fields: ctx, installments, expectedBalance
+func (_m *MockTransactionRepository) CreateInstallments(ctx context.Context, installments []*domain.Transaction, expectedBalance *float64) ([]*domain.Transaction, error) {
+	ret := _m.Called(ctx, installments, expectedBalance)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInstallments")
+	}
+
+	var r0 []*domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Transaction, *float64) ([]*domain.Transaction, error)); ok {
+		return rf(ctx, installments, expectedBalance)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Transaction, *float64) []*domain.Transaction); ok {
+		r0 = rf(ctx, installments, expectedBalance)
 	} else {
-		r2 = ret.Error(2)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Transaction)
+		}
 	}
 
-	return r0, r1, r2
+	if rf, ok := ret.Get(1).(func(context.Context, []*domain.Transaction, *float64) error); ok {
+		r1 = rf(ctx, installments, expectedBalance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockTransactionRepository_FindByAccountIDPaginated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByAccountIDPaginated'
-type MockTransactionRepository_FindByAccountIDPaginated_Call struct {
+// MockTransactionRepository_CreateInstallments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateInstallments'
+type MockTransactionRepository_CreateInstallments_Call struct {
 	*mock.Call
 }
 
-// FindByAccountIDPaginated is a helper method to define mock.On call
+// CreateInstallments is a helper method to define mock.On call
 //   - ctx context.Context
-//   - accountID int64
-//   - limit int64
-//   - offset int64
-func (_e *MockTransactionRepository_Expecter) FindByAccountIDPaginated(ctx interface{}, accountID interface{}, limit interface{}, offset interface{}) *MockTransactionRepository_FindByAccountIDPaginated_Call {
-	return &MockTransactionRepository_FindByAccountIDPaginated_Call{Call: _e.mock.On("FindByAccountIDPaginated", ctx, accountID, limit, offset)}
+//   - installments []*domain.Transaction
+//   - expectedBalance *float64
+func (_e *MockTransactionRepository_Expecter) CreateInstallments(ctx interface{}, installments interface{}, expectedBalance interface{}) *MockTransactionRepository_CreateInstallments_Call {
+	return &MockTransactionRepository_CreateInstallments_Call{Call: _e.mock.On("CreateInstallments", ctx, installments, expectedBalance)}
 }
 
-func (_c *MockTransactionRepository_FindByAccountIDPaginated_Call) Run(run func(ctx context.Context, accountID int64, limit int64, offset int64)) *MockTransactionRepository_FindByAccountIDPaginated_Call {
+func (_c *MockTransactionRepository_CreateInstallments_Call) Run(run func(ctx context.Context, installments []*domain.Transaction, expectedBalance *float64)) *MockTransactionRepository_CreateInstallments_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64))
+		run(args[0].(context.Context), args[1].([]*domain.Transaction), args[2].(*float64))
 	})
 	return _c
 }
 
-func (_c *MockTransactionRepository_FindByAccountIDPaginated_Call) Return(_a0 []*domain.Transaction, _a1 int64, _a2 error) *MockTransactionRepository_FindByAccountIDPaginated_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *MockTransactionRepository_CreateInstallments_Call) Return(_a0 []*domain.Transaction, _a1 error) *MockTransactionRepository_CreateInstallments_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockTransactionRepository_FindByAccountIDPaginated_Call) RunAndReturn(run func(context.Context, int64, int64, int64) ([]*domain.Transaction, int64, error)) *MockTransactionRepository_FindByAccountIDPaginated_Call {
+func (_c *MockTransactionRepository_CreateInstallments_Call) RunAndReturn(run func(context.Context, []*domain.Transaction, *float64) ([]*domain.Transaction, error)) *MockTransactionRepository_CreateInstallments_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindByID provides a mock function with given fields: ctx, id
-func (_m *MockTransactionRepository) FindByID(ctx context.Context, id int64) (*domain.Transaction, error) {
-	ret := _m.Called(ctx, id)
+// CreateWithDischarge provides a mock function with given fields: ctx, transaction, discharges, expectedBalance
+func (_m *MockTransactionRepository) CreateWithDischarge(ctx context.Context, transaction *domain.Transaction, discharges []domain.Discharge, expectedBalance *float64) (*domain.Transaction, error) {
+	ret := _m.Called(ctx, transaction, discharges, expectedBalance)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindByID")
+		panic("no return value specified for CreateWithDischarge")
 	}
 
 	var r0 *domain.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (*domain.Transaction, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Transaction, []domain.Discharge, *float64) (*domain.Transaction, error)); ok {
+		return rf(ctx, transaction, discharges, expectedBalance)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Transaction); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Transaction, []domain.Discharge, *float64) *domain.Transaction); ok {
+		r0 = rf(ctx, transaction, discharges, expectedBalance)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Transaction, []domain.Discharge, *float64) error); ok {
+		r1 = rf(ctx, transaction, discharges, expectedBalance)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -238,58 +351,60 @@ func (_m *MockTransactionRepository) FindByID(ctx context.Context, id int64) (*d
 	return r0, r1
 }
 
-// MockTransactionRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
-type MockTransactionRepository_FindByID_Call struct {
+// MockTransactionRepository_CreateWithDischarge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWithDischarge'
+type MockTransactionRepository_CreateWithDischarge_Call struct {
 	*mock.Call
 }
 
-// FindByID is a helper method to define mock.On call
+// CreateWithDischarge is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int64
-func (_e *MockTransactionRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockTransactionRepository_FindByID_Call {
-	return &MockTransactionRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+//   - transaction *domain.Transaction
+//   - discharges []domain.Discharge
+//   - expectedBalance *float64
+func (_e *MockTransactionRepository_Expecter) CreateWithDischarge(ctx interface{}, transaction interface{}, discharges interface{}, expectedBalance interface{}) *MockTransactionRepository_CreateWithDischarge_Call {
+	return &MockTransactionRepository_CreateWithDischarge_Call{Call: _e.mock.On("CreateWithDischarge", ctx, transaction, discharges, expectedBalance)}
 }
 
-func (_c *MockTransactionRepository_FindByID_Call) Run(run func(ctx context.Context, id int64)) *MockTransactionRepository_FindByID_Call {
+func (_c *MockTransactionRepository_CreateWithDischarge_Call) Run(run func(ctx context.Context, transaction *domain.Transaction, discharges []domain.Discharge, expectedBalance *float64)) *MockTransactionRepository_CreateWithDischarge_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(*domain.Transaction), args[2].([]domain.Discharge), args[3].(*float64))
 	})
 	return _c
 }
 
-func (_c *MockTransactionRepository_FindByID_Call) Return(_a0 *domain.Transaction, _a1 error) *MockTransactionRepository_FindByID_Call {
+func (_c *MockTransactionRepository_CreateWithDischarge_Call) Return(_a0 *domain.Transaction, _a1 error) *MockTransactionRepository_CreateWithDischarge_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockTransactionRepository_FindByID_Call) RunAndReturn(run func(context.Context, int64) (*domain.Transaction, error)) *MockTransactionRepository_FindByID_Call {
+func (_c *MockTransactionRepository_CreateWithDischarge_Call) RunAndReturn(run func(context.Context, *domain.Transaction, []domain.Discharge, *float64) (*domain.Transaction, error)) *MockTransactionRepository_CreateWithDischarge_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAll provides a mock function with given fields: ctx
-func (_m *MockTransactionRepository) GetAll(ctx context.Context) ([]*domain.Transaction, error) {
-	ret := _m.Called(ctx)
+// DailyTotalsBetween provides a mock function with given fields: ctx, accountID, start, end
+func (_m *MockTransactionRepository) DailyTotalsBetween(ctx context.Context, accountID int64, start time.Time, end time.Time) ([]*domain.DailyTotal, error) {
+	ret := _m.Called(ctx, accountID, start, end)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAll")
+		panic("no return value specified for DailyTotalsBetween")
 	}
 
-	var r0 []*domain.Transaction
+	var r0 []*domain.DailyTotal
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.Transaction, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) ([]*domain.DailyTotal, error)); ok {
+		return rf(ctx, accountID, start, end)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*domain.Transaction); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) []*domain.DailyTotal); ok {
+		r0 = rf(ctx, accountID, start, end)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*domain.Transaction)
+			r0 = ret.Get(0).([]*domain.DailyTotal)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, accountID, start, end)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -297,30 +412,1237 @@ func (_m *MockTransactionRepository) GetAll(ctx context.Context) ([]*domain.Tran
 	return r0, r1
 }
 
-// MockTransactionRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
-type MockTransactionRepository_GetAll_Call struct {
+// MockTransactionRepository_DailyTotalsBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DailyTotalsBetween'
+type MockTransactionRepository_DailyTotalsBetween_Call struct {
 	*mock.Call
 }
 
-// GetAll is a helper method to define mock.On call
+// DailyTotalsBetween is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockTransactionRepository_Expecter) GetAll(ctx interface{}) *MockTransactionRepository_GetAll_Call {
-	return &MockTransactionRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx)}
+//   - accountID int64
+//   - start time.Time
+//   - end time.Time
+func (_e *MockTransactionRepository_Expecter) DailyTotalsBetween(ctx interface{}, accountID interface{}, start interface{}, end interface{}) *MockTransactionRepository_DailyTotalsBetween_Call {
+	return &MockTransactionRepository_DailyTotalsBetween_Call{Call: _e.mock.On("DailyTotalsBetween", ctx, accountID, start, end)}
 }
 
-func (_c *MockTransactionRepository_GetAll_Call) Run(run func(ctx context.Context)) *MockTransactionRepository_GetAll_Call {
+func (_c *MockTransactionRepository_DailyTotalsBetween_Call) Run(run func(ctx context.Context, accountID int64, start time.Time, end time.Time)) *MockTransactionRepository_DailyTotalsBetween_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time), args[3].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockTransactionRepository_GetAll_Call) Return(_a0 []*domain.Transaction, _a1 error) *MockTransactionRepository_GetAll_Call {
+func (_c *MockTransactionRepository_DailyTotalsBetween_Call) Return(_a0 []*domain.DailyTotal, _a1 error) *MockTransactionRepository_DailyTotalsBetween_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockTransactionRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]*domain.Transaction, error)) *MockTransactionRepository_GetAll_Call {
+func (_c *MockTransactionRepository_DailyTotalsBetween_Call) RunAndReturn(run func(context.Context, int64, time.Time, time.Time) ([]*domain.DailyTotal, error)) *MockTransactionRepository_DailyTotalsBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindAllPaginated provides a mock function with given fields: ctx, limit, offset
+func (_m *MockTransactionRepository) FindAllPaginated(ctx context.Context, limit int64, offset int64) ([]*domain.Transaction, int64, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllPaginated")
+	}
+
+	var r0 []*domain.Transaction
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) ([]*domain.Transaction, int64, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []*domain.Transaction); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) int64); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64, int64) error); ok {
+		r2 = rf(ctx, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockTransactionRepository_FindAllPaginated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAllPaginated'
+type MockTransactionRepository_FindAllPaginated_Call struct {
+	*mock.Call
+}
+
+// FindAllPaginated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int64
+//   - offset int64
+func (_e *MockTransactionRepository_Expecter) FindAllPaginated(ctx interface{}, limit interface{}, offset interface{}) *MockTransactionRepository_FindAllPaginated_Call {
+	return &MockTransactionRepository_FindAllPaginated_Call{Call: _e.mock.On("FindAllPaginated", ctx, limit, offset)}
+}
+
+func (_c *MockTransactionRepository_FindAllPaginated_Call) Run(run func(ctx context.Context, limit int64, offset int64)) *MockTransactionRepository_FindAllPaginated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindAllPaginated_Call) Return(_a0 []*domain.Transaction, _a1 int64, _a2 error) *MockTransactionRepository_FindAllPaginated_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindAllPaginated_Call) RunAndReturn(run func(context.Context, int64, int64) ([]*domain.Transaction, int64, error)) *MockTransactionRepository_FindAllPaginated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByAccountID provides a mock function with given fields: ctx, accountID
+func (_m *MockTransactionRepository) FindByAccountID(ctx context.Context, accountID int64) ([]*domain.Transaction, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByAccountID")
+	}
+
+	var r0 []*domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]*domain.Transaction, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*domain.Transaction); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_FindByAccountID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByAccountID'
+type MockTransactionRepository_FindByAccountID_Call struct {
+	*mock.Call
+}
+
+// FindByAccountID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID int64
+func (_e *MockTransactionRepository_Expecter) FindByAccountID(ctx interface{}, accountID interface{}) *MockTransactionRepository_FindByAccountID_Call {
+	return &MockTransactionRepository_FindByAccountID_Call{Call: _e.mock.On("FindByAccountID", ctx, accountID)}
+}
+
+func (_c *MockTransactionRepository_FindByAccountID_Call) Run(run func(ctx context.Context, accountID int64)) *MockTransactionRepository_FindByAccountID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByAccountID_Call) Return(_a0 []*domain.Transaction, _a1 error) *MockTransactionRepository_FindByAccountID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByAccountID_Call) RunAndReturn(run func(context.Context, int64) ([]*domain.Transaction, error)) *MockTransactionRepository_FindByAccountID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByAccountIDBetween provides a mock function with given fields: ctx, accountID, start, end
+func (_m *MockTransactionRepository) FindByAccountIDBetween(ctx context.Context, accountID int64, start time.Time, end time.Time) ([]*domain.Transaction, error) {
+	ret := _m.Called(ctx, accountID, start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByAccountIDBetween")
+	}
+
+	var r0 []*domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) ([]*domain.Transaction, error)); ok {
+		return rf(ctx, accountID, start, end)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) []*domain.Transaction); ok {
+		r0 = rf(ctx, accountID, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, accountID, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_FindByAccountIDBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByAccountIDBetween'
+type MockTransactionRepository_FindByAccountIDBetween_Call struct {
+	*mock.Call
+}
+
+// FindByAccountIDBetween is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID int64
+//   - start time.Time
+//   - end time.Time
+func (_e *MockTransactionRepository_Expecter) FindByAccountIDBetween(ctx interface{}, accountID interface{}, start interface{}, end interface{}) *MockTransactionRepository_FindByAccountIDBetween_Call {
+	return &MockTransactionRepository_FindByAccountIDBetween_Call{Call: _e.mock.On("FindByAccountIDBetween", ctx, accountID, start, end)}
+}
+
+func (_c *MockTransactionRepository_FindByAccountIDBetween_Call) Run(run func(ctx context.Context, accountID int64, start time.Time, end time.Time)) *MockTransactionRepository_FindByAccountIDBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByAccountIDBetween_Call) Return(_a0 []*domain.Transaction, _a1 error) *MockTransactionRepository_FindByAccountIDBetween_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByAccountIDBetween_Call) RunAndReturn(run func(context.Context, int64, time.Time, time.Time) ([]*domain.Transaction, error)) *MockTransactionRepository_FindByAccountIDBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByAccountIDPaginated provides a mock function with given fields: ctx, accountID, limit, offset
+func (_m *MockTransactionRepository) FindByAccountIDPaginated(ctx context.Context, accountID int64, limit int64, offset int64) ([]*domain.AccountTransaction, int64, error) {
+	ret := _m.Called(ctx, accountID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByAccountIDPaginated")
+	}
+
+	var r0 []*domain.AccountTransaction
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) ([]*domain.AccountTransaction, int64, error)); ok {
+		return rf(ctx, accountID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) []*domain.AccountTransaction); ok {
+		r0 = rf(ctx, accountID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AccountTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) int64); ok {
+		r1 = rf(ctx, accountID, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64, int64, int64) error); ok {
+		r2 = rf(ctx, accountID, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockTransactionRepository_FindByAccountIDPaginated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByAccountIDPaginated'
+type MockTransactionRepository_FindByAccountIDPaginated_Call struct {
+	*mock.Call
+}
+
+// FindByAccountIDPaginated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID int64
+//   - limit int64
+//   - offset int64
+func (_e *MockTransactionRepository_Expecter) FindByAccountIDPaginated(ctx interface{}, accountID interface{}, limit interface{}, offset interface{}) *MockTransactionRepository_FindByAccountIDPaginated_Call {
+	return &MockTransactionRepository_FindByAccountIDPaginated_Call{Call: _e.mock.On("FindByAccountIDPaginated", ctx, accountID, limit, offset)}
+}
+
+func (_c *MockTransactionRepository_FindByAccountIDPaginated_Call) Run(run func(ctx context.Context, accountID int64, limit int64, offset int64)) *MockTransactionRepository_FindByAccountIDPaginated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByAccountIDPaginated_Call) Return(_a0 []*domain.AccountTransaction, _a1 int64, _a2 error) *MockTransactionRepository_FindByAccountIDPaginated_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByAccountIDPaginated_Call) RunAndReturn(run func(context.Context, int64, int64, int64) ([]*domain.AccountTransaction, int64, error)) *MockTransactionRepository_FindByAccountIDPaginated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByAccountIDPaginatedFiltered provides a mock function with given fields: ctx, accountID, filter, limit, offset
+func (_m *MockTransactionRepository) FindByAccountIDPaginatedFiltered(ctx context.Context, accountID int64, filter domain.TransactionFilter, limit int64, offset int64) ([]*domain.AccountTransaction, int64, error) {
+	ret := _m.Called(ctx, accountID, filter, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByAccountIDPaginatedFiltered")
+	}
+
+	var r0 []*domain.AccountTransaction
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.TransactionFilter, int64, int64) ([]*domain.AccountTransaction, int64, error)); ok {
+		return rf(ctx, accountID, filter, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.TransactionFilter, int64, int64) []*domain.AccountTransaction); ok {
+		r0 = rf(ctx, accountID, filter, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AccountTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.TransactionFilter, int64, int64) int64); ok {
+		r1 = rf(ctx, accountID, filter, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64, domain.TransactionFilter, int64, int64) error); ok {
+		r2 = rf(ctx, accountID, filter, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockTransactionRepository_FindByAccountIDPaginatedFiltered_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByAccountIDPaginatedFiltered'
+type MockTransactionRepository_FindByAccountIDPaginatedFiltered_Call struct {
+	*mock.Call
+}
+
+// FindByAccountIDPaginatedFiltered is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID int64
+//   - filter domain.TransactionFilter
+//   - limit int64
+//   - offset int64
+func (_e *MockTransactionRepository_Expecter) FindByAccountIDPaginatedFiltered(ctx interface{}, accountID interface{}, filter interface{}, limit interface{}, offset interface{}) *MockTransactionRepository_FindByAccountIDPaginatedFiltered_Call {
+	return &MockTransactionRepository_FindByAccountIDPaginatedFiltered_Call{Call: _e.mock.On("FindByAccountIDPaginatedFiltered", ctx, accountID, filter, limit, offset)}
+}
+
+func (_c *MockTransactionRepository_FindByAccountIDPaginatedFiltered_Call) Run(run func(ctx context.Context, accountID int64, filter domain.TransactionFilter, limit int64, offset int64)) *MockTransactionRepository_FindByAccountIDPaginatedFiltered_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(domain.TransactionFilter), args[3].(int64), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByAccountIDPaginatedFiltered_Call) Return(_a0 []*domain.AccountTransaction, _a1 int64, _a2 error) *MockTransactionRepository_FindByAccountIDPaginatedFiltered_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByAccountIDPaginatedFiltered_Call) RunAndReturn(run func(context.Context, int64, domain.TransactionFilter, int64, int64) ([]*domain.AccountTransaction, int64, error)) *MockTransactionRepository_FindByAccountIDPaginatedFiltered_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *MockTransactionRepository) FindByID(ctx context.Context, id int64) (*domain.Transaction, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*domain.Transaction, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Transaction); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockTransactionRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockTransactionRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockTransactionRepository_FindByID_Call {
+	return &MockTransactionRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockTransactionRepository_FindByID_Call) Run(run func(ctx context.Context, id int64)) *MockTransactionRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByID_Call) Return(_a0 *domain.Transaction, _a1 error) *MockTransactionRepository_FindByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByID_Call) RunAndReturn(run func(context.Context, int64) (*domain.Transaction, error)) *MockTransactionRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByIdempotencyKey provides a mock function with given fields: ctx, accountID, key
+func (_m *MockTransactionRepository) FindByIdempotencyKey(ctx context.Context, accountID int64, key string) (*domain.Transaction, error) {
+	ret := _m.Called(ctx, accountID, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByIdempotencyKey")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (*domain.Transaction, error)); ok {
+		return rf(ctx, accountID, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *domain.Transaction); ok {
+		r0 = rf(ctx, accountID, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, accountID, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_FindByIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByIdempotencyKey'
+type MockTransactionRepository_FindByIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// FindByIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID int64
+//   - key string
+func (_e *MockTransactionRepository_Expecter) FindByIdempotencyKey(ctx interface{}, accountID interface{}, key interface{}) *MockTransactionRepository_FindByIdempotencyKey_Call {
+	return &MockTransactionRepository_FindByIdempotencyKey_Call{Call: _e.mock.On("FindByIdempotencyKey", ctx, accountID, key)}
+}
+
+func (_c *MockTransactionRepository_FindByIdempotencyKey_Call) Run(run func(ctx context.Context, accountID int64, key string)) *MockTransactionRepository_FindByIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByIdempotencyKey_Call) Return(_a0 *domain.Transaction, _a1 error) *MockTransactionRepository_FindByIdempotencyKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindByIdempotencyKey_Call) RunAndReturn(run func(context.Context, int64, string) (*domain.Transaction, error)) *MockTransactionRepository_FindByIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindOutstandingByAccountID provides a mock function with given fields: ctx, accountID
+func (_m *MockTransactionRepository) FindOutstandingByAccountID(ctx context.Context, accountID int64) ([]*domain.Transaction, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOutstandingByAccountID")
+	}
+
+	var r0 []*domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]*domain.Transaction, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*domain.Transaction); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_FindOutstandingByAccountID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOutstandingByAccountID'
+type MockTransactionRepository_FindOutstandingByAccountID_Call struct {
+	*mock.Call
+}
+
+// FindOutstandingByAccountID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID int64
+func (_e *MockTransactionRepository_Expecter) FindOutstandingByAccountID(ctx interface{}, accountID interface{}) *MockTransactionRepository_FindOutstandingByAccountID_Call {
+	return &MockTransactionRepository_FindOutstandingByAccountID_Call{Call: _e.mock.On("FindOutstandingByAccountID", ctx, accountID)}
+}
+
+func (_c *MockTransactionRepository_FindOutstandingByAccountID_Call) Run(run func(ctx context.Context, accountID int64)) *MockTransactionRepository_FindOutstandingByAccountID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindOutstandingByAccountID_Call) Return(_a0 []*domain.Transaction, _a1 error) *MockTransactionRepository_FindOutstandingByAccountID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindOutstandingByAccountID_Call) RunAndReturn(run func(context.Context, int64) ([]*domain.Transaction, error)) *MockTransactionRepository_FindOutstandingByAccountID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRecent provides a mock function with given fields: ctx, limit
+func (_m *MockTransactionRepository) FindRecent(ctx context.Context, limit int64) ([]*domain.RecentTransaction, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRecent")
+	}
+
+	var r0 []*domain.RecentTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]*domain.RecentTransaction, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*domain.RecentTransaction); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.RecentTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_FindRecent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRecent'
+type MockTransactionRepository_FindRecent_Call struct {
+	*mock.Call
+}
+
+// FindRecent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int64
+func (_e *MockTransactionRepository_Expecter) FindRecent(ctx interface{}, limit interface{}) *MockTransactionRepository_FindRecent_Call {
+	return &MockTransactionRepository_FindRecent_Call{Call: _e.mock.On("FindRecent", ctx, limit)}
+}
+
+func (_c *MockTransactionRepository_FindRecent_Call) Run(run func(ctx context.Context, limit int64)) *MockTransactionRepository_FindRecent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindRecent_Call) Return(_a0 []*domain.RecentTransaction, _a1 error) *MockTransactionRepository_FindRecent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindRecent_Call) RunAndReturn(run func(context.Context, int64) ([]*domain.RecentTransaction, error)) *MockTransactionRepository_FindRecent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRecentDuplicate provides a mock function with given fields: ctx, transaction, window
+func (_m *MockTransactionRepository) FindRecentDuplicate(ctx context.Context, transaction *domain.Transaction, window time.Duration) (*domain.Transaction, error) {
+	ret := _m.Called(ctx, transaction, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRecentDuplicate")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Transaction, time.Duration) (*domain.Transaction, error)); ok {
+		return rf(ctx, transaction, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Transaction, time.Duration) *domain.Transaction); ok {
+		r0 = rf(ctx, transaction, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Transaction, time.Duration) error); ok {
+		r1 = rf(ctx, transaction, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_FindRecentDuplicate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRecentDuplicate'
+type MockTransactionRepository_FindRecentDuplicate_Call struct {
+	*mock.Call
+}
+
+// FindRecentDuplicate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transaction *domain.Transaction
+//   - window time.Duration
+func (_e *MockTransactionRepository_Expecter) FindRecentDuplicate(ctx interface{}, transaction interface{}, window interface{}) *MockTransactionRepository_FindRecentDuplicate_Call {
+	return &MockTransactionRepository_FindRecentDuplicate_Call{Call: _e.mock.On("FindRecentDuplicate", ctx, transaction, window)}
+}
+
+func (_c *MockTransactionRepository_FindRecentDuplicate_Call) Run(run func(ctx context.Context, transaction *domain.Transaction, window time.Duration)) *MockTransactionRepository_FindRecentDuplicate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Transaction), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindRecentDuplicate_Call) Return(_a0 *domain.Transaction, _a1 error) *MockTransactionRepository_FindRecentDuplicate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindRecentDuplicate_Call) RunAndReturn(run func(context.Context, *domain.Transaction, time.Duration) (*domain.Transaction, error)) *MockTransactionRepository_FindRecentDuplicate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindReversal provides a mock function with given fields: ctx, transactionID
+func (_m *MockTransactionRepository) FindReversal(ctx context.Context, transactionID int64) (*domain.Transaction, error) {
+	ret := _m.Called(ctx, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindReversal")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*domain.Transaction, error)); ok {
+		return rf(ctx, transactionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Transaction); ok {
+		r0 = rf(ctx, transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_FindReversal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindReversal'
+type MockTransactionRepository_FindReversal_Call struct {
+	*mock.Call
+}
+
+// FindReversal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transactionID int64
+func (_e *MockTransactionRepository_Expecter) FindReversal(ctx interface{}, transactionID interface{}) *MockTransactionRepository_FindReversal_Call {
+	return &MockTransactionRepository_FindReversal_Call{Call: _e.mock.On("FindReversal", ctx, transactionID)}
+}
+
+func (_c *MockTransactionRepository_FindReversal_Call) Run(run func(ctx context.Context, transactionID int64)) *MockTransactionRepository_FindReversal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindReversal_Call) Return(_a0 *domain.Transaction, _a1 error) *MockTransactionRepository_FindReversal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindReversal_Call) RunAndReturn(run func(context.Context, int64) (*domain.Transaction, error)) *MockTransactionRepository_FindReversal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindReversed provides a mock function with given fields: ctx, transactionID
+func (_m *MockTransactionRepository) FindReversed(ctx context.Context, transactionID int64) (*domain.Transaction, error) {
+	ret := _m.Called(ctx, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindReversed")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*domain.Transaction, error)); ok {
+		return rf(ctx, transactionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.Transaction); ok {
+		r0 = rf(ctx, transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_FindReversed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindReversed'
+type MockTransactionRepository_FindReversed_Call struct {
+	*mock.Call
+}
+
+// FindReversed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transactionID int64
+func (_e *MockTransactionRepository_Expecter) FindReversed(ctx interface{}, transactionID interface{}) *MockTransactionRepository_FindReversed_Call {
+	return &MockTransactionRepository_FindReversed_Call{Call: _e.mock.On("FindReversed", ctx, transactionID)}
+}
+
+func (_c *MockTransactionRepository_FindReversed_Call) Run(run func(ctx context.Context, transactionID int64)) *MockTransactionRepository_FindReversed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindReversed_Call) Return(_a0 *domain.Transaction, _a1 error) *MockTransactionRepository_FindReversed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindReversed_Call) RunAndReturn(run func(context.Context, int64) (*domain.Transaction, error)) *MockTransactionRepository_FindReversed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: ctx
+func (_m *MockTransactionRepository) GetAll(ctx context.Context) ([]*domain.Transaction, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.Transaction, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.Transaction); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockTransactionRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTransactionRepository_Expecter) GetAll(ctx interface{}) *MockTransactionRepository_GetAll_Call {
+	return &MockTransactionRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx)}
+}
+
+func (_c *MockTransactionRepository_GetAll_Call) Run(run func(ctx context.Context)) *MockTransactionRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_GetAll_Call) Return(_a0 []*domain.Transaction, _a1 error) *MockTransactionRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]*domain.Transaction, error)) *MockTransactionRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MonthlyTotalsBetween provides a mock function with given fields: ctx, accountID, start, end
+func (_m *MockTransactionRepository) MonthlyTotalsBetween(ctx context.Context, accountID int64, start time.Time, end time.Time) ([]*domain.MonthlyTotal, error) {
+	ret := _m.Called(ctx, accountID, start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MonthlyTotalsBetween")
+	}
+
+	var r0 []*domain.MonthlyTotal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) ([]*domain.MonthlyTotal, error)); ok {
+		return rf(ctx, accountID, start, end)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) []*domain.MonthlyTotal); ok {
+		r0 = rf(ctx, accountID, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.MonthlyTotal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, accountID, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_MonthlyTotalsBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MonthlyTotalsBetween'
+type MockTransactionRepository_MonthlyTotalsBetween_Call struct {
+	*mock.Call
+}
+
+// MonthlyTotalsBetween is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID int64
+//   - start time.Time
+//   - end time.Time
+func (_e *MockTransactionRepository_Expecter) MonthlyTotalsBetween(ctx interface{}, accountID interface{}, start interface{}, end interface{}) *MockTransactionRepository_MonthlyTotalsBetween_Call {
+	return &MockTransactionRepository_MonthlyTotalsBetween_Call{Call: _e.mock.On("MonthlyTotalsBetween", ctx, accountID, start, end)}
+}
+
+func (_c *MockTransactionRepository_MonthlyTotalsBetween_Call) Run(run func(ctx context.Context, accountID int64, start time.Time, end time.Time)) *MockTransactionRepository_MonthlyTotalsBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_MonthlyTotalsBetween_Call) Return(_a0 []*domain.MonthlyTotal, _a1 error) *MockTransactionRepository_MonthlyTotalsBetween_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_MonthlyTotalsBetween_Call) RunAndReturn(run func(context.Context, int64, time.Time, time.Time) ([]*domain.MonthlyTotal, error)) *MockTransactionRepository_MonthlyTotalsBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunningTotalsBetween provides a mock function with given fields: ctx, accountID, start, end
+func (_m *MockTransactionRepository) RunningTotalsBetween(ctx context.Context, accountID int64, start time.Time, end time.Time) ([]*domain.DailyBalance, error) {
+	ret := _m.Called(ctx, accountID, start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunningTotalsBetween")
+	}
+
+	var r0 []*domain.DailyBalance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) ([]*domain.DailyBalance, error)); ok {
+		return rf(ctx, accountID, start, end)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) []*domain.DailyBalance); ok {
+		r0 = rf(ctx, accountID, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.DailyBalance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, accountID, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_RunningTotalsBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunningTotalsBetween'
+type MockTransactionRepository_RunningTotalsBetween_Call struct {
+	*mock.Call
+}
+
+// RunningTotalsBetween is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID int64
+//   - start time.Time
+//   - end time.Time
+func (_e *MockTransactionRepository_Expecter) RunningTotalsBetween(ctx interface{}, accountID interface{}, start interface{}, end interface{}) *MockTransactionRepository_RunningTotalsBetween_Call {
+	return &MockTransactionRepository_RunningTotalsBetween_Call{Call: _e.mock.On("RunningTotalsBetween", ctx, accountID, start, end)}
+}
+
+func (_c *MockTransactionRepository_RunningTotalsBetween_Call) Run(run func(ctx context.Context, accountID int64, start time.Time, end time.Time)) *MockTransactionRepository_RunningTotalsBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_RunningTotalsBetween_Call) Return(_a0 []*domain.DailyBalance, _a1 error) *MockTransactionRepository_RunningTotalsBetween_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_RunningTotalsBetween_Call) RunAndReturn(run func(context.Context, int64, time.Time, time.Time) ([]*domain.DailyBalance, error)) *MockTransactionRepository_RunningTotalsBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamByAccountID provides a mock function with given fields: ctx, accountID, fn
+func (_m *MockTransactionRepository) StreamByAccountID(ctx context.Context, accountID int64, fn func(*domain.Transaction) error) error {
+	ret := _m.Called(ctx, accountID, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamByAccountID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, func(*domain.Transaction) error) error); ok {
+		r0 = rf(ctx, accountID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransactionRepository_StreamByAccountID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamByAccountID'
+type MockTransactionRepository_StreamByAccountID_Call struct {
+	*mock.Call
+}
+
+// StreamByAccountID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID int64
+//   - fn func(*domain.Transaction) error
+func (_e *MockTransactionRepository_Expecter) StreamByAccountID(ctx interface{}, accountID interface{}, fn interface{}) *MockTransactionRepository_StreamByAccountID_Call {
+	return &MockTransactionRepository_StreamByAccountID_Call{Call: _e.mock.On("StreamByAccountID", ctx, accountID, fn)}
+}
+
+func (_c *MockTransactionRepository_StreamByAccountID_Call) Run(run func(ctx context.Context, accountID int64, fn func(*domain.Transaction) error)) *MockTransactionRepository_StreamByAccountID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(func(*domain.Transaction) error))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_StreamByAccountID_Call) Return(_a0 error) *MockTransactionRepository_StreamByAccountID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransactionRepository_StreamByAccountID_Call) RunAndReturn(run func(context.Context, int64, func(*domain.Transaction) error) error) *MockTransactionRepository_StreamByAccountID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SumAsOf provides a mock function with given fields: ctx, accountID, asOf
+func (_m *MockTransactionRepository) SumAsOf(ctx context.Context, accountID int64, asOf time.Time) (float64, error) {
+	ret := _m.Called(ctx, accountID, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumAsOf")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) (float64, error)); ok {
+		return rf(ctx, accountID, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) float64); ok {
+		r0 = rf(ctx, accountID, asOf)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = rf(ctx, accountID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_SumAsOf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SumAsOf'
+type MockTransactionRepository_SumAsOf_Call struct {
+	*mock.Call
+}
+
+// SumAsOf is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID int64
+//   - asOf time.Time
+func (_e *MockTransactionRepository_Expecter) SumAsOf(ctx interface{}, accountID interface{}, asOf interface{}) *MockTransactionRepository_SumAsOf_Call {
+	return &MockTransactionRepository_SumAsOf_Call{Call: _e.mock.On("SumAsOf", ctx, accountID, asOf)}
+}
+
+func (_c *MockTransactionRepository_SumAsOf_Call) Run(run func(ctx context.Context, accountID int64, asOf time.Time)) *MockTransactionRepository_SumAsOf_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_SumAsOf_Call) Return(_a0 float64, _a1 error) *MockTransactionRepository_SumAsOf_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_SumAsOf_Call) RunAndReturn(run func(context.Context, int64, time.Time) (float64, error)) *MockTransactionRepository_SumAsOf_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SumBefore provides a mock function with given fields: ctx, accountID, before
+func (_m *MockTransactionRepository) SumBefore(ctx context.Context, accountID int64, before time.Time) (float64, error) {
+	ret := _m.Called(ctx, accountID, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumBefore")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) (float64, error)); ok {
+		return rf(ctx, accountID, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) float64); ok {
+		r0 = rf(ctx, accountID, before)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = rf(ctx, accountID, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_SumBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SumBefore'
+type MockTransactionRepository_SumBefore_Call struct {
+	*mock.Call
+}
+
+// SumBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID int64
+//   - before time.Time
+func (_e *MockTransactionRepository_Expecter) SumBefore(ctx interface{}, accountID interface{}, before interface{}) *MockTransactionRepository_SumBefore_Call {
+	return &MockTransactionRepository_SumBefore_Call{Call: _e.mock.On("SumBefore", ctx, accountID, before)}
+}
+
+func (_c *MockTransactionRepository_SumBefore_Call) Run(run func(ctx context.Context, accountID int64, before time.Time)) *MockTransactionRepository_SumBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_SumBefore_Call) Return(_a0 float64, _a1 error) *MockTransactionRepository_SumBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_SumBefore_Call) RunAndReturn(run func(context.Context, int64, time.Time) (float64, error)) *MockTransactionRepository_SumBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SumByAccountID provides a mock function with given fields: ctx, accountID
+func (_m *MockTransactionRepository) SumByAccountID(ctx context.Context, accountID int64) (float64, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumByAccountID")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (float64, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) float64); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_SumByAccountID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SumByAccountID'
+type MockTransactionRepository_SumByAccountID_Call struct {
+	*mock.Call
+}
+
+// SumByAccountID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID int64
+func (_e *MockTransactionRepository_Expecter) SumByAccountID(ctx interface{}, accountID interface{}) *MockTransactionRepository_SumByAccountID_Call {
+	return &MockTransactionRepository_SumByAccountID_Call{Call: _e.mock.On("SumByAccountID", ctx, accountID)}
+}
+
+func (_c *MockTransactionRepository_SumByAccountID_Call) Run(run func(ctx context.Context, accountID int64)) *MockTransactionRepository_SumByAccountID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_SumByAccountID_Call) Return(_a0 float64, _a1 error) *MockTransactionRepository_SumByAccountID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_SumByAccountID_Call) RunAndReturn(run func(context.Context, int64) (float64, error)) *MockTransactionRepository_SumByAccountID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SumByDirection provides a mock function with given fields: ctx, accountID
+func (_m *MockTransactionRepository) SumByDirection(ctx context.Context, accountID int64) (float64, float64, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumByDirection")
+	}
+
+	var r0 float64
+	var r1 float64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (float64, float64, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) float64); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) float64); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Get(1).(float64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64) error); ok {
+		r2 = rf(ctx, accountID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockTransactionRepository_SumByDirection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SumByDirection'
+type MockTransactionRepository_SumByDirection_Call struct {
+	*mock.Call
+}
+
+// SumByDirection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID int64
+func (_e *MockTransactionRepository_Expecter) SumByDirection(ctx interface{}, accountID interface{}) *MockTransactionRepository_SumByDirection_Call {
+	return &MockTransactionRepository_SumByDirection_Call{Call: _e.mock.On("SumByDirection", ctx, accountID)}
+}
+
+func (_c *MockTransactionRepository_SumByDirection_Call) Run(run func(ctx context.Context, accountID int64)) *MockTransactionRepository_SumByDirection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_SumByDirection_Call) Return(debit float64, credit float64, err error) *MockTransactionRepository_SumByDirection_Call {
+	_c.Call.Return(debit, credit, err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_SumByDirection_Call) RunAndReturn(run func(context.Context, int64) (float64, float64, error)) *MockTransactionRepository_SumByDirection_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,122 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockTx is an autogenerated mock type for the Tx type
+type MockTx struct {
+	mock.Mock
+}
+
+type MockTx_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTx) EXPECT() *MockTx_Expecter {
+	return &MockTx_Expecter{mock: &_m.Mock}
+}
+
+// Commit provides a mock function with no fields
+func (_m *MockTx) Commit() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Commit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTx_Commit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Commit'
+type MockTx_Commit_Call struct {
+	*mock.Call
+}
+
+// Commit is a helper method to define mock.On call
+func (_e *MockTx_Expecter) Commit() *MockTx_Commit_Call {
+	return &MockTx_Commit_Call{Call: _e.mock.On("Commit")}
+}
+
+func (_c *MockTx_Commit_Call) Run(run func()) *MockTx_Commit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTx_Commit_Call) Return(_a0 error) *MockTx_Commit_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTx_Commit_Call) RunAndReturn(run func() error) *MockTx_Commit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rollback provides a mock function with no fields
+func (_m *MockTx) Rollback() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rollback")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTx_Rollback_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rollback'
+type MockTx_Rollback_Call struct {
+	*mock.Call
+}
+
+// Rollback is a helper method to define mock.On call
+func (_e *MockTx_Expecter) Rollback() *MockTx_Rollback_Call {
+	return &MockTx_Rollback_Call{Call: _e.mock.On("Rollback")}
+}
+
+func (_c *MockTx_Rollback_Call) Run(run func()) *MockTx_Rollback_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTx_Rollback_Call) Return(_a0 error) *MockTx_Rollback_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTx_Rollback_Call) RunAndReturn(run func() error) *MockTx_Rollback_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTx creates a new instance of MockTx. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTx(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTx {
+	mock := &MockTx{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,152 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	ports "github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTxProvider is an autogenerated mock type for the TxProvider type
+type MockTxProvider struct {
+	mock.Mock
+}
+
+type MockTxProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTxProvider) EXPECT() *MockTxProvider_Expecter {
+	return &MockTxProvider_Expecter{mock: &_m.Mock}
+}
+
+// Begin provides a mock function with given fields: ctx
+func (_m *MockTxProvider) Begin(ctx context.Context) (context.Context, ports.Tx, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Begin")
+	}
+
+	var r0 context.Context
+	var r1 ports.Tx
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (context.Context, ports.Tx, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) context.Context); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) ports.Tx); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(ports.Tx)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockTxProvider_Begin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Begin'
+type MockTxProvider_Begin_Call struct {
+	*mock.Call
+}
+
+// Begin is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTxProvider_Expecter) Begin(ctx interface{}) *MockTxProvider_Begin_Call {
+	return &MockTxProvider_Begin_Call{Call: _e.mock.On("Begin", ctx)}
+}
+
+func (_c *MockTxProvider_Begin_Call) Run(run func(ctx context.Context)) *MockTxProvider_Begin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockTxProvider_Begin_Call) Return(_a0 context.Context, _a1 ports.Tx, _a2 error) *MockTxProvider_Begin_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockTxProvider_Begin_Call) RunAndReturn(run func(context.Context) (context.Context, ports.Tx, error)) *MockTxProvider_Begin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FromContext provides a mock function with given fields: ctx
+func (_m *MockTxProvider) FromContext(ctx context.Context) ports.Tx {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FromContext")
+	}
+
+	var r0 ports.Tx
+	if rf, ok := ret.Get(0).(func(context.Context) ports.Tx); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ports.Tx)
+		}
+	}
+
+	return r0
+}
+
+// MockTxProvider_FromContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FromContext'
+type MockTxProvider_FromContext_Call struct {
+	*mock.Call
+}
+
+// FromContext is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTxProvider_Expecter) FromContext(ctx interface{}) *MockTxProvider_FromContext_Call {
+	return &MockTxProvider_FromContext_Call{Call: _e.mock.On("FromContext", ctx)}
+}
+
+func (_c *MockTxProvider_FromContext_Call) Run(run func(ctx context.Context)) *MockTxProvider_FromContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockTxProvider_FromContext_Call) Return(_a0 ports.Tx) *MockTxProvider_FromContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTxProvider_FromContext_Call) RunAndReturn(run func(context.Context) ports.Tx) *MockTxProvider_FromContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTxProvider creates a new instance of MockTxProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTxProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTxProvider {
+	mock := &MockTxProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+
+// TransactionMetrics defines the interface for recording transaction business metrics
+type TransactionMetrics interface {
+	// TransactionCreated records a successfully persisted transaction with its normalized amount
+	TransactionCreated(operationType *domain.OperationType, amount float64)
+}
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
 )
@@ -9,8 +10,60 @@ import (
 // TransactionRepository defines the interface for transaction data operations
 type TransactionRepository interface {
 	Create(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error)
+	// CreateIfBalance atomically creates the transaction only if the account balance equals expectedBalance,
+	// returning domain.ErrBalanceChanged otherwise
+	CreateIfBalance(ctx context.Context, transaction *domain.Transaction, expectedBalance float64) (*domain.Transaction, error)
+	// CreateWithDischarge atomically applies the discharges to the debts they pay down and creates the transaction
+	// When expectedBalance is set it is checked like CreateIfBalance; a discharged debt whose balance no longer
+	// matches its Previous value also fails with domain.ErrBalanceChanged
+	CreateWithDischarge(ctx context.Context, transaction *domain.Transaction, discharges []domain.Discharge, expectedBalance *float64) (*domain.Transaction, error)
+	// CreateInstallments atomically creates the installments of a split purchase, linking every one after the
+	// first to it through InstallmentOf; expectedBalance, when set, is checked like CreateIfBalance
+	CreateInstallments(ctx context.Context, installments []*domain.Transaction, expectedBalance *float64) ([]*domain.Transaction, error)
+	// CreateBatch creates all the transactions in a single database transaction; none is created if one fails
+	CreateBatch(ctx context.Context, transactions []*domain.Transaction) ([]*domain.Transaction, error)
 	FindByID(ctx context.Context, id int64) (*domain.Transaction, error)
+	// FindOutstandingByAccountID returns the account's transactions with a negative balance left, oldest first
+	FindOutstandingByAccountID(ctx context.Context, accountID int64) ([]*domain.Transaction, error)
+	// FindByIdempotencyKey returns the account's transaction created with the given Idempotency-Key, or nil when there is none
+	FindByIdempotencyKey(ctx context.Context, accountID int64, key string) (*domain.Transaction, error)
+	// FindReversal returns the transaction reversing the given one, or nil when it was not reversed
+	FindReversal(ctx context.Context, transactionID int64) (*domain.Transaction, error)
+	// FindReversed returns the transaction the given one reverses, or nil when it is not a reversal
+	FindReversed(ctx context.Context, transactionID int64) (*domain.Transaction, error)
+	// FindRecentDuplicate returns the latest transaction with the same account, operation type and amount
+	// created within the given window, or nil when there is none
+	FindRecentDuplicate(ctx context.Context, transaction *domain.Transaction, window time.Duration) (*domain.Transaction, error)
 	FindByAccountID(ctx context.Context, accountID int64) ([]*domain.Transaction, error)
 	GetAll(ctx context.Context) ([]*domain.Transaction, error)
-	FindByAccountIDPaginated(ctx context.Context, accountID int64, limit int64, offset int64) ([]*domain.Transaction, int64, error)
+	FindByAccountIDPaginated(ctx context.Context, accountID int64, limit int64, offset int64) ([]*domain.AccountTransaction, int64, error)
+	// FindByAccountIDPaginatedFiltered is FindByAccountIDPaginated restricted to the transactions matching filter;
+	// the total counts only those
+	FindByAccountIDPaginatedFiltered(ctx context.Context, accountID int64, filter domain.TransactionFilter, limit int64, offset int64) ([]*domain.AccountTransaction, int64, error)
+	// FindAllPaginated returns a page of every account's transactions, newest first, with the overall total
+	FindAllPaginated(ctx context.Context, limit int64, offset int64) ([]*domain.Transaction, int64, error)
+	// StreamByAccountID calls fn for each transaction of the account as rows are read, without buffering them
+	StreamByAccountID(ctx context.Context, accountID int64, fn func(*domain.Transaction) error) error
+	// SumByAccountID returns the current balance of the account, i.e. the sum of all its transactions
+	SumByAccountID(ctx context.Context, accountID int64) (float64, error)
+	// SumByDirection returns the sum of the account's negative amounts (debit) and of its positive amounts (credit)
+	SumByDirection(ctx context.Context, accountID int64) (debit float64, credit float64, err error)
+	// SumBefore returns the sum of the account's transactions dated strictly before the given time
+	SumBefore(ctx context.Context, accountID int64, before time.Time) (float64, error)
+	// SumAsOf returns the balance of the account at the given time, i.e. the sum of its transactions dated up to it
+	SumAsOf(ctx context.Context, accountID int64, asOf time.Time) (float64, error)
+	// FindByAccountIDBetween returns the account's transactions dated within [start, end], oldest first
+	FindByAccountIDBetween(ctx context.Context, accountID int64, start, end time.Time) ([]*domain.Transaction, error)
+	// RunningTotalsBetween returns, for each UTC day with activity within [start, end], the sum of the account's
+	// transactions from start through the end of that day, oldest first
+	RunningTotalsBetween(ctx context.Context, accountID int64, start, end time.Time) ([]*domain.DailyBalance, error)
+	// DailyTotalsBetween groups the account's transactions dated within [start, end] by UTC day, oldest first
+	DailyTotalsBetween(ctx context.Context, accountID int64, start, end time.Time) ([]*domain.DailyTotal, error)
+	// MonthlyTotalsBetween sums the account's debits and credits dated within [start, end] by UTC month, oldest
+	// first; months without transactions are left out
+	MonthlyTotalsBetween(ctx context.Context, accountID int64, start, end time.Time) ([]*domain.MonthlyTotal, error)
+	// ActivityRange returns the first and last event dates of the account's transactions and their count
+	ActivityRange(ctx context.Context, accountID int64) (*domain.ActivityRange, error)
+	// FindRecent returns the latest transactions across all accounts joined with the account document number
+	FindRecent(ctx context.Context, limit int64) ([]*domain.RecentTransaction, error)
 }
@@ -0,0 +1,18 @@
+package ports
+
+import "context"
+
+// Tx is a database transaction started by a TxProvider
+type Tx interface {
+	Commit() error
+	Rollback() error
+}
+
+// TxProvider defines the interface for binding a database transaction to a context, so every repository call
+// made with that context takes part in it and a multi-step operation commits or rolls back as a whole
+type TxProvider interface {
+	// Begin starts a transaction and returns a context carrying it; it fails when ctx already carries one
+	Begin(ctx context.Context) (context.Context, Tx, error)
+	// FromContext returns the transaction carried by ctx, or nil when there is none
+	FromContext(ctx context.Context) Tx
+}
@@ -0,0 +1,22 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// missingAccount explains why the lookups did not find an account: domain.ErrAccountClosed when it was
+// soft-deleted, notFound when it never existed
+func missingAccount(ctx context.Context, accountRepo ports.AccountRepository, accountID int64, notFound error) error {
+	closed, err := accountRepo.IsClosed(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to find account: %w", err)
+	}
+	if closed {
+		return domain.ErrAccountClosed
+	}
+	return notFound
+}
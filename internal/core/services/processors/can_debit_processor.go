@@ -0,0 +1,48 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// CanDebitProcessor previews whether an account can afford a debit without creating it
+type CanDebitProcessor struct {
+	transactionRepo ports.TransactionRepository
+	accountRepo     ports.AccountRepository
+}
+
+// NewCanDebitProcessor creates a new CanDebitProcessor
+func NewCanDebitProcessor(transactionRepo ports.TransactionRepository, accountRepo ports.AccountRepository) *CanDebitProcessor {
+	return &CanDebitProcessor{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+	}
+}
+
+// Process compares the amount with the account's available funds (current balance and limits)
+func (p *CanDebitProcessor) Process(ctx context.Context, req domain.CanDebitRequest) (*domain.CanDebitResponse, error) {
+	account, err := p.accountRepo.FindByID(ctx, req.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find account: %w", err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("account with id %d not found", req.AccountID)
+	}
+
+	balance, err := p.transactionRepo.SumByAccountID(ctx, req.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute balance: %w", err)
+	}
+
+	available := account.AvailableFunds(balance)
+
+	return &domain.CanDebitResponse{
+		AccountID: req.AccountID,
+		Amount:    req.Amount,
+		Allowed:   domain.CanDebit(available, req.Amount),
+		Available: available,
+	}, nil
+}
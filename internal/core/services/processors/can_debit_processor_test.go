@@ -0,0 +1,104 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanDebitProcessor_Process(t *testing.T) {
+	tests := []struct {
+		name           string
+		amount         float64
+		setupMocks     func(*mocks.MockTransactionRepository, *mocks.MockAccountRepository)
+		expectedResult *domain.CanDebitResponse
+		wantErr        string
+	}{
+		{
+			name:   "affordable amount",
+			amount: 50.0,
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+				txRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(120.5, nil).Once()
+			},
+			expectedResult: &domain.CanDebitResponse{AccountID: 1, Amount: 50.0, Allowed: true, Available: 120.5},
+		},
+		{
+			name:   "amount equal to the available funds",
+			amount: 0.3,
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+				txRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(0.1+0.2, nil).Once()
+			},
+			expectedResult: &domain.CanDebitResponse{AccountID: 1, Amount: 0.3, Allowed: true, Available: 0.3},
+		},
+		{
+			name:   "unaffordable amount",
+			amount: 120.51,
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+				txRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(120.5, nil).Once()
+			},
+			expectedResult: &domain.CanDebitResponse{AccountID: 1, Amount: 120.51, Allowed: false, Available: 120.5},
+		},
+		{
+			name:   "negative balance cannot debit",
+			amount: 1.0,
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+				txRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(-10.0, nil).Once()
+			},
+			expectedResult: &domain.CanDebitResponse{AccountID: 1, Amount: 1.0, Allowed: false, Available: -10.0},
+		},
+		{
+			name:   "credit limit extends the available funds",
+			amount: 100.0,
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1, CreditLimit: 200.0}, nil).Once()
+				txRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(-50.0, nil).Once()
+			},
+			expectedResult: &domain.CanDebitResponse{AccountID: 1, Amount: 100.0, Allowed: true, Available: 150.0},
+		},
+		{
+			name:   "account not found",
+			amount: 50.0,
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(nil, nil).Once()
+			},
+			wantErr: "account with id 1 not found",
+		},
+		{
+			name:   "balance error",
+			amount: 50.0,
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+				txRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(0, errors.New("database error")).Once()
+			},
+			wantErr: "failed to compute balance",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			tt.setupMocks(mockTxRepo, mockAccRepo)
+
+			processor := NewCanDebitProcessor(mockTxRepo, mockAccRepo)
+			result, err := processor.Process(context.Background(), domain.CanDebitRequest{AccountID: 1, Amount: tt.amount})
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
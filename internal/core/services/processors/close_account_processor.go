@@ -0,0 +1,65 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// CloseAccountProcessor soft-deletes an account, keeping its transaction history
+// The balance check and the delete share a database transaction, so no transaction lands in between
+type CloseAccountProcessor struct {
+	transactionRepo ports.TransactionRepository
+	accountRepo     ports.AccountRepository
+	txProvider      ports.TxProvider
+}
+
+// NewCloseAccountProcessor creates a new CloseAccountProcessor
+func NewCloseAccountProcessor(transactionRepo ports.TransactionRepository, accountRepo ports.AccountRepository, txProvider ports.TxProvider) *CloseAccountProcessor {
+	return &CloseAccountProcessor{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		txProvider:      txProvider,
+	}
+}
+
+// Process closes the account once its balance is zero, or regardless of it when forced
+// A closed account is no longer found, so it can neither be read nor receive transactions
+func (p *CloseAccountProcessor) Process(ctx context.Context, req domain.CloseAccountRequest) error {
+	return inTransaction(ctx, p.txProvider, func(ctx context.Context) error {
+		return p.close(ctx, req)
+	})
+}
+
+func (p *CloseAccountProcessor) close(ctx context.Context, req domain.CloseAccountRequest) error {
+	account, err := p.accountRepo.FindByID(ctx, req.AccountID)
+	if err != nil {
+		return fmt.Errorf("failed to find account: %w", err)
+	}
+	if account == nil {
+		return fmt.Errorf("account with id %d not found", req.AccountID)
+	}
+
+	if !req.Force {
+		balance, err := p.transactionRepo.SumByAccountID(ctx, req.AccountID)
+		if err != nil {
+			return fmt.Errorf("failed to compute balance: %w", err)
+		}
+		if err := req.CheckClose(balance); err != nil {
+			return err
+		}
+	}
+
+	deleted, err := p.accountRepo.SoftDelete(ctx, req.AccountID)
+	if err != nil {
+		return err
+	}
+	// Closed by a concurrent request since it was found
+	if !deleted {
+		return fmt.Errorf("account with id %d not found", req.AccountID)
+	}
+
+	return nil
+}
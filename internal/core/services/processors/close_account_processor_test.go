@@ -0,0 +1,105 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCloseAccountProcessor_Process(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        domain.CloseAccountRequest
+		setupMocks func(*mocks.MockTransactionRepository, *mocks.MockAccountRepository)
+		wantErr    error
+		wantErrMsg string
+	}{
+		{
+			name: "closes an account with a zero balance",
+			req:  domain.CloseAccountRequest{AccountID: 1},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+				txRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(0.1+0.2-0.3, nil).Once()
+				accRepo.EXPECT().SoftDelete(mock.Anything, int64(1)).Return(true, nil).Once()
+			},
+		},
+		{
+			name: "refuses a nonzero balance",
+			req:  domain.CloseAccountRequest{AccountID: 1},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+				txRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(-42.5, nil).Once()
+			},
+			wantErr: domain.ErrAccountHasBalance,
+		},
+		{
+			name: "force closes regardless of the balance",
+			req:  domain.CloseAccountRequest{AccountID: 1, Force: true},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+				accRepo.EXPECT().SoftDelete(mock.Anything, int64(1)).Return(true, nil).Once()
+			},
+		},
+		{
+			name: "account not found",
+			req:  domain.CloseAccountRequest{AccountID: 1},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(nil, nil).Once()
+			},
+			wantErrMsg: "account with id 1 not found",
+		},
+		{
+			name: "closed concurrently",
+			req:  domain.CloseAccountRequest{AccountID: 1},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+				txRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(0, nil).Once()
+				accRepo.EXPECT().SoftDelete(mock.Anything, int64(1)).Return(false, nil).Once()
+			},
+			wantErrMsg: "account with id 1 not found",
+		},
+		{
+			name: "balance error",
+			req:  domain.CloseAccountRequest{AccountID: 1},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+				txRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(0, errors.New("database error")).Once()
+			},
+			wantErrMsg: "failed to compute balance",
+		},
+		{
+			name: "delete error",
+			req:  domain.CloseAccountRequest{AccountID: 1, Force: true},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+				accRepo.EXPECT().SoftDelete(mock.Anything, int64(1)).Return(false, errors.New("failed to delete account: database error")).Once()
+			},
+			wantErrMsg: "failed to delete account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			tt.setupMocks(mockTxRepo, mockAccRepo)
+
+			processor := NewCloseAccountProcessor(mockTxRepo, mockAccRepo, boundTxProvider(t))
+			err := processor.Process(context.Background(), tt.req)
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.wantErrMsg != "":
+				assert.ErrorContains(t, err, tt.wantErrMsg)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
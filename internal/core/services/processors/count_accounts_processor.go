@@ -0,0 +1,31 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// CountAccountsProcessor counts every account
+type CountAccountsProcessor struct {
+	accountRepo ports.AccountRepository
+}
+
+// NewCountAccountsProcessor creates a new CountAccountsProcessor
+func NewCountAccountsProcessor(accountRepo ports.AccountRepository) *CountAccountsProcessor {
+	return &CountAccountsProcessor{
+		accountRepo: accountRepo,
+	}
+}
+
+// Process returns the number of accounts
+func (p *CountAccountsProcessor) Process(ctx context.Context) (*domain.CountAccountsResponse, error) {
+	count, err := p.accountRepo.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count accounts: %w", err)
+	}
+
+	return &domain.CountAccountsResponse{Count: count}, nil
+}
@@ -0,0 +1,34 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountAccountsProcessor_Process(t *testing.T) {
+	t.Run("returns the count", func(t *testing.T) {
+		accRepo := mocks.NewMockAccountRepository(t)
+		accRepo.EXPECT().Count(mock.Anything).Return(int64(3), nil).Once()
+
+		result, err := NewCountAccountsProcessor(accRepo).Process(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), result.Count)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		accRepo := mocks.NewMockAccountRepository(t)
+		accRepo.EXPECT().Count(mock.Anything).Return(int64(0), errors.New("database error")).Once()
+
+		result, err := NewCountAccountsProcessor(accRepo).Process(context.Background())
+
+		assert.ErrorContains(t, err, "failed to count accounts")
+		assert.Nil(t, result)
+	})
+}
@@ -9,17 +9,48 @@ import (
 )
 
 type CreateAccountProcessor struct {
-	accountRepo ports.AccountRepository
+	accountRepo        ports.AccountRepository
+	allowlist          domain.DocumentNumberAllowlist
+	defaultCreditLimit float64
 }
 
-func NewCreateAccountProcessor(accountRepo ports.AccountRepository) *CreateAccountProcessor {
-	return &CreateAccountProcessor{
+// CreateAccountOption configures optional behavior of the CreateAccountProcessor
+type CreateAccountOption func(*CreateAccountProcessor)
+
+// WithDocumentNumberAllowlist only accepts document numbers starting with one of the allowlist's prefixes
+// (no restriction by default)
+func WithDocumentNumberAllowlist(allowlist domain.DocumentNumberAllowlist) CreateAccountOption {
+	return func(p *CreateAccountProcessor) {
+		p.allowlist = allowlist
+	}
+}
+
+// WithDefaultCreditLimit sets the credit limit of every new account (0 by default)
+func WithDefaultCreditLimit(limit float64) CreateAccountOption {
+	return func(p *CreateAccountProcessor) {
+		p.defaultCreditLimit = limit
+	}
+}
+
+func NewCreateAccountProcessor(accountRepo ports.AccountRepository, opts ...CreateAccountOption) *CreateAccountProcessor {
+	p := &CreateAccountProcessor{
 		accountRepo: accountRepo,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 func (p *CreateAccountProcessor) Process(ctx context.Context, req domain.CreateAccountRequest) (*domain.CreateAccountResponse, error) {
-	account := &domain.Account{DocumentNumber: req.DocumentNumber}
+	if !p.allowlist.Allows(req.DocumentNumber) {
+		return nil, domain.ErrDocumentNumberNotAllowed
+	}
+
+	account := &domain.Account{DocumentNumber: req.DocumentNumber, Tier: req.Tier, CreditLimit: p.defaultCreditLimit}
+	if account.Tier == "" {
+		account.Tier = domain.DefaultAccountTier
+	}
 
 	// Check if account with this document number already exists
 	existing, err := p.accountRepo.FindByDocumentNumber(ctx, req.DocumentNumber)
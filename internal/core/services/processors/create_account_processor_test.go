@@ -10,6 +10,7 @@ import (
 	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateAccountProcessor_Process(t *testing.T) {
@@ -168,3 +169,96 @@ func TestCreateAccountProcessor_Process(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateAccountProcessor_DocumentNumberAllowlist(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowlist      domain.DocumentNumberAllowlist
+		documentNumber string
+		wantAllowed    bool
+	}{
+		{
+			name:           "no allowlist accepts any document number",
+			documentNumber: "12345678900",
+			wantAllowed:    true,
+		},
+		{
+			name:           "document number with an allowed prefix",
+			allowlist:      domain.DocumentNumberAllowlist{"000", "999"},
+			documentNumber: "99912345678",
+			wantAllowed:    true,
+		},
+		{
+			name:           "document number without an allowed prefix",
+			allowlist:      domain.DocumentNumberAllowlist{"000", "999"},
+			documentNumber: "12345678900",
+			wantAllowed:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockAccountRepository(t)
+			if tt.wantAllowed {
+				mockRepo.EXPECT().
+					FindByDocumentNumber(mock.Anything, tt.documentNumber).
+					Return(nil, nil).
+					Once()
+				mockRepo.EXPECT().
+					Create(mock.Anything, mock.Anything).
+					Return(&domain.Account{ID: 1, DocumentNumber: tt.documentNumber}, nil).
+					Once()
+			}
+
+			processor := NewCreateAccountProcessor(mockRepo, WithDocumentNumberAllowlist(tt.allowlist))
+
+			result, err := processor.Process(context.Background(), domain.CreateAccountRequest{DocumentNumber: tt.documentNumber})
+
+			if tt.wantAllowed {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.documentNumber, result.Account.DocumentNumber)
+			} else {
+				assert.ErrorIs(t, err, domain.ErrDocumentNumberNotAllowed)
+				assert.Nil(t, result)
+			}
+		})
+	}
+}
+
+func TestCreateAccountProcessor_DefaultCreditLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []CreateAccountOption
+		wantLimit float64
+	}{
+		{
+			name:      "no credit by default",
+			wantLimit: 0,
+		},
+		{
+			name:      "configured default",
+			opts:      []CreateAccountOption{WithDefaultCreditLimit(500)},
+			wantLimit: 500,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockAccountRepository(t)
+			mockRepo.EXPECT().FindByDocumentNumber(mock.Anything, "12345678900").Return(nil, nil).Once()
+			mockRepo.EXPECT().
+				Create(mock.Anything, mock.MatchedBy(func(account *domain.Account) bool {
+					return account.CreditLimit == tt.wantLimit
+				})).
+				Return(&domain.Account{ID: 1, DocumentNumber: "12345678900", CreditLimit: tt.wantLimit}, nil).
+				Once()
+
+			processor := NewCreateAccountProcessor(mockRepo, tt.opts...)
+
+			result, err := processor.Process(context.Background(), domain.CreateAccountRequest{DocumentNumber: "12345678900"})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLimit, result.Account.CreditLimit)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package processors
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -14,35 +15,189 @@ type CreateTransactionProcessor struct {
 	transactionRepo   ports.TransactionRepository
 	accountRepo       ports.AccountRepository
 	operationTypeRepo ports.OperationTypeRepository
+	metrics           ports.TransactionMetrics
+	permissions       domain.OperationPermissions
+	duplicateWindow   time.Duration
+	installmentPolicy domain.InstallmentPolicy
+	maxRowsPerRequest int64
+	reverification    domain.ReverificationPolicy
+	enforceLimit      bool
+	magicAmounts      domain.MagicAmounts
+}
+
+// CreateTransactionOption configures optional behavior of the CreateTransactionProcessor
+type CreateTransactionOption func(*CreateTransactionProcessor)
+
+// WithTransactionMetrics records a metric for every successfully created transaction
+func WithTransactionMetrics(metrics ports.TransactionMetrics) CreateTransactionOption {
+	return func(p *CreateTransactionProcessor) {
+		p.metrics = metrics
+	}
+}
+
+// WithOperationPermissions restricts the operation types available to each account tier
+func WithOperationPermissions(permissions domain.OperationPermissions) CreateTransactionOption {
+	return func(p *CreateTransactionProcessor) {
+		p.permissions = permissions
+	}
+}
+
+// WithDuplicateWindow rejects a transaction identical to one created within the window unless it is forced
+// A zero window disables the check
+func WithDuplicateWindow(window time.Duration) CreateTransactionOption {
+	return func(p *CreateTransactionProcessor) {
+		p.duplicateWindow = window
+	}
+}
+
+// WithInstallmentPolicy bounds the installment count and per-installment amount of split purchases
+func WithInstallmentPolicy(policy domain.InstallmentPolicy) CreateTransactionOption {
+	return func(p *CreateTransactionProcessor) {
+		p.installmentPolicy = policy
+	}
+}
+
+// WithMaxRowsPerRequest caps the transaction rows (installments included) one request may create
+func WithMaxRowsPerRequest(maxRows int64) CreateTransactionOption {
+	return func(p *CreateTransactionProcessor) {
+		p.maxRowsPerRequest = maxRows
+	}
+}
+
+// WithReverificationPolicy rejects transactions on never verified accounts older than the policy allows
+func WithReverificationPolicy(policy domain.ReverificationPolicy) CreateTransactionOption {
+	return func(p *CreateTransactionProcessor) {
+		p.reverification = policy
+	}
+}
+
+// WithCreditLimitEnforcement rejects debits that would take the balance below the account's credit limit
+func WithCreditLimitEnforcement(enabled bool) CreateTransactionOption {
+	return func(p *CreateTransactionProcessor) {
+		p.enforceLimit = enabled
+	}
+}
+
+// WithMagicAmounts makes transactions of the given sandbox amounts fail with their simulated outcome
+// before anything is checked or stored; only configure it in sandbox environments
+func WithMagicAmounts(amounts domain.MagicAmounts) CreateTransactionOption {
+	return func(p *CreateTransactionProcessor) {
+		p.magicAmounts = amounts
+	}
 }
 
 // NewCreateTransactionProcessor creates a new CreateTransactionProcessor
-func NewCreateTransactionProcessor(transactionRepo ports.TransactionRepository, accountRepo ports.AccountRepository, operationTypeRepo ports.OperationTypeRepository) *CreateTransactionProcessor {
-	return &CreateTransactionProcessor{
+func NewCreateTransactionProcessor(transactionRepo ports.TransactionRepository, accountRepo ports.AccountRepository, operationTypeRepo ports.OperationTypeRepository, opts ...CreateTransactionOption) *CreateTransactionProcessor {
+	p := &CreateTransactionProcessor{
 		transactionRepo:   transactionRepo,
 		accountRepo:       accountRepo,
 		operationTypeRepo: operationTypeRepo,
+		installmentPolicy: domain.DefaultInstallmentPolicy(),
+		maxRowsPerRequest: domain.DefaultMaxRowsPerRequest,
+	}
+
+	for _, opt := range opts {
+		opt(p)
 	}
+
+	return p
 }
 
 // Process creates a new transaction with proper amount normalization
 func (p *CreateTransactionProcessor) Process(ctx context.Context, req domain.CreateTransactionRequest) (*domain.CreateTransactionResponse, error) {
+	// Sandbox magic amounts answer with their simulated outcome instead of being processed
+	if err := p.magicAmounts.Outcome(req.Amount); err != nil {
+		return nil, err
+	}
+
+	transaction, operationType, account, err := p.prepare(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Catch double-submits from clients that do not send idempotency keys
+	if p.duplicateWindow > 0 && !req.Force {
+		duplicate, err := p.transactionRepo.FindRecentDuplicate(ctx, transaction, p.duplicateWindow)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for duplicate transaction: %w", err)
+		}
+		if duplicate != nil {
+			return nil, &domain.DuplicateTransactionError{ExistingTransactionID: duplicate.ID}
+		}
+	}
+
+	created, err := p.store(ctx, transaction, operationType, account, req.InstallmentCount(), req.ExpectedBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build response
+	first := created[0]
+	response := &domain.CreateTransactionResponse{
+		TransactionID:   first.ID,
+		AccountID:       first.AccountID,
+		OperationTypeID: first.OperationTypeID,
+		Amount:          first.Amount,
+		EventDate:       first.EventDate,
+	}
+	if len(created) > 1 {
+		response.Amount = transaction.Amount
+		for _, installment := range created {
+			response.TransactionIDs = append(response.TransactionIDs, installment.ID)
+		}
+	}
+
+	return response, nil
+}
+
+// prepare applies every business rule to the request and returns the normalized transaction to store,
+// with the operation type and account it was checked against
+func (p *CreateTransactionProcessor) prepare(ctx context.Context, req domain.CreateTransactionRequest) (*domain.Transaction, *domain.OperationType, *domain.Account, error) {
+	// Bound the rows written by a single request before touching the database
+	if domain.RowsForRequests(req) > p.maxRowsPerRequest {
+		return nil, nil, nil, domain.ErrTooManyRowsPerRequest
+	}
+
 	// Validate account exists
 	account, err := p.accountRepo.FindByID(ctx, req.AccountID)
 	if err != nil {
-		return nil, fmt.Errorf("account not found: %w", err)
+		return nil, nil, nil, fmt.Errorf("account not found: %w", err)
 	}
 	if account == nil {
-		return nil, fmt.Errorf("account with id %d does not exist", req.AccountID)
+		return nil, nil, nil, missingAccount(ctx, p.accountRepo, req.AccountID, fmt.Errorf("account with id %d does not exist", req.AccountID))
+	}
+
+	// Long-standing accounts must have been verified before they may transact
+	if err := p.reverification.Check(account, time.Now().UTC()); err != nil {
+		return nil, nil, nil, err
 	}
 
 	// Validate operation type exists
 	operationType, err := p.operationTypeRepo.FindByID(ctx, req.OperationTypeID)
 	if err != nil {
-		return nil, fmt.Errorf("operation type not found: %w", err)
+		return nil, nil, nil, fmt.Errorf("operation type not found: %w", err)
 	}
 	if operationType == nil {
-		return nil, domain.ErrInvalidOperationType
+		return nil, nil, nil, domain.ErrInvalidOperationType
+	}
+
+	// Validate the account tier may perform this operation
+	if !p.permissions.Allows(account.Tier, operationType.ID) {
+		return nil, nil, nil, domain.ErrOperationNotPermitted
+	}
+
+	// Only purchases with installments may be split, within the installment policy
+	if err := p.validateInstallments(req, operationType); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Backfilled transactions must not predate the account
+	eventDate := time.Now().UTC()
+	if req.EventDate != nil {
+		eventDate = req.EventDate.UTC()
+		if eventDate.Before(account.CreatedAt) {
+			return nil, nil, nil, domain.ErrEventDateBeforeAccountCreation
+		}
 	}
 
 	// Create transaction entity
@@ -50,31 +205,130 @@ func (p *CreateTransactionProcessor) Process(ctx context.Context, req domain.Cre
 		AccountID:       req.AccountID,
 		OperationTypeID: req.OperationTypeID,
 		Amount:          req.Amount,
-		EventDate:       time.Now().UTC(),
+		EventDate:       eventDate,
+		IdempotencyKey:  req.IdempotencyKey,
 	}
 
 	// Validate transaction
 	if err := transaction.Validate(); err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	// Normalize amount based on operation type
 	if err := transaction.NormalizeAmount(operationType); err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
-	// Save transaction
-	createdTransaction, err := p.transactionRepo.Create(ctx, transaction)
+	// Debts start fully outstanding; credits are settled against them in store
+	transaction.Balance = transaction.Amount
+
+	return transaction, operationType, account, nil
+}
+
+// store saves a prepared transaction, letting credits pay down the oldest outstanding debts first
+// A purchase with more than one installment is split and saved as linked installments; every saved row is returned.
+// When expectedBalance is set the transaction is only saved if the account balance still matches it
+func (p *CreateTransactionProcessor) store(ctx context.Context, transaction *domain.Transaction, operationType *domain.OperationType, account *domain.Account, installments int64, expectedBalance *float64) ([]*domain.Transaction, error) {
+	// The debit is only saved if the balance it was checked against is still current,
+	// so concurrent debits cannot overdraw the limit together
+	if p.enforceLimit && operationType.IsDebitOperation() {
+		balance, err := p.checkCreditLimit(ctx, transaction, account, expectedBalance)
+		if err != nil {
+			return nil, err
+		}
+		expectedBalance = &balance
+	}
+
+	var discharges []domain.Discharge
+	if operationType.IsCreditOperation() {
+		outstanding, err := p.transactionRepo.FindOutstandingByAccountID(ctx, transaction.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find outstanding transactions: %w", err)
+		}
+		discharges, transaction.Balance = domain.DischargeDebts(transaction.Amount, outstanding)
+	}
+
+	if installments > 1 {
+		return p.storeInstallments(ctx, domain.SplitInstallments(transaction, installments), operationType, expectedBalance)
+	}
+
+	var createdTransaction *domain.Transaction
+	var err error
+	switch {
+	case len(discharges) > 0:
+		createdTransaction, err = p.transactionRepo.CreateWithDischarge(ctx, transaction, discharges, expectedBalance)
+	case expectedBalance != nil:
+		createdTransaction, err = p.transactionRepo.CreateIfBalance(ctx, transaction, *expectedBalance)
+	default:
+		createdTransaction, err = p.transactionRepo.Create(ctx, transaction)
+	}
 	if err != nil {
+		if errors.Is(err, domain.ErrBalanceChanged) {
+			return nil, domain.ErrBalanceChanged
+		}
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
-	// Build response
-	return &domain.CreateTransactionResponse{
-		TransactionID:   createdTransaction.ID,
-		AccountID:       createdTransaction.AccountID,
-		OperationTypeID: createdTransaction.OperationTypeID,
-		Amount:          createdTransaction.Amount,
-		EventDate:       createdTransaction.EventDate,
-	}, nil
+	p.recordCreated(operationType, createdTransaction.Amount)
+
+	return []*domain.Transaction{createdTransaction}, nil
+}
+
+// storeInstallments saves the installments of a split purchase together
+func (p *CreateTransactionProcessor) storeInstallments(ctx context.Context, installments []*domain.Transaction, operationType *domain.OperationType, expectedBalance *float64) ([]*domain.Transaction, error) {
+	created, err := p.transactionRepo.CreateInstallments(ctx, installments, expectedBalance)
+	if err != nil {
+		if errors.Is(err, domain.ErrBalanceChanged) {
+			return nil, domain.ErrBalanceChanged
+		}
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	for _, installment := range created {
+		p.recordCreated(operationType, installment.Amount)
+	}
+
+	return created, nil
+}
+
+// checkCreditLimit returns the balance the debit was checked against, or ErrInsufficientLimit when the debit
+// exceeds the funds of account, as loaded by prepare; a given expectedBalance is used instead of the current balance
+func (p *CreateTransactionProcessor) checkCreditLimit(ctx context.Context, transaction *domain.Transaction, account *domain.Account, expectedBalance *float64) (float64, error) {
+	var balance float64
+	if expectedBalance != nil {
+		balance = *expectedBalance
+	} else {
+		var err error
+		balance, err = p.transactionRepo.SumByAccountID(ctx, transaction.AccountID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to compute balance: %w", err)
+		}
+	}
+
+	if !domain.CanDebit(account.AvailableFunds(balance), transaction.Amount) {
+		return 0, domain.ErrInsufficientLimit
+	}
+
+	return balance, nil
+}
+
+// recordCreated reports a persisted transaction to the metrics, when configured
+func (p *CreateTransactionProcessor) recordCreated(operationType *domain.OperationType, amount float64) {
+	if p.metrics != nil {
+		p.metrics.TransactionCreated(operationType, amount)
+	}
+}
+
+// validateInstallments checks the requested installments against the operation type and the policy
+func (p *CreateTransactionProcessor) validateInstallments(req domain.CreateTransactionRequest, operationType *domain.OperationType) error {
+	installments := req.InstallmentCount()
+
+	if operationType.ID != domain.OperationTypePurchaseWithInstallments {
+		if installments > 1 {
+			return domain.ErrInstallmentsNotAllowed
+		}
+		return nil
+	}
+
+	return p.installmentPolicy.Validate(req.Amount, installments)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateTransactionProcessor_Process(t *testing.T) {
@@ -92,9 +93,15 @@ func TestCreateTransactionProcessor_Process(t *testing.T) {
 					Return(&domain.OperationType{
 						ID:          domain.OperationTypeCreditVoucher,
 						Description: "Credit Voucher",
+						IsCredit:    true,
 					}, nil).
 					Once()
 
+				mockTxRepo.EXPECT().
+					FindOutstandingByAccountID(mock.Anything, int64(1)).
+					Return(nil, nil).
+					Once()
+
 				mockTxRepo.EXPECT().
 					Create(mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
 						return tx.Amount == 100.0 // Should be positive
@@ -125,10 +132,35 @@ func TestCreateTransactionProcessor_Process(t *testing.T) {
 					FindByID(mock.Anything, int64(999)).
 					Return(nil, nil).
 					Once()
+				mockAccRepo.EXPECT().
+					IsClosed(mock.Anything, int64(999)).
+					Return(false, nil).
+					Once()
 			},
 			wantErr:        true,
 			wantErrMessage: "account with id 999 does not exist",
 		},
+		{
+			name: "closed account",
+			request: domain.CreateTransactionRequest{
+				AccountID:       int64(7),
+				OperationTypeID: domain.OperationTypePurchase,
+				Amount:          50.0,
+			},
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository, mockAccRepo *mocks.MockAccountRepository, mockOpRepo *mocks.MockOperationTypeRepository) {
+				// Soft-deleted accounts are not found, but are told apart from unknown ones
+				mockAccRepo.EXPECT().
+					FindByID(mock.Anything, int64(7)).
+					Return(nil, nil).
+					Once()
+				mockAccRepo.EXPECT().
+					IsClosed(mock.Anything, int64(7)).
+					Return(true, nil).
+					Once()
+			},
+			wantErr:        true,
+			wantErrMessage: "account is closed",
+		},
 		{
 			name: "invalid operation type",
 			request: domain.CreateTransactionRequest{
@@ -148,7 +180,7 @@ func TestCreateTransactionProcessor_Process(t *testing.T) {
 					Once()
 			},
 			wantErr:        true,
-			wantErrMessage: "operation_type_id must be between 1 and 4",
+			wantErrMessage: "operation_type_id is not a known operation type",
 		},
 		{
 			name: "withdrawal transaction (negative)",
@@ -256,3 +288,916 @@ func TestCreateTransactionProcessor_Process(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateTransactionProcessor_RecordsMetrics(t *testing.T) {
+	mockTxRepo := mocks.NewMockTransactionRepository(t)
+	mockAccRepo := mocks.NewMockAccountRepository(t)
+	mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+	mockMetrics := mocks.NewMockTransactionMetrics(t)
+
+	withdrawal := &domain.OperationType{ID: domain.OperationTypeWithdrawal, Description: "Withdrawal"}
+
+	mockAccRepo.EXPECT().
+		FindByID(mock.Anything, int64(1)).
+		Return(&domain.Account{ID: int64(1)}, nil).
+		Once()
+
+	mockOpRepo.EXPECT().
+		FindByID(mock.Anything, int64(domain.OperationTypeWithdrawal)).
+		Return(withdrawal, nil).
+		Once()
+
+	mockTxRepo.EXPECT().
+		Create(mock.Anything, mock.Anything).
+		Return(&domain.Transaction{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypeWithdrawal, Amount: -30.0}, nil).
+		Once()
+
+	// Metric recorded with the withdrawal type and the normalized amount
+	mockMetrics.EXPECT().
+		TransactionCreated(withdrawal, -30.0).
+		Once()
+
+	processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo, WithTransactionMetrics(mockMetrics))
+
+	_, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+		AccountID:       1,
+		OperationTypeID: domain.OperationTypeWithdrawal,
+		Amount:          30.0,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestCreateTransactionProcessor_DoesNotRecordMetricsOnFailure(t *testing.T) {
+	mockTxRepo := mocks.NewMockTransactionRepository(t)
+	mockAccRepo := mocks.NewMockAccountRepository(t)
+	mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+	mockMetrics := mocks.NewMockTransactionMetrics(t)
+
+	mockAccRepo.EXPECT().
+		FindByID(mock.Anything, int64(1)).
+		Return(&domain.Account{ID: int64(1)}, nil).
+		Once()
+
+	mockOpRepo.EXPECT().
+		FindByID(mock.Anything, int64(domain.OperationTypePurchase)).
+		Return(&domain.OperationType{ID: domain.OperationTypePurchase}, nil).
+		Once()
+
+	mockTxRepo.EXPECT().
+		Create(mock.Anything, mock.Anything).
+		Return(nil, errors.New("database insert failed")).
+		Once()
+
+	processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo, WithTransactionMetrics(mockMetrics))
+
+	_, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+		AccountID:       1,
+		OperationTypeID: domain.OperationTypePurchase,
+		Amount:          30.0,
+	})
+
+	assert.Error(t, err)
+	mockMetrics.AssertNotCalled(t, "TransactionCreated", mock.Anything, mock.Anything)
+}
+
+func TestCreateTransactionProcessor_OperationPermissions(t *testing.T) {
+	permissions := domain.OperationPermissions{
+		"basic": {domain.OperationTypePurchase, domain.OperationTypePurchaseWithInstallments, domain.OperationTypeCreditVoucher},
+	}
+
+	tests := []struct {
+		name            string
+		tier            string
+		operationTypeID int64
+		wantErr         error
+	}{
+		{name: "basic tier blocks withdrawals", tier: "basic", operationTypeID: domain.OperationTypeWithdrawal, wantErr: domain.ErrOperationNotPermitted},
+		{name: "basic tier allows purchases", tier: "basic", operationTypeID: domain.OperationTypePurchase},
+		{name: "default tier allows withdrawals", tier: domain.DefaultAccountTier, operationTypeID: domain.OperationTypeWithdrawal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+			mockAccRepo.EXPECT().
+				FindByID(mock.Anything, int64(1)).
+				Return(&domain.Account{ID: 1, Tier: tt.tier}, nil).
+				Once()
+
+			mockOpRepo.EXPECT().
+				FindByID(mock.Anything, tt.operationTypeID).
+				Return(&domain.OperationType{ID: tt.operationTypeID}, nil).
+				Once()
+
+			if tt.wantErr == nil {
+				mockTxRepo.EXPECT().
+					Create(mock.Anything, mock.Anything).
+					Return(&domain.Transaction{ID: 1, AccountID: 1, OperationTypeID: tt.operationTypeID, Amount: -10.0}, nil).
+					Once()
+			}
+
+			processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo, WithOperationPermissions(permissions))
+
+			_, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+				AccountID:       1,
+				OperationTypeID: tt.operationTypeID,
+				Amount:          10.0,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateTransactionProcessor_EventDate(t *testing.T) {
+	accountCreatedAt := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		eventDate time.Time
+		wantErr   error
+	}{
+		{name: "before account creation", eventDate: accountCreatedAt.Add(-time.Second), wantErr: domain.ErrEventDateBeforeAccountCreation},
+		{name: "equal to account creation", eventDate: accountCreatedAt},
+		{name: "after account creation", eventDate: accountCreatedAt.Add(24 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+			mockAccRepo.EXPECT().
+				FindByID(mock.Anything, int64(1)).
+				Return(&domain.Account{ID: 1, CreatedAt: accountCreatedAt}, nil).
+				Once()
+
+			mockOpRepo.EXPECT().
+				FindByID(mock.Anything, int64(domain.OperationTypePurchase)).
+				Return(&domain.OperationType{ID: domain.OperationTypePurchase}, nil).
+				Once()
+
+			if tt.wantErr == nil {
+				mockTxRepo.EXPECT().
+					Create(mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+						return tx.EventDate.Equal(tt.eventDate)
+					})).
+					RunAndReturn(func(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error) {
+						created := *tx
+						created.ID = 1
+						return &created, nil
+					}).
+					Once()
+			}
+
+			processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo)
+
+			eventDate := tt.eventDate
+			result, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+				AccountID:       1,
+				OperationTypeID: domain.OperationTypePurchase,
+				Amount:          10.0,
+				EventDate:       &eventDate,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, result.EventDate.Equal(tt.eventDate))
+		})
+	}
+}
+
+func TestCreateTransactionProcessor_ExpectedBalance(t *testing.T) {
+	expected := 100.0
+
+	tests := []struct {
+		name       string
+		setupMocks func(*mocks.MockTransactionRepository)
+		wantErr    error
+	}{
+		{
+			name: "expected balance matches",
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository) {
+				mockTxRepo.EXPECT().
+					CreateIfBalance(mock.Anything, mock.Anything, expected).
+					Return(&domain.Transaction{ID: 1, AccountID: 1, OperationTypeID: 4, Amount: 25.0}, nil).
+					Once()
+			},
+		},
+		{
+			name: "expected balance mismatches",
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository) {
+				mockTxRepo.EXPECT().
+					CreateIfBalance(mock.Anything, mock.Anything, expected).
+					Return(nil, domain.ErrBalanceChanged).
+					Once()
+			},
+			wantErr: domain.ErrBalanceChanged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+			mockAccRepo.EXPECT().
+				FindByID(mock.Anything, int64(1)).
+				Return(&domain.Account{ID: 1}, nil).
+				Once()
+
+			mockOpRepo.EXPECT().
+				FindByID(mock.Anything, int64(domain.OperationTypeCreditVoucher)).
+				Return(&domain.OperationType{ID: domain.OperationTypeCreditVoucher, IsCredit: true}, nil).
+				Once()
+
+			mockTxRepo.EXPECT().
+				FindOutstandingByAccountID(mock.Anything, int64(1)).
+				Return(nil, nil).
+				Once()
+
+			tt.setupMocks(mockTxRepo)
+
+			processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo)
+
+			balance := expected
+			result, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+				AccountID:       1,
+				OperationTypeID: domain.OperationTypeCreditVoucher,
+				Amount:          25.0,
+				ExpectedBalance: &balance,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), result.TransactionID)
+			}
+			mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateTransactionProcessor_DuplicateWindow(t *testing.T) {
+	window := 10 * time.Second
+
+	tests := []struct {
+		name       string
+		force      bool
+		setupMocks func(*mocks.MockTransactionRepository)
+		wantErr    error
+		wantID     int64
+	}{
+		{
+			name: "identical transaction within window is rejected",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository) {
+				txRepo.EXPECT().
+					FindRecentDuplicate(mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+						return tx.Amount == -10.0
+					}), window).
+					Return(&domain.Transaction{ID: 7}, nil).
+					Once()
+			},
+			wantErr: domain.ErrPossibleDuplicateTransaction,
+			wantID:  7,
+		},
+		{
+			name: "distinct transaction is created",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository) {
+				txRepo.EXPECT().FindRecentDuplicate(mock.Anything, mock.Anything, window).Return(nil, nil).Once()
+				txRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(&domain.Transaction{ID: 8, Amount: -10.0}, nil).Once()
+			},
+			wantID: 8,
+		},
+		{
+			name:  "force skips the duplicate check",
+			force: true,
+			setupMocks: func(txRepo *mocks.MockTransactionRepository) {
+				txRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(&domain.Transaction{ID: 9, Amount: -10.0}, nil).Once()
+			},
+			wantID: 9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+			mockAccRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+			mockOpRepo.EXPECT().
+				FindByID(mock.Anything, int64(domain.OperationTypePurchase)).
+				Return(&domain.OperationType{ID: domain.OperationTypePurchase}, nil).
+				Once()
+			tt.setupMocks(mockTxRepo)
+
+			processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo, WithDuplicateWindow(window))
+
+			result, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+				AccountID:       1,
+				OperationTypeID: domain.OperationTypePurchase,
+				Amount:          10.0,
+				Force:           tt.force,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				var duplicateErr *domain.DuplicateTransactionError
+				require.ErrorAs(t, err, &duplicateErr)
+				assert.Equal(t, tt.wantID, duplicateErr.ExistingTransactionID)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, result.TransactionID)
+		})
+	}
+}
+
+func TestCreateTransactionProcessor_DuplicateWindowDisabledByDefault(t *testing.T) {
+	mockTxRepo := mocks.NewMockTransactionRepository(t)
+	mockAccRepo := mocks.NewMockAccountRepository(t)
+	mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+	mockAccRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+	mockOpRepo.EXPECT().
+		FindByID(mock.Anything, int64(domain.OperationTypePurchase)).
+		Return(&domain.OperationType{ID: domain.OperationTypePurchase}, nil).
+		Once()
+	mockTxRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(&domain.Transaction{ID: 1, Amount: -10.0}, nil).Once()
+
+	processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo)
+
+	_, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+		AccountID:       1,
+		OperationTypeID: domain.OperationTypePurchase,
+		Amount:          10.0,
+	})
+
+	require.NoError(t, err)
+	mockTxRepo.AssertNotCalled(t, "FindRecentDuplicate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateTransactionProcessor_Installments(t *testing.T) {
+	policy := domain.InstallmentPolicy{MaxInstallments: 12, MinInstallmentAmount: 5.0}
+
+	tests := []struct {
+		name            string
+		operationTypeID int64
+		amount          float64
+		installments    int64
+		wantErr         error
+	}{
+		{name: "at the maximum installment count", operationTypeID: domain.OperationTypePurchaseWithInstallments, amount: 600.0, installments: 12},
+		{name: "above the maximum installment count", operationTypeID: domain.OperationTypePurchaseWithInstallments, amount: 600.0, installments: 13, wantErr: domain.ErrTooManyInstallments},
+		{name: "installment exactly at the minimum", operationTypeID: domain.OperationTypePurchaseWithInstallments, amount: 60.0, installments: 12},
+		{name: "installment below the minimum", operationTypeID: domain.OperationTypePurchaseWithInstallments, amount: 59.99, installments: 12, wantErr: domain.ErrInstallmentBelowMinimum},
+		{name: "installments on a normal purchase", operationTypeID: domain.OperationTypePurchase, amount: 600.0, installments: 2, wantErr: domain.ErrInstallmentsNotAllowed},
+		{name: "single installment on a normal purchase", operationTypeID: domain.OperationTypePurchase, amount: 600.0, installments: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+			mockAccRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+			mockOpRepo.EXPECT().
+				FindByID(mock.Anything, tt.operationTypeID).
+				Return(&domain.OperationType{ID: tt.operationTypeID}, nil).
+				Once()
+			if tt.wantErr == nil && tt.installments > 1 {
+				mockTxRepo.EXPECT().
+					CreateInstallments(mock.Anything, mock.Anything, (*float64)(nil)).
+					RunAndReturn(func(_ context.Context, installments []*domain.Transaction, _ *float64) ([]*domain.Transaction, error) {
+						return installments, nil
+					}).
+					Once()
+			} else if tt.wantErr == nil {
+				mockTxRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(&domain.Transaction{ID: 1, Amount: -tt.amount}, nil).Once()
+			}
+
+			processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo, WithInstallmentPolicy(policy))
+
+			installments := tt.installments
+			_, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+				AccountID:       1,
+				OperationTypeID: tt.operationTypeID,
+				Amount:          tt.amount,
+				Installments:    &installments,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCreateTransactionProcessor_SplitsInstallments(t *testing.T) {
+	eventDate := time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		amount       float64
+		installments int64
+		wantAmounts  []float64
+	}{
+		{name: "even split", amount: 300.0, installments: 3, wantAmounts: []float64{-100.0, -100.0, -100.0}},
+		{name: "uneven split puts the remainder on the first installment", amount: 100.0, installments: 3, wantAmounts: []float64{-33.34, -33.33, -33.33}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+			mockAccRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+			mockOpRepo.EXPECT().
+				FindByID(mock.Anything, int64(domain.OperationTypePurchaseWithInstallments)).
+				Return(&domain.OperationType{ID: domain.OperationTypePurchaseWithInstallments}, nil).
+				Once()
+
+			var stored []*domain.Transaction
+			mockTxRepo.EXPECT().
+				CreateInstallments(mock.Anything, mock.Anything, (*float64)(nil)).
+				RunAndReturn(func(_ context.Context, installments []*domain.Transaction, _ *float64) ([]*domain.Transaction, error) {
+					stored = installments
+					created := make([]*domain.Transaction, len(installments))
+					for i, installment := range installments {
+						saved := *installment
+						saved.ID = int64(i + 10)
+						created[i] = &saved
+					}
+					return created, nil
+				}).
+				Once()
+
+			processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo)
+			installments := tt.installments
+			result, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+				AccountID:       1,
+				OperationTypeID: domain.OperationTypePurchaseWithInstallments,
+				Amount:          tt.amount,
+				EventDate:       &eventDate,
+				Installments:    &installments,
+				IdempotencyKey:  "purchase-key",
+			})
+
+			require.NoError(t, err)
+			require.Len(t, stored, len(tt.wantAmounts))
+			for i, installment := range stored {
+				assert.Equal(t, tt.wantAmounts[i], installment.Amount)
+				assert.Equal(t, tt.wantAmounts[i], installment.Balance, "Each installment starts fully outstanding")
+			}
+			assert.Equal(t, eventDate, stored[0].EventDate)
+			assert.Equal(t, time.Date(2025, 2, 28, 10, 0, 0, 0, time.UTC), stored[1].EventDate, "Clamped to the end of a shorter month")
+			assert.Equal(t, time.Date(2025, 3, 31, 10, 0, 0, 0, time.UTC), stored[2].EventDate)
+			assert.Equal(t, "purchase-key", stored[0].IdempotencyKey)
+			assert.Empty(t, stored[1].IdempotencyKey, "Only the first installment carries the key")
+
+			assert.Equal(t, int64(10), result.TransactionID)
+			assert.Equal(t, []int64{10, 11, 12}, result.TransactionIDs)
+			assert.Equal(t, -tt.amount, result.Amount, "The response reports the whole purchase")
+		})
+	}
+}
+
+func TestCreateTransactionProcessor_MaxRowsPerRequest(t *testing.T) {
+	mockTxRepo := mocks.NewMockTransactionRepository(t)
+	mockAccRepo := mocks.NewMockAccountRepository(t)
+	mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+	processor := NewCreateTransactionProcessor(
+		mockTxRepo,
+		mockAccRepo,
+		mockOpRepo,
+		WithInstallmentPolicy(domain.InstallmentPolicy{MaxInstallments: 48}),
+		WithMaxRowsPerRequest(24),
+	)
+
+	// Allowed by the installment policy, but over the row cap; rejected before any query
+	installments := int64(48)
+	_, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+		AccountID:       1,
+		OperationTypeID: domain.OperationTypePurchaseWithInstallments,
+		Amount:          4800.0,
+		Installments:    &installments,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrTooManyRowsPerRequest)
+}
+
+func TestCreateTransactionProcessor_RejectsAmountAboveMaximum(t *testing.T) {
+	mockTxRepo := mocks.NewMockTransactionRepository(t)
+	mockAccRepo := mocks.NewMockAccountRepository(t)
+	mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+	mockAccRepo.EXPECT().
+		FindByID(mock.Anything, int64(1)).
+		Return(&domain.Account{ID: 1}, nil).
+		Once()
+	mockOpRepo.EXPECT().
+		FindByID(mock.Anything, int64(domain.OperationTypePurchase)).
+		Return(&domain.OperationType{ID: domain.OperationTypePurchase}, nil).
+		Once()
+
+	processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo)
+	_, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+		AccountID:       1,
+		OperationTypeID: domain.OperationTypePurchase,
+		Amount:          1e12,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrAmountTooLarge)
+}
+
+func TestCreateTransactionProcessor_StoresIdempotencyKey(t *testing.T) {
+	mockTxRepo := mocks.NewMockTransactionRepository(t)
+	mockAccRepo := mocks.NewMockAccountRepository(t)
+	mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+	mockAccRepo.EXPECT().
+		FindByID(mock.Anything, int64(1)).
+		Return(&domain.Account{ID: 1}, nil).
+		Once()
+	mockOpRepo.EXPECT().
+		FindByID(mock.Anything, int64(domain.OperationTypeCreditVoucher)).
+		Return(&domain.OperationType{ID: domain.OperationTypeCreditVoucher, IsCredit: true}, nil).
+		Once()
+	mockTxRepo.EXPECT().
+		FindOutstandingByAccountID(mock.Anything, int64(1)).
+		Return(nil, nil).
+		Once()
+	mockTxRepo.EXPECT().
+		Create(mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+			return tx.IdempotencyKey == "key-1"
+		})).
+		Return(&domain.Transaction{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypeCreditVoucher, Amount: 10}, nil).
+		Once()
+
+	processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo)
+	_, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+		AccountID:       1,
+		OperationTypeID: domain.OperationTypeCreditVoucher,
+		Amount:          10,
+		IdempotencyKey:  "key-1",
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestCreateTransactionProcessor_CreditDischargesOutstandingDebts(t *testing.T) {
+	// Oldest first: a 50.00 purchase, then a 30.00 withdrawal
+	outstanding := func() []*domain.Transaction {
+		return []*domain.Transaction{
+			{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50.0, Balance: -50.0},
+			{ID: 2, AccountID: 1, OperationTypeID: domain.OperationTypeWithdrawal, Amount: -30.0, Balance: -30.0},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		amount         float64
+		wantDischarges []domain.Discharge
+		wantBalance    float64
+	}{
+		{
+			name:   "partial discharge",
+			amount: 60.0,
+			wantDischarges: []domain.Discharge{
+				{TransactionID: 1, Previous: -50.0, Balance: 0},
+				{TransactionID: 2, Previous: -30.0, Balance: -20.0},
+			},
+			wantBalance: 0,
+		},
+		{
+			name:   "exact discharge",
+			amount: 80.0,
+			wantDischarges: []domain.Discharge{
+				{TransactionID: 1, Previous: -50.0, Balance: 0},
+				{TransactionID: 2, Previous: -30.0, Balance: 0},
+			},
+			wantBalance: 0,
+		},
+		{
+			name:   "overpayment keeps the leftover",
+			amount: 100.0,
+			wantDischarges: []domain.Discharge{
+				{TransactionID: 1, Previous: -50.0, Balance: 0},
+				{TransactionID: 2, Previous: -30.0, Balance: 0},
+			},
+			wantBalance: 20.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+			mockAccRepo.EXPECT().
+				FindByID(mock.Anything, int64(1)).
+				Return(&domain.Account{ID: 1}, nil).
+				Once()
+			mockOpRepo.EXPECT().
+				FindByID(mock.Anything, int64(domain.OperationTypeCreditVoucher)).
+				Return(&domain.OperationType{ID: domain.OperationTypeCreditVoucher, IsCredit: true}, nil).
+				Once()
+			mockTxRepo.EXPECT().
+				FindOutstandingByAccountID(mock.Anything, int64(1)).
+				Return(outstanding(), nil).
+				Once()
+			mockTxRepo.EXPECT().
+				CreateWithDischarge(mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+					return tx.Amount == tt.amount && tx.Balance == tt.wantBalance
+				}), tt.wantDischarges, (*float64)(nil)).
+				Return(&domain.Transaction{ID: 3, AccountID: 1, OperationTypeID: domain.OperationTypeCreditVoucher, Amount: tt.amount}, nil).
+				Once()
+
+			processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo)
+			result, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+				AccountID:       1,
+				OperationTypeID: domain.OperationTypeCreditVoucher,
+				Amount:          tt.amount,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), result.TransactionID)
+		})
+	}
+}
+
+func TestCreateTransactionProcessor_DebitStartsOutstanding(t *testing.T) {
+	mockTxRepo := mocks.NewMockTransactionRepository(t)
+	mockAccRepo := mocks.NewMockAccountRepository(t)
+	mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+	mockAccRepo.EXPECT().
+		FindByID(mock.Anything, int64(1)).
+		Return(&domain.Account{ID: 1}, nil).
+		Once()
+	mockOpRepo.EXPECT().
+		FindByID(mock.Anything, int64(domain.OperationTypePurchase)).
+		Return(&domain.OperationType{ID: domain.OperationTypePurchase}, nil).
+		Once()
+	mockTxRepo.EXPECT().
+		Create(mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+			return tx.Amount == -50.0 && tx.Balance == -50.0
+		})).
+		Return(&domain.Transaction{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50.0}, nil).
+		Once()
+
+	processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo)
+	_, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+		AccountID:       1,
+		OperationTypeID: domain.OperationTypePurchase,
+		Amount:          50.0,
+	})
+
+	assert.NoError(t, err)
+	mockTxRepo.AssertNotCalled(t, "FindOutstandingByAccountID", mock.Anything, mock.Anything)
+}
+
+func TestCreateTransactionProcessor_Reverification(t *testing.T) {
+	verifiedAt := time.Now().UTC().AddDate(0, 0, -1)
+	oldAccount := func(verified *time.Time) *domain.Account {
+		return &domain.Account{ID: 1, CreatedAt: time.Now().UTC().AddDate(-1, 0, 0), VerifiedAt: verified}
+	}
+	policy := domain.ReverificationPolicy{MaxUnverifiedAge: 180 * 24 * time.Hour}
+
+	tests := []struct {
+		name    string
+		opts    []CreateTransactionOption
+		account *domain.Account
+		wantErr error
+	}{
+		{name: "rule disabled", account: oldAccount(nil)},
+		{name: "rule enabled and account verified", opts: []CreateTransactionOption{WithReverificationPolicy(policy)}, account: oldAccount(&verifiedAt)},
+		{name: "rule enabled and account never verified", opts: []CreateTransactionOption{WithReverificationPolicy(policy)}, account: oldAccount(nil), wantErr: domain.ErrAccountReverificationRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+			mockAccRepo.EXPECT().
+				FindByID(mock.Anything, int64(1)).
+				Return(tt.account, nil).
+				Once()
+
+			if tt.wantErr == nil {
+				mockOpRepo.EXPECT().
+					FindByID(mock.Anything, int64(domain.OperationTypePurchase)).
+					Return(&domain.OperationType{ID: domain.OperationTypePurchase}, nil).
+					Once()
+				mockTxRepo.EXPECT().
+					Create(mock.Anything, mock.Anything).
+					Return(&domain.Transaction{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50.0}, nil).
+					Once()
+			}
+
+			processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo, tt.opts...)
+			result, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+				AccountID:       1,
+				OperationTypeID: domain.OperationTypePurchase,
+				Amount:          50.0,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), result.TransactionID)
+			}
+		})
+	}
+}
+
+func TestCreateTransactionProcessor_CreditLimit(t *testing.T) {
+	// The account has 100.00 of credit limit and a balance of -40.00, leaving 60.00 available
+	account := &domain.Account{ID: 1, CreditLimit: 100.0}
+
+	tests := []struct {
+		name            string
+		operationTypeID int64
+		amount          float64
+		wantErr         error
+	}{
+		{name: "purchase within the limit", operationTypeID: domain.OperationTypePurchase, amount: 25.0},
+		{name: "purchase exactly at the limit", operationTypeID: domain.OperationTypePurchase, amount: 60.0},
+		{name: "purchase exceeding the limit", operationTypeID: domain.OperationTypePurchase, amount: 60.01, wantErr: domain.ErrInsufficientLimit},
+		{name: "installments exceeding the limit", operationTypeID: domain.OperationTypePurchaseWithInstallments, amount: 120.0, wantErr: domain.ErrInsufficientLimit},
+		{name: "withdrawal exceeding the limit", operationTypeID: domain.OperationTypeWithdrawal, amount: 75.0, wantErr: domain.ErrInsufficientLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+			// The account loaded to validate the request is reused for the limit
+			mockAccRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(account, nil).Once()
+			mockOpRepo.EXPECT().
+				FindByID(mock.Anything, tt.operationTypeID).
+				Return(&domain.OperationType{ID: tt.operationTypeID}, nil).
+				Once()
+			mockTxRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(-40.0, nil).Once()
+
+			if tt.wantErr == nil {
+				// The debit is saved only if the balance it was checked against is unchanged
+				mockTxRepo.EXPECT().
+					CreateIfBalance(mock.Anything, mock.Anything, -40.0).
+					Return(&domain.Transaction{ID: 1, AccountID: 1, OperationTypeID: tt.operationTypeID, Amount: -tt.amount}, nil).
+					Once()
+			}
+
+			processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo, WithCreditLimitEnforcement(true))
+			result, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+				AccountID:       1,
+				OperationTypeID: tt.operationTypeID,
+				Amount:          tt.amount,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, -tt.amount, result.Amount)
+			}
+		})
+	}
+}
+
+func TestCreateTransactionProcessor_CreditLimitIgnoresCreditVouchers(t *testing.T) {
+	mockTxRepo := mocks.NewMockTransactionRepository(t)
+	mockAccRepo := mocks.NewMockAccountRepository(t)
+	mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+	mockAccRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+	mockOpRepo.EXPECT().
+		FindByID(mock.Anything, int64(domain.OperationTypeCreditVoucher)).
+		Return(&domain.OperationType{ID: domain.OperationTypeCreditVoucher, IsCredit: true}, nil).
+		Once()
+	mockTxRepo.EXPECT().FindOutstandingByAccountID(mock.Anything, int64(1)).Return(nil, nil).Once()
+	mockTxRepo.EXPECT().
+		Create(mock.Anything, mock.Anything).
+		Return(&domain.Transaction{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypeCreditVoucher, Amount: 500.0}, nil).
+		Once()
+
+	processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo, WithCreditLimitEnforcement(true))
+	result, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+		AccountID:       1,
+		OperationTypeID: domain.OperationTypeCreditVoucher,
+		Amount:          500.0,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 500.0, result.Amount)
+}
+
+func TestCreateTransactionProcessor_CreditLimitUsesExpectedBalance(t *testing.T) {
+	mockTxRepo := mocks.NewMockTransactionRepository(t)
+	mockAccRepo := mocks.NewMockAccountRepository(t)
+	mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+	mockAccRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1, CreditLimit: 50.0}, nil).Once()
+	mockOpRepo.EXPECT().
+		FindByID(mock.Anything, int64(domain.OperationTypePurchase)).
+		Return(&domain.OperationType{ID: domain.OperationTypePurchase}, nil).
+		Once()
+
+	processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo, WithCreditLimitEnforcement(true))
+	expected := -30.0
+	result, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+		AccountID:       1,
+		OperationTypeID: domain.OperationTypePurchase,
+		Amount:          25.0,
+		ExpectedBalance: &expected,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInsufficientLimit)
+	assert.Nil(t, result)
+	mockTxRepo.AssertNotCalled(t, "SumByAccountID", mock.Anything, mock.Anything)
+}
+
+func TestCreateTransactionProcessor_MagicAmounts(t *testing.T) {
+	magicAmounts := domain.MagicAmounts{}
+	magicAmounts.Add(13.37, domain.ErrTransactionDeclined)
+	magicAmounts.Add(66.6, domain.ErrServiceUnavailable)
+
+	tests := []struct {
+		name    string
+		amount  float64
+		wantErr error
+	}{
+		{name: "magic decline amount", amount: 13.37, wantErr: domain.ErrTransactionDeclined},
+		{name: "magic amount sent negative", amount: -13.37, wantErr: domain.ErrTransactionDeclined},
+		{name: "magic unavailable amount", amount: 66.60, wantErr: domain.ErrServiceUnavailable},
+		{name: "normal amount", amount: 13.38},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			mockOpRepo := mocks.NewMockOperationTypeRepository(t)
+
+			// A magic amount is answered before any repository is touched
+			if tt.wantErr == nil {
+				mockAccRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+				mockOpRepo.EXPECT().
+					FindByID(mock.Anything, int64(domain.OperationTypePurchase)).
+					Return(&domain.OperationType{ID: domain.OperationTypePurchase}, nil).
+					Once()
+				mockTxRepo.EXPECT().
+					Create(mock.Anything, mock.Anything).
+					Return(&domain.Transaction{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -tt.amount}, nil).
+					Once()
+			}
+
+			processor := NewCreateTransactionProcessor(mockTxRepo, mockAccRepo, mockOpRepo, WithMagicAmounts(magicAmounts))
+			result, err := processor.Process(context.Background(), domain.CreateTransactionRequest{
+				AccountID:       1,
+				OperationTypeID: domain.OperationTypePurchase,
+				Amount:          tt.amount,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, -tt.amount, result.Amount)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// ExportTransactionsProcessor handles the business logic for streaming an account's transactions
+type ExportTransactionsProcessor struct {
+	transactionRepo ports.TransactionRepository
+	accountRepo     ports.AccountRepository
+}
+
+// NewExportTransactionsProcessor creates a new ExportTransactionsProcessor
+func NewExportTransactionsProcessor(transactionRepo ports.TransactionRepository, accountRepo ports.AccountRepository) *ExportTransactionsProcessor {
+	return &ExportTransactionsProcessor{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+	}
+}
+
+// Process validates the account and then calls emit for each transaction as it is read
+// Errors returned before the first emit can still be reported to the client
+func (p *ExportTransactionsProcessor) Process(ctx context.Context, req domain.ExportTransactionsRequest, emit func(*domain.Transaction) error) error {
+	// Validate account exists
+	exists, err := p.accountRepo.Exists(ctx, req.AccountID)
+	if err != nil {
+		return fmt.Errorf("failed to find account: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("account with id %d not found", req.AccountID)
+	}
+
+	if err := p.transactionRepo.StreamByAccountID(ctx, req.AccountID, emit); err != nil {
+		return fmt.Errorf("failed to export transactions: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportTransactionsProcessor_Process(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.MockTransactionRepository, *mocks.MockAccountRepository)
+		wantErrMessage string
+		wantEmitted    []int64
+	}{
+		{
+			name: "successful - streams every transaction",
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository, mockAccRepo *mocks.MockAccountRepository) {
+				mockAccRepo.EXPECT().
+					Exists(mock.Anything, int64(1)).
+					Return(true, nil).
+					Once()
+
+				mockTxRepo.EXPECT().
+					StreamByAccountID(mock.Anything, int64(1), mock.Anything).
+					RunAndReturn(func(ctx context.Context, accountID int64, fn func(*domain.Transaction) error) error {
+						for _, id := range []int64{1, 2, 3} {
+							if err := fn(&domain.Transaction{ID: id, AccountID: accountID}); err != nil {
+								return err
+							}
+						}
+						return nil
+					}).
+					Once()
+			},
+			wantEmitted: []int64{1, 2, 3},
+		},
+		{
+			name: "error - account not found",
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository, mockAccRepo *mocks.MockAccountRepository) {
+				mockAccRepo.EXPECT().
+					Exists(mock.Anything, int64(1)).
+					Return(false, nil).
+					Once()
+			},
+			wantErrMessage: "account with id 1 not found",
+		},
+		{
+			name: "error - stream failure",
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository, mockAccRepo *mocks.MockAccountRepository) {
+				mockAccRepo.EXPECT().
+					Exists(mock.Anything, int64(1)).
+					Return(true, nil).
+					Once()
+
+				mockTxRepo.EXPECT().
+					StreamByAccountID(mock.Anything, int64(1), mock.Anything).
+					Return(errors.New("database error")).
+					Once()
+			},
+			wantErrMessage: "failed to export transactions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			tt.setupMocks(mockTxRepo, mockAccRepo)
+
+			processor := NewExportTransactionsProcessor(mockTxRepo, mockAccRepo)
+
+			var emitted []int64
+			err := processor.Process(context.Background(), domain.ExportTransactionsRequest{AccountID: 1}, func(tx *domain.Transaction) error {
+				emitted = append(emitted, tx.ID)
+				return nil
+			})
+
+			if tt.wantErrMessage != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrMessage)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantEmitted, emitted)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// GetAccountBalanceProcessor returns an account together with its current and available balances
+type GetAccountBalanceProcessor struct {
+	transactionRepo ports.TransactionRepository
+	accountRepo     ports.AccountRepository
+}
+
+// NewGetAccountBalanceProcessor creates a new GetAccountBalanceProcessor
+func NewGetAccountBalanceProcessor(transactionRepo ports.TransactionRepository, accountRepo ports.AccountRepository) *GetAccountBalanceProcessor {
+	return &GetAccountBalanceProcessor{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+	}
+}
+
+// Process sums the account's transactions and adds its credit limit to get the available balance
+func (p *GetAccountBalanceProcessor) Process(ctx context.Context, req domain.GetAccountBalanceRequest) (*domain.GetAccountBalanceResponse, error) {
+	account, err := p.accountRepo.FindByID(ctx, req.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find account: %w", err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("account with id %d not found", req.AccountID)
+	}
+
+	if req.IncludeDirection {
+		return p.withDirection(ctx, account)
+	}
+	if req.AsOf != nil {
+		return p.asOf(ctx, account, *req.AsOf)
+	}
+
+	balance, err := p.transactionRepo.SumByAccountID(ctx, req.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute balance: %w", err)
+	}
+
+	return &domain.GetAccountBalanceResponse{
+		Account:          account,
+		CurrentBalance:   domain.RoundToCents(balance),
+		AvailableBalance: account.AvailableFunds(balance),
+	}, nil
+}
+
+// asOf returns the balance the account had at the given time, against its current credit limit
+func (p *GetAccountBalanceProcessor) asOf(ctx context.Context, account *domain.Account, asOf time.Time) (*domain.GetAccountBalanceResponse, error) {
+	balance, err := p.transactionRepo.SumAsOf(ctx, account.ID, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute balance: %w", err)
+	}
+
+	asOf = asOf.UTC()
+	return &domain.GetAccountBalanceResponse{
+		Account:          account,
+		CurrentBalance:   domain.RoundToCents(balance),
+		AvailableBalance: account.AvailableFunds(balance),
+		AsOf:             &asOf,
+	}, nil
+}
+
+// withDirection derives the balance from its debit and credit sums so the three always agree
+func (p *GetAccountBalanceProcessor) withDirection(ctx context.Context, account *domain.Account) (*domain.GetAccountBalanceResponse, error) {
+	debit, credit, err := p.transactionRepo.SumByDirection(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute balance: %w", err)
+	}
+
+	// Rounded in cents first so the rounded parts add up to the rounded balance
+	netDebit := domain.RoundToCents(debit)
+	netCredit := domain.RoundToCents(credit)
+	balance := domain.RoundToCents(netDebit + netCredit)
+
+	return &domain.GetAccountBalanceResponse{
+		Account:          account,
+		CurrentBalance:   balance,
+		AvailableBalance: account.AvailableFunds(balance),
+		NetDebit:         &netDebit,
+		NetCredit:        &netCredit,
+	}, nil
+}
@@ -0,0 +1,185 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountBalanceProcessor_Process(t *testing.T) {
+	tests := []struct {
+		name          string
+		creditLimit   float64
+		balance       float64
+		setupMocks    func(*mocks.MockTransactionRepository, *mocks.MockAccountRepository, float64, float64)
+		wantCurrent   float64
+		wantAvailable float64
+		wantErr       string
+	}{
+		{
+			name:          "positive balance adds the credit limit",
+			creditLimit:   500.0,
+			balance:       120.5,
+			wantCurrent:   120.5,
+			wantAvailable: 620.5,
+		},
+		{
+			name:          "negative balance consumes the credit limit",
+			creditLimit:   500.0,
+			balance:       -150.25,
+			wantCurrent:   -150.25,
+			wantAvailable: 349.75,
+		},
+		{
+			name:          "no credit limit",
+			balance:       -10.0,
+			wantCurrent:   -10.0,
+			wantAvailable: -10.0,
+		},
+		{
+			name:          "floating point noise is rounded to cents",
+			creditLimit:   0.2,
+			balance:       0.1,
+			wantCurrent:   0.1,
+			wantAvailable: 0.3,
+		},
+		{
+			name: "account not found",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository, _, _ float64) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(nil, nil).Once()
+			},
+			wantErr: "account with id 1 not found",
+		},
+		{
+			name: "balance error",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository, _, _ float64) {
+				accRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+				txRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(0, errors.New("database error")).Once()
+			},
+			wantErr: "failed to compute balance",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockTxRepo, mockAccRepo, tt.creditLimit, tt.balance)
+			} else {
+				mockAccRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1, CreditLimit: tt.creditLimit}, nil).Once()
+				mockTxRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(tt.balance, nil).Once()
+			}
+
+			processor := NewGetAccountBalanceProcessor(mockTxRepo, mockAccRepo)
+			result, err := processor.Process(context.Background(), domain.GetAccountBalanceRequest{AccountID: 1})
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), result.ID)
+			assert.Equal(t, tt.creditLimit, result.CreditLimit)
+			assert.Equal(t, tt.wantCurrent, result.CurrentBalance)
+			assert.Equal(t, tt.wantAvailable, result.AvailableBalance)
+		})
+	}
+}
+
+func TestGetAccountBalanceProcessor_Process_IncludeDirection(t *testing.T) {
+	tests := []struct {
+		name          string
+		debit         float64
+		credit        float64
+		wantBalance   float64
+		wantAvailable float64
+	}{
+		{name: "positive balance", debit: -80.5, credit: 200.0, wantBalance: 119.5, wantAvailable: 619.5},
+		{name: "negative balance", debit: -350.25, credit: 100.0, wantBalance: -250.25, wantAvailable: 249.75},
+		{name: "only debits", debit: -0.1 - 0.2, credit: 0, wantBalance: -0.3, wantAvailable: 499.7},
+		{name: "no transactions", debit: 0, credit: 0, wantBalance: 0, wantAvailable: 500.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			mockAccRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1, CreditLimit: 500.0}, nil).Once()
+			mockTxRepo.EXPECT().SumByDirection(mock.Anything, int64(1)).Return(tt.debit, tt.credit, nil).Once()
+
+			processor := NewGetAccountBalanceProcessor(mockTxRepo, mockAccRepo)
+			result, err := processor.Process(context.Background(), domain.GetAccountBalanceRequest{AccountID: 1, IncludeDirection: true})
+
+			require.NoError(t, err)
+			require.NotNil(t, result.NetDebit)
+			require.NotNil(t, result.NetCredit)
+			assert.Equal(t, tt.wantBalance, result.CurrentBalance)
+			assert.Equal(t, tt.wantAvailable, result.AvailableBalance)
+			assert.Equal(t, result.CurrentBalance, domain.RoundToCents(*result.NetDebit+*result.NetCredit), "net_debit + net_credit must equal the balance")
+			assert.LessOrEqual(t, *result.NetDebit, 0.0)
+			assert.GreaterOrEqual(t, *result.NetCredit, 0.0)
+		})
+	}
+}
+
+func TestGetAccountBalanceProcessor_Process_IncludeDirectionAccountNotFound(t *testing.T) {
+	mockTxRepo := mocks.NewMockTransactionRepository(t)
+	mockAccRepo := mocks.NewMockAccountRepository(t)
+	mockAccRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(nil, nil).Once()
+
+	processor := NewGetAccountBalanceProcessor(mockTxRepo, mockAccRepo)
+	_, err := processor.Process(context.Background(), domain.GetAccountBalanceRequest{AccountID: 1, IncludeDirection: true})
+
+	assert.ErrorContains(t, err, "account with id 1 not found")
+}
+
+func TestGetAccountBalanceProcessor_Process_AsOf(t *testing.T) {
+	asOf := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("balance at the given time", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccRepo := mocks.NewMockAccountRepository(t)
+		mockAccRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1, CreditLimit: 500.0}, nil).Once()
+		mockTxRepo.EXPECT().SumAsOf(mock.Anything, int64(1), asOf).Return(-0.1-0.2, nil).Once()
+
+		processor := NewGetAccountBalanceProcessor(mockTxRepo, mockAccRepo)
+		result, err := processor.Process(context.Background(), domain.GetAccountBalanceRequest{AccountID: 1, AsOf: &asOf})
+
+		require.NoError(t, err)
+		assert.Equal(t, -0.3, result.CurrentBalance)
+		assert.Equal(t, 499.7, result.AvailableBalance)
+		require.NotNil(t, result.AsOf)
+		assert.Equal(t, asOf, *result.AsOf)
+	})
+
+	t.Run("account not found", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccRepo := mocks.NewMockAccountRepository(t)
+		mockAccRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(nil, nil).Once()
+
+		processor := NewGetAccountBalanceProcessor(mockTxRepo, mockAccRepo)
+		_, err := processor.Process(context.Background(), domain.GetAccountBalanceRequest{AccountID: 1, AsOf: &asOf})
+
+		assert.ErrorContains(t, err, "account with id 1 not found")
+	})
+
+	t.Run("balance error", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccRepo := mocks.NewMockAccountRepository(t)
+		mockAccRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+		mockTxRepo.EXPECT().SumAsOf(mock.Anything, int64(1), asOf).Return(0, errors.New("database error")).Once()
+
+		processor := NewGetAccountBalanceProcessor(mockTxRepo, mockAccRepo)
+		_, err := processor.Process(context.Background(), domain.GetAccountBalanceRequest{AccountID: 1, AsOf: &asOf})
+
+		assert.ErrorContains(t, err, "failed to compute balance")
+	})
+}
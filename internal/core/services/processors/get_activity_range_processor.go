@@ -0,0 +1,41 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// GetActivityRangeProcessor returns when an account's activity started and last happened
+type GetActivityRangeProcessor struct {
+	transactionRepo ports.TransactionRepository
+	accountRepo     ports.AccountRepository
+}
+
+// NewGetActivityRangeProcessor creates a new GetActivityRangeProcessor
+func NewGetActivityRangeProcessor(transactionRepo ports.TransactionRepository, accountRepo ports.AccountRepository) *GetActivityRangeProcessor {
+	return &GetActivityRangeProcessor{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+	}
+}
+
+// Process returns the first and last event dates and the transaction count, aggregated in SQL
+func (p *GetActivityRangeProcessor) Process(ctx context.Context, req domain.GetActivityRangeRequest) (*domain.ActivityRange, error) {
+	exists, err := p.accountRepo.Exists(ctx, req.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find account: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("account with id %d not found", req.AccountID)
+	}
+
+	activity, err := p.transactionRepo.ActivityRange(ctx, req.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity range: %w", err)
+	}
+
+	return activity, nil
+}
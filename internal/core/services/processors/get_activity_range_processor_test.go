@@ -0,0 +1,77 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetActivityRangeProcessor_Process(t *testing.T) {
+	first := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	last := time.Date(2025, 2, 1, 18, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		setupMocks   func(*mocks.MockTransactionRepository, *mocks.MockAccountRepository)
+		wantActivity *domain.ActivityRange
+		errContain   string
+	}{
+		{
+			name: "active account",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				txRepo.EXPECT().ActivityRange(mock.Anything, int64(1)).
+					Return(&domain.ActivityRange{FirstTransactionAt: &first, LastTransactionAt: &last, TotalCount: 3}, nil).Once()
+			},
+			wantActivity: &domain.ActivityRange{FirstTransactionAt: &first, LastTransactionAt: &last, TotalCount: 3},
+		},
+		{
+			name: "account without transactions",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				txRepo.EXPECT().ActivityRange(mock.Anything, int64(1)).Return(&domain.ActivityRange{}, nil).Once()
+			},
+			wantActivity: &domain.ActivityRange{},
+		},
+		{
+			name: "account not found",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(false, nil).Once()
+			},
+			errContain: "account with id 1 not found",
+		},
+		{
+			name: "repository error",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				txRepo.EXPECT().ActivityRange(mock.Anything, int64(1)).Return(nil, errors.New("database error")).Once()
+			},
+			errContain: "failed to get activity range",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			tt.setupMocks(mockTxRepo, mockAccRepo)
+
+			processor := NewGetActivityRangeProcessor(mockTxRepo, mockAccRepo)
+			result, err := processor.Process(context.Background(), domain.GetActivityRangeRequest{AccountID: 1})
+
+			if tt.errContain != "" {
+				assert.ErrorContains(t, err, tt.errContain)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantActivity, result)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// GetBalanceTrendProcessor returns an account's end-of-day balances over its last days, e.g. for sparklines
+type GetBalanceTrendProcessor struct {
+	transactionRepo ports.TransactionRepository
+	accountRepo     ports.AccountRepository
+}
+
+// NewGetBalanceTrendProcessor creates a new GetBalanceTrendProcessor
+func NewGetBalanceTrendProcessor(transactionRepo ports.TransactionRepository, accountRepo ports.AccountRepository) *GetBalanceTrendProcessor {
+	return &GetBalanceTrendProcessor{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+	}
+}
+
+// Process adds the balance carried in from before the first day to the running totals of the days with activity,
+// and carries each balance forward through the days without any
+func (p *GetBalanceTrendProcessor) Process(ctx context.Context, req domain.GetBalanceTrendRequest) (*domain.GetBalanceTrendResponse, error) {
+	days := domain.NormalizeTrendDays(req.Days)
+	last := truncateToDay(req.AsOf)
+	first := last.AddDate(0, 0, -int(days-1))
+	end := last.AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	exists, err := p.accountRepo.Exists(ctx, req.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find account: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("account with id %d not found", req.AccountID)
+	}
+
+	opening, err := p.transactionRepo.SumBefore(ctx, req.AccountID, first)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute opening balance: %w", err)
+	}
+
+	running, err := p.transactionRepo.RunningTotalsBetween(ctx, req.AccountID, first, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running totals: %w", err)
+	}
+
+	byDate := make(map[string]float64, len(running))
+	for _, day := range running {
+		byDate[day.Date] = day.Balance
+	}
+
+	trend := make([]*domain.DailyBalance, 0, days)
+	var total float64
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		date := d.Format(domain.DailyDateLayout)
+		if runningTotal, ok := byDate[date]; ok {
+			total = runningTotal
+		}
+		trend = append(trend, &domain.DailyBalance{Date: date, Balance: domain.RoundToCents(opening + total)})
+	}
+
+	return &domain.GetBalanceTrendResponse{
+		AccountID: req.AccountID,
+		Days:      trend,
+	}, nil
+}
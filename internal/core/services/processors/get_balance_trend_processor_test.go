@@ -0,0 +1,112 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBalanceTrendProcessor_Process(t *testing.T) {
+	asOf := time.Date(2025, 1, 5, 15, 30, 0, 0, time.UTC)
+	firstOfFive := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endOfFifth := time.Date(2025, 1, 5, 23, 59, 59, 999999999, time.UTC)
+
+	tests := []struct {
+		name       string
+		req        domain.GetBalanceTrendRequest
+		setupMocks func(*mocks.MockTransactionRepository, *mocks.MockAccountRepository)
+		wantDays   []*domain.DailyBalance
+		errContain string
+	}{
+		{
+			name: "opening balance and running totals carried through quiet days",
+			req:  domain.GetBalanceTrendRequest{AccountID: 1, Days: 5, AsOf: asOf},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				txRepo.EXPECT().SumBefore(mock.Anything, int64(1), firstOfFive).Return(50.0, nil).Once()
+				txRepo.EXPECT().RunningTotalsBetween(mock.Anything, int64(1), firstOfFive, endOfFifth).
+					Return([]*domain.DailyBalance{
+						{Date: "2025-01-02", Balance: 100.0},
+						{Date: "2025-01-04", Balance: 69.9},
+					}, nil).Once()
+			},
+			wantDays: []*domain.DailyBalance{
+				{Date: "2025-01-01", Balance: 50.0},
+				{Date: "2025-01-02", Balance: 150.0},
+				{Date: "2025-01-03", Balance: 150.0},
+				{Date: "2025-01-04", Balance: 119.9},
+				{Date: "2025-01-05", Balance: 119.9},
+			},
+		},
+		{
+			name: "account without transactions",
+			req:  domain.GetBalanceTrendRequest{AccountID: 1, Days: 2, AsOf: asOf},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				txRepo.EXPECT().SumBefore(mock.Anything, int64(1), mock.Anything).Return(0.0, nil).Once()
+				txRepo.EXPECT().RunningTotalsBetween(mock.Anything, int64(1), mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+			wantDays: []*domain.DailyBalance{
+				{Date: "2025-01-04", Balance: 0},
+				{Date: "2025-01-05", Balance: 0},
+			},
+		},
+		{
+			name: "days capped",
+			req:  domain.GetBalanceTrendRequest{AccountID: 1, Days: 10000, AsOf: asOf},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				firstOfYear := asOf.AddDate(0, 0, -(domain.MaxTrendDays - 1)).Truncate(24 * time.Hour)
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				txRepo.EXPECT().SumBefore(mock.Anything, int64(1), firstOfYear).Return(0.0, nil).Once()
+				txRepo.EXPECT().RunningTotalsBetween(mock.Anything, int64(1), firstOfYear, endOfFifth).Return(nil, nil).Once()
+			},
+		},
+		{
+			name: "account not found",
+			req:  domain.GetBalanceTrendRequest{AccountID: 1, AsOf: asOf},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(false, nil).Once()
+			},
+			errContain: "account with id 1 not found",
+		},
+		{
+			name: "repository error",
+			req:  domain.GetBalanceTrendRequest{AccountID: 1, AsOf: asOf},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				txRepo.EXPECT().SumBefore(mock.Anything, int64(1), mock.Anything).Return(0.0, nil).Once()
+				txRepo.EXPECT().RunningTotalsBetween(mock.Anything, int64(1), mock.Anything, mock.Anything).Return(nil, errors.New("database error")).Once()
+			},
+			errContain: "failed to get running totals",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			tt.setupMocks(mockTxRepo, mockAccRepo)
+
+			processor := NewGetBalanceTrendProcessor(mockTxRepo, mockAccRepo)
+			result, err := processor.Process(context.Background(), tt.req)
+
+			if tt.errContain != "" {
+				assert.ErrorContains(t, err, tt.errContain)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), result.AccountID)
+			assert.Len(t, result.Days, int(domain.NormalizeTrendDays(tt.req.Days)))
+			if tt.wantDays != nil {
+				assert.Equal(t, tt.wantDays, result.Days)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// Bounds used when the daily totals range is open on either side
+var (
+	dailyTotalsMinTime = time.Time{}
+	dailyTotalsMaxTime = time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+)
+
+// GetDailyTotalsProcessor groups an account's transactions by day
+type GetDailyTotalsProcessor struct {
+	transactionRepo ports.TransactionRepository
+	accountRepo     ports.AccountRepository
+}
+
+// NewGetDailyTotalsProcessor creates a new GetDailyTotalsProcessor
+func NewGetDailyTotalsProcessor(transactionRepo ports.TransactionRepository, accountRepo ports.AccountRepository) *GetDailyTotalsProcessor {
+	return &GetDailyTotalsProcessor{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+	}
+}
+
+// Process aggregates the transactions in SQL and, when asked, fills the days without activity
+// Gaps are filled across the requested range, or between the first and last active days when it is open
+func (p *GetDailyTotalsProcessor) Process(ctx context.Context, req domain.GetDailyTotalsRequest) (*domain.GetDailyTotalsResponse, error) {
+	start, end := dailyTotalsMinTime, dailyTotalsMaxTime
+	if req.Start != nil {
+		start = *req.Start
+	}
+	if req.End != nil {
+		end = *req.End
+	}
+	if end.Before(start) {
+		return nil, domain.ErrInvalidStatementPeriod
+	}
+
+	exists, err := p.accountRepo.Exists(ctx, req.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find account: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("account with id %d not found", req.AccountID)
+	}
+
+	days, err := p.transactionRepo.DailyTotalsBetween(ctx, req.AccountID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily totals: %w", err)
+	}
+
+	if req.FillGaps {
+		days, err = fillDailyGaps(days, req.Start, req.End)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Ensure we return empty array instead of null
+	if days == nil {
+		days = []*domain.DailyTotal{}
+	}
+
+	return &domain.GetDailyTotalsResponse{
+		AccountID: req.AccountID,
+		Days:      days,
+	}, nil
+}
+
+// fillDailyGaps resolves the range to fill and rejects ranges longer than domain.MaxGapFillDays
+func fillDailyGaps(days []*domain.DailyTotal, start, end *time.Time) ([]*domain.DailyTotal, error) {
+	var first, last time.Time
+
+	if start != nil {
+		first = truncateToDay(*start)
+	} else if len(days) > 0 {
+		first, _ = time.Parse(domain.DailyDateLayout, days[0].Date)
+	}
+
+	if end != nil {
+		last = truncateToDay(*end)
+	} else if len(days) > 0 {
+		last, _ = time.Parse(domain.DailyDateLayout, days[len(days)-1].Date)
+	}
+
+	// Nothing to anchor an open range on
+	if first.IsZero() || last.IsZero() {
+		return days, nil
+	}
+
+	if last.Sub(first) >= domain.MaxGapFillDays*24*time.Hour {
+		return nil, domain.ErrGapFillRangeTooLarge
+	}
+
+	return domain.FillDailyGaps(days, first, last), nil
+}
+
+// truncateToDay returns midnight UTC of t's UTC day
+func truncateToDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
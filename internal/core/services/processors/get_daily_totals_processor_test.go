@@ -0,0 +1,147 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDailyTotalsProcessor_Process(t *testing.T) {
+	date := func(day int) *time.Time {
+		d := time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	endOfDay := func(day int) *time.Time {
+		d := time.Date(2025, 1, day, 23, 59, 59, 999999999, time.UTC)
+		return &d
+	}
+	activeDays := func() []*domain.DailyTotal {
+		return []*domain.DailyTotal{
+			{Date: "2025-01-02", Count: 2, NetAmount: 70.0},
+			{Date: "2025-01-04", Count: 1, NetAmount: -10.0},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		req        domain.GetDailyTotalsRequest
+		setupMocks func(*mocks.MockTransactionRepository, *mocks.MockAccountRepository)
+		wantDays   []*domain.DailyTotal
+		wantErr    error
+		errContain string
+	}{
+		{
+			name: "days with activity only",
+			req:  domain.GetDailyTotalsRequest{AccountID: 1, Start: date(1), End: endOfDay(5)},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				txRepo.EXPECT().DailyTotalsBetween(mock.Anything, int64(1), *date(1), *endOfDay(5)).Return(activeDays(), nil).Once()
+			},
+			wantDays: activeDays(),
+		},
+		{
+			name: "gaps filled across the requested range",
+			req:  domain.GetDailyTotalsRequest{AccountID: 1, Start: date(1), End: endOfDay(5), FillGaps: true},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				txRepo.EXPECT().DailyTotalsBetween(mock.Anything, int64(1), *date(1), *endOfDay(5)).Return(activeDays(), nil).Once()
+			},
+			wantDays: []*domain.DailyTotal{
+				{Date: "2025-01-01"},
+				{Date: "2025-01-02", Count: 2, NetAmount: 70.0},
+				{Date: "2025-01-03"},
+				{Date: "2025-01-04", Count: 1, NetAmount: -10.0},
+				{Date: "2025-01-05"},
+			},
+		},
+		{
+			name: "gaps filled between first and last active days without a range",
+			req:  domain.GetDailyTotalsRequest{AccountID: 1, FillGaps: true},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				txRepo.EXPECT().DailyTotalsBetween(mock.Anything, int64(1), mock.Anything, mock.Anything).Return(activeDays(), nil).Once()
+			},
+			wantDays: []*domain.DailyTotal{
+				{Date: "2025-01-02", Count: 2, NetAmount: 70.0},
+				{Date: "2025-01-03"},
+				{Date: "2025-01-04", Count: 1, NetAmount: -10.0},
+			},
+		},
+		{
+			name: "no activity without a range",
+			req:  domain.GetDailyTotalsRequest{AccountID: 1, FillGaps: true},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				txRepo.EXPECT().DailyTotalsBetween(mock.Anything, int64(1), mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+			wantDays: []*domain.DailyTotal{},
+		},
+		{
+			name: "gap filling range too large",
+			req: domain.GetDailyTotalsRequest{
+				AccountID: 1,
+				Start:     date(1),
+				End:       func() *time.Time { d := time.Date(2026, 1, 2, 23, 59, 59, 0, time.UTC); return &d }(),
+				FillGaps:  true,
+			},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				txRepo.EXPECT().DailyTotalsBetween(mock.Anything, int64(1), mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+			wantErr: domain.ErrGapFillRangeTooLarge,
+		},
+		{
+			name:       "end before start",
+			req:        domain.GetDailyTotalsRequest{AccountID: 1, Start: date(5), End: endOfDay(1)},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {},
+			wantErr:    domain.ErrInvalidStatementPeriod,
+		},
+		{
+			name: "account not found",
+			req:  domain.GetDailyTotalsRequest{AccountID: 1},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(false, nil).Once()
+			},
+			errContain: "account with id 1 not found",
+		},
+		{
+			name: "repository error",
+			req:  domain.GetDailyTotalsRequest{AccountID: 1},
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				txRepo.EXPECT().DailyTotalsBetween(mock.Anything, int64(1), mock.Anything, mock.Anything).Return(nil, errors.New("database error")).Once()
+			},
+			errContain: "failed to get daily totals",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			tt.setupMocks(mockTxRepo, mockAccRepo)
+
+			processor := NewGetDailyTotalsProcessor(mockTxRepo, mockAccRepo)
+			result, err := processor.Process(context.Background(), tt.req)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if tt.errContain != "" {
+				assert.ErrorContains(t, err, tt.errContain)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), result.AccountID)
+			assert.Equal(t, tt.wantDays, result.Days)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// GetIdempotencyKeyProcessor reports what the idempotency store holds for a key
+type GetIdempotencyKeyProcessor struct {
+	store ports.IdempotencyStore
+	now   func() time.Time
+}
+
+// NewGetIdempotencyKeyProcessor creates a new GetIdempotencyKeyProcessor
+func NewGetIdempotencyKeyProcessor(store ports.IdempotencyStore) *GetIdempotencyKeyProcessor {
+	return &GetIdempotencyKeyProcessor{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Process returns the status, response code and age of the key, leaving out the stored response
+func (p *GetIdempotencyKeyProcessor) Process(ctx context.Context, key string) (*domain.IdempotencyKeyStatus, error) {
+	record, err := p.store.GetMetadata(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	if record == nil {
+		return &domain.IdempotencyKeyStatus{Key: key}, nil
+	}
+
+	now := p.now()
+	expired := record.Expired(now)
+	return &domain.IdempotencyKeyStatus{
+		Key:            key,
+		Cached:         !expired,
+		Status:         record.Status,
+		ResponseStatus: record.ResponseStatus,
+		AgeSeconds:     int64(now.Sub(record.CreatedAt).Seconds()),
+		Expired:        expired,
+		ExpiresAt:      record.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetIdempotencyKeyProcessor_Process(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Minute)
+
+	tests := []struct {
+		name   string
+		record *domain.IdempotencyRecord
+		want   *domain.IdempotencyKeyStatus
+	}{
+		{
+			name: "absent key",
+			want: &domain.IdempotencyKeyStatus{Key: "key-1"},
+		},
+		{
+			name:   "completed key",
+			record: &domain.IdempotencyRecord{Key: "key-1", Status: domain.IdempotencyStatusCompleted, ResponseStatus: 201, CreatedAt: now.Add(-90 * time.Second), ExpiresAt: &later},
+			want:   &domain.IdempotencyKeyStatus{Key: "key-1", Cached: true, Status: domain.IdempotencyStatusCompleted, ResponseStatus: 201, AgeSeconds: 90, ExpiresAt: &later},
+		},
+		{
+			name:   "key still processing",
+			record: &domain.IdempotencyRecord{Key: "key-1", Status: domain.IdempotencyStatusProcessing, CreatedAt: now.Add(-10 * time.Minute)},
+			want:   &domain.IdempotencyKeyStatus{Key: "key-1", Cached: true, Status: domain.IdempotencyStatusProcessing, AgeSeconds: 600},
+		},
+		{
+			name:   "expired key awaiting cleanup",
+			record: &domain.IdempotencyRecord{Key: "key-1", Status: domain.IdempotencyStatusCompleted, ResponseStatus: 201, CreatedAt: now.Add(-time.Hour), ExpiresAt: &earlier},
+			want:   &domain.IdempotencyKeyStatus{Key: "key-1", Status: domain.IdempotencyStatusCompleted, ResponseStatus: 201, AgeSeconds: 3600, Expired: true, ExpiresAt: &earlier},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := mocks.NewMockIdempotencyStore(t)
+			store.EXPECT().GetMetadata(mock.Anything, "key-1").Return(tt.record, nil).Once()
+
+			processor := NewGetIdempotencyKeyProcessor(store)
+			processor.now = func() time.Time { return now }
+			result, err := processor.Process(context.Background(), "key-1")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+
+	t.Run("store error", func(t *testing.T) {
+		store := mocks.NewMockIdempotencyStore(t)
+		store.EXPECT().GetMetadata(mock.Anything, "key-1").Return(nil, errors.New("database error")).Once()
+
+		result, err := NewGetIdempotencyKeyProcessor(store).Process(context.Background(), "key-1")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,58 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// GetMonthlyStatementProcessor summarizes an account's debits, credits and balance month by month over a year
+type GetMonthlyStatementProcessor struct {
+	transactionRepo ports.TransactionRepository
+	accountRepo     ports.AccountRepository
+}
+
+// NewGetMonthlyStatementProcessor creates a new GetMonthlyStatementProcessor
+func NewGetMonthlyStatementProcessor(transactionRepo ports.TransactionRepository, accountRepo ports.AccountRepository) *GetMonthlyStatementProcessor {
+	return &GetMonthlyStatementProcessor{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+	}
+}
+
+// Process aggregates the year's transactions by month in SQL and carries the balance forward from the
+// opening balance, every transaction before the year; all twelve months are listed
+func (p *GetMonthlyStatementProcessor) Process(ctx context.Context, req domain.GetMonthlyStatementRequest) (*domain.GetMonthlyStatementResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	exists, err := p.accountRepo.Exists(ctx, req.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find account: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("account with id %d not found", req.AccountID)
+	}
+
+	start, end := req.Period()
+
+	opening, err := p.transactionRepo.SumBefore(ctx, req.AccountID, start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute opening balance: %w", err)
+	}
+
+	totals, err := p.transactionRepo.MonthlyTotalsBetween(ctx, req.AccountID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly totals: %w", err)
+	}
+
+	return &domain.GetMonthlyStatementResponse{
+		AccountID:      req.AccountID,
+		Year:           req.Year,
+		OpeningBalance: domain.RoundToCents(opening),
+		Months:         domain.BuildMonthlyStatement(req.Year, opening, totals),
+	}, nil
+}
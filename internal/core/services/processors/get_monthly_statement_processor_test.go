@@ -0,0 +1,127 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMonthlyStatementProcessor_Process(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)
+
+	mockTxRepo := mocks.NewMockTransactionRepository(t)
+	mockAccRepo := mocks.NewMockAccountRepository(t)
+	mockAccRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+	mockTxRepo.EXPECT().SumBefore(mock.Anything, int64(1), start).Return(20.0, nil).Once()
+	mockTxRepo.EXPECT().MonthlyTotalsBetween(mock.Anything, int64(1), start, end).Return([]*domain.MonthlyTotal{
+		{Month: "2024-01", Debits: -30.1, Credits: 100.0},
+		{Month: "2024-03", Debits: -0.1 - 0.2, Credits: 0},
+		{Month: "2024-04", Debits: 0, Credits: 15.5},
+		{Month: "2024-12", Debits: -120.0, Credits: 10.0},
+	}, nil).Once()
+
+	processor := NewGetMonthlyStatementProcessor(mockTxRepo, mockAccRepo)
+	resp, err := processor.Process(context.Background(), domain.GetMonthlyStatementRequest{AccountID: 1, Year: 2024})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.AccountID)
+	assert.Equal(t, 2024, resp.Year)
+	assert.Equal(t, 20.0, resp.OpeningBalance)
+	require.Len(t, resp.Months, 12, "Every month of the year is listed")
+
+	want := map[string]domain.MonthlyStatement{
+		"2024-01": {Month: "2024-01", TotalDebits: -30.1, TotalCredits: 100.0, NetChange: 69.9, EndingBalance: 89.9},
+		"2024-02": {Month: "2024-02", EndingBalance: 89.9},
+		"2024-03": {Month: "2024-03", TotalDebits: -0.3, NetChange: -0.3, EndingBalance: 89.6},
+		"2024-04": {Month: "2024-04", TotalCredits: 15.5, NetChange: 15.5, EndingBalance: 105.1},
+		"2024-11": {Month: "2024-11", EndingBalance: 105.1},
+		"2024-12": {Month: "2024-12", TotalDebits: -120.0, TotalCredits: 10.0, NetChange: -110.0, EndingBalance: -4.9},
+	}
+	balance := resp.OpeningBalance
+	for i, month := range resp.Months {
+		assert.Equal(t, time.Date(2024, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC).Format(domain.MonthlyDateLayout), month.Month)
+		if expected, ok := want[month.Month]; ok {
+			assert.Equal(t, expected, *month, month.Month)
+		}
+		assert.Equal(t, domain.RoundToCents(month.TotalDebits+month.TotalCredits), month.NetChange, month.Month)
+		balance = domain.RoundToCents(balance + month.NetChange)
+		assert.Equal(t, balance, month.EndingBalance, "%s carries the balance forward", month.Month)
+	}
+}
+
+func TestGetMonthlyStatementProcessor_ProcessErrors(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		year       int
+		setupMocks func(*mocks.MockTransactionRepository, *mocks.MockAccountRepository)
+		wantErr    error
+		wantErrMsg string
+	}{
+		{
+			name:    "year too early",
+			year:    1899,
+			wantErr: domain.ErrInvalidStatementYear,
+		},
+		{
+			name:    "year too late",
+			year:    10000,
+			wantErr: domain.ErrInvalidStatementYear,
+		},
+		{
+			name: "account not found",
+			year: 2024,
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(false, nil).Once()
+			},
+			wantErrMsg: "account with id 1 not found",
+		},
+		{
+			name: "opening balance error",
+			year: 2024,
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				txRepo.EXPECT().SumBefore(mock.Anything, int64(1), start).Return(0, errors.New("database error")).Once()
+			},
+			wantErrMsg: "failed to compute opening balance",
+		},
+		{
+			name: "monthly totals error",
+			year: 2024,
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				txRepo.EXPECT().SumBefore(mock.Anything, int64(1), start).Return(0, nil).Once()
+				txRepo.EXPECT().MonthlyTotalsBetween(mock.Anything, int64(1), mock.Anything, mock.Anything).Return(nil, errors.New("database error")).Once()
+			},
+			wantErrMsg: "failed to get monthly totals",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockTxRepo, mockAccRepo)
+			}
+
+			processor := NewGetMonthlyStatementProcessor(mockTxRepo, mockAccRepo)
+			_, err := processor.Process(context.Background(), domain.GetMonthlyStatementRequest{AccountID: 1, Year: tt.year})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErrMsg)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// GetRecentTransactionsProcessor handles the business logic for the admin recent transactions feed
+type GetRecentTransactionsProcessor struct {
+	transactionRepo ports.TransactionRepository
+}
+
+// NewGetRecentTransactionsProcessor creates a new GetRecentTransactionsProcessor
+func NewGetRecentTransactionsProcessor(transactionRepo ports.TransactionRepository) *GetRecentTransactionsProcessor {
+	return &GetRecentTransactionsProcessor{
+		transactionRepo: transactionRepo,
+	}
+}
+
+// Process returns the most recent transactions across all accounts, newest first
+func (p *GetRecentTransactionsProcessor) Process(ctx context.Context, req domain.GetRecentTransactionsRequest) (*domain.GetRecentTransactionsResponse, error) {
+	limit, _ := domain.NormalizePagination(req.Limit, 0, domain.DefaultPagination())
+
+	transactions, err := p.transactionRepo.FindRecent(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent transactions: %w", err)
+	}
+
+	if transactions == nil {
+		transactions = []*domain.RecentTransaction{}
+	}
+
+	return &domain.GetRecentTransactionsResponse{
+		Transactions: transactions,
+	}, nil
+}
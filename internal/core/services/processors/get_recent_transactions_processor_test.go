@@ -0,0 +1,107 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetRecentTransactionsProcessor_Process(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		request        domain.GetRecentTransactionsRequest
+		setupMocks     func(*mocks.MockTransactionRepository)
+		wantErr        bool
+		wantErrMessage string
+		validateResult func(*testing.T, *domain.GetRecentTransactionsResponse)
+	}{
+		{
+			name:    "successful - returns feed in repository order",
+			request: domain.GetRecentTransactionsRequest{Limit: 2},
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository) {
+				mockTxRepo.EXPECT().
+					FindRecent(mock.Anything, int64(2)).
+					Return([]*domain.RecentTransaction{
+						{Transaction: domain.Transaction{ID: 2, AccountID: 2, Amount: 100.0, EventDate: now}, DocumentNumber: "98765432100"},
+						{Transaction: domain.Transaction{ID: 1, AccountID: 1, Amount: -50.0, EventDate: now.Add(-time.Minute)}, DocumentNumber: "12345678900"},
+					}, nil).
+					Once()
+			},
+			wantErr: false,
+			validateResult: func(t *testing.T, resp *domain.GetRecentTransactionsResponse) {
+				assert.Len(t, resp.Transactions, 2)
+				assert.Equal(t, int64(2), resp.Transactions[0].ID)
+				assert.Equal(t, "98765432100", resp.Transactions[0].DocumentNumber)
+			},
+		},
+		{
+			name:    "successful - default limit applied",
+			request: domain.GetRecentTransactionsRequest{},
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository) {
+				mockTxRepo.EXPECT().
+					FindRecent(mock.Anything, int64(50)).
+					Return(nil, nil).
+					Once()
+			},
+			wantErr: false,
+			validateResult: func(t *testing.T, resp *domain.GetRecentTransactionsResponse) {
+				assert.NotNil(t, resp.Transactions, "Empty feed should be an empty list, not null")
+				assert.Empty(t, resp.Transactions)
+			},
+		},
+		{
+			name:    "successful - limit capped at maximum",
+			request: domain.GetRecentTransactionsRequest{Limit: 1000},
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository) {
+				mockTxRepo.EXPECT().
+					FindRecent(mock.Anything, int64(100)).
+					Return([]*domain.RecentTransaction{}, nil).
+					Once()
+			},
+			wantErr: false,
+		},
+		{
+			name:    "error - repository failure",
+			request: domain.GetRecentTransactionsRequest{Limit: 10},
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository) {
+				mockTxRepo.EXPECT().
+					FindRecent(mock.Anything, int64(10)).
+					Return(nil, errors.New("database error")).
+					Once()
+			},
+			wantErr:        true,
+			wantErrMessage: "failed to get recent transactions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			tt.setupMocks(mockTxRepo)
+
+			processor := NewGetRecentTransactionsProcessor(mockTxRepo)
+
+			result, err := processor.Process(context.Background(), tt.request)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrMessage)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			if tt.validateResult != nil {
+				tt.validateResult(t, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// GetStatementProcessor builds an account statement for a period
+type GetStatementProcessor struct {
+	transactionRepo ports.TransactionRepository
+	accountRepo     ports.AccountRepository
+}
+
+// NewGetStatementProcessor creates a new GetStatementProcessor
+func NewGetStatementProcessor(transactionRepo ports.TransactionRepository, accountRepo ports.AccountRepository) *GetStatementProcessor {
+	return &GetStatementProcessor{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+	}
+}
+
+// Process computes the opening balance from every transaction before the period
+// and derives the closing balance by adding the period's transactions to it
+func (p *GetStatementProcessor) Process(ctx context.Context, req domain.GetStatementRequest) (*domain.StatementResponse, error) {
+	if req.End.Before(req.Start) {
+		return nil, domain.ErrInvalidStatementPeriod
+	}
+
+	// Validate account exists
+	exists, err := p.accountRepo.Exists(ctx, req.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find account: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("account with id %d not found", req.AccountID)
+	}
+
+	opening, err := p.transactionRepo.SumBefore(ctx, req.AccountID, req.Start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute opening balance: %w", err)
+	}
+
+	transactions, err := p.transactionRepo.FindByAccountIDBetween(ctx, req.AccountID, req.Start, req.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statement transactions: %w", err)
+	}
+
+	// Ensure we return empty array instead of null
+	if transactions == nil {
+		transactions = []*domain.Transaction{}
+	}
+
+	closing := opening
+	for _, tx := range transactions {
+		closing += tx.Amount
+	}
+
+	return &domain.StatementResponse{
+		AccountID:      req.AccountID,
+		Start:          req.Start.UTC(),
+		End:            req.End.UTC(),
+		OpeningBalance: domain.RoundToCents(opening),
+		ClosingBalance: domain.RoundToCents(closing),
+		Transactions:   transactions,
+	}, nil
+}
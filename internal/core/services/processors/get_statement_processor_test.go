@@ -0,0 +1,120 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetStatementProcessor_Process(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		request        domain.GetStatementRequest
+		setupMocks     func(*mocks.MockTransactionRepository, *mocks.MockAccountRepository)
+		wantErr        bool
+		wantErrMessage string
+		validateResult func(*testing.T, *domain.StatementResponse)
+	}{
+		{
+			name:    "opening plus period net equals closing",
+			request: domain.GetStatementRequest{AccountID: 1, Start: start, End: end},
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository, mockAccRepo *mocks.MockAccountRepository) {
+				mockAccRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				mockTxRepo.EXPECT().SumBefore(mock.Anything, int64(1), start).Return(150.10, nil).Once()
+				mockTxRepo.EXPECT().
+					FindByAccountIDBetween(mock.Anything, int64(1), start, end).
+					Return([]*domain.Transaction{
+						{ID: 3, AccountID: 1, Amount: -50.05},
+						{ID: 4, AccountID: 1, Amount: 0.10},
+						{ID: 5, AccountID: 1, Amount: 20.20},
+					}, nil).
+					Once()
+			},
+			validateResult: func(t *testing.T, resp *domain.StatementResponse) {
+				assert.Equal(t, 150.10, resp.OpeningBalance)
+				assert.Len(t, resp.Transactions, 3)
+
+				net := 0.0
+				for _, tx := range resp.Transactions {
+					net += tx.Amount
+				}
+				assert.Equal(t, domain.RoundToCents(resp.OpeningBalance+net), resp.ClosingBalance)
+				assert.Equal(t, 120.35, resp.ClosingBalance)
+			},
+		},
+		{
+			name:    "empty period keeps the balance",
+			request: domain.GetStatementRequest{AccountID: 1, Start: start, End: end},
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository, mockAccRepo *mocks.MockAccountRepository) {
+				mockAccRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				mockTxRepo.EXPECT().SumBefore(mock.Anything, int64(1), start).Return(-10.0, nil).Once()
+				mockTxRepo.EXPECT().FindByAccountIDBetween(mock.Anything, int64(1), start, end).Return(nil, nil).Once()
+			},
+			validateResult: func(t *testing.T, resp *domain.StatementResponse) {
+				assert.NotNil(t, resp.Transactions)
+				assert.Empty(t, resp.Transactions)
+				assert.Equal(t, -10.0, resp.OpeningBalance)
+				assert.Equal(t, -10.0, resp.ClosingBalance)
+			},
+		},
+		{
+			name:    "account not found",
+			request: domain.GetStatementRequest{AccountID: 999, Start: start, End: end},
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository, mockAccRepo *mocks.MockAccountRepository) {
+				mockAccRepo.EXPECT().Exists(mock.Anything, int64(999)).Return(false, nil).Once()
+			},
+			wantErr:        true,
+			wantErrMessage: "account with id 999 not found",
+		},
+		{
+			name:           "end before start",
+			request:        domain.GetStatementRequest{AccountID: 1, Start: end, End: start},
+			setupMocks:     func(mockTxRepo *mocks.MockTransactionRepository, mockAccRepo *mocks.MockAccountRepository) {},
+			wantErr:        true,
+			wantErrMessage: domain.ErrInvalidStatementPeriod.Error(),
+		},
+		{
+			name:    "repository error",
+			request: domain.GetStatementRequest{AccountID: 1, Start: start, End: end},
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository, mockAccRepo *mocks.MockAccountRepository) {
+				mockAccRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+				mockTxRepo.EXPECT().SumBefore(mock.Anything, int64(1), start).Return(0, errors.New("database error")).Once()
+			},
+			wantErr:        true,
+			wantErrMessage: "failed to compute opening balance",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			tt.setupMocks(mockTxRepo, mockAccRepo)
+
+			processor := NewGetStatementProcessor(mockTxRepo, mockAccRepo)
+
+			result, err := processor.Process(context.Background(), tt.request)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrMessage)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			if tt.validateResult != nil {
+				tt.validateResult(t, result)
+			}
+		})
+	}
+}
@@ -12,58 +12,101 @@ import (
 type GetTransactionsProcessor struct {
 	transactionRepo ports.TransactionRepository
 	accountRepo     ports.AccountRepository
+	pagination      domain.PaginationDefaults
+}
+
+// GetTransactionsOption configures optional behavior of the GetTransactionsProcessor
+type GetTransactionsOption func(*GetTransactionsProcessor)
+
+// WithPaginationDefaults overrides the page size limits (domain.DefaultPagination by default)
+func WithPaginationDefaults(defaults domain.PaginationDefaults) GetTransactionsOption {
+	return func(p *GetTransactionsProcessor) {
+		p.pagination = defaults
+	}
 }
 
 // NewGetTransactionsProcessor creates a new GetTransactionsProcessor
-func NewGetTransactionsProcessor(transactionRepo ports.TransactionRepository, accountRepo ports.AccountRepository) *GetTransactionsProcessor {
-	return &GetTransactionsProcessor{
+func NewGetTransactionsProcessor(transactionRepo ports.TransactionRepository, accountRepo ports.AccountRepository, opts ...GetTransactionsOption) *GetTransactionsProcessor {
+	p := &GetTransactionsProcessor{
 		transactionRepo: transactionRepo,
 		accountRepo:     accountRepo,
+		pagination:      domain.DefaultPagination(),
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
+// Process returns a page of the account's transactions
+// Out-of-range pagination is clamped (see domain.NormalizePagination) before the query,
+// so a zero limit never reaches the repository or the page count
 func (p *GetTransactionsProcessor) Process(ctx context.Context, req domain.GetTransactionsRequest) (*domain.GetTransactionsResponse, error) {
-	// Validate and normalize pagination parameters
-	p.validatePagination(&req)
+	req.Limit, req.Offset = domain.NormalizePagination(req.Limit, req.Offset, p.pagination)
+
+	if req.From != nil && req.To != nil && req.To.Before(*req.From) {
+		return nil, domain.ErrInvalidDateRange
+	}
+
+	// A cursor keeps the order it was issued for; asking for the other one would skip or repeat rows
+	if req.Cursor != nil {
+		if req.Offset > 0 {
+			return nil, domain.ErrCursorWithOffset
+		}
+		if req.Order != "" && req.Order != req.Cursor.Order {
+			return nil, domain.ErrCursorOrderMismatch
+		}
+		req.Order = req.Cursor.Order
+	}
+	if req.Order == "" {
+		req.Order = domain.SortDescending
+	}
 
 	// Validate account exists
-	account, err := p.accountRepo.FindByID(ctx, req.AccountID)
+	exists, err := p.accountRepo.Exists(ctx, req.AccountID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find account: %w", err)
 	}
-	if account == nil {
-		return nil, fmt.Errorf("account with id %d not found", req.AccountID)
+	if !exists {
+		return nil, missingAccount(ctx, p.accountRepo, req.AccountID, fmt.Errorf("account with id %d not found", req.AccountID))
 	}
 
-	transactions, total, err := p.transactionRepo.FindByAccountIDPaginated(ctx, req.AccountID, req.Limit, req.Offset)
+	var transactions []*domain.AccountTransaction
+	var total int64
+	switch filter := req.Filter(); {
+	case filter.After != nil:
+		// The position after a cursor is unknown, so one extra row tells whether another page follows
+		transactions, total, err = p.transactionRepo.FindByAccountIDPaginatedFiltered(ctx, req.AccountID, filter, req.Limit+1, 0)
+	case !filter.IsZero():
+		transactions, total, err = p.transactionRepo.FindByAccountIDPaginatedFiltered(ctx, req.AccountID, filter, req.Limit, req.Offset)
+	default:
+		transactions, total, err = p.transactionRepo.FindByAccountIDPaginated(ctx, req.AccountID, req.Limit, req.Offset)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
 
-	// Calculate number of pages
-	pages := (total + req.Limit - 1) / req.Limit
-	if pages < 1 {
-		pages = 1
+	hasMore := req.Offset+int64(len(transactions)) < total
+	if req.Cursor != nil {
+		hasMore = int64(len(transactions)) > req.Limit
+		if hasMore {
+			transactions = transactions[:req.Limit]
+		}
+	}
+
+	// In strict mode, overshooting the result set is reported instead of returning an empty page
+	if req.StrictPagination && req.Offset > 0 && req.Offset >= total {
+		return nil, fmt.Errorf("%w (%d)", domain.ErrOffsetExceedsTotal, total)
 	}
 
 	// Build response
-	return &domain.GetTransactionsResponse{
+	response := &domain.GetTransactionsResponse{
 		Transactions: transactions,
-		Pagination: domain.PaginationMetadata{
-			Total:  total,
-			Limit:  req.Limit,
-			Offset: req.Offset,
-			Pages:  pages,
-		},
-	}, nil
-}
-
-func (p *GetTransactionsProcessor) validatePagination(req *domain.GetTransactionsRequest) {
-	// Validate pagination parameters
-	if req.Limit <= 0 || req.Limit > 100 {
-		req.Limit = 50 // Default
+		Pagination:   domain.NewPaginationMetadata(total, req.Limit, req.Offset),
 	}
-	if req.Offset < 0 {
-		req.Offset = 0 // Default
+	if hasMore && len(transactions) > 0 {
+		response.NextCursor = domain.NewTransactionCursor(&transactions[len(transactions)-1].Transaction, req.Order).Encode()
 	}
+
+	return response, nil
 }
@@ -29,12 +29,8 @@ func TestGetTransactionsProcessor_Process(t *testing.T) {
 			},
 			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository, mockAccRepo *mocks.MockAccountRepository) {
 				mockAccRepo.EXPECT().
-					FindByID(mock.Anything, int64(1)).
-					Return(&domain.Account{
-						ID: int64(1),
-						DocumentNumber: "12345678900",
-						CreatedAt:      time.Now(),
-					}, nil).
+					Exists(mock.Anything, int64(1)).
+					Return(true, nil).
 					Once()
 
 				mockTxRepo.EXPECT().
@@ -45,21 +41,21 @@ func TestGetTransactionsProcessor_Process(t *testing.T) {
 						int64(0),      // offset
 					).
 					Return(
-						[]*domain.Transaction{
-							{
+						[]*domain.AccountTransaction{
+							{Transaction: domain.Transaction{
 								ID: int64(1),
 								AccountID: int64(1),
 								OperationTypeID: domain.OperationTypePurchase,
 								Amount:          -50.0,
 								EventDate:       time.Now(),
-							},
-							{
+							}, OperationTypeDescription: "Normal Purchase"},
+							{Transaction: domain.Transaction{
 								ID: int64(2),
 								AccountID: int64(1),
 								OperationTypeID: domain.OperationTypeCreditVoucher,
 								Amount:          100.0,
 								EventDate:       time.Now(),
-							},
+							}, OperationTypeDescription: "Credit Voucher"},
 						},
 						int64(2),
 						nil,
@@ -92,14 +88,39 @@ func TestGetTransactionsProcessor_Process(t *testing.T) {
 			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository, mockAccRepo *mocks.MockAccountRepository) {
 				// Mock: Account does not exist (returns nil)
 				mockAccRepo.EXPECT().
-					FindByID(mock.Anything, int64(999)).
-					Return(nil, nil). // Returns nil, nil = account not found
+					Exists(mock.Anything, int64(999)).
+					Return(false, nil).
+					Once()
+				mockAccRepo.EXPECT().
+					IsClosed(mock.Anything, int64(999)).
+					Return(false, nil).
 					Once()
 			},
 			wantErr:        true,
 			wantErrMessage: "account with id 999 not found",
 			validateResult: nil, // Does not validate result when there is an error
 		},
+		{
+			name: "error - account is closed",
+			request: domain.GetTransactionsRequest{
+				AccountID: int64(7),
+				Limit:     10,
+				Offset:    0,
+			},
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository, mockAccRepo *mocks.MockAccountRepository) {
+				// Mock: Account was soft-deleted, so its transactions are not listed
+				mockAccRepo.EXPECT().
+					Exists(mock.Anything, int64(7)).
+					Return(false, nil).
+					Once()
+				mockAccRepo.EXPECT().
+					IsClosed(mock.Anything, int64(7)).
+					Return(true, nil).
+					Once()
+			},
+			wantErr:        true,
+			wantErrMessage: "account is closed",
+		},
 		{
 			name: "successful - account with no transactions",
 			request: domain.GetTransactionsRequest{
@@ -109,19 +130,15 @@ func TestGetTransactionsProcessor_Process(t *testing.T) {
 			},
 			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository, mockAccRepo *mocks.MockAccountRepository) {
 				mockAccRepo.EXPECT().
-					FindByID(mock.Anything, int64(1)).
-					Return(&domain.Account{
-						ID: int64(1),
-						DocumentNumber: "12345678900",
-						CreatedAt:      time.Now(),
-					}, nil).
+					Exists(mock.Anything, int64(1)).
+					Return(true, nil).
 					Once()
 
 					// Fetch transactions returns empty list
 				mockTxRepo.EXPECT().
 					FindByAccountIDPaginated(mock.Anything, int64(1), int64(50), int64(0)).
 					Return(
-						[]*domain.Transaction{}, // Empty list
+						[]*domain.AccountTransaction{}, // Empty list
 						int64(0),                // Total = 0
 						nil,
 					).
@@ -135,6 +152,53 @@ func TestGetTransactionsProcessor_Process(t *testing.T) {
 				assert.Equal(t, int64(1), resp.Pagination.Pages, "There is always at least 1 page")
 			},
 		},
+		{
+			name: "successful - overshooting offset returns empty page by default",
+			request: domain.GetTransactionsRequest{
+				AccountID: int64(1),
+				Limit:     10,
+				Offset:    20,
+			},
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository, mockAccRepo *mocks.MockAccountRepository) {
+				mockAccRepo.EXPECT().
+					Exists(mock.Anything, int64(1)).
+					Return(true, nil).
+					Once()
+
+				mockTxRepo.EXPECT().
+					FindByAccountIDPaginated(mock.Anything, int64(1), int64(10), int64(20)).
+					Return([]*domain.AccountTransaction{}, int64(5), nil).
+					Once()
+			},
+			wantErr: false,
+			validateResult: func(t *testing.T, resp *domain.GetTransactionsResponse) {
+				assert.Empty(t, resp.Transactions, "Lenient mode should return an empty page")
+				assert.Equal(t, int64(5), resp.Pagination.Total)
+				assert.Equal(t, int64(20), resp.Pagination.Offset)
+			},
+		},
+		{
+			name: "error - overshooting offset with strict pagination",
+			request: domain.GetTransactionsRequest{
+				AccountID:        int64(1),
+				Limit:            10,
+				Offset:           20,
+				StrictPagination: true,
+			},
+			setupMocks: func(mockTxRepo *mocks.MockTransactionRepository, mockAccRepo *mocks.MockAccountRepository) {
+				mockAccRepo.EXPECT().
+					Exists(mock.Anything, int64(1)).
+					Return(true, nil).
+					Once()
+
+				mockTxRepo.EXPECT().
+					FindByAccountIDPaginated(mock.Anything, int64(1), int64(10), int64(20)).
+					Return([]*domain.AccountTransaction{}, int64(5), nil).
+					Once()
+			},
+			wantErr:        true,
+			wantErrMessage: "offset exceeds total results (5)",
+		},
 	}
 
 	//LOOP: Execute each test case
@@ -170,3 +234,191 @@ func TestGetTransactionsProcessor_Process(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTransactionsProcessor_Process_ClampsPagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		limit      int64
+		offset     int64
+		wantLimit  int64
+		wantOffset int64
+		wantPages  int64
+	}{
+		{name: "zero limit uses the default", limit: 0, offset: 0, wantLimit: 50, wantOffset: 0, wantPages: 3},
+		{name: "oversized limit is capped", limit: 1000, offset: 0, wantLimit: 100, wantOffset: 0, wantPages: 2},
+		{name: "negative offset starts from the beginning", limit: 10, offset: -5, wantLimit: 10, wantOffset: 0, wantPages: 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+
+			mockAccRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+			mockTxRepo.EXPECT().
+				FindByAccountIDPaginated(mock.Anything, int64(1), tt.wantLimit, tt.wantOffset).
+				Return([]*domain.AccountTransaction{}, int64(120), nil).
+				Once()
+
+			processor := NewGetTransactionsProcessor(mockTxRepo, mockAccRepo)
+			result, err := processor.Process(context.Background(), domain.GetTransactionsRequest{
+				AccountID: 1,
+				Limit:     tt.limit,
+				Offset:    tt.offset,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLimit, result.Pagination.Limit)
+			assert.Equal(t, tt.wantOffset, result.Pagination.Offset)
+			assert.Equal(t, tt.wantPages, result.Pagination.Pages)
+		})
+	}
+}
+
+func TestGetTransactionsProcessor_Process_ConfiguredPagination(t *testing.T) {
+	mockTxRepo := mocks.NewMockTransactionRepository(t)
+	mockAccRepo := mocks.NewMockAccountRepository(t)
+
+	mockAccRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+	mockTxRepo.EXPECT().
+		FindByAccountIDPaginated(mock.Anything, int64(1), int64(500), int64(0)).
+		Return([]*domain.AccountTransaction{}, int64(0), nil).
+		Once()
+
+	processor := NewGetTransactionsProcessor(mockTxRepo, mockAccRepo,
+		WithPaginationDefaults(domain.PaginationDefaults{DefaultLimit: 20, MaxLimit: 500}))
+	_, err := processor.Process(context.Background(), domain.GetTransactionsRequest{AccountID: 1, Limit: 1000})
+
+	assert.NoError(t, err)
+}
+
+func TestGetTransactionsProcessor_Process_DateRange(t *testing.T) {
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 28, 23, 59, 59, 0, time.UTC)
+
+	t.Run("filters by the given bounds", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccRepo := mocks.NewMockAccountRepository(t)
+
+		mockAccRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+		mockTxRepo.EXPECT().
+			FindByAccountIDPaginatedFiltered(mock.Anything, int64(1), domain.TransactionFilter{From: &from, Order: domain.SortDescending}, int64(50), int64(0)).
+			Return([]*domain.AccountTransaction{{Transaction: domain.Transaction{ID: 1, AccountID: 1}}}, int64(1), nil).
+			Once()
+
+		processor := NewGetTransactionsProcessor(mockTxRepo, mockAccRepo)
+		result, err := processor.Process(context.Background(), domain.GetTransactionsRequest{AccountID: 1, From: &from})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Transactions, 1)
+		assert.Equal(t, int64(1), result.Pagination.Total)
+	})
+
+	t.Run("filters by operation type", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccRepo := mocks.NewMockAccountRepository(t)
+
+		mockAccRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+		mockTxRepo.EXPECT().
+			FindByAccountIDPaginatedFiltered(mock.Anything, int64(1), domain.TransactionFilter{OperationTypeID: 4, Order: domain.SortDescending}, int64(50), int64(0)).
+			Return([]*domain.AccountTransaction{{Transaction: domain.Transaction{ID: 2, AccountID: 1, OperationTypeID: 4}}}, int64(1), nil).
+			Once()
+
+		processor := NewGetTransactionsProcessor(mockTxRepo, mockAccRepo)
+		result, err := processor.Process(context.Background(), domain.GetTransactionsRequest{AccountID: 1, OperationTypeID: 4})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), result.Pagination.Total)
+	})
+
+	t.Run("rejects to before from", func(t *testing.T) {
+		processor := NewGetTransactionsProcessor(mocks.NewMockTransactionRepository(t), mocks.NewMockAccountRepository(t))
+		_, err := processor.Process(context.Background(), domain.GetTransactionsRequest{AccountID: 1, From: &to, To: &from})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidDateRange)
+	})
+}
+
+func TestGetTransactionsProcessor_Process_Cursor(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	page := func(ids ...int64) []*domain.AccountTransaction {
+		transactions := make([]*domain.AccountTransaction, 0, len(ids))
+		for _, id := range ids {
+			transactions = append(transactions, &domain.AccountTransaction{Transaction: domain.Transaction{ID: id, AccountID: 1, EventDate: day.AddDate(0, 0, int(id))}})
+		}
+		return transactions
+	}
+	cursor := &domain.TransactionCursor{Order: domain.SortAscending, EventDate: day.AddDate(0, 0, 2), ID: 2}
+
+	t.Run("first page by offset returns a cursor when more remain", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccRepo := mocks.NewMockAccountRepository(t)
+
+		mockAccRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+		mockTxRepo.EXPECT().
+			FindByAccountIDPaginatedFiltered(mock.Anything, int64(1), domain.TransactionFilter{Order: domain.SortAscending}, int64(2), int64(0)).
+			Return(page(1, 2), int64(5), nil).
+			Once()
+
+		processor := NewGetTransactionsProcessor(mockTxRepo, mockAccRepo)
+		result, err := processor.Process(context.Background(), domain.GetTransactionsRequest{AccountID: 1, Limit: 2, Order: domain.SortAscending})
+
+		assert.NoError(t, err)
+		next, err := domain.DecodeTransactionCursor(result.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, cursor, next)
+	})
+
+	t.Run("cursor page fetches one extra row to detect the next page", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccRepo := mocks.NewMockAccountRepository(t)
+
+		mockAccRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+		mockTxRepo.EXPECT().
+			FindByAccountIDPaginatedFiltered(mock.Anything, int64(1), domain.TransactionFilter{Order: domain.SortAscending, After: cursor}, int64(3), int64(0)).
+			Return(page(3, 4, 5), int64(5), nil).
+			Once()
+
+		processor := NewGetTransactionsProcessor(mockTxRepo, mockAccRepo)
+		result, err := processor.Process(context.Background(), domain.GetTransactionsRequest{AccountID: 1, Limit: 2, Cursor: cursor})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Transactions, 2)
+		next, err := domain.DecodeTransactionCursor(result.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), next.ID)
+		assert.Equal(t, domain.SortAscending, next.Order, "The cursor keeps its order")
+	})
+
+	t.Run("last cursor page has no next cursor", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccRepo := mocks.NewMockAccountRepository(t)
+
+		mockAccRepo.EXPECT().Exists(mock.Anything, int64(1)).Return(true, nil).Once()
+		mockTxRepo.EXPECT().
+			FindByAccountIDPaginatedFiltered(mock.Anything, int64(1), domain.TransactionFilter{Order: domain.SortAscending, After: cursor}, int64(3), int64(0)).
+			Return(page(3, 4), int64(4), nil).
+			Once()
+
+		processor := NewGetTransactionsProcessor(mockTxRepo, mockAccRepo)
+		result, err := processor.Process(context.Background(), domain.GetTransactionsRequest{AccountID: 1, Limit: 2, Cursor: cursor})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Transactions, 2)
+		assert.Empty(t, result.NextCursor)
+	})
+
+	t.Run("rejects a cursor for the other order", func(t *testing.T) {
+		processor := NewGetTransactionsProcessor(mocks.NewMockTransactionRepository(t), mocks.NewMockAccountRepository(t))
+		_, err := processor.Process(context.Background(), domain.GetTransactionsRequest{AccountID: 1, Order: domain.SortDescending, Cursor: cursor})
+
+		assert.ErrorIs(t, err, domain.ErrCursorOrderMismatch)
+	})
+
+	t.Run("rejects a cursor with an offset", func(t *testing.T) {
+		processor := NewGetTransactionsProcessor(mocks.NewMockTransactionRepository(t), mocks.NewMockAccountRepository(t))
+		_, err := processor.Process(context.Background(), domain.GetTransactionsRequest{AccountID: 1, Offset: 10, Cursor: cursor})
+
+		assert.ErrorIs(t, err, domain.ErrCursorWithOffset)
+	})
+}
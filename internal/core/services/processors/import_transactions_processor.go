@@ -0,0 +1,133 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// ImportTransactionsProcessor handles the business logic for importing transactions in bulk
+type ImportTransactionsProcessor struct {
+	creator         *CreateTransactionProcessor
+	transactionRepo ports.TransactionRepository
+	batchSize       int
+}
+
+// ImportTransactionsOption configures optional behavior of the ImportTransactionsProcessor
+type ImportTransactionsOption func(*ImportTransactionsProcessor)
+
+// WithImportBatchSize sets how many transactions are written per database transaction
+func WithImportBatchSize(size int) ImportTransactionsOption {
+	return func(p *ImportTransactionsProcessor) {
+		if size > 0 {
+			p.batchSize = size
+		}
+	}
+}
+
+// NewImportTransactionsProcessor creates a new ImportTransactionsProcessor
+// Every line goes through the same rules as a single transaction created by creator
+func NewImportTransactionsProcessor(creator *CreateTransactionProcessor, transactionRepo ports.TransactionRepository, opts ...ImportTransactionsOption) *ImportTransactionsProcessor {
+	p := &ImportTransactionsProcessor{
+		creator:         creator,
+		transactionRepo: transactionRepo,
+		batchSize:       domain.DefaultImportBatchSize,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// pendingImport is a prepared transaction waiting for its batch to be written
+type pendingImport struct {
+	line          int64
+	transaction   *domain.Transaction
+	operationType *domain.OperationType
+}
+
+// Process reads lines from next until it returns io.EOF, storing the valid ones in batches
+// A failing line is reported in the summary and does not stop the import; a failing batch fails all its lines.
+// Credits, balance-checked lines and purchases split into installments are stored on their own, after the pending
+// batch, so they see every earlier line.
+// Duplicate detection is not applied to imports.
+func (p *ImportTransactionsProcessor) Process(ctx context.Context, next func() (*domain.ImportLine, error)) (*domain.ImportTransactionsResponse, error) {
+	response := domain.NewImportTransactionsResponse()
+	batch := make([]pendingImport, 0, p.batchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		p.flush(ctx, batch, response)
+		batch = batch[:0]
+	}
+
+	for {
+		line, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// The rest of the input cannot be read; keep what was read so far
+			flush()
+			return response, fmt.Errorf("failed to read import: %w", err)
+		}
+
+		response.Processed++
+		if line.Err != nil {
+			response.Fail(line.Number, line.Err)
+			continue
+		}
+
+		transaction, operationType, account, err := p.creator.prepare(ctx, line.Request)
+		if err != nil {
+			response.Fail(line.Number, err)
+			continue
+		}
+
+		if operationType.IsCreditOperation() || line.Request.ExpectedBalance != nil || line.Request.InstallmentCount() > 1 {
+			flush()
+			if _, err := p.creator.store(ctx, transaction, operationType, account, line.Request.InstallmentCount(), line.Request.ExpectedBalance); err != nil {
+				response.Fail(line.Number, err)
+				continue
+			}
+			response.Succeeded++
+			continue
+		}
+
+		batch = append(batch, pendingImport{line: line.Number, transaction: transaction, operationType: operationType})
+		if len(batch) >= p.batchSize {
+			flush()
+		}
+	}
+
+	flush()
+	return response, nil
+}
+
+// flush writes the batch in a single database transaction
+func (p *ImportTransactionsProcessor) flush(ctx context.Context, batch []pendingImport, response *domain.ImportTransactionsResponse) {
+	transactions := make([]*domain.Transaction, len(batch))
+	for i, pending := range batch {
+		transactions[i] = pending.transaction
+	}
+
+	created, err := p.transactionRepo.CreateBatch(ctx, transactions)
+	if err != nil {
+		err = fmt.Errorf("failed to create transaction: %w", err)
+		for _, pending := range batch {
+			response.Fail(pending.line, err)
+		}
+		return
+	}
+
+	for i, transaction := range created {
+		p.creator.recordCreated(batch[i].operationType, transaction.Amount)
+	}
+	response.Succeeded += int64(len(created))
+}
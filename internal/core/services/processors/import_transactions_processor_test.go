@@ -0,0 +1,144 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// importLines feeds the lines to the processor one at a time, then io.EOF
+func importLines(lines ...domain.ImportLine) func() (*domain.ImportLine, error) {
+	return func() (*domain.ImportLine, error) {
+		if len(lines) == 0 {
+			return nil, io.EOF
+		}
+		line := lines[0]
+		lines = lines[1:]
+		return &line, nil
+	}
+}
+
+func newImportTestProcessor(t *testing.T, opts ...ImportTransactionsOption) (*ImportTransactionsProcessor, *mocks.MockTransactionRepository, *mocks.MockOperationTypeRepository) {
+	txRepo := mocks.NewMockTransactionRepository(t)
+	accRepo := mocks.NewMockAccountRepository(t)
+	opRepo := mocks.NewMockOperationTypeRepository(t)
+
+	accRepo.EXPECT().FindByID(mock.Anything, int64(1)).
+		Return(&domain.Account{ID: 1, CreatedAt: time.Now().Add(-time.Hour)}, nil).Maybe()
+	opRepo.EXPECT().FindByID(mock.Anything, int64(domain.OperationTypePurchase)).
+		Return(&domain.OperationType{ID: domain.OperationTypePurchase}, nil).Maybe()
+	opRepo.EXPECT().FindByID(mock.Anything, int64(99)).Return(nil, nil).Maybe()
+
+	creator := NewCreateTransactionProcessor(txRepo, accRepo, opRepo)
+	return NewImportTransactionsProcessor(creator, txRepo, opts...), txRepo, opRepo
+}
+
+func purchaseLine(number int64, amount float64) domain.ImportLine {
+	return domain.ImportLine{
+		Number:  number,
+		Request: domain.CreateTransactionRequest{AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: amount},
+	}
+}
+
+func TestImportTransactionsProcessor_Process_BatchesValidLines(t *testing.T) {
+	processor, txRepo, _ := newImportTestProcessor(t, WithImportBatchSize(2))
+
+	var batches [][]float64
+	txRepo.EXPECT().CreateBatch(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, transactions []*domain.Transaction) ([]*domain.Transaction, error) {
+			var amounts []float64
+			for _, tx := range transactions {
+				amounts = append(amounts, tx.Amount)
+			}
+			batches = append(batches, amounts)
+			return transactions, nil
+		}).Times(2)
+
+	response, err := processor.Process(context.Background(), importLines(
+		purchaseLine(1, 10),
+		purchaseLine(2, 20),
+		domain.ImportLine{Number: 3, Err: errors.New("Invalid request body: malformed JSON")},
+		domain.ImportLine{Number: 4, Request: domain.CreateTransactionRequest{AccountID: 1, OperationTypeID: 99, Amount: 5}},
+		purchaseLine(5, 30),
+	))
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), response.Processed)
+	assert.Equal(t, int64(3), response.Succeeded)
+	assert.Equal(t, []domain.ImportFailure{
+		{Line: 3, Error: "Invalid request body: malformed JSON"},
+		{Line: 4, Error: domain.ErrInvalidOperationType.Error()},
+	}, response.Failed)
+	assert.Equal(t, [][]float64{{-10, -20}, {-30}}, batches, "Amounts are normalized and written two at a time")
+}
+
+func TestImportTransactionsProcessor_Process_FailedBatchFailsItsLines(t *testing.T) {
+	processor, txRepo, _ := newImportTestProcessor(t)
+
+	txRepo.EXPECT().CreateBatch(mock.Anything, mock.Anything).Return(nil, errors.New("disk full")).Once()
+
+	response, err := processor.Process(context.Background(), importLines(purchaseLine(1, 10), purchaseLine(2, 20)))
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), response.Processed)
+	assert.Zero(t, response.Succeeded)
+	require.Len(t, response.Failed, 2)
+	assert.Equal(t, int64(1), response.Failed[0].Line)
+	assert.Contains(t, response.Failed[1].Error, "disk full")
+}
+
+func TestImportTransactionsProcessor_Process_CreditsAreStoredAfterThePendingBatch(t *testing.T) {
+	processor, txRepo, opRepo := newImportTestProcessor(t)
+
+	opRepo.EXPECT().FindByID(mock.Anything, int64(domain.OperationTypeCreditVoucher)).
+		Return(&domain.OperationType{ID: domain.OperationTypeCreditVoucher, IsCredit: true}, nil).Once()
+
+	debt := &domain.Transaction{ID: 1, AccountID: 1, Amount: -10, Balance: -10}
+	batchCall := txRepo.EXPECT().CreateBatch(mock.Anything, mock.Anything).Return([]*domain.Transaction{debt}, nil).Once()
+	txRepo.EXPECT().FindOutstandingByAccountID(mock.Anything, int64(1)).Return([]*domain.Transaction{debt}, nil).Once().
+		NotBefore(batchCall)
+	txRepo.EXPECT().CreateWithDischarge(mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+		return tx.Amount == 15 && tx.Balance == 5
+	}), []domain.Discharge{{TransactionID: 1, Previous: -10, Balance: 0}}, (*float64)(nil)).
+		Return(&domain.Transaction{ID: 2, AccountID: 1, Amount: 15, Balance: 5}, nil).Once()
+
+	response, err := processor.Process(context.Background(), importLines(
+		purchaseLine(1, 10),
+		domain.ImportLine{Number: 2, Request: domain.CreateTransactionRequest{AccountID: 1, OperationTypeID: domain.OperationTypeCreditVoucher, Amount: 15}},
+	))
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), response.Succeeded)
+	assert.Empty(t, response.Failed)
+}
+
+func TestImportTransactionsProcessor_Process_ReadErrorKeepsEarlierLines(t *testing.T) {
+	processor, txRepo, _ := newImportTestProcessor(t)
+
+	txRepo.EXPECT().CreateBatch(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, transactions []*domain.Transaction) ([]*domain.Transaction, error) {
+			return transactions, nil
+		}).Once()
+
+	read := importLines(purchaseLine(1, 10))
+	calls := 0
+	response, err := processor.Process(context.Background(), func() (*domain.ImportLine, error) {
+		calls++
+		if calls > 1 {
+			return nil, errors.New("connection reset")
+		}
+		return read()
+	})
+
+	assert.ErrorContains(t, err, "connection reset")
+	require.NotNil(t, response)
+	assert.Equal(t, int64(1), response.Succeeded)
+}
@@ -0,0 +1,40 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// ListAccountsProcessor pages through every account
+type ListAccountsProcessor struct {
+	accountRepo ports.AccountRepository
+}
+
+// NewListAccountsProcessor creates a new ListAccountsProcessor
+func NewListAccountsProcessor(accountRepo ports.AccountRepository) *ListAccountsProcessor {
+	return &ListAccountsProcessor{
+		accountRepo: accountRepo,
+	}
+}
+
+// Process returns one page of accounts
+// Pagination is expected to be normalized already (see domain.NormalizePagination)
+func (p *ListAccountsProcessor) Process(ctx context.Context, req domain.ListAccountsRequest) (*domain.ListAccountsResponse, error) {
+	accounts, total, err := p.accountRepo.GetAllPaginated(ctx, req.Limit, req.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	// Ensure we return empty array instead of null
+	if accounts == nil {
+		accounts = []*domain.Account{}
+	}
+
+	return &domain.ListAccountsResponse{
+		Accounts:   accounts,
+		Pagination: domain.NewPaginationMetadata(total, req.Limit, req.Offset),
+	}, nil
+}
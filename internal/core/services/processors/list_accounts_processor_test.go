@@ -0,0 +1,83 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAccountsProcessor_Process(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            domain.ListAccountsRequest
+		setupMock      func(*mocks.MockAccountRepository)
+		wantCount      int
+		wantPagination domain.PaginationMetadata
+		wantErr        string
+	}{
+		{
+			name: "no accounts",
+			req:  domain.ListAccountsRequest{Limit: 50},
+			setupMock: func(accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().GetAllPaginated(mock.Anything, int64(50), int64(0)).Return(nil, int64(0), nil).Once()
+			},
+			wantCount:      0,
+			wantPagination: domain.PaginationMetadata{Total: 0, Limit: 50, Offset: 0, Pages: 1},
+		},
+		{
+			name: "single page",
+			req:  domain.ListAccountsRequest{Limit: 50},
+			setupMock: func(accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().GetAllPaginated(mock.Anything, int64(50), int64(0)).
+					Return([]*domain.Account{{ID: 2}, {ID: 1}}, int64(2), nil).
+					Once()
+			},
+			wantCount:      2,
+			wantPagination: domain.PaginationMetadata{Total: 2, Limit: 50, Offset: 0, Pages: 1},
+		},
+		{
+			name: "middle of several pages",
+			req:  domain.ListAccountsRequest{Limit: 2, Offset: 2},
+			setupMock: func(accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().GetAllPaginated(mock.Anything, int64(2), int64(2)).
+					Return([]*domain.Account{{ID: 3}, {ID: 2}}, int64(5), nil).
+					Once()
+			},
+			wantCount:      2,
+			wantPagination: domain.PaginationMetadata{Total: 5, Limit: 2, Offset: 2, Pages: 3},
+		},
+		{
+			name: "repository error",
+			req:  domain.ListAccountsRequest{Limit: 50},
+			setupMock: func(accRepo *mocks.MockAccountRepository) {
+				accRepo.EXPECT().GetAllPaginated(mock.Anything, int64(50), int64(0)).Return(nil, int64(0), errors.New("database error")).Once()
+			},
+			wantErr: "failed to list accounts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAccRepo := mocks.NewMockAccountRepository(t)
+			tt.setupMock(mockAccRepo)
+
+			processor := NewListAccountsProcessor(mockAccRepo)
+			result, err := processor.Process(context.Background(), tt.req)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, result.Accounts)
+			assert.Len(t, result.Accounts, tt.wantCount)
+			assert.Equal(t, tt.wantPagination, result.Pagination)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package processors
+
+import (
+	"context"
+	"sync"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// ListOperationTypesProcessor lists the operation types with their localized descriptions
+// Operation types are static after seeding, so they are loaded once and served from memory
+type ListOperationTypesProcessor struct {
+	operationTypeRepo ports.OperationTypeRepository
+
+	mu         sync.RWMutex
+	cached     []*domain.OperationType
+	directions map[int64]string
+}
+
+func NewListOperationTypesProcessor(operationTypeRepo ports.OperationTypeRepository) *ListOperationTypesProcessor {
+	return &ListOperationTypesProcessor{
+		operationTypeRepo: operationTypeRepo,
+	}
+}
+
+// Process returns the cached operation types, loading them on first use
+func (p *ListOperationTypesProcessor) Process(ctx context.Context) (*domain.ListOperationTypesResponse, error) {
+	p.mu.RLock()
+	cached := p.cached
+	p.mu.RUnlock()
+
+	if cached == nil {
+		if err := p.Refresh(ctx); err != nil {
+			return nil, err
+		}
+
+		p.mu.RLock()
+		cached = p.cached
+		p.mu.RUnlock()
+	}
+
+	return &domain.ListOperationTypesResponse{
+		OperationTypes: cached,
+	}, nil
+}
+
+// Directions returns the cached direction of each operation type, loading them on first use
+func (p *ListOperationTypesProcessor) Directions(ctx context.Context) (map[int64]string, error) {
+	p.mu.RLock()
+	directions := p.directions
+	p.mu.RUnlock()
+
+	if directions == nil {
+		if err := p.Refresh(ctx); err != nil {
+			return nil, err
+		}
+
+		p.mu.RLock()
+		directions = p.directions
+		p.mu.RUnlock()
+	}
+
+	return directions, nil
+}
+
+// Refresh reloads the operation types from the repository, e.g. after re-seeding
+func (p *ListOperationTypesProcessor) Refresh(ctx context.Context) error {
+	operationTypes, err := p.operationTypeRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Ensure we return empty array instead of null
+	if operationTypes == nil {
+		operationTypes = []*domain.OperationType{}
+	}
+
+	directions := domain.OperationTypeDirections(operationTypes)
+
+	p.mu.Lock()
+	p.cached = operationTypes
+	p.directions = directions
+	p.mu.Unlock()
+
+	return nil
+}
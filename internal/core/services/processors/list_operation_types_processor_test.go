@@ -0,0 +1,197 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListOperationTypesProcessor_Process(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.MockOperationTypeRepository)
+		wantErr        bool
+		wantErrMessage string
+		wantCount      int
+	}{
+		{
+			name: "returns localized operation types",
+			setupMocks: func(mockRepo *mocks.MockOperationTypeRepository) {
+				mockRepo.EXPECT().
+					GetAll(mock.Anything).
+					Return([]*domain.OperationType{
+						{ID: 1, Description: "COMPRA A VISTA"},
+						{ID: 4, Description: "PAGAMENTO"},
+					}, nil).
+					Once()
+			},
+			wantCount: 2,
+		},
+		{
+			name: "empty list instead of null",
+			setupMocks: func(mockRepo *mocks.MockOperationTypeRepository) {
+				mockRepo.EXPECT().
+					GetAll(mock.Anything).
+					Return(nil, nil).
+					Once()
+			},
+			wantCount: 0,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(mockRepo *mocks.MockOperationTypeRepository) {
+				mockRepo.EXPECT().
+					GetAll(mock.Anything).
+					Return(nil, errors.New("database error")).
+					Once()
+			},
+			wantErr:        true,
+			wantErrMessage: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockOperationTypeRepository(t)
+			tt.setupMocks(mockRepo)
+
+			processor := NewListOperationTypesProcessor(mockRepo)
+
+			result, err := processor.Process(context.Background())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrMessage)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result.OperationTypes)
+			assert.Len(t, result.OperationTypes, tt.wantCount)
+		})
+	}
+}
+
+func TestListOperationTypesProcessor_ServesFromCache(t *testing.T) {
+	mockRepo := mocks.NewMockOperationTypeRepository(t)
+	mockRepo.EXPECT().
+		GetAll(mock.Anything).
+		Return([]*domain.OperationType{
+			{ID: 1, Description: "Normal Purchase"},
+			{ID: 2, Description: "Purchase with installments"},
+			{ID: 3, Description: "Withdrawal"},
+			{ID: 4, Description: "Credit Voucher"},
+		}, nil).
+		Once()
+
+	processor := NewListOperationTypesProcessor(mockRepo)
+
+	for i := 0; i < 3; i++ {
+		result, err := processor.Process(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, result.OperationTypes, 4)
+	}
+
+	// Only the first call reached the repository
+	mockRepo.AssertNumberOfCalls(t, "GetAll", 1)
+}
+
+func TestListOperationTypesProcessor_Refresh(t *testing.T) {
+	mockRepo := mocks.NewMockOperationTypeRepository(t)
+	mockRepo.EXPECT().
+		GetAll(mock.Anything).
+		Return([]*domain.OperationType{{ID: 1, Description: "Normal Purchase"}}, nil).
+		Once()
+	mockRepo.EXPECT().
+		GetAll(mock.Anything).
+		Return([]*domain.OperationType{{ID: 1, Description: "COMPRA A VISTA"}}, nil).
+		Once()
+
+	processor := NewListOperationTypesProcessor(mockRepo)
+
+	result, err := processor.Process(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "Normal Purchase", result.OperationTypes[0].Description)
+
+	assert.NoError(t, processor.Refresh(context.Background()))
+
+	result, err = processor.Process(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "COMPRA A VISTA", result.OperationTypes[0].Description)
+	mockRepo.AssertNumberOfCalls(t, "GetAll", 2)
+}
+
+func TestListOperationTypesProcessor_DoesNotCacheErrors(t *testing.T) {
+	mockRepo := mocks.NewMockOperationTypeRepository(t)
+	mockRepo.EXPECT().
+		GetAll(mock.Anything).
+		Return(nil, errors.New("database error")).
+		Once()
+	mockRepo.EXPECT().
+		GetAll(mock.Anything).
+		Return([]*domain.OperationType{{ID: 1, Description: "Normal Purchase"}}, nil).
+		Once()
+
+	processor := NewListOperationTypesProcessor(mockRepo)
+
+	_, err := processor.Process(context.Background())
+	assert.Error(t, err)
+
+	result, err := processor.Process(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, result.OperationTypes, 1)
+}
+
+func TestListOperationTypesProcessor_Directions(t *testing.T) {
+	operationTypes, err := domain.OperationTypes(domain.DefaultLocale)
+	assert.NoError(t, err)
+
+	mockRepo := mocks.NewMockOperationTypeRepository(t)
+	mockRepo.EXPECT().
+		GetAll(mock.Anything).
+		Return(operationTypes, nil).
+		Once()
+
+	processor := NewListOperationTypesProcessor(mockRepo)
+
+	directions, err := processor.Directions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64]string{1: "debit", 2: "debit", 3: "debit", 4: "credit"}, directions)
+
+	// The map matches the domain classification of every type
+	for _, ot := range operationTypes {
+		if ot.IsCreditOperation() {
+			assert.Equal(t, domain.DirectionCredit, directions[ot.ID])
+		} else {
+			assert.True(t, ot.IsDebitOperation())
+			assert.Equal(t, domain.DirectionDebit, directions[ot.ID])
+		}
+	}
+
+	// Served from the same cache as Process
+	_, err = processor.Process(context.Background())
+	assert.NoError(t, err)
+	_, err = processor.Directions(context.Background())
+	assert.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "GetAll", 1)
+}
+
+func TestListOperationTypesProcessor_Directions_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewMockOperationTypeRepository(t)
+	mockRepo.EXPECT().
+		GetAll(mock.Anything).
+		Return(nil, errors.New("database error")).
+		Once()
+
+	processor := NewListOperationTypesProcessor(mockRepo)
+
+	directions, err := processor.Directions(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, directions)
+}
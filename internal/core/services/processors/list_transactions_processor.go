@@ -0,0 +1,40 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// ListTransactionsProcessor pages through the transactions of every account
+type ListTransactionsProcessor struct {
+	transactionRepo ports.TransactionRepository
+}
+
+// NewListTransactionsProcessor creates a new ListTransactionsProcessor
+func NewListTransactionsProcessor(transactionRepo ports.TransactionRepository) *ListTransactionsProcessor {
+	return &ListTransactionsProcessor{
+		transactionRepo: transactionRepo,
+	}
+}
+
+// Process returns one page of the system-wide listing
+// Pagination is expected to be normalized already (see domain.NormalizePagination)
+func (p *ListTransactionsProcessor) Process(ctx context.Context, req domain.ListTransactionsRequest) (*domain.ListTransactionsResponse, error) {
+	transactions, total, err := p.transactionRepo.FindAllPaginated(ctx, req.Limit, req.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+
+	// Ensure we return empty array instead of null
+	if transactions == nil {
+		transactions = []*domain.Transaction{}
+	}
+
+	return &domain.ListTransactionsResponse{
+		Transactions: transactions,
+		Pagination:   domain.NewPaginationMetadata(total, req.Limit, req.Offset),
+	}, nil
+}
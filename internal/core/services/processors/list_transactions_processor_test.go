@@ -0,0 +1,72 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTransactionsProcessor_Process(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            domain.ListTransactionsRequest
+		setupMock      func(*mocks.MockTransactionRepository)
+		wantCount      int
+		wantPagination domain.PaginationMetadata
+		wantErr        string
+	}{
+		{
+			name: "middle page",
+			req:  domain.ListTransactionsRequest{Limit: 2, Offset: 2},
+			setupMock: func(txRepo *mocks.MockTransactionRepository) {
+				txRepo.EXPECT().FindAllPaginated(mock.Anything, int64(2), int64(2)).
+					Return([]*domain.Transaction{{ID: 3, AccountID: 2}, {ID: 2, AccountID: 1}}, int64(5), nil).
+					Once()
+			},
+			wantCount:      2,
+			wantPagination: domain.PaginationMetadata{Total: 5, Limit: 2, Offset: 2, Pages: 3},
+		},
+		{
+			name: "no transactions",
+			req:  domain.ListTransactionsRequest{Limit: 50},
+			setupMock: func(txRepo *mocks.MockTransactionRepository) {
+				txRepo.EXPECT().FindAllPaginated(mock.Anything, int64(50), int64(0)).Return(nil, int64(0), nil).Once()
+			},
+			wantCount:      0,
+			wantPagination: domain.PaginationMetadata{Total: 0, Limit: 50, Offset: 0, Pages: 1},
+		},
+		{
+			name: "repository error",
+			req:  domain.ListTransactionsRequest{Limit: 50},
+			setupMock: func(txRepo *mocks.MockTransactionRepository) {
+				txRepo.EXPECT().FindAllPaginated(mock.Anything, int64(50), int64(0)).Return(nil, int64(0), errors.New("database error")).Once()
+			},
+			wantErr: "failed to list transactions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxRepo := mocks.NewMockTransactionRepository(t)
+			tt.setupMock(mockTxRepo)
+
+			processor := NewListTransactionsProcessor(mockTxRepo)
+			result, err := processor.Process(context.Background(), tt.req)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, result.Transactions)
+			assert.Len(t, result.Transactions, tt.wantCount)
+			assert.Equal(t, tt.wantPagination, result.Pagination)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCanDebitProcessorInterface is an autogenerated mock type for the CanDebitProcessorInterface type
+type MockCanDebitProcessorInterface struct {
+	mock.Mock
+}
+
+type MockCanDebitProcessorInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCanDebitProcessorInterface) EXPECT() *MockCanDebitProcessorInterface_Expecter {
+	return &MockCanDebitProcessorInterface_Expecter{mock: &_m.Mock}
+}
+
+// Process provides a mock function with given fields: ctx, req
+func (_m *MockCanDebitProcessorInterface) Process(ctx context.Context, req domain.CanDebitRequest) (*domain.CanDebitResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Process")
+	}
+
+	var r0 *domain.CanDebitResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CanDebitRequest) (*domain.CanDebitResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CanDebitRequest) *domain.CanDebitResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CanDebitResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CanDebitRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCanDebitProcessorInterface_Process_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Process'
+type MockCanDebitProcessorInterface_Process_Call struct {
+	*mock.Call
+}
+
+// Process is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req domain.CanDebitRequest
+func (_e *MockCanDebitProcessorInterface_Expecter) Process(ctx interface{}, req interface{}) *MockCanDebitProcessorInterface_Process_Call {
+	return &MockCanDebitProcessorInterface_Process_Call{Call: _e.mock.On("Process", ctx, req)}
+}
+
+func (_c *MockCanDebitProcessorInterface_Process_Call) Run(run func(ctx context.Context, req domain.CanDebitRequest)) *MockCanDebitProcessorInterface_Process_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CanDebitRequest))
+	})
+	return _c
+}
+
+func (_c *MockCanDebitProcessorInterface_Process_Call) Return(_a0 *domain.CanDebitResponse, _a1 error) *MockCanDebitProcessorInterface_Process_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCanDebitProcessorInterface_Process_Call) RunAndReturn(run func(context.Context, domain.CanDebitRequest) (*domain.CanDebitResponse, error)) *MockCanDebitProcessorInterface_Process_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCanDebitProcessorInterface creates a new instance of MockCanDebitProcessorInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCanDebitProcessorInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCanDebitProcessorInterface {
+	mock := &MockCanDebitProcessorInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCloseAccountProcessorInterface is an autogenerated mock type for the CloseAccountProcessorInterface type
+type MockCloseAccountProcessorInterface struct {
+	mock.Mock
+}
+
+type MockCloseAccountProcessorInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCloseAccountProcessorInterface) EXPECT() *MockCloseAccountProcessorInterface_Expecter {
+	return &MockCloseAccountProcessorInterface_Expecter{mock: &_m.Mock}
+}
+
+// Process provides a mock function with given fields: ctx, req
+func (_m *MockCloseAccountProcessorInterface) Process(ctx context.Context, req domain.CloseAccountRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Process")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CloseAccountRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCloseAccountProcessorInterface_Process_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Process'
+type MockCloseAccountProcessorInterface_Process_Call struct {
+	*mock.Call
+}
+
+// Process is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req domain.CloseAccountRequest
+func (_e *MockCloseAccountProcessorInterface_Expecter) Process(ctx interface{}, req interface{}) *MockCloseAccountProcessorInterface_Process_Call {
+	return &MockCloseAccountProcessorInterface_Process_Call{Call: _e.mock.On("Process", ctx, req)}
+}
+
+func (_c *MockCloseAccountProcessorInterface_Process_Call) Run(run func(ctx context.Context, req domain.CloseAccountRequest)) *MockCloseAccountProcessorInterface_Process_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CloseAccountRequest))
+	})
+	return _c
+}
+
+func (_c *MockCloseAccountProcessorInterface_Process_Call) Return(_a0 error) *MockCloseAccountProcessorInterface_Process_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCloseAccountProcessorInterface_Process_Call) RunAndReturn(run func(context.Context, domain.CloseAccountRequest) error) *MockCloseAccountProcessorInterface_Process_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCloseAccountProcessorInterface creates a new instance of MockCloseAccountProcessorInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCloseAccountProcessorInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCloseAccountProcessorInterface {
+	mock := &MockCloseAccountProcessorInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,85 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockExportTransactionsProcessorInterface is an autogenerated mock type for the ExportTransactionsProcessorInterface type
+type MockExportTransactionsProcessorInterface struct {
+	mock.Mock
+}
+
+type MockExportTransactionsProcessorInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportTransactionsProcessorInterface) EXPECT() *MockExportTransactionsProcessorInterface_Expecter {
+	return &MockExportTransactionsProcessorInterface_Expecter{mock: &_m.Mock}
+}
+
+// Process provides a mock function with given fields: ctx, req, emit
+func (_m *MockExportTransactionsProcessorInterface) Process(ctx context.Context, req domain.ExportTransactionsRequest, emit func(*domain.Transaction) error) error {
+	ret := _m.Called(ctx, req, emit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Process")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ExportTransactionsRequest, func(*domain.Transaction) error) error); ok {
+		r0 = rf(ctx, req, emit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExportTransactionsProcessorInterface_Process_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Process'
+type MockExportTransactionsProcessorInterface_Process_Call struct {
+	*mock.Call
+}
+
+// Process is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req domain.ExportTransactionsRequest
+//   - emit func(*domain.Transaction) error
+func (_e *MockExportTransactionsProcessorInterface_Expecter) Process(ctx interface{}, req interface{}, emit interface{}) *MockExportTransactionsProcessorInterface_Process_Call {
+	return &MockExportTransactionsProcessorInterface_Process_Call{Call: _e.mock.On("Process", ctx, req, emit)}
+}
+
+func (_c *MockExportTransactionsProcessorInterface_Process_Call) Run(run func(ctx context.Context, req domain.ExportTransactionsRequest, emit func(*domain.Transaction) error)) *MockExportTransactionsProcessorInterface_Process_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ExportTransactionsRequest), args[2].(func(*domain.Transaction) error))
+	})
+	return _c
+}
+
+func (_c *MockExportTransactionsProcessorInterface_Process_Call) Return(_a0 error) *MockExportTransactionsProcessorInterface_Process_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExportTransactionsProcessorInterface_Process_Call) RunAndReturn(run func(context.Context, domain.ExportTransactionsRequest, func(*domain.Transaction) error) error) *MockExportTransactionsProcessorInterface_Process_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExportTransactionsProcessorInterface creates a new instance of MockExportTransactionsProcessorInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportTransactionsProcessorInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportTransactionsProcessorInterface {
+	mock := &MockExportTransactionsProcessorInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
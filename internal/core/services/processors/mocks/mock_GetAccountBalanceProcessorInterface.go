@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGetAccountBalanceProcessorInterface is an autogenerated mock type for the GetAccountBalanceProcessorInterface type
+type MockGetAccountBalanceProcessorInterface struct {
+	mock.Mock
+}
+
+type MockGetAccountBalanceProcessorInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGetAccountBalanceProcessorInterface) EXPECT() *MockGetAccountBalanceProcessorInterface_Expecter {
+	return &MockGetAccountBalanceProcessorInterface_Expecter{mock: &_m.Mock}
+}
+
+// Process provides a mock function with given fields: ctx, req
+func (_m *MockGetAccountBalanceProcessorInterface) Process(ctx context.Context, req domain.GetAccountBalanceRequest) (*domain.GetAccountBalanceResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Process")
+	}
+
+	var r0 *domain.GetAccountBalanceResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetAccountBalanceRequest) (*domain.GetAccountBalanceResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetAccountBalanceRequest) *domain.GetAccountBalanceResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.GetAccountBalanceResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.GetAccountBalanceRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetAccountBalanceProcessorInterface_Process_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Process'
+type MockGetAccountBalanceProcessorInterface_Process_Call struct {
+	*mock.Call
+}
+
+// Process is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req domain.GetAccountBalanceRequest
+func (_e *MockGetAccountBalanceProcessorInterface_Expecter) Process(ctx interface{}, req interface{}) *MockGetAccountBalanceProcessorInterface_Process_Call {
+	return &MockGetAccountBalanceProcessorInterface_Process_Call{Call: _e.mock.On("Process", ctx, req)}
+}
+
+func (_c *MockGetAccountBalanceProcessorInterface_Process_Call) Run(run func(ctx context.Context, req domain.GetAccountBalanceRequest)) *MockGetAccountBalanceProcessorInterface_Process_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.GetAccountBalanceRequest))
+	})
+	return _c
+}
+
+func (_c *MockGetAccountBalanceProcessorInterface_Process_Call) Return(_a0 *domain.GetAccountBalanceResponse, _a1 error) *MockGetAccountBalanceProcessorInterface_Process_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetAccountBalanceProcessorInterface_Process_Call) RunAndReturn(run func(context.Context, domain.GetAccountBalanceRequest) (*domain.GetAccountBalanceResponse, error)) *MockGetAccountBalanceProcessorInterface_Process_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGetAccountBalanceProcessorInterface creates a new instance of MockGetAccountBalanceProcessorInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetAccountBalanceProcessorInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetAccountBalanceProcessorInterface {
+	mock := &MockGetAccountBalanceProcessorInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGetBalanceTrendProcessorInterface is an autogenerated mock type for the GetBalanceTrendProcessorInterface type
+type MockGetBalanceTrendProcessorInterface struct {
+	mock.Mock
+}
+
+type MockGetBalanceTrendProcessorInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGetBalanceTrendProcessorInterface) EXPECT() *MockGetBalanceTrendProcessorInterface_Expecter {
+	return &MockGetBalanceTrendProcessorInterface_Expecter{mock: &_m.Mock}
+}
+
+// Process provides a mock function with given fields: ctx, req
+func (_m *MockGetBalanceTrendProcessorInterface) Process(ctx context.Context, req domain.GetBalanceTrendRequest) (*domain.GetBalanceTrendResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Process")
+	}
+
+	var r0 *domain.GetBalanceTrendResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetBalanceTrendRequest) (*domain.GetBalanceTrendResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetBalanceTrendRequest) *domain.GetBalanceTrendResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.GetBalanceTrendResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.GetBalanceTrendRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetBalanceTrendProcessorInterface_Process_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Process'
+type MockGetBalanceTrendProcessorInterface_Process_Call struct {
+	*mock.Call
+}
+
+// Process is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req domain.GetBalanceTrendRequest
+func (_e *MockGetBalanceTrendProcessorInterface_Expecter) Process(ctx interface{}, req interface{}) *MockGetBalanceTrendProcessorInterface_Process_Call {
+	return &MockGetBalanceTrendProcessorInterface_Process_Call{Call: _e.mock.On("Process", ctx, req)}
+}
+
+func (_c *MockGetBalanceTrendProcessorInterface_Process_Call) Run(run func(ctx context.Context, req domain.GetBalanceTrendRequest)) *MockGetBalanceTrendProcessorInterface_Process_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.GetBalanceTrendRequest))
+	})
+	return _c
+}
+
+func (_c *MockGetBalanceTrendProcessorInterface_Process_Call) Return(_a0 *domain.GetBalanceTrendResponse, _a1 error) *MockGetBalanceTrendProcessorInterface_Process_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetBalanceTrendProcessorInterface_Process_Call) RunAndReturn(run func(context.Context, domain.GetBalanceTrendRequest) (*domain.GetBalanceTrendResponse, error)) *MockGetBalanceTrendProcessorInterface_Process_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGetBalanceTrendProcessorInterface creates a new instance of MockGetBalanceTrendProcessorInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetBalanceTrendProcessorInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetBalanceTrendProcessorInterface {
+	mock := &MockGetBalanceTrendProcessorInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGetIdempotencyKeyProcessorInterface is an autogenerated mock type for the GetIdempotencyKeyProcessorInterface type
+type MockGetIdempotencyKeyProcessorInterface struct {
+	mock.Mock
+}
+
+type MockGetIdempotencyKeyProcessorInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGetIdempotencyKeyProcessorInterface) EXPECT() *MockGetIdempotencyKeyProcessorInterface_Expecter {
+	return &MockGetIdempotencyKeyProcessorInterface_Expecter{mock: &_m.Mock}
+}
+
+// Process provides a mock function with given fields: ctx, key
+func (_m *MockGetIdempotencyKeyProcessorInterface) Process(ctx context.Context, key string) (*domain.IdempotencyKeyStatus, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Process")
+	}
+
+	var r0 *domain.IdempotencyKeyStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.IdempotencyKeyStatus, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.IdempotencyKeyStatus); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IdempotencyKeyStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetIdempotencyKeyProcessorInterface_Process_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Process'
+type MockGetIdempotencyKeyProcessorInterface_Process_Call struct {
+	*mock.Call
+}
+
+// Process is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockGetIdempotencyKeyProcessorInterface_Expecter) Process(ctx interface{}, key interface{}) *MockGetIdempotencyKeyProcessorInterface_Process_Call {
+	return &MockGetIdempotencyKeyProcessorInterface_Process_Call{Call: _e.mock.On("Process", ctx, key)}
+}
+
+func (_c *MockGetIdempotencyKeyProcessorInterface_Process_Call) Run(run func(ctx context.Context, key string)) *MockGetIdempotencyKeyProcessorInterface_Process_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockGetIdempotencyKeyProcessorInterface_Process_Call) Return(_a0 *domain.IdempotencyKeyStatus, _a1 error) *MockGetIdempotencyKeyProcessorInterface_Process_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetIdempotencyKeyProcessorInterface_Process_Call) RunAndReturn(run func(context.Context, string) (*domain.IdempotencyKeyStatus, error)) *MockGetIdempotencyKeyProcessorInterface_Process_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGetIdempotencyKeyProcessorInterface creates a new instance of MockGetIdempotencyKeyProcessorInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetIdempotencyKeyProcessorInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetIdempotencyKeyProcessorInterface {
+	mock := &MockGetIdempotencyKeyProcessorInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGetStatementProcessorInterface is an autogenerated mock type for the GetStatementProcessorInterface type
+type MockGetStatementProcessorInterface struct {
+	mock.Mock
+}
+
+type MockGetStatementProcessorInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGetStatementProcessorInterface) EXPECT() *MockGetStatementProcessorInterface_Expecter {
+	return &MockGetStatementProcessorInterface_Expecter{mock: &_m.Mock}
+}
+
+// Process provides a mock function with given fields: ctx, req
+func (_m *MockGetStatementProcessorInterface) Process(ctx context.Context, req domain.GetStatementRequest) (*domain.StatementResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Process")
+	}
+
+	var r0 *domain.StatementResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetStatementRequest) (*domain.StatementResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetStatementRequest) *domain.StatementResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.StatementResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.GetStatementRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetStatementProcessorInterface_Process_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Process'
+type MockGetStatementProcessorInterface_Process_Call struct {
+	*mock.Call
+}
+
+// Process is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req domain.GetStatementRequest
+func (_e *MockGetStatementProcessorInterface_Expecter) Process(ctx interface{}, req interface{}) *MockGetStatementProcessorInterface_Process_Call {
+	return &MockGetStatementProcessorInterface_Process_Call{Call: _e.mock.On("Process", ctx, req)}
+}
+
+func (_c *MockGetStatementProcessorInterface_Process_Call) Run(run func(ctx context.Context, req domain.GetStatementRequest)) *MockGetStatementProcessorInterface_Process_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.GetStatementRequest))
+	})
+	return _c
+}
+
+func (_c *MockGetStatementProcessorInterface_Process_Call) Return(_a0 *domain.StatementResponse, _a1 error) *MockGetStatementProcessorInterface_Process_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetStatementProcessorInterface_Process_Call) RunAndReturn(run func(context.Context, domain.GetStatementRequest) (*domain.StatementResponse, error)) *MockGetStatementProcessorInterface_Process_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGetStatementProcessorInterface creates a new instance of MockGetStatementProcessorInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetStatementProcessorInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetStatementProcessorInterface {
+	mock := &MockGetStatementProcessorInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockImportTransactionsProcessorInterface is an autogenerated mock type for the ImportTransactionsProcessorInterface type
+type MockImportTransactionsProcessorInterface struct {
+	mock.Mock
+}
+
+type MockImportTransactionsProcessorInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockImportTransactionsProcessorInterface) EXPECT() *MockImportTransactionsProcessorInterface_Expecter {
+	return &MockImportTransactionsProcessorInterface_Expecter{mock: &_m.Mock}
+}
+
+// Process provides a mock function with given fields: ctx, next
+func (_m *MockImportTransactionsProcessorInterface) Process(ctx context.Context, next func() (*domain.ImportLine, error)) (*domain.ImportTransactionsResponse, error) {
+	ret := _m.Called(ctx, next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Process")
+	}
+
+	var r0 *domain.ImportTransactionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, func() (*domain.ImportLine, error)) (*domain.ImportTransactionsResponse, error)); ok {
+		return rf(ctx, next)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, func() (*domain.ImportLine, error)) *domain.ImportTransactionsResponse); ok {
+		r0 = rf(ctx, next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ImportTransactionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, func() (*domain.ImportLine, error)) error); ok {
+		r1 = rf(ctx, next)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockImportTransactionsProcessorInterface_Process_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Process'
+type MockImportTransactionsProcessorInterface_Process_Call struct {
+	*mock.Call
+}
+
+// Process is a helper method to define mock.On call
+//   - ctx context.Context
+//   - next func()(*domain.ImportLine , error)
+func (_e *MockImportTransactionsProcessorInterface_Expecter) Process(ctx interface{}, next interface{}) *MockImportTransactionsProcessorInterface_Process_Call {
+	return &MockImportTransactionsProcessorInterface_Process_Call{Call: _e.mock.On("Process", ctx, next)}
+}
+
+func (_c *MockImportTransactionsProcessorInterface_Process_Call) Run(run func(ctx context.Context, next func() (*domain.ImportLine, error))) *MockImportTransactionsProcessorInterface_Process_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func() (*domain.ImportLine, error)))
+	})
+	return _c
+}
+
+func (_c *MockImportTransactionsProcessorInterface_Process_Call) Return(_a0 *domain.ImportTransactionsResponse, _a1 error) *MockImportTransactionsProcessorInterface_Process_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockImportTransactionsProcessorInterface_Process_Call) RunAndReturn(run func(context.Context, func() (*domain.ImportLine, error)) (*domain.ImportTransactionsResponse, error)) *MockImportTransactionsProcessorInterface_Process_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockImportTransactionsProcessorInterface creates a new instance of MockImportTransactionsProcessorInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockImportTransactionsProcessorInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockImportTransactionsProcessorInterface {
+	mock := &MockImportTransactionsProcessorInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
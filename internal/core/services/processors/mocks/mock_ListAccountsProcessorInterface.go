@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockListAccountsProcessorInterface is an autogenerated mock type for the ListAccountsProcessorInterface type
+type MockListAccountsProcessorInterface struct {
+	mock.Mock
+}
+
+type MockListAccountsProcessorInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockListAccountsProcessorInterface) EXPECT() *MockListAccountsProcessorInterface_Expecter {
+	return &MockListAccountsProcessorInterface_Expecter{mock: &_m.Mock}
+}
+
+// Process provides a mock function with given fields: ctx, req
+func (_m *MockListAccountsProcessorInterface) Process(ctx context.Context, req domain.ListAccountsRequest) (*domain.ListAccountsResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Process")
+	}
+
+	var r0 *domain.ListAccountsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ListAccountsRequest) (*domain.ListAccountsResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ListAccountsRequest) *domain.ListAccountsResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ListAccountsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ListAccountsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockListAccountsProcessorInterface_Process_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Process'
+type MockListAccountsProcessorInterface_Process_Call struct {
+	*mock.Call
+}
+
+// Process is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req domain.ListAccountsRequest
+func (_e *MockListAccountsProcessorInterface_Expecter) Process(ctx interface{}, req interface{}) *MockListAccountsProcessorInterface_Process_Call {
+	return &MockListAccountsProcessorInterface_Process_Call{Call: _e.mock.On("Process", ctx, req)}
+}
+
+func (_c *MockListAccountsProcessorInterface_Process_Call) Run(run func(ctx context.Context, req domain.ListAccountsRequest)) *MockListAccountsProcessorInterface_Process_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ListAccountsRequest))
+	})
+	return _c
+}
+
+func (_c *MockListAccountsProcessorInterface_Process_Call) Return(_a0 *domain.ListAccountsResponse, _a1 error) *MockListAccountsProcessorInterface_Process_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockListAccountsProcessorInterface_Process_Call) RunAndReturn(run func(context.Context, domain.ListAccountsRequest) (*domain.ListAccountsResponse, error)) *MockListAccountsProcessorInterface_Process_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockListAccountsProcessorInterface creates a new instance of MockListAccountsProcessorInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockListAccountsProcessorInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockListAccountsProcessorInterface {
+	mock := &MockListAccountsProcessorInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockListOperationTypesProcessorInterface is an autogenerated mock type for the ListOperationTypesProcessorInterface type
+type MockListOperationTypesProcessorInterface struct {
+	mock.Mock
+}
+
+type MockListOperationTypesProcessorInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockListOperationTypesProcessorInterface) EXPECT() *MockListOperationTypesProcessorInterface_Expecter {
+	return &MockListOperationTypesProcessorInterface_Expecter{mock: &_m.Mock}
+}
+
+// Process provides a mock function with given fields: ctx
+func (_m *MockListOperationTypesProcessorInterface) Process(ctx context.Context) (*domain.ListOperationTypesResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Process")
+	}
+
+	var r0 *domain.ListOperationTypesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.ListOperationTypesResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.ListOperationTypesResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ListOperationTypesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockListOperationTypesProcessorInterface_Process_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Process'
+type MockListOperationTypesProcessorInterface_Process_Call struct {
+	*mock.Call
+}
+
+// Process is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockListOperationTypesProcessorInterface_Expecter) Process(ctx interface{}) *MockListOperationTypesProcessorInterface_Process_Call {
+	return &MockListOperationTypesProcessorInterface_Process_Call{Call: _e.mock.On("Process", ctx)}
+}
+
+func (_c *MockListOperationTypesProcessorInterface_Process_Call) Run(run func(ctx context.Context)) *MockListOperationTypesProcessorInterface_Process_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockListOperationTypesProcessorInterface_Process_Call) Return(_a0 *domain.ListOperationTypesResponse, _a1 error) *MockListOperationTypesProcessorInterface_Process_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockListOperationTypesProcessorInterface_Process_Call) RunAndReturn(run func(context.Context) (*domain.ListOperationTypesResponse, error)) *MockListOperationTypesProcessorInterface_Process_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockListOperationTypesProcessorInterface creates a new instance of MockListOperationTypesProcessorInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockListOperationTypesProcessorInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockListOperationTypesProcessorInterface {
+	mock := &MockListOperationTypesProcessorInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
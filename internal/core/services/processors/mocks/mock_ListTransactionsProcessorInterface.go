@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockListTransactionsProcessorInterface is an autogenerated mock type for the ListTransactionsProcessorInterface type
+type MockListTransactionsProcessorInterface struct {
+	mock.Mock
+}
+
+type MockListTransactionsProcessorInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockListTransactionsProcessorInterface) EXPECT() *MockListTransactionsProcessorInterface_Expecter {
+	return &MockListTransactionsProcessorInterface_Expecter{mock: &_m.Mock}
+}
+
+// Process provides a mock function with given fields: ctx, req
+func (_m *MockListTransactionsProcessorInterface) Process(ctx context.Context, req domain.ListTransactionsRequest) (*domain.ListTransactionsResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Process")
+	}
+
+	var r0 *domain.ListTransactionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ListTransactionsRequest) (*domain.ListTransactionsResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ListTransactionsRequest) *domain.ListTransactionsResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ListTransactionsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ListTransactionsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockListTransactionsProcessorInterface_Process_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Process'
+type MockListTransactionsProcessorInterface_Process_Call struct {
+	*mock.Call
+}
+
+// Process is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req domain.ListTransactionsRequest
+func (_e *MockListTransactionsProcessorInterface_Expecter) Process(ctx interface{}, req interface{}) *MockListTransactionsProcessorInterface_Process_Call {
+	return &MockListTransactionsProcessorInterface_Process_Call{Call: _e.mock.On("Process", ctx, req)}
+}
+
+func (_c *MockListTransactionsProcessorInterface_Process_Call) Run(run func(ctx context.Context, req domain.ListTransactionsRequest)) *MockListTransactionsProcessorInterface_Process_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ListTransactionsRequest))
+	})
+	return _c
+}
+
+func (_c *MockListTransactionsProcessorInterface_Process_Call) Return(_a0 *domain.ListTransactionsResponse, _a1 error) *MockListTransactionsProcessorInterface_Process_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockListTransactionsProcessorInterface_Process_Call) RunAndReturn(run func(context.Context, domain.ListTransactionsRequest) (*domain.ListTransactionsResponse, error)) *MockListTransactionsProcessorInterface_Process_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockListTransactionsProcessorInterface creates a new instance of MockListTransactionsProcessorInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockListTransactionsProcessorInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockListTransactionsProcessorInterface {
+	mock := &MockListTransactionsProcessorInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockOperationTypeDirectionsProcessorInterface is an autogenerated mock type for the OperationTypeDirectionsProcessorInterface type
+type MockOperationTypeDirectionsProcessorInterface struct {
+	mock.Mock
+}
+
+type MockOperationTypeDirectionsProcessorInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOperationTypeDirectionsProcessorInterface) EXPECT() *MockOperationTypeDirectionsProcessorInterface_Expecter {
+	return &MockOperationTypeDirectionsProcessorInterface_Expecter{mock: &_m.Mock}
+}
+
+// Directions provides a mock function with given fields: ctx
+func (_m *MockOperationTypeDirectionsProcessorInterface) Directions(ctx context.Context) (map[int64]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Directions")
+	}
+
+	var r0 map[int64]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[int64]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[int64]string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOperationTypeDirectionsProcessorInterface_Directions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Directions'
+type MockOperationTypeDirectionsProcessorInterface_Directions_Call struct {
+	*mock.Call
+}
+
+// Directions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockOperationTypeDirectionsProcessorInterface_Expecter) Directions(ctx interface{}) *MockOperationTypeDirectionsProcessorInterface_Directions_Call {
+	return &MockOperationTypeDirectionsProcessorInterface_Directions_Call{Call: _e.mock.On("Directions", ctx)}
+}
+
+func (_c *MockOperationTypeDirectionsProcessorInterface_Directions_Call) Run(run func(ctx context.Context)) *MockOperationTypeDirectionsProcessorInterface_Directions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockOperationTypeDirectionsProcessorInterface_Directions_Call) Return(_a0 map[int64]string, _a1 error) *MockOperationTypeDirectionsProcessorInterface_Directions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOperationTypeDirectionsProcessorInterface_Directions_Call) RunAndReturn(run func(context.Context) (map[int64]string, error)) *MockOperationTypeDirectionsProcessorInterface_Directions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOperationTypeDirectionsProcessorInterface creates a new instance of MockOperationTypeDirectionsProcessorInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOperationTypeDirectionsProcessorInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOperationTypeDirectionsProcessorInterface {
+	mock := &MockOperationTypeDirectionsProcessorInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
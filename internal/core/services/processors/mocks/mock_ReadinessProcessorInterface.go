@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockReadinessProcessorInterface is an autogenerated mock type for the ReadinessProcessorInterface type
+type MockReadinessProcessorInterface struct {
+	mock.Mock
+}
+
+type MockReadinessProcessorInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReadinessProcessorInterface) EXPECT() *MockReadinessProcessorInterface_Expecter {
+	return &MockReadinessProcessorInterface_Expecter{mock: &_m.Mock}
+}
+
+// Process provides a mock function with given fields: ctx
+func (_m *MockReadinessProcessorInterface) Process(ctx context.Context) (*domain.ReadinessReport, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Process")
+	}
+
+	var r0 *domain.ReadinessReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.ReadinessReport, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.ReadinessReport); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ReadinessReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReadinessProcessorInterface_Process_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Process'
+type MockReadinessProcessorInterface_Process_Call struct {
+	*mock.Call
+}
+
+// Process is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockReadinessProcessorInterface_Expecter) Process(ctx interface{}) *MockReadinessProcessorInterface_Process_Call {
+	return &MockReadinessProcessorInterface_Process_Call{Call: _e.mock.On("Process", ctx)}
+}
+
+func (_c *MockReadinessProcessorInterface_Process_Call) Run(run func(ctx context.Context)) *MockReadinessProcessorInterface_Process_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockReadinessProcessorInterface_Process_Call) Return(_a0 *domain.ReadinessReport, _a1 error) *MockReadinessProcessorInterface_Process_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReadinessProcessorInterface_Process_Call) RunAndReturn(run func(context.Context) (*domain.ReadinessReport, error)) *MockReadinessProcessorInterface_Process_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReadinessProcessorInterface creates a new instance of MockReadinessProcessorInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReadinessProcessorInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReadinessProcessorInterface {
+	mock := &MockReadinessProcessorInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,155 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockReverseTransactionProcessorInterface is an autogenerated mock type for the ReverseTransactionProcessorInterface type
+type MockReverseTransactionProcessorInterface struct {
+	mock.Mock
+}
+
+type MockReverseTransactionProcessorInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReverseTransactionProcessorInterface) EXPECT() *MockReverseTransactionProcessorInterface_Expecter {
+	return &MockReverseTransactionProcessorInterface_Expecter{mock: &_m.Mock}
+}
+
+// Process provides a mock function with given fields: ctx, req
+func (_m *MockReverseTransactionProcessorInterface) Process(ctx context.Context, req domain.ReverseTransactionRequest) (*domain.ReverseTransactionResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Process")
+	}
+
+	var r0 *domain.ReverseTransactionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReverseTransactionRequest) (*domain.ReverseTransactionResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReverseTransactionRequest) *domain.ReverseTransactionResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ReverseTransactionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ReverseTransactionRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReverseTransactionProcessorInterface_Process_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Process'
+type MockReverseTransactionProcessorInterface_Process_Call struct {
+	*mock.Call
+}
+
+// Process is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req domain.ReverseTransactionRequest
+func (_e *MockReverseTransactionProcessorInterface_Expecter) Process(ctx interface{}, req interface{}) *MockReverseTransactionProcessorInterface_Process_Call {
+	return &MockReverseTransactionProcessorInterface_Process_Call{Call: _e.mock.On("Process", ctx, req)}
+}
+
+func (_c *MockReverseTransactionProcessorInterface_Process_Call) Run(run func(ctx context.Context, req domain.ReverseTransactionRequest)) *MockReverseTransactionProcessorInterface_Process_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ReverseTransactionRequest))
+	})
+	return _c
+}
+
+func (_c *MockReverseTransactionProcessorInterface_Process_Call) Return(_a0 *domain.ReverseTransactionResponse, _a1 error) *MockReverseTransactionProcessorInterface_Process_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReverseTransactionProcessorInterface_Process_Call) RunAndReturn(run func(context.Context, domain.ReverseTransactionRequest) (*domain.ReverseTransactionResponse, error)) *MockReverseTransactionProcessorInterface_Process_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessByID provides a mock function with given fields: ctx, transactionID
+func (_m *MockReverseTransactionProcessorInterface) ProcessByID(ctx context.Context, transactionID int64) (*domain.ReverseTransactionResponse, error) {
+	ret := _m.Called(ctx, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessByID")
+	}
+
+	var r0 *domain.ReverseTransactionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*domain.ReverseTransactionResponse, error)); ok {
+		return rf(ctx, transactionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domain.ReverseTransactionResponse); ok {
+		r0 = rf(ctx, transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ReverseTransactionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReverseTransactionProcessorInterface_ProcessByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessByID'
+type MockReverseTransactionProcessorInterface_ProcessByID_Call struct {
+	*mock.Call
+}
+
+// ProcessByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transactionID int64
+func (_e *MockReverseTransactionProcessorInterface_Expecter) ProcessByID(ctx interface{}, transactionID interface{}) *MockReverseTransactionProcessorInterface_ProcessByID_Call {
+	return &MockReverseTransactionProcessorInterface_ProcessByID_Call{Call: _e.mock.On("ProcessByID", ctx, transactionID)}
+}
+
+func (_c *MockReverseTransactionProcessorInterface_ProcessByID_Call) Run(run func(ctx context.Context, transactionID int64)) *MockReverseTransactionProcessorInterface_ProcessByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockReverseTransactionProcessorInterface_ProcessByID_Call) Return(_a0 *domain.ReverseTransactionResponse, _a1 error) *MockReverseTransactionProcessorInterface_ProcessByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReverseTransactionProcessorInterface_ProcessByID_Call) RunAndReturn(run func(context.Context, int64) (*domain.ReverseTransactionResponse, error)) *MockReverseTransactionProcessorInterface_ProcessByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReverseTransactionProcessorInterface creates a new instance of MockReverseTransactionProcessorInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReverseTransactionProcessorInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReverseTransactionProcessorInterface {
+	mock := &MockReverseTransactionProcessorInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -14,6 +14,10 @@ type GetAccountProcessorInterface interface {
 	Process(ctx context.Context, req domain.GetAccountRequest) (*domain.GetAccountResponse, error)
 }
 
+type CloseAccountProcessorInterface interface {
+	Process(ctx context.Context, req domain.CloseAccountRequest) error
+}
+
 type CreateTransactionProcessorInterface interface {
 	Process(ctx context.Context, req domain.CreateTransactionRequest) (*domain.CreateTransactionResponse, error)
 }
@@ -21,3 +25,80 @@ type CreateTransactionProcessorInterface interface {
 type GetTransactionsProcessorInterface interface {
 	Process(ctx context.Context, req domain.GetTransactionsRequest) (*domain.GetTransactionsResponse, error)
 }
+
+type GetRecentTransactionsProcessorInterface interface {
+	Process(ctx context.Context, req domain.GetRecentTransactionsRequest) (*domain.GetRecentTransactionsResponse, error)
+}
+
+type ExportTransactionsProcessorInterface interface {
+	Process(ctx context.Context, req domain.ExportTransactionsRequest, emit func(*domain.Transaction) error) error
+}
+
+type GetStatementProcessorInterface interface {
+	Process(ctx context.Context, req domain.GetStatementRequest) (*domain.StatementResponse, error)
+}
+
+type GetMonthlyStatementProcessorInterface interface {
+	Process(ctx context.Context, req domain.GetMonthlyStatementRequest) (*domain.GetMonthlyStatementResponse, error)
+}
+
+type ListOperationTypesProcessorInterface interface {
+	Process(ctx context.Context) (*domain.ListOperationTypesResponse, error)
+}
+
+type OperationTypeDirectionsProcessorInterface interface {
+	Directions(ctx context.Context) (map[int64]string, error)
+}
+
+type RecomputeBalancesProcessorInterface interface {
+	Process(ctx context.Context, req domain.RecomputeBalancesRequest) (*domain.RecomputeBalancesResponse, error)
+}
+
+type CanDebitProcessorInterface interface {
+	Process(ctx context.Context, req domain.CanDebitRequest) (*domain.CanDebitResponse, error)
+}
+
+type GetAccountBalanceProcessorInterface interface {
+	Process(ctx context.Context, req domain.GetAccountBalanceRequest) (*domain.GetAccountBalanceResponse, error)
+}
+
+type GetDailyTotalsProcessorInterface interface {
+	Process(ctx context.Context, req domain.GetDailyTotalsRequest) (*domain.GetDailyTotalsResponse, error)
+}
+
+type GetBalanceTrendProcessorInterface interface {
+	Process(ctx context.Context, req domain.GetBalanceTrendRequest) (*domain.GetBalanceTrendResponse, error)
+}
+
+type GetActivityRangeProcessorInterface interface {
+	Process(ctx context.Context, req domain.GetActivityRangeRequest) (*domain.ActivityRange, error)
+}
+
+type ListTransactionsProcessorInterface interface {
+	Process(ctx context.Context, req domain.ListTransactionsRequest) (*domain.ListTransactionsResponse, error)
+}
+
+type ReverseTransactionProcessorInterface interface {
+	Process(ctx context.Context, req domain.ReverseTransactionRequest) (*domain.ReverseTransactionResponse, error)
+	ProcessByID(ctx context.Context, transactionID int64) (*domain.ReverseTransactionResponse, error)
+}
+
+type ImportTransactionsProcessorInterface interface {
+	Process(ctx context.Context, next func() (*domain.ImportLine, error)) (*domain.ImportTransactionsResponse, error)
+}
+
+type ListAccountsProcessorInterface interface {
+	Process(ctx context.Context, req domain.ListAccountsRequest) (*domain.ListAccountsResponse, error)
+}
+
+type CountAccountsProcessorInterface interface {
+	Process(ctx context.Context) (*domain.CountAccountsResponse, error)
+}
+
+type GetIdempotencyKeyProcessorInterface interface {
+	Process(ctx context.Context, key string) (*domain.IdempotencyKeyStatus, error)
+}
+
+type ReadinessProcessorInterface interface {
+	Process(ctx context.Context) (*domain.ReadinessReport, error)
+}
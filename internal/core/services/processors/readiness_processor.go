@@ -0,0 +1,30 @@
+package processors
+
+import (
+	"context"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// ReadinessProcessor runs the readiness checks and summarizes them
+type ReadinessProcessor struct {
+	checks []ports.HealthChecker
+}
+
+// NewReadinessProcessor creates a new ReadinessProcessor running the checks in order
+func NewReadinessProcessor(checks ...ports.HealthChecker) *ReadinessProcessor {
+	return &ReadinessProcessor{
+		checks: checks,
+	}
+}
+
+// Process runs every check, even after one fails, so the report shows all problems at once
+func (p *ReadinessProcessor) Process(ctx context.Context) (*domain.ReadinessReport, error) {
+	results := make([]domain.HealthCheck, 0, len(p.checks))
+	for _, check := range p.checks {
+		results = append(results, check.Check(ctx))
+	}
+
+	return domain.NewReadinessReport(results), nil
+}
@@ -0,0 +1,46 @@
+package processors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadinessProcessor_Process(t *testing.T) {
+	healthy := domain.HealthCheck{Name: "database", Status: domain.HealthStatusHealthy}
+	degraded := domain.HealthCheck{Name: "wal", Status: domain.HealthStatusDegraded, Detail: "70000000 bytes, above 67108864"}
+	unhealthy := domain.HealthCheck{Name: "migrations", Status: domain.HealthStatusUnhealthy, Detail: "version 6 of 7"}
+
+	tests := []struct {
+		name       string
+		results    []domain.HealthCheck
+		wantStatus domain.HealthStatus
+	}{
+		{name: "healthy", results: []domain.HealthCheck{healthy, healthy}, wantStatus: domain.HealthStatusHealthy},
+		{name: "degraded", results: []domain.HealthCheck{healthy, degraded}, wantStatus: domain.HealthStatusDegraded},
+		{name: "unhealthy", results: []domain.HealthCheck{unhealthy, degraded}, wantStatus: domain.HealthStatusUnhealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var checks []*mocks.MockHealthChecker
+			for _, result := range tt.results {
+				check := mocks.NewMockHealthChecker(t)
+				check.EXPECT().Check(mock.Anything).Return(result).Once()
+				checks = append(checks, check)
+			}
+
+			processor := NewReadinessProcessor(checks[0], checks[1])
+			report, err := processor.Process(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, report.Status)
+			assert.Equal(t, tt.results, report.Checks, "Every check runs, in order")
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// RecomputeBalancesProcessor rebuilds the account balances cache from the transaction log
+type RecomputeBalancesProcessor struct {
+	balanceRepo ports.AccountBalanceRepository
+}
+
+// NewRecomputeBalancesProcessor creates a new RecomputeBalancesProcessor
+func NewRecomputeBalancesProcessor(balanceRepo ports.AccountBalanceRepository) *RecomputeBalancesProcessor {
+	return &RecomputeBalancesProcessor{
+		balanceRepo: balanceRepo,
+	}
+}
+
+// Process recomputes balances batch by batch, in account ID order, until every account is done
+// On failure the progress so far is returned with the error, so the run can resume from LastAccountID
+func (p *RecomputeBalancesProcessor) Process(ctx context.Context, req domain.RecomputeBalancesRequest) (*domain.RecomputeBalancesResponse, error) {
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = domain.DefaultRecomputeBatchSize
+	}
+	if batchSize > domain.MaxRecomputeBatchSize {
+		batchSize = domain.MaxRecomputeBatchSize
+	}
+
+	progress := &domain.RecomputeBalancesResponse{
+		LastAccountID: req.AfterAccountID,
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return progress, fmt.Errorf("recompute interrupted after account %d: %w", progress.LastAccountID, err)
+		}
+
+		lastAccountID, processed, err := p.balanceRepo.RecomputeBatch(ctx, progress.LastAccountID, batchSize)
+		if err != nil {
+			return progress, fmt.Errorf("failed to recompute balances after account %d: %w", progress.LastAccountID, err)
+		}
+		if processed == 0 {
+			return progress, nil
+		}
+
+		progress.AccountsProcessed += processed
+		progress.Batches++
+		progress.LastAccountID = lastAccountID
+
+		// A short batch means there are no accounts left
+		if processed < batchSize {
+			return progress, nil
+		}
+	}
+}
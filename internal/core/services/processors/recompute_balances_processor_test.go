@@ -0,0 +1,97 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecomputeBalancesProcessor_Process(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        domain.RecomputeBalancesRequest
+		setupMock      func(*mocks.MockAccountBalanceRepository)
+		expectedResult *domain.RecomputeBalancesResponse
+		wantErr        bool
+	}{
+		{
+			name:    "iterates batches until a short batch",
+			request: domain.RecomputeBalancesRequest{BatchSize: 2},
+			setupMock: func(repo *mocks.MockAccountBalanceRepository) {
+				repo.EXPECT().RecomputeBatch(mock.Anything, int64(0), int64(2)).Return(int64(2), int64(2), nil).Once()
+				repo.EXPECT().RecomputeBatch(mock.Anything, int64(2), int64(2)).Return(int64(7), int64(2), nil).Once()
+				repo.EXPECT().RecomputeBatch(mock.Anything, int64(7), int64(2)).Return(int64(9), int64(1), nil).Once()
+			},
+			expectedResult: &domain.RecomputeBalancesResponse{AccountsProcessed: 5, Batches: 3, LastAccountID: 9},
+		},
+		{
+			name:    "stops on an empty batch",
+			request: domain.RecomputeBalancesRequest{BatchSize: 2},
+			setupMock: func(repo *mocks.MockAccountBalanceRepository) {
+				repo.EXPECT().RecomputeBatch(mock.Anything, int64(0), int64(2)).Return(int64(2), int64(2), nil).Once()
+				repo.EXPECT().RecomputeBatch(mock.Anything, int64(2), int64(2)).Return(int64(2), int64(0), nil).Once()
+			},
+			expectedResult: &domain.RecomputeBalancesResponse{AccountsProcessed: 2, Batches: 1, LastAccountID: 2},
+		},
+		{
+			name:    "resumes after the given account with the default batch size",
+			request: domain.RecomputeBalancesRequest{AfterAccountID: 40},
+			setupMock: func(repo *mocks.MockAccountBalanceRepository) {
+				repo.EXPECT().RecomputeBatch(mock.Anything, int64(40), int64(domain.DefaultRecomputeBatchSize)).Return(int64(45), int64(3), nil).Once()
+			},
+			expectedResult: &domain.RecomputeBalancesResponse{AccountsProcessed: 3, Batches: 1, LastAccountID: 45},
+		},
+		{
+			name:    "failure reports the progress to resume from",
+			request: domain.RecomputeBalancesRequest{BatchSize: 2},
+			setupMock: func(repo *mocks.MockAccountBalanceRepository) {
+				repo.EXPECT().RecomputeBatch(mock.Anything, int64(0), int64(2)).Return(int64(2), int64(2), nil).Once()
+				repo.EXPECT().RecomputeBatch(mock.Anything, int64(2), int64(2)).Return(int64(0), int64(0), errors.New("disk I/O error")).Once()
+			},
+			expectedResult: &domain.RecomputeBalancesResponse{AccountsProcessed: 2, Batches: 1, LastAccountID: 2},
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockAccountBalanceRepository(t)
+			tt.setupMock(mockRepo)
+
+			processor := NewRecomputeBalancesProcessor(mockRepo)
+			result, err := processor.Process(context.Background(), tt.request)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestRecomputeBalancesProcessor_StopsWhenCanceled(t *testing.T) {
+	mockRepo := mocks.NewMockAccountBalanceRepository(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockRepo.EXPECT().
+		RecomputeBatch(mock.Anything, int64(0), int64(2)).
+		RunAndReturn(func(context.Context, int64, int64) (int64, int64, error) {
+			cancel()
+			return 2, 2, nil
+		}).
+		Once()
+
+	processor := NewRecomputeBalancesProcessor(mockRepo)
+	result, err := processor.Process(ctx, domain.RecomputeBalancesRequest{BatchSize: 2})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int64(2), result.LastAccountID)
+}
@@ -0,0 +1,172 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// ReverseTransactionProcessor cancels a transaction identified by its ID or by the Idempotency-Key it was created with
+// The lookups and the insert of the reversal share a database transaction, so concurrent requests reverse it once
+type ReverseTransactionProcessor struct {
+	transactionRepo ports.TransactionRepository
+	accountRepo     ports.AccountRepository
+	txProvider      ports.TxProvider
+	enforceLimit    bool
+}
+
+// ReverseTransactionOption configures optional behavior of the ReverseTransactionProcessor
+type ReverseTransactionOption func(*ReverseTransactionProcessor)
+
+// WithReversalCreditLimitEnforcement rejects reversals of credits that would take the balance below
+// the account's credit limit, like any other debit
+func WithReversalCreditLimitEnforcement(enabled bool) ReverseTransactionOption {
+	return func(p *ReverseTransactionProcessor) {
+		p.enforceLimit = enabled
+	}
+}
+
+// NewReverseTransactionProcessor creates a new ReverseTransactionProcessor
+func NewReverseTransactionProcessor(transactionRepo ports.TransactionRepository, accountRepo ports.AccountRepository, txProvider ports.TxProvider, opts ...ReverseTransactionOption) *ReverseTransactionProcessor {
+	p := &ReverseTransactionProcessor{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		txProvider:      txProvider,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Process creates the reversal of the original transaction: same account and operation type, opposite amount
+// A transaction is reversed at most once
+func (p *ReverseTransactionProcessor) Process(ctx context.Context, req domain.ReverseTransactionRequest) (*domain.ReverseTransactionResponse, error) {
+	var response *domain.ReverseTransactionResponse
+	err := inTransaction(ctx, p.txProvider, func(ctx context.Context) error {
+		original, err := p.transactionRepo.FindByIdempotencyKey(ctx, req.AccountID, req.IdempotencyKey)
+		if err != nil {
+			return fmt.Errorf("failed to find transaction: %w", err)
+		}
+		if original == nil {
+			return domain.ErrIdempotencyKeyNotFound
+		}
+
+		response, err = p.reverse(ctx, original)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ProcessByID reverses the transaction with the given ID
+// A reversal cannot itself be reversed
+func (p *ReverseTransactionProcessor) ProcessByID(ctx context.Context, transactionID int64) (*domain.ReverseTransactionResponse, error) {
+	var response *domain.ReverseTransactionResponse
+	err := inTransaction(ctx, p.txProvider, func(ctx context.Context) error {
+		original, err := p.transactionRepo.FindByID(ctx, transactionID)
+		if err != nil {
+			return fmt.Errorf("failed to find transaction: %w", err)
+		}
+		if original == nil {
+			return domain.ErrTransactionNotFound
+		}
+
+		reversed, err := p.transactionRepo.FindReversed(ctx, original.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find reversed transaction: %w", err)
+		}
+		if reversed != nil {
+			return domain.ErrReversalNotReversible
+		}
+
+		response, err = p.reverse(ctx, original)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// reverse creates the reversal of original unless it was already reversed, undoing what original did to the
+// outstanding debts (see domain.ReversalDischarges) in the same write
+// A closed account is not found, so its transactions cannot be reversed, which keeps its balance at zero
+func (p *ReverseTransactionProcessor) reverse(ctx context.Context, original *domain.Transaction) (*domain.ReverseTransactionResponse, error) {
+	account, err := p.accountRepo.FindByID(ctx, original.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find account: %w", err)
+	}
+	if account == nil {
+		return nil, domain.ErrAccountNotFound
+	}
+
+	reversal, err := p.transactionRepo.FindReversal(ctx, original.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reversal: %w", err)
+	}
+	if reversal != nil {
+		return nil, domain.ErrTransactionAlreadyReversed
+	}
+
+	// The amount is negated as stored, so it is not normalized by operation type again
+	transaction := &domain.Transaction{
+		AccountID:       original.AccountID,
+		OperationTypeID: original.OperationTypeID,
+		Amount:          domain.RoundToCents(-original.Amount),
+		EventDate:       time.Now().UTC(),
+		ReversalOf:      original.ID,
+	}
+
+	// Reversing a credit debits the account, so it is only saved if the balance it was checked against is current
+	var expectedBalance *float64
+	if p.enforceLimit && transaction.Amount < 0 {
+		balance, err := p.transactionRepo.SumByAccountID(ctx, transaction.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute balance: %w", err)
+		}
+		if !domain.CanDebit(account.AvailableFunds(balance), transaction.Amount) {
+			return nil, domain.ErrInsufficientLimit
+		}
+		expectedBalance = &balance
+	}
+
+	// Only the reversal of a debt frees money to pay the other debts with
+	var outstanding []*domain.Transaction
+	if transaction.Amount > 0 {
+		outstanding, err = p.transactionRepo.FindOutstandingByAccountID(ctx, transaction.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find outstanding transactions: %w", err)
+		}
+	}
+
+	var discharges []domain.Discharge
+	discharges, transaction.Balance = domain.ReversalDischarges(original, outstanding)
+
+	created, err := p.transactionRepo.CreateWithDischarge(ctx, transaction, discharges, expectedBalance)
+	if err != nil {
+		if errors.Is(err, domain.ErrBalanceChanged) {
+			return nil, domain.ErrBalanceChanged
+		}
+		return nil, fmt.Errorf("failed to create reversal: %w", err)
+	}
+
+	return &domain.ReverseTransactionResponse{
+		CreateTransactionResponse: domain.CreateTransactionResponse{
+			TransactionID:   created.ID,
+			AccountID:       created.AccountID,
+			OperationTypeID: created.OperationTypeID,
+			Amount:          created.Amount,
+			EventDate:       created.EventDate,
+		},
+		ReversedTransactionID: original.ID,
+	}, nil
+}
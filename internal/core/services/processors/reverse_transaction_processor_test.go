@@ -0,0 +1,359 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseTransactionProcessor_Process(t *testing.T) {
+	now := time.Now().UTC()
+	// A purchase of which credits already paid 30.00
+	original := &domain.Transaction{ID: 10, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50.25, Balance: -20.25, EventDate: now}
+	request := domain.ReverseTransactionRequest{AccountID: 1, IdempotencyKey: "key-1"}
+	account := &domain.Account{ID: 1, DocumentNumber: "12345678900"}
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.MockTransactionRepository, *mocks.MockAccountRepository)
+		expectedResult *domain.ReverseTransactionResponse
+		wantErr        error
+		wantErrMsg     string
+	}{
+		{
+			name: "known key cancels the debt and frees what was paid of it",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accountRepo *mocks.MockAccountRepository) {
+				txRepo.EXPECT().FindByIdempotencyKey(mock.Anything, int64(1), "key-1").Return(original, nil).Once()
+				accountRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(account, nil).Once()
+				txRepo.EXPECT().FindReversal(mock.Anything, int64(10)).Return(nil, nil).Once()
+				txRepo.EXPECT().
+					FindOutstandingByAccountID(mock.Anything, int64(1)).
+					Return([]*domain.Transaction{
+						{ID: 10, AccountID: 1, Amount: -50.25, Balance: -20.25},
+						{ID: 12, AccountID: 1, Amount: -40.0, Balance: -40.0},
+					}, nil).
+					Once()
+				txRepo.EXPECT().
+					CreateWithDischarge(mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+						return tx.AccountID == 1 &&
+							tx.OperationTypeID == domain.OperationTypePurchase &&
+							tx.Amount == 50.25 &&
+							tx.Balance == 0 &&
+							tx.ReversalOf == 10 &&
+							tx.IdempotencyKey == ""
+					}), []domain.Discharge{
+						{TransactionID: 10, Previous: -20.25, Balance: 0},
+						{TransactionID: 12, Previous: -40.0, Balance: -10.0},
+					}, (*float64)(nil)).
+					Return(&domain.Transaction{ID: 11, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: 50.25, EventDate: now}, nil).
+					Once()
+			},
+			expectedResult: &domain.ReverseTransactionResponse{
+				CreateTransactionResponse: domain.CreateTransactionResponse{
+					TransactionID:   11,
+					AccountID:       1,
+					OperationTypeID: domain.OperationTypePurchase,
+					Amount:          50.25,
+					EventDate:       now,
+				},
+				ReversedTransactionID: 10,
+			},
+		},
+		{
+			name: "unknown key",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accountRepo *mocks.MockAccountRepository) {
+				txRepo.EXPECT().FindByIdempotencyKey(mock.Anything, int64(1), "key-1").Return(nil, nil).Once()
+			},
+			wantErr: domain.ErrIdempotencyKeyNotFound,
+		},
+		{
+			name: "already reversed",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accountRepo *mocks.MockAccountRepository) {
+				txRepo.EXPECT().FindByIdempotencyKey(mock.Anything, int64(1), "key-1").Return(original, nil).Once()
+				accountRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(account, nil).Once()
+				txRepo.EXPECT().FindReversal(mock.Anything, int64(10)).Return(&domain.Transaction{ID: 11}, nil).Once()
+			},
+			wantErr: domain.ErrTransactionAlreadyReversed,
+		},
+		{
+			name: "closed account",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accountRepo *mocks.MockAccountRepository) {
+				txRepo.EXPECT().FindByIdempotencyKey(mock.Anything, int64(1), "key-1").Return(original, nil).Once()
+				// Soft-deleted accounts are not found, so nothing is written to them
+				accountRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(nil, nil).Once()
+			},
+			wantErr: domain.ErrAccountNotFound,
+		},
+		{
+			name: "lookup error",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accountRepo *mocks.MockAccountRepository) {
+				txRepo.EXPECT().FindByIdempotencyKey(mock.Anything, int64(1), "key-1").Return(nil, errors.New("db down")).Once()
+			},
+			wantErrMsg: "failed to find transaction",
+		},
+		{
+			name: "outstanding lookup error",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accountRepo *mocks.MockAccountRepository) {
+				txRepo.EXPECT().FindByIdempotencyKey(mock.Anything, int64(1), "key-1").Return(original, nil).Once()
+				accountRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(account, nil).Once()
+				txRepo.EXPECT().FindReversal(mock.Anything, int64(10)).Return(nil, nil).Once()
+				txRepo.EXPECT().FindOutstandingByAccountID(mock.Anything, int64(1)).Return(nil, errors.New("db down")).Once()
+			},
+			wantErrMsg: "failed to find outstanding transactions",
+		},
+		{
+			name: "create error",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accountRepo *mocks.MockAccountRepository) {
+				txRepo.EXPECT().FindByIdempotencyKey(mock.Anything, int64(1), "key-1").Return(original, nil).Once()
+				accountRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(account, nil).Once()
+				txRepo.EXPECT().FindReversal(mock.Anything, int64(10)).Return(nil, nil).Once()
+				txRepo.EXPECT().FindOutstandingByAccountID(mock.Anything, int64(1)).Return(nil, nil).Once()
+				txRepo.EXPECT().CreateWithDischarge(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+			},
+			wantErrMsg: "failed to create reversal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txRepo := mocks.NewMockTransactionRepository(t)
+			accountRepo := mocks.NewMockAccountRepository(t)
+			tt.setupMocks(txRepo, accountRepo)
+
+			processor := NewReverseTransactionProcessor(txRepo, accountRepo, boundTxProvider(t))
+			result, err := processor.Process(context.Background(), request)
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+			case tt.wantErrMsg != "":
+				assert.ErrorContains(t, err, tt.wantErrMsg)
+				assert.Nil(t, result)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestReverseTransactionProcessor_ProcessByID(t *testing.T) {
+	now := time.Now().UTC()
+	// A credit voucher of which 50.00 paid debts and 30.00 is left
+	original := &domain.Transaction{ID: 10, AccountID: 1, OperationTypeID: domain.OperationTypeCreditVoucher, Amount: 80.0, Balance: 30.0, EventDate: now}
+	account := &domain.Account{ID: 1, DocumentNumber: "12345678900"}
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.MockTransactionRepository, *mocks.MockAccountRepository)
+		expectedResult *domain.ReverseTransactionResponse
+		wantErr        error
+		wantErrMsg     string
+	}{
+		{
+			name: "takes back the credit and owes again what it paid",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accountRepo *mocks.MockAccountRepository) {
+				txRepo.EXPECT().FindByID(mock.Anything, int64(10)).Return(original, nil).Once()
+				txRepo.EXPECT().FindReversed(mock.Anything, int64(10)).Return(nil, nil).Once()
+				accountRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(account, nil).Once()
+				txRepo.EXPECT().FindReversal(mock.Anything, int64(10)).Return(nil, nil).Once()
+				txRepo.EXPECT().
+					CreateWithDischarge(mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+						return tx.AccountID == 1 &&
+							tx.OperationTypeID == domain.OperationTypeCreditVoucher &&
+							tx.Amount == -80.0 &&
+							tx.Balance == -50.0 &&
+							tx.ReversalOf == 10
+					}), []domain.Discharge{
+						{TransactionID: 10, Previous: 30.0, Balance: 0},
+					}, (*float64)(nil)).
+					Return(&domain.Transaction{ID: 11, AccountID: 1, OperationTypeID: domain.OperationTypeCreditVoucher, Amount: -80.0, EventDate: now}, nil).
+					Once()
+			},
+			expectedResult: &domain.ReverseTransactionResponse{
+				CreateTransactionResponse: domain.CreateTransactionResponse{
+					TransactionID:   11,
+					AccountID:       1,
+					OperationTypeID: domain.OperationTypeCreditVoucher,
+					Amount:          -80.0,
+					EventDate:       now,
+				},
+				ReversedTransactionID: 10,
+			},
+		},
+		{
+			name: "unknown transaction",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accountRepo *mocks.MockAccountRepository) {
+				txRepo.EXPECT().FindByID(mock.Anything, int64(10)).Return(nil, nil).Once()
+			},
+			wantErr: domain.ErrTransactionNotFound,
+		},
+		{
+			name: "already reversed",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accountRepo *mocks.MockAccountRepository) {
+				txRepo.EXPECT().FindByID(mock.Anything, int64(10)).Return(original, nil).Once()
+				txRepo.EXPECT().FindReversed(mock.Anything, int64(10)).Return(nil, nil).Once()
+				accountRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(account, nil).Once()
+				txRepo.EXPECT().FindReversal(mock.Anything, int64(10)).Return(&domain.Transaction{ID: 11}, nil).Once()
+			},
+			wantErr: domain.ErrTransactionAlreadyReversed,
+		},
+		{
+			name: "transaction is itself a reversal",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accountRepo *mocks.MockAccountRepository) {
+				txRepo.EXPECT().FindByID(mock.Anything, int64(10)).Return(original, nil).Once()
+				txRepo.EXPECT().FindReversed(mock.Anything, int64(10)).Return(&domain.Transaction{ID: 9}, nil).Once()
+			},
+			wantErr: domain.ErrReversalNotReversible,
+		},
+		{
+			name: "closed account",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accountRepo *mocks.MockAccountRepository) {
+				txRepo.EXPECT().FindByID(mock.Anything, int64(10)).Return(original, nil).Once()
+				txRepo.EXPECT().FindReversed(mock.Anything, int64(10)).Return(nil, nil).Once()
+				accountRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(nil, nil).Once()
+			},
+			wantErr: domain.ErrAccountNotFound,
+		},
+		{
+			name: "account lookup error",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accountRepo *mocks.MockAccountRepository) {
+				txRepo.EXPECT().FindByID(mock.Anything, int64(10)).Return(original, nil).Once()
+				txRepo.EXPECT().FindReversed(mock.Anything, int64(10)).Return(nil, nil).Once()
+				accountRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(nil, errors.New("db down")).Once()
+			},
+			wantErrMsg: "failed to find account",
+		},
+		{
+			name: "lookup error",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accountRepo *mocks.MockAccountRepository) {
+				txRepo.EXPECT().FindByID(mock.Anything, int64(10)).Return(nil, errors.New("db down")).Once()
+			},
+			wantErrMsg: "failed to find transaction",
+		},
+		{
+			name: "reversed lookup error",
+			setupMocks: func(txRepo *mocks.MockTransactionRepository, accountRepo *mocks.MockAccountRepository) {
+				txRepo.EXPECT().FindByID(mock.Anything, int64(10)).Return(original, nil).Once()
+				txRepo.EXPECT().FindReversed(mock.Anything, int64(10)).Return(nil, errors.New("db down")).Once()
+			},
+			wantErrMsg: "failed to find reversed transaction",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txRepo := mocks.NewMockTransactionRepository(t)
+			accountRepo := mocks.NewMockAccountRepository(t)
+			tt.setupMocks(txRepo, accountRepo)
+
+			processor := NewReverseTransactionProcessor(txRepo, accountRepo, boundTxProvider(t))
+			result, err := processor.ProcessByID(context.Background(), 10)
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+			case tt.wantErrMsg != "":
+				assert.ErrorContains(t, err, tt.wantErrMsg)
+				assert.Nil(t, result)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestReverseTransactionProcessor_CreditLimit(t *testing.T) {
+	now := time.Now().UTC()
+	credit := &domain.Transaction{ID: 10, AccountID: 1, OperationTypeID: domain.OperationTypeCreditVoucher, Amount: 80.0, Balance: 80.0, EventDate: now}
+	debit := &domain.Transaction{ID: 10, AccountID: 1, OperationTypeID: domain.OperationTypeWithdrawal, Amount: -80.0, Balance: -80.0, EventDate: now}
+	balanceOf := func(want float64) interface{} {
+		return mock.MatchedBy(func(balance *float64) bool { return balance != nil && *balance == want })
+	}
+
+	tests := []struct {
+		name        string
+		original    *domain.Transaction
+		creditLimit float64
+		setupMocks  func(*mocks.MockTransactionRepository)
+		wantErr     error
+	}{
+		{
+			name:        "credit reversal within the limit is saved against the balance it was checked with",
+			original:    credit,
+			creditLimit: 100.0,
+			setupMocks: func(txRepo *mocks.MockTransactionRepository) {
+				// 30.00 + 100.00 of limit covers the 80.00 taken back
+				txRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(30.0, nil).Once()
+				txRepo.EXPECT().
+					CreateWithDischarge(mock.Anything, mock.Anything, mock.Anything, balanceOf(30.0)).
+					Return(&domain.Transaction{ID: 11, AccountID: 1, Amount: -80.0, EventDate: now}, nil).
+					Once()
+			},
+		},
+		{
+			name:     "credit reversal over the limit is rejected",
+			original: credit,
+			setupMocks: func(txRepo *mocks.MockTransactionRepository) {
+				txRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(30.0, nil).Once()
+			},
+			wantErr: domain.ErrInsufficientLimit,
+		},
+		{
+			name:     "balance changed since the check",
+			original: credit,
+			setupMocks: func(txRepo *mocks.MockTransactionRepository) {
+				txRepo.EXPECT().SumByAccountID(mock.Anything, int64(1)).Return(80.0, nil).Once()
+				txRepo.EXPECT().
+					CreateWithDischarge(mock.Anything, mock.Anything, mock.Anything, balanceOf(80.0)).
+					Return(nil, domain.ErrBalanceChanged).
+					Once()
+			},
+			wantErr: domain.ErrBalanceChanged,
+		},
+		{
+			name:     "debit reversal is never blocked",
+			original: debit,
+			setupMocks: func(txRepo *mocks.MockTransactionRepository) {
+				txRepo.EXPECT().FindOutstandingByAccountID(mock.Anything, int64(1)).Return([]*domain.Transaction{debit}, nil).Once()
+				txRepo.EXPECT().
+					CreateWithDischarge(mock.Anything, mock.Anything, mock.Anything, (*float64)(nil)).
+					Return(&domain.Transaction{ID: 11, AccountID: 1, Amount: 80.0, EventDate: now}, nil).
+					Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txRepo := mocks.NewMockTransactionRepository(t)
+			accountRepo := mocks.NewMockAccountRepository(t)
+			txRepo.EXPECT().FindByID(mock.Anything, int64(10)).Return(tt.original, nil).Once()
+			txRepo.EXPECT().FindReversed(mock.Anything, int64(10)).Return(nil, nil).Once()
+			accountRepo.EXPECT().FindByID(mock.Anything, int64(1)).Return(&domain.Account{ID: 1, CreditLimit: tt.creditLimit}, nil).Once()
+			txRepo.EXPECT().FindReversal(mock.Anything, int64(10)).Return(nil, nil).Once()
+			tt.setupMocks(txRepo)
+
+			processor := NewReverseTransactionProcessor(txRepo, accountRepo, boundTxProvider(t), WithReversalCreditLimitEnforcement(true))
+			result, err := processor.ProcessByID(context.Background(), 10)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(10), result.ReversedTransactionID)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// inTransaction runs fn so its checks and writes commit or roll back as a whole
+// It joins the transaction already bound to ctx (by the Transactional middleware), leaving its commit to whoever
+// began it; otherwise it begins one that is committed when fn succeeds
+func inTransaction(ctx context.Context, provider ports.TxProvider, fn func(ctx context.Context) error) error {
+	if provider.FromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	txCtx, tx, err := provider.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			tx.Rollback()
+		}
+	}()
+
+	if err := fn(txCtx); err != nil {
+		return err
+	}
+
+	committed = true
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
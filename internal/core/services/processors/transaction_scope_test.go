@@ -0,0 +1,105 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type scopeTxKey struct{}
+
+// boundTxProvider is a provider whose contexts already carry a transaction, as on a Transactional route
+func boundTxProvider(t *testing.T) *mocks.MockTxProvider {
+	provider := mocks.NewMockTxProvider(t)
+	provider.EXPECT().FromContext(mock.Anything).Return(mocks.NewMockTx(t)).Maybe()
+	return provider
+}
+
+func TestInTransaction(t *testing.T) {
+	tests := []struct {
+		name    string
+		fnErr   error
+		setupTx func(*mocks.MockTx)
+		wantErr string
+	}{
+		{
+			name: "commits when fn succeeds",
+			setupTx: func(tx *mocks.MockTx) {
+				tx.EXPECT().Commit().Return(nil).Once()
+			},
+		},
+		{
+			name:  "rolls back when fn fails",
+			fnErr: errors.New("balance check failed"),
+			setupTx: func(tx *mocks.MockTx) {
+				tx.EXPECT().Rollback().Return(nil).Once()
+			},
+			wantErr: "balance check failed",
+		},
+		{
+			name: "reports a failed commit",
+			setupTx: func(tx *mocks.MockTx) {
+				tx.EXPECT().Commit().Return(errors.New("disk full")).Once()
+			},
+			wantErr: "failed to commit transaction: disk full",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := mocks.NewMockTx(t)
+			tt.setupTx(tx)
+			provider := mocks.NewMockTxProvider(t)
+			provider.EXPECT().FromContext(mock.Anything).Return(nil).Once()
+			provider.EXPECT().
+				Begin(mock.Anything).
+				RunAndReturn(func(ctx context.Context) (context.Context, ports.Tx, error) {
+					return context.WithValue(ctx, scopeTxKey{}, tx), tx, nil
+				}).
+				Once()
+
+			err := inTransaction(context.Background(), provider, func(ctx context.Context) error {
+				assert.Equal(t, tx, ctx.Value(scopeTxKey{}), "fn runs with the transaction's context")
+				return tt.fnErr
+			})
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestInTransaction_JoinsBoundTransaction(t *testing.T) {
+	// Neither commits nor rolls back: the transaction belongs to whoever began it
+	provider := boundTxProvider(t)
+
+	called := false
+	err := inTransaction(context.Background(), provider, func(ctx context.Context) error {
+		called = true
+		return errors.New("rejected")
+	})
+
+	assert.EqualError(t, err, "rejected")
+	assert.True(t, called)
+}
+
+func TestInTransaction_BeginFailure(t *testing.T) {
+	provider := mocks.NewMockTxProvider(t)
+	provider.EXPECT().FromContext(mock.Anything).Return(nil).Once()
+	provider.EXPECT().Begin(mock.Anything).Return(nil, nil, errors.New("database is closed")).Once()
+
+	err := inTransaction(context.Background(), provider, func(ctx context.Context) error {
+		t.Fatal("fn must not run without a transaction")
+		return nil
+	})
+
+	assert.EqualError(t, err, "database is closed")
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type CanDebitHandler struct {
+	processor processors.CanDebitProcessorInterface
+}
+
+func NewCanDebitHandler(processor processors.CanDebitProcessorInterface) *CanDebitHandler {
+	return &CanDebitHandler{
+		processor: processor,
+	}
+}
+
+// Handle previews whether the account can afford the debit given in the amount query parameter
+func (h *CanDebitHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	accountID, err := parseAccountIDParam(r)
+	if err != nil || accountID <= 0 {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid account ID")
+		return
+	}
+
+	amount, err := strconv.ParseFloat(r.URL.Query().Get("amount"), 64)
+	if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) || amount <= 0 {
+		respondWithError(w, r, http.StatusBadRequest, "amount must be a positive number")
+		return
+	}
+
+	req := domain.CanDebitRequest{
+		AccountID: accountID,
+		Amount:    amount,
+	}
+
+	response, err := h.processor.Process(r.Context(), req)
+	if err != nil {
+		if contains(err.Error(), "not found") {
+			respondWithError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to check debit")
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusOK, response)
+}
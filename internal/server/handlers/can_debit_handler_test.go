@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCanDebitHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name           string
+		accountID      string
+		queryParams    string
+		setupMock      func(*mocks.MockCanDebitProcessorInterface)
+		expectedStatus int
+		validateResp   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "affordable debit",
+			accountID:   "1",
+			queryParams: "?amount=50",
+			setupMock: func(mockProc *mocks.MockCanDebitProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.CanDebitRequest{AccountID: 1, Amount: 50}).
+					Return(&domain.CanDebitResponse{AccountID: 1, Amount: 50, Allowed: true, Available: 120.5}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"account_id":1,"amount":50,"allowed":true,"available":120.5}`, w.Body.String())
+			},
+		},
+		{
+			name:        "unaffordable debit",
+			accountID:   "1",
+			queryParams: "?amount=500",
+			setupMock: func(mockProc *mocks.MockCanDebitProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.CanDebitRequest{AccountID: 1, Amount: 500}).
+					Return(&domain.CanDebitResponse{AccountID: 1, Amount: 500, Allowed: false, Available: 120.5}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), `"allowed":false`)
+			},
+		},
+		{
+			name:           "missing amount",
+			accountID:      "1",
+			queryParams:    "",
+			setupMock:      func(mockProc *mocks.MockCanDebitProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "amount must be a positive number")
+			},
+		},
+		{
+			name:           "negative amount",
+			accountID:      "1",
+			queryParams:    "?amount=-5",
+			setupMock:      func(mockProc *mocks.MockCanDebitProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid account ID",
+			accountID:      "abc",
+			queryParams:    "?amount=5",
+			setupMock:      func(mockProc *mocks.MockCanDebitProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "account not found",
+			accountID:   "999",
+			queryParams: "?amount=5",
+			setupMock: func(mockProc *mocks.MockCanDebitProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.CanDebitRequest{AccountID: 999, Amount: 5}).
+					Return(nil, errors.New("account with id 999 not found")).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockCanDebitProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewCanDebitHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/"+tt.accountID+"/can-debit"+tt.queryParams, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", tt.accountID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateResp != nil {
+				tt.validateResp(t, w)
+			}
+		})
+	}
+}
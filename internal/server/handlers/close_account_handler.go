@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type CloseAccountHandler struct {
+	processor processors.CloseAccountProcessorInterface
+	force     bool
+}
+
+// NewCloseAccountHandler closes accounts whose balance is zero
+func NewCloseAccountHandler(processor processors.CloseAccountProcessorInterface) *CloseAccountHandler {
+	return &CloseAccountHandler{
+		processor: processor,
+	}
+}
+
+// NewForceCloseAccountHandler closes accounts whatever their balance; mount it on admin routes only
+func NewForceCloseAccountHandler(processor processors.CloseAccountProcessorInterface) *CloseAccountHandler {
+	return &CloseAccountHandler{
+		processor: processor,
+		force:     true,
+	}
+}
+
+// Handle soft-deletes the account and answers 204; its transactions are kept
+func (h *CloseAccountHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	accountID, err := parseAccountIDParam(r)
+	if err != nil || accountID <= 0 {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid account ID")
+		return
+	}
+
+	err = h.processor.Process(r.Context(), domain.CloseAccountRequest{AccountID: accountID, Force: h.force})
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountHasBalance) {
+			respondWithError(w, r, http.StatusConflict, err.Error())
+			return
+		}
+		if contains(err.Error(), "not found") {
+			respondWithError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to close account")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCloseAccountHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name           string
+		accountID      string
+		force          bool
+		setupMock      func(*mocks.MockCloseAccountProcessorInterface)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "closes the account",
+			accountID: "1",
+			setupMock: func(mockProc *mocks.MockCloseAccountProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything, domain.CloseAccountRequest{AccountID: 1}).Return(nil).Once()
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:      "force closes from the admin route",
+			accountID: "1",
+			force:     true,
+			setupMock: func(mockProc *mocks.MockCloseAccountProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything, domain.CloseAccountRequest{AccountID: 1, Force: true}).Return(nil).Once()
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:      "nonzero balance",
+			accountID: "1",
+			setupMock: func(mockProc *mocks.MockCloseAccountProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything, domain.CloseAccountRequest{AccountID: 1}).Return(domain.ErrAccountHasBalance).Once()
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "cannot close account with nonzero balance",
+		},
+		{
+			name:      "account not found",
+			accountID: "999",
+			setupMock: func(mockProc *mocks.MockCloseAccountProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything, domain.CloseAccountRequest{AccountID: 999}).Return(fmt.Errorf("account with id 999 not found")).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid account ID",
+			accountID:      "abc",
+			setupMock:      func(mockProc *mocks.MockCloseAccountProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "internal server error",
+			accountID: "1",
+			setupMock: func(mockProc *mocks.MockCloseAccountProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything, domain.CloseAccountRequest{AccountID: 1}).Return(errors.New("database error")).Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "Failed to close account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockCloseAccountProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewCloseAccountHandler(mockProc)
+			if tt.force {
+				handler = NewForceCloseAccountHandler(mockProc)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/v1/accounts/"+tt.accountID, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", tt.accountID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusNoContent {
+				assert.Empty(t, w.Body.String())
+			}
+			if tt.expectedBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type CountAccountsHandler struct {
+	processor processors.CountAccountsProcessorInterface
+}
+
+func NewCountAccountsHandler(processor processors.CountAccountsProcessorInterface) *CountAccountsHandler {
+	return &CountAccountsHandler{
+		processor: processor,
+	}
+}
+
+// Handle returns the number of accounts
+func (h *CountAccountsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	response, err := h.processor.Process(r.Context())
+	if err != nil {
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to count accounts")
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusOK, response)
+}
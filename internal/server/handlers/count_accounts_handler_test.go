@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCountAccountsHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(*mocks.MockCountAccountsProcessorInterface)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "no accounts",
+			setupMock: func(mockProc *mocks.MockCountAccountsProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything).Return(&domain.CountAccountsResponse{Count: 0}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"count":0}`,
+		},
+		{
+			name: "several accounts",
+			setupMock: func(mockProc *mocks.MockCountAccountsProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything).Return(&domain.CountAccountsResponse{Count: 42}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"count":42}`,
+		},
+		{
+			name: "processor error",
+			setupMock: func(mockProc *mocks.MockCountAccountsProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything).Return(nil, errors.New("database error")).Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"Internal Server Error","message":"Failed to count accounts"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockCountAccountsProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewCountAccountsHandler(mockProc)
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/accounts/count", nil)
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
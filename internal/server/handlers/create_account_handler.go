@@ -1,7 +1,7 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
@@ -20,32 +20,45 @@ func NewCreateAccountHandler(processor processors.CreateAccountProcessorInterfac
 
 func (h *CreateAccountHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	var req domain.CreateAccountRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+	if err := decodeJSON(w, r, &req); err != nil {
+		respondWithDecodeError(w, r, err)
 		return
 	}
 
 	if err := h.validateRequest(req); err != nil {
-		respondWithError(w, http.StatusBadRequest, err.Error())
+		respondWithValidationError(w, r, err)
 		return
 	}
 
 	response, err := h.processor.Process(r.Context(), req)
 	if err != nil {
 		if err.Error() == "account with this document number already exists" {
-			respondWithError(w, http.StatusConflict, err.Error())
+			respondWithError(w, r, http.StatusConflict, err.Error())
 			return
 		}
-		respondWithError(w, http.StatusInternalServerError, "Failed to create account")
+		if errors.Is(err, domain.ErrDocumentNumberNotAllowed) {
+			respondWithError(w, r, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if errors.Is(err, domain.ErrServiceUnavailable) {
+			respondWithServiceUnavailable(w, r)
+			return
+		}
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to create account")
 		return
 	}
 
-	respondWithJSON(w, http.StatusCreated, response.Account)
+	respondWithJSON(w, r, http.StatusCreated, response.Account)
 }
 
 func (h *CreateAccountHandler) validateRequest(req domain.CreateAccountRequest) error {
+	if err := validateStruct(req); err != nil {
+		return err
+	}
+
 	account := &domain.Account{
 		DocumentNumber: req.DocumentNumber,
+		Tier:           req.Tier,
 	}
 	return account.Validate()
 }
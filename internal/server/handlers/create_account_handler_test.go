@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -26,15 +27,15 @@ func TestCreateAccountHandler_Handle(t *testing.T) {
 		{
 			name: "successful account creation",
 			requestBody: map[string]string{
-				"document_number": "12345678900",
+				"document_number": "12345678909",
 			},
 			setupMock: func(mockProc *mocks.MockCreateAccountProcessorInterface) {
 				mockProc.On("Process", mock.Anything, domain.CreateAccountRequest{
-					DocumentNumber: "12345678900",
+					DocumentNumber: "12345678909",
 				}).Return(&domain.CreateAccountResponse{
 					Account: &domain.Account{
 						ID:             1,
-						DocumentNumber: "12345678900",
+						DocumentNumber: "12345678909",
 						CreatedAt:      time.Now(),
 					},
 				}, nil).Once()
@@ -45,7 +46,7 @@ func TestCreateAccountHandler_Handle(t *testing.T) {
 				err := json.Unmarshal(w.Body.Bytes(), &result)
 				assert.NoError(t, err)
 				assert.Equal(t, int64(1), result.ID)
-				assert.Equal(t, "12345678900", result.DocumentNumber)
+				assert.Equal(t, "12345678909", result.DocumentNumber)
 			},
 		},
 		{
@@ -58,6 +59,18 @@ func TestCreateAccountHandler_Handle(t *testing.T) {
 				assert.Contains(t, w.Body.String(), "Invalid request body")
 			},
 		},
+		{
+			name: "array instead of an object",
+			requestBody: []map[string]string{
+				{"document_number": "12345678909"},
+			},
+			setupMock: func(mockProc *mocks.MockCreateAccountProcessorInterface) {
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Invalid request body: expected a JSON object, got an array")
+			},
+		},
 		{
 			name: "empty document number",
 			requestBody: map[string]string{
@@ -79,7 +92,7 @@ func TestCreateAccountHandler_Handle(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
-				assert.Contains(t, w.Body.String(), "document_number must have between 11 and 14 characters")
+				assert.Contains(t, w.Body.String(), "document_number must have 11 or 14 characters")
 			},
 		},
 		{
@@ -91,7 +104,20 @@ func TestCreateAccountHandler_Handle(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
-				assert.Contains(t, w.Body.String(), "document_number must have between 11 and 14 characters")
+				assert.Contains(t, w.Body.String(), "document_number must have 11 or 14 characters")
+			},
+		},
+		{
+			name: "document number between cpf and cnpj lengths",
+			requestBody: map[string]string{
+				"document_number": "123456789012",
+			},
+			setupMock: func(mockProc *mocks.MockCreateAccountProcessorInterface) {
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), `"field":"document_number"`)
+				assert.Contains(t, w.Body.String(), `"rule":"len=11|14"`)
 			},
 		},
 		{
@@ -119,10 +145,47 @@ func TestCreateAccountHandler_Handle(t *testing.T) {
 			},
 		},
 		{
-			name: "duplicate document number",
+			name: "CPF with wrong check digits",
 			requestBody: map[string]string{
 				"document_number": "12345678900",
 			},
+			setupMock: func(mockProc *mocks.MockCreateAccountProcessorInterface) {
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "invalid CPF")
+			},
+		},
+		{
+			name: "CNPJ of a repeated digit",
+			requestBody: map[string]string{
+				"document_number": "00000000000000",
+			},
+			setupMock: func(mockProc *mocks.MockCreateAccountProcessorInterface) {
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "invalid CNPJ")
+			},
+		},
+		{
+			name: "credit limit chosen by the client",
+			requestBody: map[string]interface{}{
+				"document_number": "12345678909",
+				"credit_limit":    1e12,
+			},
+			setupMock: func(mockProc *mocks.MockCreateAccountProcessorInterface) {
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "credit_limit")
+			},
+		},
+		{
+			name: "duplicate document number",
+			requestBody: map[string]string{
+				"document_number": "12345678909",
+			},
 			setupMock: func(mockProc *mocks.MockCreateAccountProcessorInterface) {
 				mockProc.On("Process", mock.Anything, mock.Anything).
 					Return(nil, errors.New("account with this document number already exists")).
@@ -133,10 +196,42 @@ func TestCreateAccountHandler_Handle(t *testing.T) {
 				assert.Contains(t, w.Body.String(), "account with this document number already exists")
 			},
 		},
+		{
+			name: "document number outside the allowlist",
+			requestBody: map[string]string{
+				"document_number": "12345678909",
+			},
+			setupMock: func(mockProc *mocks.MockCreateAccountProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.Anything).
+					Return(nil, domain.ErrDocumentNumberNotAllowed).
+					Once()
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "document_number is not in an allowed range")
+			},
+		},
+		{
+			name: "database saturated",
+			requestBody: map[string]string{
+				"document_number": "12345678909",
+			},
+			setupMock: func(mockProc *mocks.MockCreateAccountProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("failed to create account: %w", fmt.Errorf("%w: database is locked", domain.ErrServiceUnavailable))).
+					Once()
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Equal(t, "1", w.Header().Get("Retry-After"))
+				assert.Contains(t, w.Body.String(), "service temporarily unavailable")
+				assert.NotContains(t, w.Body.String(), "database is locked")
+			},
+		},
 		{
 			name: "internal server error",
 			requestBody: map[string]string{
-				"document_number": "12345678900",
+				"document_number": "12345678909",
 			},
 			setupMock: func(mockProc *mocks.MockCreateAccountProcessorInterface) {
 				mockProc.On("Process", mock.Anything, mock.Anything).
@@ -1,14 +1,22 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
 	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+	customMiddleware "github.com/larissamartinsss/simple-banking-api/internal/server/middleware"
 )
 
+// DuplicateTransactionResponse points the client at the transaction its request duplicates
+type DuplicateTransactionResponse struct {
+	Error                 string `json:"error"`
+	Message               string `json:"message"`
+	ExistingTransactionID int64  `json:"existing_transaction_id"`
+}
+
 type CreateTransactionHandler struct {
 	processor processors.CreateTransactionProcessorInterface
 }
@@ -20,57 +28,98 @@ func NewCreateTransactionHandler(processor processors.CreateTransactionProcessor
 }
 
 func (h *CreateTransactionHandler) Handle(w http.ResponseWriter, r *http.Request) {
-	// Validate required Idempotency-Key header
-	idempotencyKey := r.Header.Get("Idempotency-Key")
-	if idempotencyKey == "" {
-		respondWithError(w, http.StatusBadRequest, "Idempotency-Key header is required")
+	// The header and the body are checked independently, so the message names whatever is missing
+	idempotencyKey := r.Header.Get(customMiddleware.IdempotencyKeyHeader)
+	bodyErr := requireBody(r)
+	switch {
+	case idempotencyKey == "" && bodyErr != nil:
+		respondWithError(w, r, http.StatusBadRequest, "Idempotency-Key header and request body are required")
+		return
+	case idempotencyKey == "":
+		respondWithError(w, r, http.StatusBadRequest, "Idempotency-Key header is required")
+		return
+	case bodyErr != nil:
+		respondWithDecodeError(w, r, bodyErr)
 		return
 	}
 
 	var req domain.CreateTransactionRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+	if err := decodeJSON(w, r, &req); err != nil {
+		respondWithDecodeError(w, r, err)
 		return
 	}
 
-	if err := h.validateRequest(req); err != nil {
-		respondWithError(w, http.StatusBadRequest, err.Error())
+	req.IdempotencyKey = idempotencyKey
+
+	if err := checkAmount(req.Amount); err != nil {
+		respondWithDecodeError(w, r, err)
+		return
+	}
+
+	if err := validateCreateTransactionRequest(req); err != nil {
+		respondWithValidationError(w, r, err)
 		return
 	}
 
 	response, err := h.processor.Process(r.Context(), req)
 	if err != nil {
+		var duplicateErr *domain.DuplicateTransactionError
+		if errors.As(err, &duplicateErr) {
+			respondWithJSON(w, r, http.StatusConflict, DuplicateTransactionResponse{
+				Error:                 http.StatusText(http.StatusConflict),
+				Message:               duplicateErr.Error(),
+				ExistingTransactionID: duplicateErr.ExistingTransactionID,
+			})
+			return
+		}
+		if errors.Is(err, domain.ErrServiceUnavailable) {
+			respondWithServiceUnavailable(w, r)
+			return
+		}
+
 		switch err {
 		case domain.ErrInvalidOperationType:
-			respondWithError(w, http.StatusBadRequest, err.Error())
-		case domain.ErrZeroAmount:
-			respondWithError(w, http.StatusBadRequest, err.Error())
+			respondWithError(w, r, http.StatusBadRequest, err.Error())
+		case domain.ErrZeroAmount, domain.ErrAmountTooLarge, domain.ErrAmountTooPrecise:
+			respondWithError(w, r, http.StatusBadRequest, err.Error())
+		case domain.ErrOperationNotPermitted, domain.ErrAccountReverificationRequired:
+			respondWithError(w, r, http.StatusForbidden, err.Error())
+		case domain.ErrEventDateBeforeAccountCreation,
+			domain.ErrInstallmentsNotAllowed,
+			domain.ErrTooManyInstallments,
+			domain.ErrInstallmentBelowMinimum,
+			domain.ErrTooManyRowsPerRequest,
+			domain.ErrInsufficientLimit:
+			respondWithError(w, r, http.StatusUnprocessableEntity, err.Error())
+		case domain.ErrBalanceChanged:
+			respondWithError(w, r, http.StatusConflict, err.Error())
+		case domain.ErrTransactionDeclined:
+			respondWithError(w, r, http.StatusPaymentRequired, err.Error())
+		case domain.ErrAccountClosed:
+			respondWithError(w, r, http.StatusNotFound, err.Error())
 		default:
 			// Check if it's an account not found error
 			errMsg := err.Error()
 			if strings.Contains(errMsg, "account not found") ||
 				strings.Contains(errMsg, "account with id") ||
 				strings.Contains(errMsg, "does not exist") {
-				respondWithError(w, http.StatusNotFound, err.Error())
+				respondWithError(w, r, http.StatusNotFound, err.Error())
 			} else {
-				respondWithError(w, http.StatusInternalServerError, "Failed to create transaction")
+				respondWithError(w, r, http.StatusInternalServerError, "Failed to create transaction")
 			}
 		}
 		return
 	}
 
 	// Respond with success
-	respondWithJSON(w, http.StatusCreated, response)
+	respondWithJSON(w, r, http.StatusCreated, response)
 }
 
-func (h *CreateTransactionHandler) validateRequest(req domain.CreateTransactionRequest) error {
-	if req.AccountID <= 0 {
-		return domain.ErrInvalidAccountID
-	}
-
-	if req.OperationTypeID < 1 || req.OperationTypeID > 4 {
-		return domain.ErrInvalidOperationType
+// validateCreateTransactionRequest checks a transaction request before it reaches a processor
+func validateCreateTransactionRequest(req domain.CreateTransactionRequest) error {
+	if err := validateStruct(req); err != nil {
+		return err
 	}
 
 	if req.Amount == 0 {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -53,6 +54,7 @@ func TestCreateTransactionHandler_Handle(t *testing.T) {
 					AccountID:       1,
 					OperationTypeID: 1,
 					Amount:          50.0,
+					IdempotencyKey:  "test-key-1",
 				}).Return(&domain.CreateTransactionResponse{
 					TransactionID:   1,
 					AccountID:       1,
@@ -84,6 +86,7 @@ func TestCreateTransactionHandler_Handle(t *testing.T) {
 					AccountID:       1,
 					OperationTypeID: 4,
 					Amount:          100.0,
+					IdempotencyKey:  "test-key-2",
 				}).Return(&domain.CreateTransactionResponse{
 					TransactionID:   2,
 					AccountID:       1,
@@ -100,6 +103,33 @@ func TestCreateTransactionHandler_Handle(t *testing.T) {
 				assert.Equal(t, 100.0, result.Amount)
 			},
 		},
+		{
+			name:           "idempotency key without a body",
+			requestBody:    "",
+			idempotencyKey: "test-key-empty",
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Request body is required")
+				assert.NotContains(t, w.Body.String(), "Invalid request body")
+			},
+		},
+		{
+			name:           "neither idempotency key nor body",
+			requestBody:    "",
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Idempotency-Key header and request body are required")
+			},
+		},
+		{
+			name:           "whitespace-only body",
+			requestBody:    "  \n",
+			idempotencyKey: "test-key-blank",
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "body must not be empty")
+			},
+		},
 		{
 			name:           "invalid JSON body",
 			requestBody:    "invalid json",
@@ -153,11 +183,14 @@ func TestCreateTransactionHandler_Handle(t *testing.T) {
 			},
 			idempotencyKey: "test-key-6",
 			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
-				// No mock expectations as validation should fail
+				// Unknown types are only found out by the processor's lookup
+				mockProc.On("Process", mock.Anything, mock.Anything).
+					Return(nil, domain.ErrInvalidOperationType).
+					Once()
 			},
 			expectedStatus: http.StatusBadRequest,
 			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
-				assert.Contains(t, w.Body.String(), "operation_type_id must be between 1 and 4")
+				assert.Contains(t, w.Body.String(), "operation_type_id is not a known operation type")
 			},
 		},
 		{
@@ -173,7 +206,7 @@ func TestCreateTransactionHandler_Handle(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
-				assert.Contains(t, w.Body.String(), "operation_type_id must be between 1 and 4")
+				assert.Contains(t, w.Body.String(), "operation_type_id must be greater than 0")
 			},
 		},
 		{
@@ -192,6 +225,52 @@ func TestCreateTransactionHandler_Handle(t *testing.T) {
 				assert.Contains(t, w.Body.String(), "amount cannot be zero")
 			},
 		},
+		{
+			name: "amount beyond the maximum is rejected before the processor",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 1,
+				"amount":            1e300,
+			},
+			idempotencyKey: "test-key-amount-large",
+			setupMock:      func(mockProc *mocks.MockCreateTransactionProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Invalid request body: amount must not exceed 1000000000 in absolute value")
+			},
+		},
+		{
+			name: "amount with more than two decimals is rejected before the processor",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 1,
+				"amount":            10.555,
+			},
+			idempotencyKey: "test-key-amount-precise",
+			setupMock:      func(mockProc *mocks.MockCreateTransactionProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "amount must not have more than 2 decimal places")
+			},
+		},
+		{
+			name: "domain amount bound maps to 400",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 1,
+				"amount":            999999999.99,
+			},
+			idempotencyKey: "test-key-amount-domain",
+			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.Anything).
+					Return(nil, domain.ErrAmountTooLarge).
+					Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), domain.ErrAmountTooLarge.Error())
+			},
+		},
 		{
 			name: "account not found",
 			requestBody: map[string]interface{}{
@@ -211,6 +290,24 @@ func TestCreateTransactionHandler_Handle(t *testing.T) {
 				assert.Contains(t, w.Body.String(), "account with id 999")
 			},
 		},
+		{
+			name: "account is closed",
+			requestBody: map[string]interface{}{
+				"account_id":        7,
+				"operation_type_id": 1,
+				"amount":            50.0,
+			},
+			idempotencyKey: "test-key-closed",
+			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.Anything).
+					Return(nil, domain.ErrAccountClosed).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "account is closed")
+			},
+		},
 		{
 			name: "invalid operation type from processor",
 			requestBody: map[string]interface{}{
@@ -226,7 +323,253 @@ func TestCreateTransactionHandler_Handle(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
-				assert.Contains(t, w.Body.String(), "operation_type_id must be between 1 and 4")
+				assert.Contains(t, w.Body.String(), "operation_type_id is not a known operation type")
+			},
+		},
+		{
+			name: "operation not permitted for account tier",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 3,
+				"amount":            50.0,
+			},
+			idempotencyKey: "test-key-tier",
+			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.Anything).
+					Return(nil, domain.ErrOperationNotPermitted).
+					Once()
+			},
+			expectedStatus: http.StatusForbidden,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "not permitted for this account tier")
+			},
+		},
+		{
+			name: "account requires reverification",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 1,
+				"amount":            50.0,
+			},
+			idempotencyKey: "test-key-reverification",
+			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.Anything).
+					Return(nil, domain.ErrAccountReverificationRequired).
+					Once()
+			},
+			expectedStatus: http.StatusForbidden,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), domain.ErrAccountReverificationRequired.Error())
+			},
+		},
+		{
+			name: "event date before account creation",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 1,
+				"amount":            50.0,
+				"event_date":        "2020-01-01T00:00:00Z",
+			},
+			idempotencyKey: "test-key-backfill",
+			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.MatchedBy(func(req domain.CreateTransactionRequest) bool {
+					return req.EventDate != nil && req.EventDate.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+				})).
+					Return(nil, domain.ErrEventDateBeforeAccountCreation).
+					Once()
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "earlier than the account creation date")
+			},
+		},
+		{
+			name: "expected balance changed",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 4,
+				"amount":            25.0,
+				"expected_balance":  100.0,
+			},
+			idempotencyKey: "test-key-cas",
+			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.MatchedBy(func(req domain.CreateTransactionRequest) bool {
+					return req.ExpectedBalance != nil && *req.ExpectedBalance == 100.0
+				})).
+					Return(nil, domain.ErrBalanceChanged).
+					Once()
+			},
+			expectedStatus: http.StatusConflict,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "balance changed")
+			},
+		},
+		{
+			name: "simulated decline",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 1,
+				"amount":            13.37,
+			},
+			idempotencyKey: "test-key-decline",
+			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.Anything).
+					Return(nil, domain.ErrTransactionDeclined).
+					Once()
+			},
+			expectedStatus: http.StatusPaymentRequired,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "transaction declined")
+			},
+		},
+		{
+			name: "too many installments",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 2,
+				"amount":            50.0,
+				"installments":      48,
+			},
+			idempotencyKey: "test-key-installments",
+			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.Anything).
+					Return(nil, domain.ErrTooManyInstallments).
+					Once()
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "installments exceed the maximum allowed")
+			},
+		},
+		{
+			name: "too many rows for one request",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 2,
+				"amount":            5000.0,
+				"installments":      1000,
+			},
+			idempotencyKey: "test-key-rows",
+			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.Anything).
+					Return(nil, domain.ErrTooManyRowsPerRequest).
+					Once()
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "request would create more transactions than allowed")
+			},
+		},
+		{
+			name: "installment below minimum",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 2,
+				"amount":            10.0,
+				"installments":      12,
+			},
+			idempotencyKey: "test-key-installment-min",
+			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.Anything).
+					Return(nil, domain.ErrInstallmentBelowMinimum).
+					Once()
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "installment amount is below the minimum allowed")
+			},
+		},
+		{
+			name: "purchase exceeding the credit limit",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 1,
+				"amount":            5000.0,
+			},
+			idempotencyKey: "test-key-credit-limit",
+			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.Anything).
+					Return(nil, domain.ErrInsufficientLimit).
+					Once()
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "transaction exceeds the account's available credit limit")
+			},
+		},
+		{
+			name: "zero installments",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 2,
+				"amount":            10.0,
+				"installments":      0,
+			},
+			idempotencyKey: "test-key-installment-zero",
+			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "installments must be greater than or equal to 1")
+			},
+		},
+		{
+			name: "possible duplicate transaction",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 1,
+				"amount":            50.0,
+			},
+			idempotencyKey: "test-key-duplicate",
+			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.Anything).
+					Return(nil, &domain.DuplicateTransactionError{ExistingTransactionID: 42}).
+					Once()
+			},
+			expectedStatus: http.StatusConflict,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "possible duplicate transaction")
+				assert.Contains(t, w.Body.String(), `"existing_transaction_id":42`)
+			},
+		},
+		{
+			name: "force flag is passed to the processor",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 1,
+				"amount":            50.0,
+				"force":             true,
+			},
+			idempotencyKey: "test-key-force",
+			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.MatchedBy(func(req domain.CreateTransactionRequest) bool {
+					return req.Force
+				})).
+					Return(&domain.CreateTransactionResponse{TransactionID: 43, AccountID: 1, OperationTypeID: 1, Amount: -50.0}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusCreated,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), `"transaction_id":43`)
+			},
+		},
+		{
+			name: "database saturated",
+			requestBody: map[string]interface{}{
+				"account_id":        1,
+				"operation_type_id": 1,
+				"amount":            50.0,
+			},
+			idempotencyKey: "test-key-busy",
+			setupMock: func(mockProc *mocks.MockCreateTransactionProcessorInterface) {
+				mockProc.On("Process", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("failed to create transaction: %w", fmt.Errorf("%w: database is locked", domain.ErrServiceUnavailable))).
+					Once()
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Equal(t, "1", w.Header().Get("Retry-After"))
+				assert.Contains(t, w.Body.String(), "service temporarily unavailable")
 			},
 		},
 		{
@@ -288,3 +631,59 @@ func TestCreateTransactionHandler_Handle(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateTransactionHandler_OperationTypeIDMustBeInteger(t *testing.T) {
+	tests := []struct {
+		name           string
+		operationType  string
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:           "float",
+			operationType:  `1.5`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Invalid request body: operation_type_id must be an integer",
+		},
+		{
+			name:           "string",
+			operationType:  `"1"`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Invalid request body: operation_type_id must be an integer",
+		},
+		{
+			name:           "valid integer",
+			operationType:  `1`,
+			expectedStatus: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockCreateTransactionProcessorInterface(t)
+			if tt.expectedStatus == http.StatusCreated {
+				mockProc.EXPECT().
+					Process(mock.Anything, mock.MatchedBy(func(req domain.CreateTransactionRequest) bool {
+						return req.OperationTypeID == 1
+					})).
+					Return(&domain.CreateTransactionResponse{TransactionID: 1, OperationTypeID: 1}, nil).
+					Once()
+			}
+
+			body := fmt.Sprintf(`{"account_id": 1, "operation_type_id": %s, "amount": 50.0}`, tt.operationType)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Idempotency-Key", "operation-type-key")
+			w := httptest.NewRecorder()
+
+			NewCreateTransactionHandler(mockProc).Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedMsg != "" {
+				var resp ErrorResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, tt.expectedMsg, resp.Message)
+			}
+		})
+	}
+}
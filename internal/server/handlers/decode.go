@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+)
+
+// maxRequestBodyBytes caps the size of JSON request bodies (1MB)
+const maxRequestBodyBytes = 1 << 20
+
+// decodeError describes why a request body could not be decoded
+type decodeError struct {
+	status  int
+	message string
+}
+
+func (e *decodeError) Error() string {
+	return e.message
+}
+
+// errBodyRequired is reported for a request sent without any body
+var errBodyRequired = &decodeError{status: http.StatusBadRequest, message: "Request body is required"}
+
+// requireBody reports errBodyRequired when the request has no body at all, so a client that forgot it is
+// told so instead of that its body is invalid; the byte peeked to find out is kept for the decoder
+func requireBody(r *http.Request) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return errBodyRequired
+	}
+
+	body := bufio.NewReader(r.Body)
+	if _, err := body.Peek(1); errors.Is(err, io.EOF) {
+		return errBodyRequired
+	}
+	r.Body = peekedBody{Reader: body, Closer: r.Body}
+	return nil
+}
+
+// peekedBody reads a request body through the buffer that peeked at it
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// decodeJSON decodes a single JSON object from the request body into dst
+// It limits the body size, rejects unknown fields and trailing data, and
+// returns a *decodeError with a precise 4xx status and message on failure
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+	return decodeJSONValue(r.Body, dst)
+}
+
+// decodeJSONValue decodes the single JSON value read from src into dst, with the same rules as decodeJSON
+func decodeJSONValue(src io.Reader, dst interface{}) error {
+	body := bufio.NewReader(src)
+	if err := checkBodyShape(body, dst); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		return toDecodeError(err)
+	}
+
+	// The body must contain exactly one JSON value
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return toDecodeError(err)
+		}
+		return badRequestBody("body must contain a single JSON object")
+	}
+
+	return nil
+}
+
+// checkBodyShape peeks at the first non-whitespace byte of the body, so an array sent where dst expects
+// an object (or the reverse) is reported plainly instead of as an unmarshal type error
+// Leading whitespace is consumed; anything else, including read errors, is left to the decoder
+func checkBodyShape(body *bufio.Reader, dst interface{}) error {
+	want := jsonContainer(reflect.TypeOf(dst))
+	if want == "" {
+		return nil
+	}
+
+	for {
+		next, err := body.Peek(1)
+		if err != nil {
+			return nil
+		}
+
+		var got string
+		switch next[0] {
+		case ' ', '\t', '\r', '\n':
+			body.ReadByte()
+			continue
+		case '{':
+			got = "object"
+		case '[':
+			got = "array"
+		}
+
+		if got != "" && got != want {
+			return badRequestBody(fmt.Sprintf("expected a JSON %s, got an %s", want, got))
+		}
+		return nil
+	}
+}
+
+// jsonContainer returns "object" or "array" when t (or what it points to) decodes from one, "" otherwise
+func jsonContainer(t reflect.Type) string {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return ""
+	}
+}
+
+// toDecodeError maps json/http decoding errors to client-facing messages
+func toDecodeError(err error) *decodeError {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return badRequestBody("body must not be empty")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return badRequestBody("malformed JSON")
+	case errors.As(err, &syntaxErr):
+		return badRequestBody(fmt.Sprintf("malformed JSON at position %d", syntaxErr.Offset))
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return badRequestBody(fmt.Sprintf("body must be %s", jsonTypeName(typeErr.Type)))
+		}
+		return badRequestBody(fmt.Sprintf("%s must be %s", typeErr.Field, jsonTypeName(typeErr.Type)))
+	case errors.As(err, &maxBytesErr):
+		return &decodeError{
+			status:  http.StatusRequestEntityTooLarge,
+			message: fmt.Sprintf("Request body must not be larger than %d bytes", maxBytesErr.Limit),
+		}
+	default:
+		// DisallowUnknownFields reports unknown fields as a plain error: json: unknown field "x"
+		if field, ok := unknownField(err); ok {
+			return badRequestBody(fmt.Sprintf("unknown field %s", field))
+		}
+		return badRequestBody("could not be decoded")
+	}
+}
+
+func badRequestBody(detail string) *decodeError {
+	return &decodeError{
+		status:  http.StatusBadRequest,
+		message: "Invalid request body: " + detail,
+	}
+}
+
+// unknownField extracts the field name from encoding/json's unknown field error
+func unknownField(err error) (string, bool) {
+	const prefix = "json: unknown field "
+	msg := err.Error()
+	if len(msg) > len(prefix) && msg[:len(prefix)] == prefix {
+		return msg[len(prefix):], true
+	}
+	return "", false
+}
+
+// jsonTypeName describes a Go type using JSON vocabulary
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Struct, reflect.Map:
+		return "an object"
+	default:
+		return "a valid " + t.String()
+	}
+}
+
+// checkAmount is a cheap sanity check on a decoded amount so absurd values never reach the processor
+// It applies the same bounds as domain validation and fails like a malformed body
+func checkAmount(amount float64) error {
+	if err := domain.ValidateAmountMagnitude(amount); err != nil {
+		return badRequestBody(err.Error())
+	}
+	if err := domain.ValidateAmountPrecision(amount); err != nil {
+		return badRequestBody(err.Error())
+	}
+	return nil
+}
+
+// respondWithDecodeError sends the status and message carried by a decode error
+func respondWithDecodeError(w http.ResponseWriter, r *http.Request, err error) {
+	var decodeErr *decodeError
+	if errors.As(err, &decodeErr) {
+		respondWithError(w, r, decodeErr.status, decodeErr.message)
+		return
+	}
+	respondWithError(w, r, http.StatusBadRequest, "Invalid request body")
+}
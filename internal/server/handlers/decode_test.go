@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	type payload struct {
+		Name  string  `json:"name"`
+		Count int64   `json:"count"`
+		Value float64 `json:"value"`
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		wantErr        bool
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:    "valid body",
+			body:    `{"name":"test","count":2,"value":1.5}`,
+			wantErr: false,
+		},
+		{
+			name:           "empty body",
+			body:           "",
+			wantErr:        true,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Invalid request body: body must not be empty",
+		},
+		{
+			name:           "syntax error",
+			body:           `{"name": test}`,
+			wantErr:        true,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Invalid request body: malformed JSON at position 11",
+		},
+		{
+			name:           "truncated body",
+			body:           `{"name": "test"`,
+			wantErr:        true,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Invalid request body: malformed JSON",
+		},
+		{
+			name:           "wrong field type",
+			body:           `{"count": "two"}`,
+			wantErr:        true,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Invalid request body: count must be an integer",
+		},
+		{
+			name:           "unknown field",
+			body:           `{"name": "test", "extra": true}`,
+			wantErr:        true,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `Invalid request body: unknown field "extra"`,
+		},
+		{
+			name:           "trailing data",
+			body:           `{"name": "test"}{"name": "again"}`,
+			wantErr:        true,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Invalid request body: body must contain a single JSON object",
+		},
+		{
+			name:           "array where an object is expected",
+			body:           `[{"name": "test"}]`,
+			wantErr:        true,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Invalid request body: expected a JSON object, got an array",
+		},
+		{
+			name:           "array after leading whitespace",
+			body:           " \n\t[]",
+			wantErr:        true,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Invalid request body: expected a JSON object, got an array",
+		},
+		{
+			name:           "body too large",
+			body:           `{"name": "` + strings.Repeat("a", maxRequestBodyBytes) + `"}`,
+			wantErr:        true,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedMsg:    "Request body must not be larger than 1048576 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			var dst payload
+			err := decodeJSON(w, req, &dst)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, payload{Name: "test", Count: 2, Value: 1.5}, dst)
+				return
+			}
+
+			var decodeErr *decodeError
+			require.True(t, errors.As(err, &decodeErr), "Should return a decodeError")
+			assert.Equal(t, tt.expectedStatus, decodeErr.status)
+			assert.Equal(t, tt.expectedMsg, decodeErr.message)
+		})
+	}
+}
+
+func TestDecodeJSON_ObjectWhereArrayExpected(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`  {"name": "test"}`))
+	w := httptest.NewRecorder()
+
+	var dst []map[string]interface{}
+	err := decodeJSON(w, req, &dst)
+
+	var decodeErr *decodeError
+	require.True(t, errors.As(err, &decodeErr), "Should return a decodeError")
+	assert.Equal(t, http.StatusBadRequest, decodeErr.status)
+	assert.Equal(t, "Invalid request body: expected a JSON array, got an object", decodeErr.message)
+}
+
+func TestDecodeJSON_ArrayWhereArrayExpected(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(` [{"name": "test"}]`))
+	w := httptest.NewRecorder()
+
+	var dst []map[string]interface{}
+	require.NoError(t, decodeJSON(w, req, &dst))
+	assert.Equal(t, []map[string]interface{}{{"name": "test"}}, dst)
+}
+
+func TestRequireBody(t *testing.T) {
+	for name, req := range map[string]*http.Request{
+		"no body":    httptest.NewRequest(http.MethodPost, "/test", nil),
+		"empty body": httptest.NewRequest(http.MethodPost, "/test", strings.NewReader("")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, errBodyRequired, requireBody(req))
+		})
+	}
+
+	t.Run("the peeked body is still decoded whole", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`{"name":"test"}`))
+		require.NoError(t, requireBody(req))
+
+		var dst struct {
+			Name string `json:"name"`
+		}
+		require.NoError(t, decodeJSON(httptest.NewRecorder(), req, &dst))
+		assert.Equal(t, "test", dst.Name)
+	})
+}
+
+func TestRespondWithDecodeError(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/test", nil)
+	w := httptest.NewRecorder()
+
+	respondWithDecodeError(w, r, &decodeError{status: http.StatusRequestEntityTooLarge, message: "too large"})
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), "too large")
+}
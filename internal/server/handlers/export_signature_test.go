@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testExportSecret = "0123456789abcdef0123456789abcdef"
+
+func TestExportSigner_SignAndVerify(t *testing.T) {
+	signer := NewExportSigner(testExportSecret)
+	content := []byte("transaction_id,operation_type,amount,event_date\n1,Normal Purchase,-50.00,2025-03-04T10:30:00Z\n")
+
+	signature := signer.Sign(content)
+
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, signature)
+	assert.True(t, signer.Verify(content, signature))
+
+	tampered := []byte("transaction_id,operation_type,amount,event_date\n1,Normal Purchase,-5.00,2025-03-04T10:30:00Z\n")
+	assert.NotEqual(t, signature, signer.Sign(tampered), "The signature changes with the content")
+	assert.False(t, signer.Verify(tampered, signature))
+
+	assert.False(t, NewExportSigner("another-secret-another-secret-xx").Verify(content, signature))
+	assert.False(t, signer.Verify(content, signature[len("sha256="):]), "The algorithm prefix is required")
+	assert.False(t, signer.Verify(content, "sha256=not-hex"))
+
+	assert.Nil(t, NewExportSigner(""), "An empty secret disables signing")
+}
+
+// exportTransactions makes the mock processor emit the transactions, then fail with err when set
+func exportTransactions(mockProc *mocks.MockExportTransactionsProcessorInterface, transactions []*domain.Transaction, err error) {
+	mockProc.EXPECT().
+		Process(mock.Anything, domain.ExportTransactionsRequest{AccountID: 1}, mock.Anything).
+		RunAndReturn(func(ctx context.Context, req domain.ExportTransactionsRequest, emit func(*domain.Transaction) error) error {
+			for _, tx := range transactions {
+				if err := emit(tx); err != nil {
+					return err
+				}
+			}
+			return err
+		}).
+		Once()
+}
+
+func serveExport(handler http.Handler) *http.Response {
+	req := httptest.NewRequest(http.MethodGet, "/v1/accounts/1/transactions/export", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("accountId", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	return w.Result()
+}
+
+func TestExportHandlers_SignStreamedExports(t *testing.T) {
+	eventDate := time.Date(2025, 3, 4, 10, 30, 0, 0, time.UTC)
+	signer := NewExportSigner(testExportSecret)
+
+	handlers := map[string]func(*mocks.MockExportTransactionsProcessorInterface) http.Handler{
+		"csv": func(mockProc *mocks.MockExportTransactionsProcessorInterface) http.Handler {
+			return http.HandlerFunc(NewExportTransactionsCSVHandler(mockProc, listedOperationTypes(t), WithExportSigner(signer)).Handle)
+		},
+		"jsonl": func(mockProc *mocks.MockExportTransactionsProcessorInterface) http.Handler {
+			return http.HandlerFunc(NewExportTransactionsJSONLHandler(mockProc, WithExportSigner(signer)).Handle)
+		},
+	}
+
+	for name, newHandler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			export := func(amount float64) ([]byte, string) {
+				mockProc := mocks.NewMockExportTransactionsProcessorInterface(t)
+				exportTransactions(mockProc, []*domain.Transaction{
+					{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: amount, EventDate: eventDate},
+				}, nil)
+
+				resp := serveExport(newHandler(mockProc))
+				require.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, ExportSignatureHeader, resp.Header.Get("Trailer"))
+
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				return body, resp.Trailer.Get(ExportSignatureHeader)
+			}
+
+			body, signature := export(-50)
+			require.NotEmpty(t, signature)
+			assert.True(t, signer.Verify(body, signature), "The trailer signs the exported body")
+
+			otherBody, otherSignature := export(-5)
+			assert.NotEqual(t, body, otherBody)
+			assert.NotEqual(t, signature, otherSignature, "The signature changes with the content")
+			assert.True(t, signer.Verify(otherBody, otherSignature))
+		})
+	}
+}
+
+func TestExportHandlers_DoNotSignAbortedStreams(t *testing.T) {
+	eventDate := time.Date(2025, 3, 4, 10, 30, 0, 0, time.UTC)
+	mockProc := mocks.NewMockExportTransactionsProcessorInterface(t)
+	exportTransactions(mockProc, []*domain.Transaction{
+		{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50, EventDate: eventDate},
+	}, errors.New("database error"))
+
+	handler := NewExportTransactionsCSVHandler(mockProc, listedOperationTypes(t), WithExportSigner(NewExportSigner(testExportSecret)))
+	resp := serveExport(http.HandlerFunc(handler.Handle))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "The status was sent before the failure")
+	assert.Empty(t, resp.Trailer.Get(ExportSignatureHeader), "A truncated export is never signed")
+}
+
+func TestExportTransactionsOFXHandler_SignsDocument(t *testing.T) {
+	eventDate := time.Date(2025, 3, 4, 10, 30, 0, 0, time.UTC)
+	signer := NewExportSigner(testExportSecret)
+	mockProc := mocks.NewMockExportTransactionsProcessorInterface(t)
+	exportTransactions(mockProc, []*domain.Transaction{
+		{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50, EventDate: eventDate},
+	}, nil)
+
+	handler := NewExportTransactionsOFXHandler(mockProc, WithExportSigner(signer))
+	resp := serveExport(http.HandlerFunc(handler.Handle))
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.True(t, signer.Verify(body, resp.Header.Get(ExportSignatureHeader)))
+}
+
+func TestExportHandlers_UnsignedByDefault(t *testing.T) {
+	mockProc := mocks.NewMockExportTransactionsProcessorInterface(t)
+	exportTransactions(mockProc, nil, nil)
+
+	resp := serveExport(http.HandlerFunc(NewExportTransactionsJSONLHandler(mockProc).Handle))
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Trailer"))
+	assert.Empty(t, resp.Trailer.Get(ExportSignatureHeader))
+}
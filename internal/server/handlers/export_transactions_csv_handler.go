@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+// csvFlushEvery controls how many rows are written between flushes
+const csvFlushEvery = 100
+
+// csvHeader names the columns of the CSV export
+var csvHeader = []string{"transaction_id", "operation_type", "amount", "event_date"}
+
+type ExportTransactionsCSVHandler struct {
+	processor      processors.ExportTransactionsProcessorInterface
+	operationTypes processors.ListOperationTypesProcessorInterface
+	signer         *ExportSigner
+}
+
+// NewExportTransactionsCSVHandler creates the CSV export handler; operationTypes describes the operation type of each row
+func NewExportTransactionsCSVHandler(processor processors.ExportTransactionsProcessorInterface, operationTypes processors.ListOperationTypesProcessorInterface, opts ...ExportHandlerOption) *ExportTransactionsCSVHandler {
+	return &ExportTransactionsCSVHandler{
+		processor:      processor,
+		operationTypes: operationTypes,
+		signer:         newExportHandlerOptions(opts).signer,
+	}
+}
+
+// Handle streams the account's transactions as a CSV attachment, one row per transaction
+// format is optional and csv is the only supported value
+func (h *ExportTransactionsCSVHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	accountID, err := parseAccountIDParam(r)
+	if err != nil || accountID <= 0 {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid account ID")
+		return
+	}
+
+	if format := r.URL.Query().Get("format"); format != "" && format != "csv" {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid format: only csv is supported")
+		return
+	}
+
+	// Rows are labeled with the stored descriptions, so types added after the seed are described too
+	listed, err := h.operationTypes.Process(r.Context())
+	if err != nil {
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to export transactions")
+		return
+	}
+	descriptions := domain.OperationTypeDescriptions(listed.OperationTypes)
+
+	req := domain.ExportTransactionsRequest{
+		AccountID: accountID,
+	}
+
+	flusher, _ := w.(http.Flusher)
+	stream := h.signer.stream(w)
+	writer := csv.NewWriter(stream.Writer())
+	started := false
+	rows := 0
+
+	// Headers are written lazily so errors raised before the first row can still be reported
+	startStream := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="account-%d-transactions.csv"`, accountID))
+		stream.DeclareTrailer()
+		w.WriteHeader(http.StatusOK)
+		return writer.Write(csvHeader)
+	}
+
+	err = h.processor.Process(r.Context(), req, func(transaction *domain.Transaction) error {
+		if err := startStream(); err != nil {
+			return err
+		}
+
+		if err := writer.Write([]string{
+			strconv.FormatInt(transaction.ID, 10),
+			operationTypeLabel(descriptions, transaction.OperationTypeID),
+			strconv.FormatFloat(transaction.Amount, 'f', 2, 64),
+			transaction.EventDate.UTC().Format(time.RFC3339),
+		}); err != nil {
+			return err
+		}
+
+		rows++
+		if rows%csvFlushEvery == 0 {
+			writer.Flush()
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		return writer.Error()
+	})
+
+	if err != nil {
+		if started {
+			// Status already sent; the truncated stream is the only signal left to the client
+			log.Printf("csv export for account %d aborted after %d rows: %v", accountID, rows, err)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			respondWithError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to export transactions")
+		return
+	}
+
+	if err := startStream(); err != nil {
+		log.Printf("csv export for account %d failed to write the header: %v", accountID, err)
+		return
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.Printf("csv export for account %d failed to flush: %v", accountID, err)
+		return
+	}
+	stream.Finish()
+	if flusher != nil {
+		flusher.Flush()
+	}
+}
+
+// operationTypeLabel returns the description of an operation type, or its ID when it has none
+func operationTypeLabel(descriptions map[int64]string, id int64) string {
+	if description, ok := descriptions[id]; ok {
+		return description
+	}
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// listedOperationTypes serves the operation types as stored, including one added by a migration after the seed
+func listedOperationTypes(t *testing.T) *mocks.MockListOperationTypesProcessorInterface {
+	lister := mocks.NewMockListOperationTypesProcessorInterface(t)
+	lister.EXPECT().
+		Process(mock.Anything).
+		Return(&domain.ListOperationTypesResponse{OperationTypes: []*domain.OperationType{
+			{ID: domain.OperationTypePurchase, Description: "Normal Purchase"},
+			{ID: domain.OperationTypeCreditVoucher, Description: "Credit Voucher", IsCredit: true},
+			{ID: 5, Description: "Cashback", IsCredit: true},
+		}}, nil).
+		Maybe()
+	return lister
+}
+
+func TestExportTransactionsCSVHandler_Handle(t *testing.T) {
+	eventDate := time.Date(2025, 3, 4, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		accountID      string
+		query          string
+		setupMock      func(*mocks.MockExportTransactionsProcessorInterface)
+		expectedStatus int
+		validateResp   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "streams a header row and one row per transaction",
+			accountID: "1",
+			query:     "?format=csv",
+			setupMock: func(mockProc *mocks.MockExportTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ExportTransactionsRequest{AccountID: 1}, mock.Anything).
+					RunAndReturn(func(ctx context.Context, req domain.ExportTransactionsRequest, emit func(*domain.Transaction) error) error {
+						transactions := []*domain.Transaction{
+							{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50.5, EventDate: eventDate},
+							{ID: 2, AccountID: 1, OperationTypeID: domain.OperationTypeCreditVoucher, Amount: 100.0, EventDate: eventDate},
+							{ID: 3, AccountID: 1, OperationTypeID: 5, Amount: 7.5, EventDate: eventDate},
+							{ID: 4, AccountID: 1, OperationTypeID: 9, Amount: -1.0, EventDate: eventDate},
+						}
+						for _, tx := range transactions {
+							if err := emit(tx); err != nil {
+								return err
+							}
+						}
+						return nil
+					}).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+				assert.Equal(t, `attachment; filename="account-1-transactions.csv"`, w.Header().Get("Content-Disposition"))
+
+				records, err := csv.NewReader(w.Body).ReadAll()
+				require.NoError(t, err)
+				assert.Equal(t, [][]string{
+					{"transaction_id", "operation_type", "amount", "event_date"},
+					{"1", "Normal Purchase", "-50.50", "2025-03-04T10:30:00Z"},
+					{"2", "Credit Voucher", "100.00", "2025-03-04T10:30:00Z"},
+					{"3", "Cashback", "7.50", "2025-03-04T10:30:00Z"},
+					{"4", "9", "-1.00", "2025-03-04T10:30:00Z"},
+				}, records, "Types are described from the stored rows; an unknown one by its ID")
+			},
+		},
+		{
+			name:      "header row only for account without transactions",
+			accountID: "1",
+			setupMock: func(mockProc *mocks.MockExportTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ExportTransactionsRequest{AccountID: 1}, mock.Anything).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+				assert.Equal(t, "transaction_id,operation_type,amount,event_date\n", w.Body.String())
+			},
+		},
+		{
+			name:           "unsupported format",
+			accountID:      "1",
+			query:          "?format=xlsx",
+			setupMock:      func(mockProc *mocks.MockExportTransactionsProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid account ID",
+			accountID:      "abc",
+			setupMock:      func(mockProc *mocks.MockExportTransactionsProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "account not found",
+			accountID: "999",
+			setupMock: func(mockProc *mocks.MockExportTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ExportTransactionsRequest{AccountID: 999}, mock.Anything).
+					Return(errors.New("account with id 999 not found")).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "not found")
+			},
+		},
+		{
+			name:      "processor error",
+			accountID: "1",
+			setupMock: func(mockProc *mocks.MockExportTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ExportTransactionsRequest{AccountID: 1}, mock.Anything).
+					Return(errors.New("db down")).
+					Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockExportTransactionsProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewExportTransactionsCSVHandler(mockProc, listedOperationTypes(t))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/"+tt.accountID+"/transactions/export"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", tt.accountID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateResp != nil {
+				tt.validateResp(t, w)
+			}
+		})
+	}
+}
+
+func TestExportTransactionsCSVHandler_OperationTypesError(t *testing.T) {
+	mockProc := mocks.NewMockExportTransactionsProcessorInterface(t)
+	lister := mocks.NewMockListOperationTypesProcessorInterface(t)
+	lister.EXPECT().Process(mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	handler := NewExportTransactionsCSVHandler(mockProc, lister)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/accounts/1/transactions/export", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("accountId", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.Handle(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "Failed to export transactions")
+}
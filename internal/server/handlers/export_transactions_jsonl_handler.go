@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+// jsonlFlushEvery controls how many lines are written between flushes
+const jsonlFlushEvery = 100
+
+type ExportTransactionsJSONLHandler struct {
+	processor processors.ExportTransactionsProcessorInterface
+	signer    *ExportSigner
+}
+
+func NewExportTransactionsJSONLHandler(processor processors.ExportTransactionsProcessorInterface, opts ...ExportHandlerOption) *ExportTransactionsJSONLHandler {
+	return &ExportTransactionsJSONLHandler{
+		processor: processor,
+		signer:    newExportHandlerOptions(opts).signer,
+	}
+}
+
+// Handle streams the account's transactions as JSON Lines, one object per line
+func (h *ExportTransactionsJSONLHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	accountID, err := parseAccountIDParam(r)
+	if err != nil || accountID <= 0 {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid account ID")
+		return
+	}
+
+	req := domain.ExportTransactionsRequest{
+		AccountID: accountID,
+	}
+
+	flusher, _ := w.(http.Flusher)
+	stream := h.signer.stream(w)
+	encoder := json.NewEncoder(stream.Writer())
+	started := false
+	lines := 0
+
+	// Headers are written lazily so errors raised before the first row can still be reported
+	startStream := func() {
+		if started {
+			return
+		}
+		started = true
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		stream.DeclareTrailer()
+		w.WriteHeader(http.StatusOK)
+	}
+
+	err = h.processor.Process(r.Context(), req, func(transaction *domain.Transaction) error {
+		startStream()
+
+		if err := encoder.Encode(transaction); err != nil {
+			return err
+		}
+
+		lines++
+		if flusher != nil && lines%jsonlFlushEvery == 0 {
+			flusher.Flush()
+		}
+		return nil
+	})
+
+	if err != nil {
+		if started {
+			// Status already sent; the truncated stream is the only signal left to the client
+			log.Printf("jsonl export for account %d aborted after %d lines: %v", accountID, lines, err)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			respondWithError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to export transactions")
+		return
+	}
+
+	startStream()
+	stream.Finish()
+	if flusher != nil {
+		flusher.Flush()
+	}
+}
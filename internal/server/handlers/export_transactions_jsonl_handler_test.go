@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportTransactionsJSONLHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name           string
+		accountID      string
+		setupMock      func(*mocks.MockExportTransactionsProcessorInterface)
+		expectedStatus int
+		validateResp   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "streams one JSON object per line",
+			accountID: "1",
+			setupMock: func(mockProc *mocks.MockExportTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ExportTransactionsRequest{AccountID: 1}, mock.Anything).
+					RunAndReturn(func(ctx context.Context, req domain.ExportTransactionsRequest, emit func(*domain.Transaction) error) error {
+						transactions := []*domain.Transaction{
+							{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50.0, EventDate: time.Now()},
+							{ID: 2, AccountID: 1, OperationTypeID: domain.OperationTypeCreditVoucher, Amount: 100.0, EventDate: time.Now()},
+						}
+						for _, tx := range transactions {
+							if err := emit(tx); err != nil {
+								return err
+							}
+						}
+						return nil
+					}).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+
+				var parsed []domain.Transaction
+				scanner := bufio.NewScanner(w.Body)
+				for scanner.Scan() {
+					var tx domain.Transaction
+					require.NoError(t, json.Unmarshal(scanner.Bytes(), &tx))
+					parsed = append(parsed, tx)
+				}
+
+				require.Len(t, parsed, 2)
+				assert.Equal(t, int64(1), parsed[0].ID)
+				assert.Equal(t, -50.0, parsed[0].Amount)
+				assert.Equal(t, int64(2), parsed[1].ID)
+				assert.Equal(t, 100.0, parsed[1].Amount)
+			},
+		},
+		{
+			name:      "empty stream for account without transactions",
+			accountID: "1",
+			setupMock: func(mockProc *mocks.MockExportTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ExportTransactionsRequest{AccountID: 1}, mock.Anything).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+				assert.Empty(t, w.Body.String())
+			},
+		},
+		{
+			name:           "invalid account ID",
+			accountID:      "abc",
+			setupMock:      func(mockProc *mocks.MockExportTransactionsProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "account not found",
+			accountID: "999",
+			setupMock: func(mockProc *mocks.MockExportTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ExportTransactionsRequest{AccountID: 999}, mock.Anything).
+					Return(errors.New("account with id 999 not found")).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "not found")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockExportTransactionsProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewExportTransactionsJSONLHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/"+tt.accountID+"/transactions.jsonl", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", tt.accountID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateResp != nil {
+				tt.validateResp(t, w)
+			}
+		})
+	}
+}
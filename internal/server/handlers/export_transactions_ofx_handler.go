@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+const (
+	// ofxCurrency is the currency of every account (document numbers are CPF/CNPJ)
+	ofxCurrency = domain.CurrencyBRL
+
+	// ofxDateLayout is the OFX datetime format, always written in GMT
+	ofxDateLayout = "20060102150405.000"
+)
+
+// ofxHeader is the OFX 1.x (SGML) header expected by personal finance tools
+const ofxHeader = "OFXHEADER:100\r\n" +
+	"DATA:OFXSGML\r\n" +
+	"VERSION:102\r\n" +
+	"SECURITY:NONE\r\n" +
+	"ENCODING:USASCII\r\n" +
+	"CHARSET:1252\r\n" +
+	"COMPRESSION:NONE\r\n" +
+	"OLDFILEUID:NONE\r\n" +
+	"NEWFILEUID:NONE\r\n" +
+	"\r\n"
+
+type ExportTransactionsOFXHandler struct {
+	processor processors.ExportTransactionsProcessorInterface
+	signer    *ExportSigner
+}
+
+func NewExportTransactionsOFXHandler(processor processors.ExportTransactionsProcessorInterface, opts ...ExportHandlerOption) *ExportTransactionsOFXHandler {
+	return &ExportTransactionsOFXHandler{
+		processor: processor,
+		signer:    newExportHandlerOptions(opts).signer,
+	}
+}
+
+// Handle renders the account's transactions as an OFX 1.x credit card statement
+// The ledger balance and the statement period follow the transaction list, so the document is built in memory
+func (h *ExportTransactionsOFXHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	accountID, err := parseAccountIDParam(r)
+	if err != nil || accountID <= 0 {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid account ID")
+		return
+	}
+
+	req := domain.ExportTransactionsRequest{
+		AccountID: accountID,
+	}
+
+	var transactions []*domain.Transaction
+	err = h.processor.Process(r.Context(), req, func(transaction *domain.Transaction) error {
+		transactions = append(transactions, transaction)
+		return nil
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			respondWithError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to export transactions")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ofx")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="account-%d.ofx"`, accountID))
+
+	// The document is already built, so its signature goes in a header rather than a trailer
+	document := renderOFX(accountID, transactions, time.Now().UTC())
+	if h.signer != nil {
+		w.Header().Set(ExportSignatureHeader, h.signer.Sign(document))
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(document)
+}
+
+// renderOFX builds the OFX document; every amount keeps the sign stored in the ledger
+func renderOFX(accountID int64, transactions []*domain.Transaction, now time.Time) []byte {
+	start, end := now, now
+	balance := 0.0
+	for i, transaction := range transactions {
+		if i == 0 || transaction.EventDate.Before(start) {
+			start = transaction.EventDate
+		}
+		if i == 0 || transaction.EventDate.After(end) {
+			end = transaction.EventDate
+		}
+		balance += transaction.Amount
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(ofxHeader)
+	buf.WriteString("<OFX>\r\n")
+
+	buf.WriteString("<SIGNONMSGSRSV1>\r\n<SONRS>\r\n")
+	buf.WriteString("<STATUS>\r\n<CODE>0\r\n<SEVERITY>INFO\r\n</STATUS>\r\n")
+	fmt.Fprintf(&buf, "<DTSERVER>%s\r\n", formatOFXDate(now))
+	buf.WriteString("<LANGUAGE>ENG\r\n")
+	buf.WriteString("</SONRS>\r\n</SIGNONMSGSRSV1>\r\n")
+
+	buf.WriteString("<CREDITCARDMSGSRSV1>\r\n<CCSTMTTRNRS>\r\n")
+	buf.WriteString("<TRNUID>0\r\n")
+	buf.WriteString("<STATUS>\r\n<CODE>0\r\n<SEVERITY>INFO\r\n</STATUS>\r\n")
+	buf.WriteString("<CCSTMTRS>\r\n")
+	fmt.Fprintf(&buf, "<CURDEF>%s\r\n", ofxCurrency)
+	fmt.Fprintf(&buf, "<CCACCTFROM>\r\n<ACCTID>%d\r\n</CCACCTFROM>\r\n", accountID)
+
+	buf.WriteString("<BANKTRANLIST>\r\n")
+	fmt.Fprintf(&buf, "<DTSTART>%s\r\n", formatOFXDate(start))
+	fmt.Fprintf(&buf, "<DTEND>%s\r\n", formatOFXDate(end))
+	for _, transaction := range transactions {
+		buf.WriteString("<STMTTRN>\r\n")
+		fmt.Fprintf(&buf, "<TRNTYPE>%s\r\n", ofxTransactionType(transaction))
+		fmt.Fprintf(&buf, "<DTPOSTED>%s\r\n", formatOFXDate(transaction.EventDate))
+		fmt.Fprintf(&buf, "<TRNAMT>%s\r\n", formatOFXAmount(transaction.Amount))
+		fmt.Fprintf(&buf, "<FITID>%d\r\n", transaction.ID)
+		fmt.Fprintf(&buf, "<MEMO>Operation type %d\r\n", transaction.OperationTypeID)
+		buf.WriteString("</STMTTRN>\r\n")
+	}
+	buf.WriteString("</BANKTRANLIST>\r\n")
+
+	buf.WriteString("<LEDGERBAL>\r\n")
+	fmt.Fprintf(&buf, "<BALAMT>%s\r\n", formatOFXAmount(balance))
+	fmt.Fprintf(&buf, "<DTASOF>%s\r\n", formatOFXDate(now))
+	buf.WriteString("</LEDGERBAL>\r\n")
+
+	buf.WriteString("</CCSTMTRS>\r\n</CCSTMTTRNRS>\r\n</CREDITCARDMSGSRSV1>\r\n")
+	buf.WriteString("</OFX>\r\n")
+
+	return buf.Bytes()
+}
+
+// ofxTransactionType maps the ledger direction to the OFX TRNTYPE
+func ofxTransactionType(transaction *domain.Transaction) string {
+	if transaction.Amount < 0 {
+		return "DEBIT"
+	}
+	return "CREDIT"
+}
+
+func formatOFXDate(t time.Time) string {
+	return t.UTC().Format(ofxDateLayout) + "[0:GMT]"
+}
+
+func formatOFXAmount(amount float64) string {
+	return strconv.FormatFloat(domain.RoundToCents(amount), 'f', 2, 64)
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportTransactionsOFXHandler_Handle(t *testing.T) {
+	purchaseDate := time.Date(2024, 3, 10, 12, 30, 0, 0, time.UTC)
+	paymentDate := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		accountID      string
+		setupMock      func(*mocks.MockExportTransactionsProcessorInterface)
+		expectedStatus int
+		validateResp   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "renders account, transactions and balance",
+			accountID: "1",
+			setupMock: func(mockProc *mocks.MockExportTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ExportTransactionsRequest{AccountID: 1}, mock.Anything).
+					RunAndReturn(func(ctx context.Context, req domain.ExportTransactionsRequest, emit func(*domain.Transaction) error) error {
+						transactions := []*domain.Transaction{
+							{ID: 7, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50.5, EventDate: purchaseDate},
+							{ID: 8, AccountID: 1, OperationTypeID: domain.OperationTypeCreditVoucher, Amount: 100.0, EventDate: paymentDate},
+						}
+						for _, tx := range transactions {
+							if err := emit(tx); err != nil {
+								return err
+							}
+						}
+						return nil
+					}).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Equal(t, "application/x-ofx", w.Header().Get("Content-Type"))
+
+				body := w.Body.String()
+				assert.True(t, strings.HasPrefix(body, "OFXHEADER:100\r\n"))
+				assert.Contains(t, body, "<CCACCTFROM>\r\n<ACCTID>1\r\n</CCACCTFROM>")
+				assert.Contains(t, body, "<DTSTART>20240310123000.000[0:GMT]")
+				assert.Contains(t, body, "<DTEND>20240315090000.000[0:GMT]")
+				assert.Equal(t, 2, strings.Count(body, "<STMTTRN>"))
+				assert.Contains(t, body, "<STMTTRN>\r\n<TRNTYPE>DEBIT\r\n<DTPOSTED>20240310123000.000[0:GMT]\r\n<TRNAMT>-50.50\r\n<FITID>7\r\n")
+				assert.Contains(t, body, "<STMTTRN>\r\n<TRNTYPE>CREDIT\r\n<DTPOSTED>20240315090000.000[0:GMT]\r\n<TRNAMT>100.00\r\n<FITID>8\r\n")
+				assert.Contains(t, body, "<LEDGERBAL>\r\n<BALAMT>49.50\r\n")
+				assert.True(t, strings.HasSuffix(body, "</OFX>\r\n"))
+			},
+		},
+		{
+			name:      "account without transactions has an empty list",
+			accountID: "1",
+			setupMock: func(mockProc *mocks.MockExportTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ExportTransactionsRequest{AccountID: 1}, mock.Anything).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				body := w.Body.String()
+				assert.NotContains(t, body, "<STMTTRN>")
+				assert.Contains(t, body, "<BANKTRANLIST>")
+				assert.Contains(t, body, "<BALAMT>0.00")
+			},
+		},
+		{
+			name:           "invalid account ID",
+			accountID:      "abc",
+			setupMock:      func(mockProc *mocks.MockExportTransactionsProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "account not found",
+			accountID: "999",
+			setupMock: func(mockProc *mocks.MockExportTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ExportTransactionsRequest{AccountID: 999}, mock.Anything).
+					Return(errors.New("account with id 999 not found")).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "not found")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockExportTransactionsProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewExportTransactionsOFXHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/"+tt.accountID+"/transactions.ofx", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", tt.accountID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateResp != nil {
+				tt.validateResp(t, w)
+			}
+		})
+	}
+}
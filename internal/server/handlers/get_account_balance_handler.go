@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type GetAccountBalanceHandler struct {
+	processor processors.GetAccountBalanceProcessorInterface
+}
+
+func NewGetAccountBalanceHandler(processor processors.GetAccountBalanceProcessorInterface) *GetAccountBalanceHandler {
+	return &GetAccountBalanceHandler{
+		processor: processor,
+	}
+}
+
+// Handle returns the account with its current balance, credit limit and available balance
+// include=direction adds net_debit and net_credit; as_of returns the balance at that time instead (a plain date means its end)
+func (h *GetAccountBalanceHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	accountID, err := parseAccountIDParam(r)
+	if err != nil || accountID <= 0 {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid account ID")
+		return
+	}
+
+	req := domain.GetAccountBalanceRequest{AccountID: accountID}
+
+	switch include := r.URL.Query().Get("include"); include {
+	case "":
+	case "direction":
+		req.IncludeDirection = true
+	default:
+		respondWithError(w, r, http.StatusBadRequest, "Invalid include: only \"direction\" is supported")
+		return
+	}
+
+	asOf, ok := parseOptionalTime(r.URL.Query().Get("as_of"), true)
+	if !ok {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid as_of: use RFC 3339 or YYYY-MM-DD")
+		return
+	}
+	if asOf != nil && req.IncludeDirection {
+		respondWithError(w, r, http.StatusBadRequest, "include=direction is not supported with as_of")
+		return
+	}
+	req.AsOf = asOf
+
+	response, err := h.processor.Process(r.Context(), req)
+	if err != nil {
+		if contains(err.Error(), "not found") {
+			respondWithError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to get account balance")
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusOK, response)
+}
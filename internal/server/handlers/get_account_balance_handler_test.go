@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAccountBalanceHandler_Handle(t *testing.T) {
+	createdAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		accountID      string
+		query          string
+		setupMock      func(*mocks.MockGetAccountBalanceProcessorInterface)
+		expectedStatus int
+		validateResp   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "returns the account with its balances",
+			accountID: "1",
+			setupMock: func(mockProc *mocks.MockGetAccountBalanceProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetAccountBalanceRequest{AccountID: 1}).
+					Return(&domain.GetAccountBalanceResponse{
+						Account:          &domain.Account{ID: 1, DocumentNumber: "12345678900", Tier: "standard", CreditLimit: 500, CreatedAt: createdAt},
+						CurrentBalance:   -150.25,
+						AvailableBalance: 349.75,
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{
+					"account_id": 1,
+					"document_number": "12345678900",
+					"tier": "standard",
+					"credit_limit": 500,
+					"created_at": "2024-01-15T10:00:00Z",
+					"current_balance": -150.25,
+					"available_balance": 349.75
+				}`, w.Body.String())
+			},
+		},
+		{
+			name:      "includes the direction breakdown",
+			accountID: "1",
+			query:     "?include=direction",
+			setupMock: func(mockProc *mocks.MockGetAccountBalanceProcessorInterface) {
+				netDebit, netCredit := -350.25, 200.0
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetAccountBalanceRequest{AccountID: 1, IncludeDirection: true}).
+					Return(&domain.GetAccountBalanceResponse{
+						Account:          &domain.Account{ID: 1, CreditLimit: 500},
+						CurrentBalance:   -150.25,
+						AvailableBalance: 349.75,
+						NetDebit:         &netDebit,
+						NetCredit:        &netCredit,
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), `"net_debit":-350.25`)
+				assert.Contains(t, w.Body.String(), `"net_credit":200`)
+			},
+		},
+		{
+			name:           "unsupported include",
+			accountID:      "1",
+			query:          "?include=everything",
+			setupMock:      func(mockProc *mocks.MockGetAccountBalanceProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "balance as of a timestamp",
+			accountID: "1",
+			query:     "?as_of=2024-01-01T00:00:00Z",
+			setupMock: func(mockProc *mocks.MockGetAccountBalanceProcessorInterface) {
+				asOf := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetAccountBalanceRequest{AccountID: 1, AsOf: &asOf}).
+					Return(&domain.GetAccountBalanceResponse{
+						Account:          &domain.Account{ID: 1, CreditLimit: 500},
+						CurrentBalance:   80,
+						AvailableBalance: 580,
+						AsOf:             &asOf,
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), `"current_balance":80`)
+				assert.Contains(t, w.Body.String(), `"as_of":"2024-01-01T00:00:00Z"`)
+			},
+		},
+		{
+			name:      "balance as of a date covers the whole day",
+			accountID: "1",
+			query:     "?as_of=2024-01-01",
+			setupMock: func(mockProc *mocks.MockGetAccountBalanceProcessorInterface) {
+				asOf := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetAccountBalanceRequest{AccountID: 1, AsOf: &asOf}).
+					Return(&domain.GetAccountBalanceResponse{Account: &domain.Account{ID: 1}, AsOf: &asOf}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid as_of",
+			accountID:      "1",
+			query:          "?as_of=01/01/2024",
+			setupMock:      func(mockProc *mocks.MockGetAccountBalanceProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Invalid as_of")
+			},
+		},
+		{
+			name:           "as_of with the direction breakdown",
+			accountID:      "1",
+			query:          "?as_of=2024-01-01&include=direction",
+			setupMock:      func(mockProc *mocks.MockGetAccountBalanceProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "balance as of a date for a missing account",
+			accountID: "999",
+			query:     "?as_of=2024-01-01T00:00:00Z",
+			setupMock: func(mockProc *mocks.MockGetAccountBalanceProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, mock.Anything).
+					Return(nil, errors.New("account with id 999 not found")).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid account ID",
+			accountID:      "abc",
+			setupMock:      func(mockProc *mocks.MockGetAccountBalanceProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "account not found",
+			accountID: "999",
+			setupMock: func(mockProc *mocks.MockGetAccountBalanceProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetAccountBalanceRequest{AccountID: 999}).
+					Return(nil, errors.New("account with id 999 not found")).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:      "internal server error",
+			accountID: "1",
+			setupMock: func(mockProc *mocks.MockGetAccountBalanceProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetAccountBalanceRequest{AccountID: 1}).
+					Return(nil, errors.New("database error")).
+					Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Failed to get account balance")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockGetAccountBalanceProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewGetAccountBalanceHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/"+tt.accountID+"/balance"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", tt.accountID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateResp != nil {
+				tt.validateResp(t, w)
+			}
+		})
+	}
+}
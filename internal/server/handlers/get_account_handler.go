@@ -2,9 +2,7 @@ package handlers
 
 import (
 	"net/http"
-	"strconv"
 
-	"github.com/go-chi/chi/v5"
 	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
 	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
 )
@@ -21,34 +19,33 @@ func NewGetAccountHandler(processor processors.GetAccountProcessorInterface) *Ge
 
 func (h *GetAccountHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	// Extract account ID from URL parameter
-	accountIDStr := chi.URLParam(r, "accountId")
-	accountID, err := strconv.Atoi(accountIDStr)
+	accountID, err := parseAccountIDParam(r)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid account ID")
+		respondWithError(w, r, http.StatusBadRequest, "Invalid account ID")
 		return
 	}
 
 	// Validate request
 	req := domain.GetAccountRequest{
-		AccountID: int64(accountID),
+		AccountID: accountID,
 	}
 	if err := h.validateRequest(req); err != nil {
-		respondWithError(w, http.StatusBadRequest, err.Error())
+		respondWithError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	response, err := h.processor.Process(r.Context(), req)
 	if err != nil {
 		if err.Error() == "account not found" {
-			respondWithError(w, http.StatusNotFound, err.Error())
+			respondWithError(w, r, http.StatusNotFound, err.Error())
 			return
 		}
-		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve account")
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to retrieve account")
 		return
 	}
 
 	// Return 200 OK with the account
-	respondWithJSON(w, http.StatusOK, response.Account)
+	respondWithJSON(w, r, http.StatusOK, response.Account)
 }
 
 func (h *GetAccountHandler) validateRequest(req domain.GetAccountRequest) error {
@@ -47,6 +47,32 @@ func TestGetAccountHandler_Handle(t *testing.T) {
 				assert.Equal(t, "12345678900", result.DocumentNumber)
 			},
 		},
+		{
+			name:      "account ID larger than int32 max",
+			accountID: "3000000000",
+			setupMock: func(mockProc *mocks.MockGetAccountProcessorInterface) {
+				mockProc.On("Process", mock.Anything, domain.GetAccountRequest{
+					AccountID: 3000000000,
+				}).Return(&domain.GetAccountResponse{
+					Account: &domain.Account{ID: 3000000000, DocumentNumber: "12345678900"},
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var result domain.Account
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+				assert.Equal(t, int64(3000000000), result.ID)
+			},
+		},
+		{
+			name:           "account ID larger than int64 max",
+			accountID:      "9223372036854775808",
+			setupMock:      func(mockProc *mocks.MockGetAccountProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Invalid account ID")
+			},
+		},
 		{
 			name:      "account not found",
 			accountID: "999",
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type GetActivityRangeHandler struct {
+	processor processors.GetActivityRangeProcessorInterface
+}
+
+func NewGetActivityRangeHandler(processor processors.GetActivityRangeProcessorInterface) *GetActivityRangeHandler {
+	return &GetActivityRangeHandler{
+		processor: processor,
+	}
+}
+
+// Handle returns the event dates of the account's first and last transactions and their count
+// Both dates are null for an account without transactions
+func (h *GetActivityRangeHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	accountID, err := parseAccountIDParam(r)
+	if err != nil || accountID <= 0 {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid account ID")
+		return
+	}
+
+	response, err := h.processor.Process(r.Context(), domain.GetActivityRangeRequest{AccountID: accountID})
+	if err != nil {
+		if contains(err.Error(), "not found") {
+			respondWithError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to get activity range")
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusOK, response)
+}
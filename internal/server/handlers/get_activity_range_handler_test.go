@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetActivityRangeHandler_Handle(t *testing.T) {
+	first := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	last := time.Date(2025, 2, 1, 18, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		accountID      string
+		setupMock      func(*mocks.MockGetActivityRangeProcessorInterface)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "active account",
+			accountID: "1",
+			setupMock: func(mockProc *mocks.MockGetActivityRangeProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetActivityRangeRequest{AccountID: 1}).
+					Return(&domain.ActivityRange{FirstTransactionAt: &first, LastTransactionAt: &last, TotalCount: 3}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"first_transaction_at":"2025-01-01T09:00:00Z","last_transaction_at":"2025-02-01T18:30:00Z","total_count":3}`,
+		},
+		{
+			name:      "account without transactions",
+			accountID: "2",
+			setupMock: func(mockProc *mocks.MockGetActivityRangeProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetActivityRangeRequest{AccountID: 2}).
+					Return(&domain.ActivityRange{}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"first_transaction_at":null,"last_transaction_at":null,"total_count":0}`,
+		},
+		{
+			name:           "invalid account ID",
+			accountID:      "abc",
+			setupMock:      func(mockProc *mocks.MockGetActivityRangeProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "account not found",
+			accountID: "999",
+			setupMock: func(mockProc *mocks.MockGetActivityRangeProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetActivityRangeRequest{AccountID: 999}).
+					Return(nil, errors.New("account with id 999 not found")).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:      "processor error",
+			accountID: "1",
+			setupMock: func(mockProc *mocks.MockGetActivityRangeProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, mock.Anything).
+					Return(nil, errors.New("database error")).
+					Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockGetActivityRangeProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewGetActivityRangeHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/"+tt.accountID+"/activity-range", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", tt.accountID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type GetBalanceTrendHandler struct {
+	processor processors.GetBalanceTrendProcessorInterface
+}
+
+func NewGetBalanceTrendHandler(processor processors.GetBalanceTrendProcessorInterface) *GetBalanceTrendHandler {
+	return &GetBalanceTrendHandler{
+		processor: processor,
+	}
+}
+
+// Handle returns the account's end-of-day balance for each of the last days (today included, in UTC)
+// days defaults to 30 and is capped at 365
+func (h *GetBalanceTrendHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	accountID, err := parseAccountIDParam(r)
+	if err != nil || accountID <= 0 {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid account ID")
+		return
+	}
+
+	days, ok := parseIntQueryParam(r, "days", domain.DefaultTrendDays, 1)
+	if !ok {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid days")
+		return
+	}
+
+	response, err := h.processor.Process(r.Context(), domain.GetBalanceTrendRequest{
+		AccountID: accountID,
+		Days:      days,
+		AsOf:      time.Now().UTC(),
+	})
+	if err != nil {
+		if contains(err.Error(), "not found") {
+			respondWithError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to get balance trend")
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusOK, response)
+}
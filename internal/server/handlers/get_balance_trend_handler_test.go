@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetBalanceTrendHandler_Handle(t *testing.T) {
+	requestFor := func(accountID, days int64) interface{} {
+		return mock.MatchedBy(func(req domain.GetBalanceTrendRequest) bool {
+			return req.AccountID == accountID && req.Days == days && !req.AsOf.IsZero()
+		})
+	}
+
+	tests := []struct {
+		name           string
+		accountID      string
+		query          string
+		setupMock      func(*mocks.MockGetBalanceTrendProcessorInterface)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "trend for the requested days",
+			accountID: "1",
+			query:     "days=2",
+			setupMock: func(mockProc *mocks.MockGetBalanceTrendProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, requestFor(1, 2)).
+					Return(&domain.GetBalanceTrendResponse{
+						AccountID: 1,
+						Days: []*domain.DailyBalance{
+							{Date: "2025-01-01", Balance: 100},
+							{Date: "2025-01-02", Balance: 69.9},
+						},
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"account_id":1,"days":[{"date":"2025-01-01","balance":100},{"date":"2025-01-02","balance":69.9}]}`,
+		},
+		{
+			name:      "default days",
+			accountID: "1",
+			query:     "",
+			setupMock: func(mockProc *mocks.MockGetBalanceTrendProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, requestFor(1, domain.DefaultTrendDays)).
+					Return(&domain.GetBalanceTrendResponse{AccountID: 1, Days: []*domain.DailyBalance{}}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid days",
+			accountID:      "1",
+			query:          "days=0",
+			setupMock:      func(mockProc *mocks.MockGetBalanceTrendProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid account ID",
+			accountID:      "abc",
+			setupMock:      func(mockProc *mocks.MockGetBalanceTrendProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "account not found",
+			accountID: "999",
+			setupMock: func(mockProc *mocks.MockGetBalanceTrendProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, mock.Anything).
+					Return(nil, errors.New("account with id 999 not found")).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockGetBalanceTrendProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewGetBalanceTrendHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/"+tt.accountID+"/trend?"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", tt.accountID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
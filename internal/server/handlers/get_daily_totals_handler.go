@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type GetDailyTotalsHandler struct {
+	processor processors.GetDailyTotalsProcessorInterface
+}
+
+func NewGetDailyTotalsHandler(processor processors.GetDailyTotalsProcessorInterface) *GetDailyTotalsHandler {
+	return &GetDailyTotalsHandler{
+		processor: processor,
+	}
+}
+
+// Handle returns the account's transaction count and net amount per UTC day
+// start and end are optional and inclusive; fill_gaps=true adds days without activity
+func (h *GetDailyTotalsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	accountID, err := parseAccountIDParam(r)
+	if err != nil || accountID <= 0 {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid account ID")
+		return
+	}
+
+	req := domain.GetDailyTotalsRequest{AccountID: accountID}
+
+	if startStr := r.URL.Query().Get("start"); startStr != "" {
+		start, ok := parseStatementTime(startStr, false, time.UTC)
+		if !ok {
+			respondWithError(w, r, http.StatusBadRequest, "Invalid start: use RFC 3339 or YYYY-MM-DD")
+			return
+		}
+		req.Start = &start
+	}
+
+	if endStr := r.URL.Query().Get("end"); endStr != "" {
+		end, ok := parseStatementTime(endStr, true, time.UTC)
+		if !ok {
+			respondWithError(w, r, http.StatusBadRequest, "Invalid end: use RFC 3339 or YYYY-MM-DD")
+			return
+		}
+		req.End = &end
+	}
+
+	if fillStr := r.URL.Query().Get("fill_gaps"); fillStr != "" {
+		fillGaps, err := strconv.ParseBool(fillStr)
+		if err != nil {
+			respondWithError(w, r, http.StatusBadRequest, "Invalid fill_gaps")
+			return
+		}
+		req.FillGaps = fillGaps
+	}
+
+	response, err := h.processor.Process(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidStatementPeriod) || errors.Is(err, domain.ErrGapFillRangeTooLarge) {
+			respondWithError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		if contains(err.Error(), "not found") {
+			respondWithError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to get daily totals")
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusOK, response)
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetDailyTotalsHandler_Handle(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 3, 23, 59, 59, 999999999, time.UTC)
+
+	tests := []struct {
+		name           string
+		accountID      string
+		query          string
+		setupMock      func(*mocks.MockGetDailyTotalsProcessorInterface)
+		expectedStatus int
+		validateResp   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "daily totals with gaps filled",
+			accountID: "1",
+			query:     "start=2025-01-01&end=2025-01-03&fill_gaps=true",
+			setupMock: func(mockProc *mocks.MockGetDailyTotalsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetDailyTotalsRequest{AccountID: 1, Start: &start, End: &end, FillGaps: true}).
+					Return(&domain.GetDailyTotalsResponse{
+						AccountID: 1,
+						Days: []*domain.DailyTotal{
+							{Date: "2025-01-01", Count: 2, NetAmount: 69.9},
+							{Date: "2025-01-02"},
+							{Date: "2025-01-03", Count: 1, NetAmount: -5.0},
+						},
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"account_id":1,"days":[
+					{"date":"2025-01-01","count":2,"net_amount":69.9},
+					{"date":"2025-01-02","count":0,"net_amount":0},
+					{"date":"2025-01-03","count":1,"net_amount":-5}
+				]}`, w.Body.String())
+			},
+		},
+		{
+			name:      "without a range",
+			accountID: "1",
+			query:     "",
+			setupMock: func(mockProc *mocks.MockGetDailyTotalsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetDailyTotalsRequest{AccountID: 1}).
+					Return(&domain.GetDailyTotalsResponse{AccountID: 1, Days: []*domain.DailyTotal{}}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid fill_gaps",
+			accountID:      "1",
+			query:          "fill_gaps=sometimes",
+			setupMock:      func(mockProc *mocks.MockGetDailyTotalsProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid start",
+			accountID:      "1",
+			query:          "start=yesterday",
+			setupMock:      func(mockProc *mocks.MockGetDailyTotalsProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "gap filling range too large",
+			accountID: "1",
+			query:     "start=2020-01-01&end=2025-01-01&fill_gaps=true",
+			setupMock: func(mockProc *mocks.MockGetDailyTotalsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, mock.Anything).
+					Return(nil, domain.ErrGapFillRangeTooLarge).
+					Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "account not found",
+			accountID: "999",
+			query:     "",
+			setupMock: func(mockProc *mocks.MockGetDailyTotalsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetDailyTotalsRequest{AccountID: 999}).
+					Return(nil, errors.New("account with id 999 not found")).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockGetDailyTotalsProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewGetDailyTotalsHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/"+tt.accountID+"/daily?"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", tt.accountID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateResp != nil {
+				tt.validateResp(t, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type GetIdempotencyKeyHandler struct {
+	processor processors.GetIdempotencyKeyProcessorInterface
+}
+
+func NewGetIdempotencyKeyHandler(processor processors.GetIdempotencyKeyProcessorInterface) *GetIdempotencyKeyHandler {
+	return &GetIdempotencyKeyHandler{
+		processor: processor,
+	}
+}
+
+// Handle reports whether a response is cached for the Idempotency-Key in the path, without its body
+func (h *GetIdempotencyKeyHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "key")
+	if key == "" {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid idempotency key")
+		return
+	}
+
+	response, err := h.processor.Process(r.Context(), key)
+	if err != nil {
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to get idempotency key")
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusOK, response)
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetIdempotencyKeyHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name           string
+		key            string
+		setupMock      func(*mocks.MockGetIdempotencyKeyProcessorInterface)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "cached key",
+			key:  "key-1",
+			setupMock: func(mockProc *mocks.MockGetIdempotencyKeyProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything, "key-1").Return(&domain.IdempotencyKeyStatus{
+					Key:            "key-1",
+					Cached:         true,
+					Status:         domain.IdempotencyStatusCompleted,
+					ResponseStatus: 201,
+					AgeSeconds:     30,
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"key":"key-1","cached":true,"status":"completed","response_status":201,"age_seconds":30}`,
+		},
+		{
+			name: "absent key",
+			key:  "key-2",
+			setupMock: func(mockProc *mocks.MockGetIdempotencyKeyProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything, "key-2").Return(&domain.IdempotencyKeyStatus{Key: "key-2"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"key":"key-2","cached":false}`,
+		},
+		{
+			name:           "missing key",
+			setupMock:      func(*mocks.MockGetIdempotencyKeyProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"Bad Request","message":"Invalid idempotency key"}`,
+		},
+		{
+			name: "processor error",
+			key:  "key-1",
+			setupMock: func(mockProc *mocks.MockGetIdempotencyKeyProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything, "key-1").Return(nil, errors.New("database error")).Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"Internal Server Error","message":"Failed to get idempotency key"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockGetIdempotencyKeyProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewGetIdempotencyKeyHandler(mockProc)
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/idempotency/"+tt.key, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("key", tt.key)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type GetRecentTransactionsHandler struct {
+	processor processors.GetRecentTransactionsProcessorInterface
+}
+
+func NewGetRecentTransactionsHandler(processor processors.GetRecentTransactionsProcessorInterface) *GetRecentTransactionsHandler {
+	return &GetRecentTransactionsHandler{
+		processor: processor,
+	}
+}
+
+func (h *GetRecentTransactionsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	// Parse limit (processor applies the default and the cap)
+	limit, ok := parseIntQueryParam(r, "limit", 0, 1)
+	if !ok {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+	req := domain.GetRecentTransactionsRequest{Limit: limit}
+
+	response, err := h.processor.Process(r.Context(), req)
+	if err != nil {
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to get recent transactions")
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusOK, response)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetRecentTransactionsHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		setupMock      func(*mocks.MockGetRecentTransactionsProcessorInterface)
+		expectedStatus int
+		validateResp   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "successfully get recent transactions",
+			queryParams: "?limit=2",
+			setupMock: func(mockProc *mocks.MockGetRecentTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetRecentTransactionsRequest{Limit: 2}).
+					Return(&domain.GetRecentTransactionsResponse{
+						Transactions: []*domain.RecentTransaction{
+							{
+								Transaction:    domain.Transaction{ID: 2, AccountID: 2, OperationTypeID: 4, Amount: 100.0, EventDate: time.Now()},
+								DocumentNumber: "98765432100",
+							},
+						},
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var result map[string][]map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Len(t, result["transactions"], 1)
+				assert.Equal(t, float64(2), result["transactions"][0]["transaction_id"])
+				assert.Equal(t, "98765432100", result["transactions"][0]["document_number"])
+			},
+		},
+		{
+			name:        "default limit delegated to processor",
+			queryParams: "",
+			setupMock: func(mockProc *mocks.MockGetRecentTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetRecentTransactionsRequest{}).
+					Return(&domain.GetRecentTransactionsResponse{Transactions: []*domain.RecentTransaction{}}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid limit",
+			queryParams:    "?limit=abc",
+			setupMock:      func(mockProc *mocks.MockGetRecentTransactionsProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Invalid limit")
+			},
+		},
+		{
+			name:        "internal server error",
+			queryParams: "",
+			setupMock: func(mockProc *mocks.MockGetRecentTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, mock.Anything).
+					Return(nil, errors.New("database error")).
+					Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Failed to get recent transactions")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockGetRecentTransactionsProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewGetRecentTransactionsHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/transactions/recent"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateResp != nil {
+				tt.validateResp(t, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+// statementDateLayout is accepted for start and end in addition to RFC 3339
+const statementDateLayout = "2006-01-02"
+
+type GetStatementHandler struct {
+	processor processors.GetStatementProcessorInterface
+	monthly   processors.GetMonthlyStatementProcessorInterface
+}
+
+func NewGetStatementHandler(processor processors.GetStatementProcessorInterface, monthly processors.GetMonthlyStatementProcessorInterface) *GetStatementHandler {
+	return &GetStatementHandler{
+		processor: processor,
+		monthly:   monthly,
+	}
+}
+
+// Handle returns the statement of a period, or the monthly summary of a year when year is given
+func (h *GetStatementHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	accountID, err := parseAccountIDParam(r)
+	if err != nil || accountID <= 0 {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid account ID")
+		return
+	}
+
+	if r.URL.Query().Has("year") {
+		h.handleMonthly(w, r, accountID)
+		return
+	}
+
+	loc, ok := parseTimeZone(r.URL.Query().Get("tz"))
+	if !ok {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid tz: use an IANA time zone name such as America/Sao_Paulo")
+		return
+	}
+
+	start, ok := parseStatementTime(r.URL.Query().Get("start"), false, loc)
+	if !ok {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid start: use RFC 3339 or YYYY-MM-DD")
+		return
+	}
+
+	end, ok := parseStatementTime(r.URL.Query().Get("end"), true, loc)
+	if !ok {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid end: use RFC 3339 or YYYY-MM-DD")
+		return
+	}
+
+	req := domain.GetStatementRequest{
+		AccountID: accountID,
+		Start:     start,
+		End:       end,
+	}
+
+	response, err := h.processor.Process(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidStatementPeriod) {
+			respondWithError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		if contains(err.Error(), "not found") {
+			respondWithError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to get statement")
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusOK, response)
+}
+
+// handleMonthly answers a year's statement, one summary per UTC month
+func (h *GetStatementHandler) handleMonthly(w http.ResponseWriter, r *http.Request, accountID int64) {
+	query := r.URL.Query()
+	if query.Has("start") || query.Has("end") || query.Has("tz") {
+		respondWithError(w, r, http.StatusBadRequest, "year cannot be combined with start, end or tz")
+		return
+	}
+
+	year, err := strconv.Atoi(query.Get("year"))
+	if err != nil {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid year: use a year such as 2024")
+		return
+	}
+
+	response, err := h.monthly.Process(r.Context(), domain.GetMonthlyStatementRequest{AccountID: accountID, Year: year})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidStatementYear) {
+			respondWithError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		if contains(err.Error(), "not found") {
+			respondWithError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to get statement")
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusOK, response)
+}
+
+// parseTimeZone loads an IANA time zone, defaulting to UTC when none is given
+// "Local" is rejected since it depends on the server's own zone
+func parseTimeZone(name string) (*time.Location, bool) {
+	if name == "" {
+		return time.UTC, true
+	}
+	if name == "Local" {
+		return nil, false
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, false
+	}
+	return loc, true
+}
+
+// parseStatementTime parses an RFC 3339 timestamp or a date
+// A date is read as a calendar day in loc and converted to UTC; used as the period end it covers the whole day
+// RFC 3339 timestamps carry their own offset, so loc does not apply to them
+func parseStatementTime(value string, endOfDay bool, loc *time.Location) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t.UTC(), true
+	}
+
+	t, err := time.ParseInLocation(statementDateLayout, value, loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if endOfDay {
+		// AddDate keeps the wall clock, so days shortened or lengthened by DST still end at midnight
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return t.UTC(), true
+}
@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStatementHandler_Handle(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endOfDay := time.Date(2025, 1, 31, 23, 59, 59, 999999999, time.UTC)
+
+	tests := []struct {
+		name           string
+		accountID      string
+		query          string
+		setupMock      func(*mocks.MockGetStatementProcessorInterface)
+		expectedStatus int
+		validateResp   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "statement for date range",
+			accountID: "1",
+			query:     "start=2025-01-01&end=2025-01-31",
+			setupMock: func(mockProc *mocks.MockGetStatementProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetStatementRequest{AccountID: 1, Start: start, End: endOfDay}).
+					Return(&domain.StatementResponse{
+						AccountID:      1,
+						Start:          start,
+						End:            endOfDay,
+						OpeningBalance: 100.0,
+						ClosingBalance: 70.0,
+						Transactions:   []*domain.Transaction{{ID: 1, AccountID: 1, Amount: -30.0}},
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var result domain.StatementResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+				assert.Equal(t, 100.0, result.OpeningBalance)
+				assert.Equal(t, 70.0, result.ClosingBalance)
+				assert.Len(t, result.Transactions, 1)
+			},
+		},
+		{
+			name:      "statement for RFC 3339 range",
+			accountID: "1",
+			query:     "start=2025-01-01T00:00:00Z&end=2025-01-15T12:00:00Z",
+			setupMock: func(mockProc *mocks.MockGetStatementProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetStatementRequest{
+						AccountID: 1,
+						Start:     start,
+						End:       time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC),
+					}).
+					Return(&domain.StatementResponse{Transactions: []*domain.Transaction{}}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "date range in a time zone is converted to UTC",
+			accountID: "1",
+			query:     "start=2025-01-01&end=2025-01-31&tz=America/Sao_Paulo",
+			setupMock: func(mockProc *mocks.MockGetStatementProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetStatementRequest{
+						AccountID: 1,
+						Start:     time.Date(2025, 1, 1, 3, 0, 0, 0, time.UTC),
+						End:       time.Date(2025, 2, 1, 2, 59, 59, 999999999, time.UTC),
+					}).
+					Return(&domain.StatementResponse{Transactions: []*domain.Transaction{}}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "time zone does not shift RFC 3339 timestamps",
+			accountID: "1",
+			query:     "start=2025-01-01T00:00:00Z&end=2025-01-15T12:00:00Z&tz=Asia/Tokyo",
+			setupMock: func(mockProc *mocks.MockGetStatementProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetStatementRequest{
+						AccountID: 1,
+						Start:     start,
+						End:       time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC),
+					}).
+					Return(&domain.StatementResponse{Transactions: []*domain.Transaction{}}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown time zone",
+			accountID:      "1",
+			query:          "start=2025-01-01&end=2025-01-31&tz=Mars/Olympus",
+			setupMock:      func(mockProc *mocks.MockGetStatementProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Invalid tz")
+			},
+		},
+		{
+			name:           "server local time zone is rejected",
+			accountID:      "1",
+			query:          "start=2025-01-01&end=2025-01-31&tz=Local",
+			setupMock:      func(mockProc *mocks.MockGetStatementProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing start",
+			accountID:      "1",
+			query:          "end=2025-01-31",
+			setupMock:      func(mockProc *mocks.MockGetStatementProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Invalid start")
+			},
+		},
+		{
+			name:           "invalid end",
+			accountID:      "1",
+			query:          "start=2025-01-01&end=tomorrow",
+			setupMock:      func(mockProc *mocks.MockGetStatementProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Invalid end")
+			},
+		},
+		{
+			name:           "invalid account ID",
+			accountID:      "abc",
+			query:          "start=2025-01-01&end=2025-01-31",
+			setupMock:      func(mockProc *mocks.MockGetStatementProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "end before start",
+			accountID: "1",
+			query:     "start=2025-02-01&end=2025-01-01",
+			setupMock: func(mockProc *mocks.MockGetStatementProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, mock.Anything).
+					Return(nil, domain.ErrInvalidStatementPeriod).
+					Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "account not found",
+			accountID: "999",
+			query:     "start=2025-01-01&end=2025-01-31",
+			setupMock: func(mockProc *mocks.MockGetStatementProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, mock.Anything).
+					Return(nil, errors.New("account with id 999 not found")).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:      "internal server error",
+			accountID: "1",
+			query:     "start=2025-01-01&end=2025-01-31",
+			setupMock: func(mockProc *mocks.MockGetStatementProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, mock.Anything).
+					Return(nil, errors.New("database error")).
+					Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Failed to get statement")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockGetStatementProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewGetStatementHandler(mockProc, mocks.NewMockGetMonthlyStatementProcessorInterface(t))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/"+tt.accountID+"/statement?"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", tt.accountID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateResp != nil {
+				tt.validateResp(t, w)
+			}
+		})
+	}
+}
+
+// A transaction at 01:30 UTC on Jan 2 happened on Jan 1 in Sao Paulo (UTC-3):
+// filtering "today" by date alone must include it only when the zone is given
+func TestParseStatementTime_DayBoundary(t *testing.T) {
+	saoPaulo, ok := parseTimeZone("America/Sao_Paulo")
+	require.True(t, ok)
+
+	eventDate := time.Date(2025, 1, 2, 1, 30, 0, 0, time.UTC)
+
+	within := func(loc *time.Location) bool {
+		start, ok := parseStatementTime("2025-01-01", false, loc)
+		require.True(t, ok)
+		end, ok := parseStatementTime("2025-01-01", true, loc)
+		require.True(t, ok)
+		return !eventDate.Before(start) && !eventDate.After(end)
+	}
+
+	assert.False(t, within(time.UTC), "In UTC the event falls on Jan 2")
+	assert.True(t, within(saoPaulo), "In Sao Paulo the event falls on Jan 1")
+}
+
+func TestGetStatementHandler_Monthly(t *testing.T) {
+	tests := []struct {
+		name           string
+		accountID      string
+		query          string
+		setupMock      func(*mocks.MockGetMonthlyStatementProcessorInterface)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "monthly summary of a year",
+			accountID: "1",
+			query:     "?year=2024",
+			setupMock: func(mockProc *mocks.MockGetMonthlyStatementProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetMonthlyStatementRequest{AccountID: 1, Year: 2024}).
+					Return(&domain.GetMonthlyStatementResponse{
+						AccountID:      1,
+						Year:           2024,
+						OpeningBalance: 20,
+						Months: []*domain.MonthlyStatement{
+							{Month: "2024-01", TotalDebits: -30.1, TotalCredits: 100, NetChange: 69.9, EndingBalance: 89.9},
+						},
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"months":[{"month":"2024-01","total_debits":-30.1,"total_credits":100,"net_change":69.9,"ending_balance":89.9}]`,
+		},
+		{
+			name:           "year is not a number",
+			accountID:      "1",
+			query:          "?year=last",
+			setupMock:      func(mockProc *mocks.MockGetMonthlyStatementProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "Invalid year",
+		},
+		{
+			name:           "empty year",
+			accountID:      "1",
+			query:          "?year=",
+			setupMock:      func(mockProc *mocks.MockGetMonthlyStatementProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "year out of range",
+			accountID: "1",
+			query:     "?year=20240",
+			setupMock: func(mockProc *mocks.MockGetMonthlyStatementProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetMonthlyStatementRequest{AccountID: 1, Year: 20240}).
+					Return(nil, domain.ErrInvalidStatementYear).
+					Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "year must be between 1900 and 9999",
+		},
+		{
+			name:           "year with a period",
+			accountID:      "1",
+			query:          "?year=2024&start=2024-01-01",
+			setupMock:      func(mockProc *mocks.MockGetMonthlyStatementProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "year cannot be combined with start, end or tz",
+		},
+		{
+			name:      "account not found",
+			accountID: "999",
+			query:     "?year=2024",
+			setupMock: func(mockProc *mocks.MockGetMonthlyStatementProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetMonthlyStatementRequest{AccountID: 999, Year: 2024}).
+					Return(nil, errors.New("account with id 999 not found")).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:      "internal server error",
+			accountID: "1",
+			query:     "?year=2024",
+			setupMock: func(mockProc *mocks.MockGetMonthlyStatementProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetMonthlyStatementRequest{AccountID: 1, Year: 2024}).
+					Return(nil, errors.New("database error")).
+					Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "Failed to get statement",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMonthly := mocks.NewMockGetMonthlyStatementProcessorInterface(t)
+			tt.setupMock(mockMonthly)
+
+			handler := NewGetStatementHandler(mocks.NewMockGetStatementProcessorInterface(t), mockMonthly)
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/accounts/"+tt.accountID+"/statement"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", tt.accountID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
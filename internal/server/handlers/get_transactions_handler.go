@@ -1,77 +1,158 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
-	"github.com/go-chi/chi/v5"
 	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
 	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
 )
 
+// largePageWarning is sent as a Warning header (code 299, miscellaneous persistent warning) on large pages
+const largePageWarning = `299 - "Large page requested (limit=%d); prefer smaller pages or the date-filtered statement endpoint"`
+
 type GetTransactionsHandler struct {
-	processor processors.GetTransactionsProcessorInterface
+	processor  processors.GetTransactionsProcessorInterface
+	pagination domain.PaginationDefaults
 }
 
-func NewGetTransactionsHandler(processor processors.GetTransactionsProcessorInterface) *GetTransactionsHandler {
-	return &GetTransactionsHandler{
-		processor: processor,
+// GetTransactionsHandlerOption configures optional behavior of the GetTransactionsHandler
+type GetTransactionsHandlerOption func(*GetTransactionsHandler)
+
+// WithPagination overrides the default (50) and maximum (100) page sizes and the large page warning threshold
+func WithPagination(defaults domain.PaginationDefaults) GetTransactionsHandlerOption {
+	return func(h *GetTransactionsHandler) {
+		h.pagination = defaults
 	}
 }
 
+func NewGetTransactionsHandler(processor processors.GetTransactionsProcessorInterface, opts ...GetTransactionsHandlerOption) *GetTransactionsHandler {
+	h := &GetTransactionsHandler{
+		processor:  processor,
+		pagination: domain.DefaultPagination(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
 func (h *GetTransactionsHandler) Handle(w http.ResponseWriter, r *http.Request) {
-	accountIDStr := chi.URLParam(r, "accountId")
-	accountID, err := strconv.ParseInt(accountIDStr, 10, 64)
+	accountID, err := parseAccountIDParam(r)
 	if err != nil || accountID <= 0 {
-		respondWithError(w, http.StatusBadRequest, "Invalid account ID")
+		respondWithError(w, r, http.StatusBadRequest, "Invalid account ID")
 		return
 	}
 
-	// Get pagination parameters from query string
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
+	// Empty limit/offset fall back to defaults; present but invalid values are rejected
+	limit, offset, ok := parsePaginationParams(w, r)
+	if !ok {
+		return
+	}
 
-	// Default values
-	limit := 50
-	offset := 0
+	// Apply the default page size and cap it at the maximum
+	limit, offset = domain.NormalizePagination(limit, offset, h.pagination)
 
-	// Parse limit
-	if limitStr != "" {
-		parsedLimit, err := strconv.Atoi(limitStr)
-		if err != nil || parsedLimit <= 0 {
-			respondWithError(w, http.StatusBadRequest, "Invalid limit")
+	// Parse strict pagination flag
+	strictPagination := false
+	if strictStr := r.URL.Query().Get("strict_pagination"); strictStr != "" {
+		parsedStrict, err := strconv.ParseBool(strictStr)
+		if err != nil {
+			respondWithError(w, r, http.StatusBadRequest, "Invalid strict_pagination")
 			return
 		}
-		limit = parsedLimit
+		strictPagination = parsedStrict
+	}
+
+	from, ok := parseOptionalTime(r.URL.Query().Get("from"), false)
+	if !ok {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid from: use RFC 3339 or YYYY-MM-DD")
+		return
 	}
 
-	// Parse offset
-	if offsetStr != "" {
-		parsedOffset, err := strconv.Atoi(offsetStr)
-		if err != nil || parsedOffset < 0 {
-			respondWithError(w, http.StatusBadRequest, "Invalid offset")
+	to, ok := parseOptionalTime(r.URL.Query().Get("to"), true)
+	if !ok {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid to: use RFC 3339 or YYYY-MM-DD")
+		return
+	}
+
+	// A type no transaction has simply matches nothing
+	operationTypeID, ok := parseIntQueryParam(r, "operation_type_id", 0, 1)
+	if !ok {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid operation_type_id: must be greater than 0")
+		return
+	}
+
+	if sort := r.URL.Query().Get("sort"); sort != "" && sort != "event_date" {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid sort: only event_date is supported")
+		return
+	}
+
+	order, ok := domain.ParseSortOrder(r.URL.Query().Get("order"))
+	if !ok {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid order: use asc or desc")
+		return
+	}
+
+	var cursor *domain.TransactionCursor
+	if raw := r.URL.Query().Get("cursor"); raw != "" {
+		cursor, err = domain.DecodeTransactionCursor(raw)
+		if err != nil {
+			respondWithError(w, r, http.StatusBadRequest, "Invalid cursor")
 			return
 		}
-		offset = parsedOffset
 	}
 
 	req := domain.GetTransactionsRequest{
-		AccountID: accountID,
-		Limit:     int64(limit),
-		Offset:    int64(offset),
+		AccountID:        accountID,
+		Limit:            limit,
+		Offset:           offset,
+		StrictPagination: strictPagination,
+		From:             from,
+		To:               to,
+		OperationTypeID:  operationTypeID,
+		Order:            order,
+		Cursor:           cursor,
 	}
 
 	response, err := h.processor.Process(r.Context(), req)
 	if err != nil {
-		if contains(err.Error(), "not found") {
-			respondWithError(w, http.StatusNotFound, err.Error())
+		if errors.Is(err, domain.ErrOffsetExceedsTotal) || errors.Is(err, domain.ErrInvalidDateRange) ||
+			errors.Is(err, domain.ErrCursorOrderMismatch) || errors.Is(err, domain.ErrCursorWithOffset) {
+			respondWithError(w, r, http.StatusBadRequest, err.Error())
 			return
 		}
-		respondWithError(w, http.StatusInternalServerError, "Failed to get transactions")
+		if errors.Is(err, domain.ErrAccountClosed) || contains(err.Error(), "not found") {
+			respondWithError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to get transactions")
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, response)
+	// Nudge clients pulling large pages toward narrower queries
+	if h.pagination.IsLargePage(limit) {
+		w.Header().Set("Warning", fmt.Sprintf(largePageWarning, limit))
+	}
+
+	respondWithJSON(w, r, http.StatusOK, response)
+}
+
+// parseOptionalTime reads a date bound like the statement's start and end (in UTC), nil when it is not given
+func parseOptionalTime(value string, endOfDay bool) (*time.Time, bool) {
+	if value == "" {
+		return nil, true
+	}
+	t, ok := parseStatementTime(value, endOfDay, time.UTC)
+	if !ok {
+		return nil, false
+	}
+	return &t, true
 }
 
 func contains(s, substr string) bool {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -37,21 +38,21 @@ func TestGetTransactionsHandler_Handle(t *testing.T) {
 						Offset:    0,
 					}).
 					Return(&domain.GetTransactionsResponse{
-						Transactions: []*domain.Transaction{
-							{
+						Transactions: []*domain.AccountTransaction{
+							{Transaction: domain.Transaction{
 								ID:              1,
 								AccountID:       1,
 								OperationTypeID: domain.OperationTypePurchase,
 								Amount:          -50.0,
 								EventDate:       time.Now(),
-							},
-							{
+							}, OperationTypeDescription: "Normal Purchase"},
+							{Transaction: domain.Transaction{
 								ID:              2,
 								AccountID:       1,
 								OperationTypeID: domain.OperationTypeCreditVoucher,
 								Amount:          100.0,
 								EventDate:       time.Now(),
-							},
+							}, OperationTypeDescription: "Credit Voucher"},
 						},
 						Pagination: domain.PaginationMetadata{
 							Total:  2,
@@ -70,6 +71,8 @@ func TestGetTransactionsHandler_Handle(t *testing.T) {
 				assert.Len(t, result.Transactions, 2)
 				assert.Equal(t, int64(2), result.Pagination.Total)
 				assert.Equal(t, int64(1), result.Transactions[0].ID)
+				assert.Equal(t, "Normal Purchase", result.Transactions[0].OperationTypeDescription)
+				assert.Contains(t, w.Body.String(), `"operation_type_id":1,`, "The raw id is kept next to the description")
 			},
 		},
 		{
@@ -84,7 +87,7 @@ func TestGetTransactionsHandler_Handle(t *testing.T) {
 						Offset:    5,
 					}).
 					Return(&domain.GetTransactionsResponse{
-						Transactions: []*domain.Transaction{},
+						Transactions: []*domain.AccountTransaction{},
 						Pagination: domain.PaginationMetadata{
 							Total:  0,
 							Limit:  10,
@@ -163,6 +166,55 @@ func TestGetTransactionsHandler_Handle(t *testing.T) {
 				assert.Contains(t, w.Body.String(), "not found")
 			},
 		},
+		{
+			name:        "account is closed",
+			accountID:   "7",
+			queryParams: "",
+			setupMock: func(mockProc *mocks.MockGetTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetTransactionsRequest{
+						AccountID: 7,
+						Limit:     50,
+						Offset:    0,
+					}).
+					Return(nil, domain.ErrAccountClosed).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "account is closed")
+			},
+		},
+		{
+			name:        "overshooting offset with strict pagination",
+			accountID:   "1",
+			queryParams: "?offset=20&strict_pagination=true",
+			setupMock: func(mockProc *mocks.MockGetTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetTransactionsRequest{
+						AccountID:        1,
+						Limit:            50,
+						Offset:           20,
+						StrictPagination: true,
+					}).
+					Return(nil, fmt.Errorf("%w (%d)", domain.ErrOffsetExceedsTotal, 5)).
+					Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "offset exceeds total results (5)")
+			},
+		},
+		{
+			name:           "invalid strict_pagination parameter",
+			accountID:      "1",
+			queryParams:    "?strict_pagination=maybe",
+			setupMock:      func(mockProc *mocks.MockGetTransactionsProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Invalid strict_pagination")
+			},
+		},
 		{
 			name:        "internal server error",
 			accountID:   "1",
@@ -215,3 +267,306 @@ func TestGetTransactionsHandler_Handle(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTransactionsHandler_WithPagination(t *testing.T) {
+	tests := []struct {
+		name        string
+		queryParams string
+		wantLimit   int64
+	}{
+		{name: "missing limit uses the configured default", queryParams: "", wantLimit: 20},
+		{name: "limit above the maximum is capped", queryParams: "?limit=40", wantLimit: 30},
+		{name: "limit within bounds is kept", queryParams: "?limit=25", wantLimit: 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockGetTransactionsProcessorInterface(t)
+			mockProc.EXPECT().
+				Process(mock.Anything, domain.GetTransactionsRequest{
+					AccountID: 1,
+					Limit:     tt.wantLimit,
+					Offset:    0,
+				}).
+				Return(&domain.GetTransactionsResponse{
+					Transactions: []*domain.AccountTransaction{},
+					Pagination:   domain.PaginationMetadata{Limit: tt.wantLimit, Pages: 1},
+				}, nil).
+				Once()
+
+			handler := NewGetTransactionsHandler(mockProc, WithPagination(domain.PaginationDefaults{DefaultLimit: 20, MaxLimit: 30}))
+
+			req := httptest.NewRequest(http.MethodGet, "/accounts/1/transactions"+tt.queryParams, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", "1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+		})
+	}
+}
+
+func TestGetTransactionsHandler_PaginationParams(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		expectedStatus int
+		wantLimit      int64
+		wantOffset     int64
+		wantError      string
+	}{
+		{name: "both empty use defaults", queryParams: "?limit=&offset=", expectedStatus: http.StatusOK, wantLimit: 50},
+		{name: "empty limit uses default", queryParams: "?limit=&offset=10", expectedStatus: http.StatusOK, wantLimit: 50, wantOffset: 10},
+		{name: "empty offset uses default", queryParams: "?limit=10&offset=", expectedStatus: http.StatusOK, wantLimit: 10},
+		{name: "zero limit is rejected", queryParams: "?limit=0", expectedStatus: http.StatusBadRequest, wantError: "Invalid limit"},
+		{name: "zero offset is kept", queryParams: "?limit=10&offset=0", expectedStatus: http.StatusOK, wantLimit: 10},
+		{name: "negative limit is rejected", queryParams: "?limit=-1", expectedStatus: http.StatusBadRequest, wantError: "Invalid limit"},
+		{name: "negative offset is rejected", queryParams: "?limit=10&offset=-1", expectedStatus: http.StatusBadRequest, wantError: "Invalid offset"},
+		{name: "non-numeric limit is rejected", queryParams: "?limit=ten&offset=0", expectedStatus: http.StatusBadRequest, wantError: "Invalid limit"},
+		{name: "non-numeric offset is rejected", queryParams: "?limit=10&offset=abc", expectedStatus: http.StatusBadRequest, wantError: "Invalid offset"},
+		{name: "whitespace is not empty", queryParams: "?offset=%20", expectedStatus: http.StatusBadRequest, wantError: "Invalid offset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockGetTransactionsProcessorInterface(t)
+			if tt.expectedStatus == http.StatusOK {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetTransactionsRequest{
+						AccountID: 1,
+						Limit:     tt.wantLimit,
+						Offset:    tt.wantOffset,
+					}).
+					Return(&domain.GetTransactionsResponse{
+						Transactions: []*domain.AccountTransaction{},
+						Pagination:   domain.PaginationMetadata{Limit: tt.wantLimit, Offset: tt.wantOffset},
+					}, nil).
+					Once()
+			}
+
+			handler := NewGetTransactionsHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodGet, "/accounts/1/transactions"+tt.queryParams, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", "1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestGetTransactionsHandler_LargePageWarning(t *testing.T) {
+	tests := []struct {
+		name        string
+		queryParams string
+		wantLimit   int64
+		wantWarning bool
+	}{
+		{name: "large limit gets a warning", queryParams: "?limit=100", wantLimit: 100, wantWarning: true},
+		{name: "capped limit gets a warning", queryParams: "?limit=500", wantLimit: 100, wantWarning: true},
+		{name: "limit at the threshold has no warning", queryParams: "?limit=80", wantLimit: 80},
+		{name: "default limit has no warning", queryParams: "", wantLimit: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockGetTransactionsProcessorInterface(t)
+			mockProc.EXPECT().
+				Process(mock.Anything, domain.GetTransactionsRequest{AccountID: 1, Limit: tt.wantLimit}).
+				Return(&domain.GetTransactionsResponse{
+					Transactions: []*domain.AccountTransaction{},
+					Pagination:   domain.PaginationMetadata{Limit: tt.wantLimit},
+				}, nil).
+				Once()
+
+			handler := NewGetTransactionsHandler(mockProc, WithPagination(domain.PaginationDefaults{DefaultLimit: 50, MaxLimit: 100, WarnAbove: 80}))
+
+			req := httptest.NewRequest(http.MethodGet, "/accounts/1/transactions"+tt.queryParams, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", "1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			if tt.wantWarning {
+				assert.Equal(t, fmt.Sprintf(`299 - "Large page requested (limit=%d); prefer smaller pages or the date-filtered statement endpoint"`, tt.wantLimit), w.Header().Get("Warning"))
+			} else {
+				assert.Empty(t, w.Header().Get("Warning"))
+			}
+		})
+	}
+}
+
+func TestGetTransactionsHandler_DateRangeParams(t *testing.T) {
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	endOfDay := time.Date(2025, 2, 28, 23, 59, 59, 999999999, time.UTC)
+	instant := time.Date(2025, 2, 10, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		procErr        error
+		expectedStatus int
+		wantFrom       *time.Time
+		wantTo         *time.Time
+		wantError      string
+	}{
+		{name: "both dates", queryParams: "?from=2025-02-01&to=2025-02-28", expectedStatus: http.StatusOK, wantFrom: &from, wantTo: &endOfDay},
+		{name: "from only", queryParams: "?from=2025-02-01", expectedStatus: http.StatusOK, wantFrom: &from},
+		{name: "RFC 3339 to", queryParams: "?to=2025-02-10T12:30:00Z", expectedStatus: http.StatusOK, wantTo: &instant},
+		{name: "invalid from", queryParams: "?from=yesterday", expectedStatus: http.StatusBadRequest, wantError: "Invalid from"},
+		{name: "invalid to", queryParams: "?to=2025-13-01", expectedStatus: http.StatusBadRequest, wantError: "Invalid to"},
+		{name: "to before from", queryParams: "?from=2025-02-01&to=2025-02-28", procErr: domain.ErrInvalidDateRange, expectedStatus: http.StatusBadRequest, wantFrom: &from, wantTo: &endOfDay, wantError: "to must not be before from"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockGetTransactionsProcessorInterface(t)
+			if tt.wantFrom != nil || tt.wantTo != nil {
+				call := mockProc.EXPECT().
+					Process(mock.Anything, domain.GetTransactionsRequest{AccountID: 1, Limit: 50, From: tt.wantFrom, To: tt.wantTo}).
+					Once()
+				if tt.procErr != nil {
+					call.Return(nil, tt.procErr)
+				} else {
+					call.Return(&domain.GetTransactionsResponse{
+						Transactions: []*domain.AccountTransaction{},
+						Pagination:   domain.PaginationMetadata{Limit: 50},
+					}, nil)
+				}
+			}
+
+			handler := NewGetTransactionsHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodGet, "/accounts/1/transactions"+tt.queryParams, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", "1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestGetTransactionsHandler_OperationTypeParam(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		expectedStatus int
+		wantType       int64
+	}{
+		{name: "credit vouchers", queryParams: "?operation_type_id=4", expectedStatus: http.StatusOK, wantType: 4},
+		{name: "purchases", queryParams: "?operation_type_id=1", expectedStatus: http.StatusOK, wantType: 1},
+		{name: "empty means every type", queryParams: "?operation_type_id=", expectedStatus: http.StatusOK},
+		{name: "zero is rejected", queryParams: "?operation_type_id=0", expectedStatus: http.StatusBadRequest},
+		{name: "types beyond the seeded ones are passed on", queryParams: "?operation_type_id=5", expectedStatus: http.StatusOK, wantType: 5},
+		{name: "non-numeric is rejected", queryParams: "?operation_type_id=purchase", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockGetTransactionsProcessorInterface(t)
+			if tt.expectedStatus == http.StatusOK {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.GetTransactionsRequest{AccountID: 1, Limit: 50, OperationTypeID: tt.wantType}).
+					Return(&domain.GetTransactionsResponse{
+						Transactions: []*domain.AccountTransaction{},
+						Pagination:   domain.PaginationMetadata{Limit: 50},
+					}, nil).
+					Once()
+			}
+
+			handler := NewGetTransactionsHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodGet, "/accounts/1/transactions"+tt.queryParams, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", "1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusBadRequest {
+				assert.Contains(t, w.Body.String(), "Invalid operation_type_id")
+			}
+		})
+	}
+}
+
+func TestGetTransactionsHandler_SortAndCursorParams(t *testing.T) {
+	cursor := &domain.TransactionCursor{Order: domain.SortAscending, EventDate: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), ID: 7}
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		procErr        error
+		expectedStatus int
+		wantOrder      domain.SortOrder
+		wantCursor     *domain.TransactionCursor
+		wantError      string
+	}{
+		{name: "ascending", queryParams: "?sort=event_date&order=asc", expectedStatus: http.StatusOK, wantOrder: domain.SortAscending},
+		{name: "descending", queryParams: "?order=desc", expectedStatus: http.StatusOK, wantOrder: domain.SortDescending},
+		{name: "cursor", queryParams: "?sort=event_date&order=asc&cursor=" + cursor.Encode(), expectedStatus: http.StatusOK, wantOrder: domain.SortAscending, wantCursor: cursor},
+		{name: "unsupported sort", queryParams: "?sort=amount", expectedStatus: http.StatusBadRequest, wantError: "Invalid sort"},
+		{name: "unknown order", queryParams: "?order=oldest", expectedStatus: http.StatusBadRequest, wantError: "Invalid order"},
+		{name: "malformed cursor", queryParams: "?cursor=abc!", expectedStatus: http.StatusBadRequest, wantError: "Invalid cursor"},
+		{name: "cursor for the other order", queryParams: "?order=desc&cursor=" + cursor.Encode(), procErr: domain.ErrCursorOrderMismatch, expectedStatus: http.StatusBadRequest, wantOrder: domain.SortDescending, wantCursor: cursor, wantError: domain.ErrCursorOrderMismatch.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockGetTransactionsProcessorInterface(t)
+			if tt.wantOrder != "" {
+				call := mockProc.EXPECT().
+					Process(mock.Anything, domain.GetTransactionsRequest{AccountID: 1, Limit: 50, Order: tt.wantOrder, Cursor: tt.wantCursor}).
+					Once()
+				if tt.procErr != nil {
+					call.Return(nil, tt.procErr)
+				} else {
+					call.Return(&domain.GetTransactionsResponse{
+						Transactions: []*domain.AccountTransaction{},
+						Pagination:   domain.PaginationMetadata{Limit: 50},
+						NextCursor:   "next",
+					}, nil)
+				}
+			}
+
+			handler := NewGetTransactionsHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodGet, "/accounts/1/transactions"+tt.queryParams, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("accountId", "1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+			} else {
+				assert.Contains(t, w.Body.String(), `"next_cursor":"next"`)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+// ndjsonContentType is the media type of newline-delimited JSON uploads
+const ndjsonContentType = "application/x-ndjson"
+
+type ImportTransactionsHandler struct {
+	processor processors.ImportTransactionsProcessorInterface
+}
+
+func NewImportTransactionsHandler(processor processors.ImportTransactionsProcessorInterface) *ImportTransactionsHandler {
+	return &ImportTransactionsHandler{
+		processor: processor,
+	}
+}
+
+// Handle imports one transaction per line of an NDJSON body, streaming it instead of reading it whole
+// Each line is limited like a single request body; blank lines are skipped but still numbered
+func (h *ImportTransactionsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != ndjsonContentType {
+		respondWithError(w, r, http.StatusUnsupportedMediaType, "Content-Type must be "+ndjsonContentType)
+		return
+	}
+
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRequestBodyBytes)
+
+	var number int64
+	done := false
+	next := func() (*domain.ImportLine, error) {
+		for !done {
+			if !scanner.Scan() {
+				done = true
+				// A line too long to read ends the import; report it against the line it happened on
+				if err := scanner.Err(); err != nil {
+					return &domain.ImportLine{Number: number + 1, Err: toImportReadError(err)}, nil
+				}
+				break
+			}
+
+			number++
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			return decodeImportLine(number, line), nil
+		}
+		return nil, io.EOF
+	}
+
+	response, err := h.processor.Process(r.Context(), next)
+	if err != nil {
+		if errors.Is(err, domain.ErrServiceUnavailable) {
+			respondWithServiceUnavailable(w, r)
+			return
+		}
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to import transactions")
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusOK, response)
+}
+
+// decodeImportLine decodes and validates a line as a single transaction request would be
+func decodeImportLine(number int64, line []byte) *domain.ImportLine {
+	var req domain.CreateTransactionRequest
+	if err := decodeJSONValue(bytes.NewReader(line), &req); err != nil {
+		return &domain.ImportLine{Number: number, Err: err}
+	}
+	if err := checkAmount(req.Amount); err != nil {
+		return &domain.ImportLine{Number: number, Err: err}
+	}
+	if err := validateCreateTransactionRequest(req); err != nil {
+		return &domain.ImportLine{Number: number, Err: err}
+	}
+	return &domain.ImportLine{Number: number, Request: req}
+}
+
+// toImportReadError describes why the upload could not be read past a line
+func toImportReadError(err error) error {
+	if errors.Is(err, bufio.ErrTooLong) {
+		return badRequestBody(fmt.Sprintf("line exceeds %d bytes", maxRequestBodyBytes))
+	}
+	return badRequestBody("failed to read line")
+}
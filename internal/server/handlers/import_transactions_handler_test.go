@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// drainImport reads every line the handler produces, failing the invalid ones like the real processor
+func drainImport(lines *[]domain.ImportLine) func(context.Context, func() (*domain.ImportLine, error)) (*domain.ImportTransactionsResponse, error) {
+	return func(_ context.Context, next func() (*domain.ImportLine, error)) (*domain.ImportTransactionsResponse, error) {
+		response := domain.NewImportTransactionsResponse()
+		for {
+			line, err := next()
+			if errors.Is(err, io.EOF) {
+				return response, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			*lines = append(*lines, *line)
+			response.Processed++
+			if line.Err != nil {
+				response.Fail(line.Number, line.Err)
+				continue
+			}
+			response.Succeeded++
+		}
+	}
+}
+
+func TestImportTransactionsHandler_Handle_MultiLineBodyWithBadLine(t *testing.T) {
+	mockProc := mocks.NewMockImportTransactionsProcessorInterface(t)
+	var lines []domain.ImportLine
+	mockProc.EXPECT().Process(mock.Anything, mock.Anything).RunAndReturn(drainImport(&lines)).Once()
+
+	body := strings.Join([]string{
+		`{"account_id": 1, "operation_type_id": 1, "amount": 50.0}`,
+		`{"account_id": 1, "operation_type_id": 1, "amount": "oops"}`,
+		``,
+		`{"account_id": 1, "operation_type_id": 4, "amount": 20.5}`,
+		`{"account_id": 1, "operation_type_id": 1, "amount": 10.0, "unknown": true}`,
+	}, "\n")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	w := httptest.NewRecorder()
+
+	NewImportTransactionsHandler(mockProc).Handle(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"processed": 4,
+		"succeeded": 2,
+		"failed": [
+			{"line": 2, "error": "Invalid request body: amount must be a number"},
+			{"line": 5, "error": "Invalid request body: unknown field \"unknown\""}
+		]
+	}`, w.Body.String())
+
+	require.Len(t, lines, 4)
+	assert.Equal(t, int64(1), lines[0].Number)
+	assert.Equal(t, domain.CreateTransactionRequest{AccountID: 1, OperationTypeID: 1, Amount: 50.0}, lines[0].Request)
+	assert.Equal(t, int64(4), lines[2].Number, "Blank lines keep their number")
+	assert.Equal(t, 20.5, lines[2].Request.Amount)
+}
+
+func TestImportTransactionsHandler_Handle_ValidatesLinesLikeSingleRequests(t *testing.T) {
+	mockProc := mocks.NewMockImportTransactionsProcessorInterface(t)
+	var lines []domain.ImportLine
+	mockProc.EXPECT().Process(mock.Anything, mock.Anything).RunAndReturn(drainImport(&lines)).Once()
+
+	body := `{"account_id": 1, "operation_type_id": 1, "amount": 0}` + "\n" +
+		`{"account_id": 1, "operation_type_id": 1, "amount": 1.234}` + "\n"
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-ndjson; charset=utf-8")
+	w := httptest.NewRecorder()
+
+	NewImportTransactionsHandler(mockProc).Handle(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, lines, 2)
+	assert.ErrorIs(t, lines[0].Err, domain.ErrZeroAmount)
+	assert.Error(t, lines[1].Err)
+}
+
+func TestImportTransactionsHandler_Handle_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		contentType    string
+		setupMock      func(*mocks.MockImportTransactionsProcessorInterface)
+		expectedStatus int
+	}{
+		{
+			name:           "not NDJSON",
+			contentType:    "application/json",
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "processor failure",
+			contentType: "application/x-ndjson",
+			setupMock: func(mockProc *mocks.MockImportTransactionsProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything, mock.Anything).Return(nil, errors.New("read failed")).Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "database saturated",
+			contentType: "application/x-ndjson",
+			setupMock: func(mockProc *mocks.MockImportTransactionsProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything, mock.Anything).Return(nil, domain.ErrServiceUnavailable).Once()
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockImportTransactionsProcessorInterface(t)
+			if tt.setupMock != nil {
+				tt.setupMock(mockProc)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions/import", strings.NewReader(`{}`))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			NewImportTransactionsHandler(mockProc).Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
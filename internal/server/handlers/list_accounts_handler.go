@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type ListAccountsHandler struct {
+	processor  processors.ListAccountsProcessorInterface
+	pagination domain.PaginationDefaults
+}
+
+// NewListAccountsHandler creates the account listing handler; pages are bounded by the shared pagination limits
+func NewListAccountsHandler(processor processors.ListAccountsProcessorInterface, pagination domain.PaginationDefaults) *ListAccountsHandler {
+	return &ListAccountsHandler{
+		processor:  processor,
+		pagination: pagination,
+	}
+}
+
+// Handle lists every account, newest first, one page at a time
+func (h *ListAccountsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePaginationParams(w, r)
+	if !ok {
+		return
+	}
+	limit, offset = domain.NormalizePagination(limit, offset, h.pagination)
+
+	response, err := h.processor.Process(r.Context(), domain.ListAccountsRequest{Limit: limit, Offset: offset})
+	if err != nil {
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to list accounts")
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusOK, response)
+}
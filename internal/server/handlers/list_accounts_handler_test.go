@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListAccountsHandler_Handle(t *testing.T) {
+	createdAt := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		setupMock      func(*mocks.MockListAccountsProcessorInterface)
+		expectedStatus int
+		validateResp   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "empty list",
+			queryParams: "",
+			setupMock: func(mockProc *mocks.MockListAccountsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ListAccountsRequest{Limit: 50}).
+					Return(&domain.ListAccountsResponse{
+						Accounts:   []*domain.Account{},
+						Pagination: domain.NewPaginationMetadata(0, 50, 0),
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"accounts": [], "pagination": {"total": 0, "limit": 50, "offset": 0, "pages": 1}}`, w.Body.String())
+			},
+		},
+		{
+			name:        "paginates the listing",
+			queryParams: "?limit=1&offset=1",
+			setupMock: func(mockProc *mocks.MockListAccountsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ListAccountsRequest{Limit: 1, Offset: 1}).
+					Return(&domain.ListAccountsResponse{
+						Accounts:   []*domain.Account{{ID: 1, DocumentNumber: "12345678900", Tier: "standard", CreatedAt: createdAt}},
+						Pagination: domain.NewPaginationMetadata(2, 1, 1),
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{
+					"accounts": [{
+						"account_id": 1,
+						"document_number": "12345678900",
+						"tier": "standard",
+						"credit_limit": 0,
+						"created_at": "2025-01-02T10:00:00Z"
+					}],
+					"pagination": {"total": 2, "limit": 1, "offset": 1, "pages": 2}
+				}`, w.Body.String())
+			},
+		},
+		{
+			name:        "limit above the maximum is capped",
+			queryParams: "?limit=1000",
+			setupMock: func(mockProc *mocks.MockListAccountsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ListAccountsRequest{Limit: 100}).
+					Return(&domain.ListAccountsResponse{Accounts: []*domain.Account{}}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid limit",
+			queryParams:    "?limit=0",
+			setupMock:      func(mockProc *mocks.MockListAccountsProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Invalid limit")
+			},
+		},
+		{
+			name:        "internal server error",
+			queryParams: "",
+			setupMock: func(mockProc *mocks.MockListAccountsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, mock.Anything).
+					Return(nil, errors.New("database error")).
+					Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockListAccountsProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewListAccountsHandler(mockProc, domain.DefaultPagination())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateResp != nil {
+				tt.validateResp(t, w)
+			}
+		})
+	}
+}
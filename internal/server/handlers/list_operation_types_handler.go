@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type ListOperationTypesHandler struct {
+	processor processors.ListOperationTypesProcessorInterface
+}
+
+func NewListOperationTypesHandler(processor processors.ListOperationTypesProcessorInterface) *ListOperationTypesHandler {
+	return &ListOperationTypesHandler{
+		processor: processor,
+	}
+}
+
+func (h *ListOperationTypesHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	response, err := h.processor.Process(r.Context())
+	if err != nil {
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to retrieve operation types")
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusOK, response)
+}
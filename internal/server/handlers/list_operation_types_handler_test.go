@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListOperationTypesHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(*mocks.MockListOperationTypesProcessorInterface)
+		expectedStatus int
+		validateResp   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "returns operation types",
+			setupMock: func(mockProc *mocks.MockListOperationTypesProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything).
+					Return(&domain.ListOperationTypesResponse{
+						OperationTypes: []*domain.OperationType{
+							{ID: 1, Description: "COMPRA A VISTA"},
+							{ID: 4, Description: "PAGAMENTO"},
+						},
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var result domain.ListOperationTypesResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+				assert.Len(t, result.OperationTypes, 2)
+				assert.Equal(t, "COMPRA A VISTA", result.OperationTypes[0].Description)
+			},
+		},
+		{
+			name: "returns all seeded operation types",
+			setupMock: func(mockProc *mocks.MockListOperationTypesProcessorInterface) {
+				seeded, err := domain.OperationTypes(domain.DefaultLocale)
+				require.NoError(t, err)
+				mockProc.EXPECT().
+					Process(mock.Anything).
+					Return(&domain.ListOperationTypesResponse{OperationTypes: seeded}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var result domain.ListOperationTypesResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+				require.Len(t, result.OperationTypes, 4)
+
+				expected := []struct {
+					id          int64
+					description string
+					isCredit    bool
+				}{
+					{domain.OperationTypePurchase, "Normal Purchase", false},
+					{domain.OperationTypePurchaseWithInstallments, "Purchase with installments", false},
+					{domain.OperationTypeWithdrawal, "Withdrawal", false},
+					{domain.OperationTypeCreditVoucher, "Credit Voucher", true},
+				}
+				for i, want := range expected {
+					assert.Equal(t, want.id, result.OperationTypes[i].ID)
+					assert.Equal(t, want.description, result.OperationTypes[i].Description)
+					assert.Equal(t, want.isCredit, result.OperationTypes[i].IsCredit)
+				}
+			},
+		},
+		{
+			name: "internal server error",
+			setupMock: func(mockProc *mocks.MockListOperationTypesProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything).
+					Return(nil, errors.New("database error")).
+					Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Failed to retrieve operation types")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockListOperationTypesProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewListOperationTypesHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/operation-types", nil)
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateResp != nil {
+				tt.validateResp(t, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type ListTransactionsHandler struct {
+	processor  processors.ListTransactionsProcessorInterface
+	pagination domain.PaginationDefaults
+}
+
+// NewListTransactionsHandler creates the admin listing handler; pages are bounded by the shared pagination limits
+func NewListTransactionsHandler(processor processors.ListTransactionsProcessorInterface, pagination domain.PaginationDefaults) *ListTransactionsHandler {
+	return &ListTransactionsHandler{
+		processor:  processor,
+		pagination: pagination,
+	}
+}
+
+// Handle lists the transactions of every account, newest first, one page at a time
+func (h *ListTransactionsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePaginationParams(w, r)
+	if !ok {
+		return
+	}
+	limit, offset = domain.NormalizePagination(limit, offset, h.pagination)
+
+	response, err := h.processor.Process(r.Context(), domain.ListTransactionsRequest{Limit: limit, Offset: offset})
+	if err != nil {
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to list transactions")
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusOK, response)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTransactionsHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		setupMock      func(*mocks.MockListTransactionsProcessorInterface)
+		expectedStatus int
+		validateResp   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "paginates the listing",
+			queryParams: "?limit=2&offset=2",
+			setupMock: func(mockProc *mocks.MockListTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ListTransactionsRequest{Limit: 2, Offset: 2}).
+					Return(&domain.ListTransactionsResponse{
+						Transactions: []*domain.Transaction{{ID: 3, AccountID: 2}, {ID: 2, AccountID: 1}},
+						Pagination:   domain.PaginationMetadata{Total: 5, Limit: 2, Offset: 2, Pages: 3},
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var result domain.ListTransactionsResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+				assert.Len(t, result.Transactions, 2)
+				assert.Equal(t, domain.PaginationMetadata{Total: 5, Limit: 2, Offset: 2, Pages: 3}, result.Pagination)
+			},
+		},
+		{
+			name:        "default page size",
+			queryParams: "",
+			setupMock: func(mockProc *mocks.MockListTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ListTransactionsRequest{Limit: 50}).
+					Return(&domain.ListTransactionsResponse{Transactions: []*domain.Transaction{}}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "limit above the maximum is capped",
+			queryParams: "?limit=10000",
+			setupMock: func(mockProc *mocks.MockListTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ListTransactionsRequest{Limit: 100}).
+					Return(&domain.ListTransactionsResponse{Transactions: []*domain.Transaction{}}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid offset",
+			queryParams:    "?offset=-1",
+			setupMock:      func(mockProc *mocks.MockListTransactionsProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Invalid offset")
+			},
+		},
+		{
+			name:        "internal server error",
+			queryParams: "",
+			setupMock: func(mockProc *mocks.MockListTransactionsProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, mock.Anything).
+					Return(nil, errors.New("database error")).
+					Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockListTransactionsProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewListTransactionsHandler(mockProc, domain.DefaultPagination())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/transactions"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateResp != nil {
+				tt.validateResp(t, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type OperationTypeDirectionsHandler struct {
+	processor processors.OperationTypeDirectionsProcessorInterface
+}
+
+func NewOperationTypeDirectionsHandler(processor processors.OperationTypeDirectionsProcessorInterface) *OperationTypeDirectionsHandler {
+	return &OperationTypeDirectionsHandler{
+		processor: processor,
+	}
+}
+
+// Handle maps each operation type ID to "debit" or "credit", so clients can label amounts
+func (h *OperationTypeDirectionsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	directions, err := h.processor.Directions(r.Context())
+	if err != nil {
+		respondWithError(w, r, http.StatusInternalServerError, "Failed to retrieve operation type directions")
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusOK, directions)
+}
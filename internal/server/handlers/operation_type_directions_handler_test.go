@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOperationTypeDirectionsHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(*mocks.MockOperationTypeDirectionsProcessorInterface)
+		expectedStatus int
+		validateResp   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "returns the direction map",
+			setupMock: func(mockProc *mocks.MockOperationTypeDirectionsProcessorInterface) {
+				mockProc.EXPECT().
+					Directions(mock.Anything).
+					Return(map[int64]string{1: "debit", 2: "debit", 3: "debit", 4: "credit"}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var result map[string]string
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+				assert.Equal(t, map[string]string{"1": "debit", "2": "debit", "3": "debit", "4": "credit"}, result)
+			},
+		},
+		{
+			name: "internal server error",
+			setupMock: func(mockProc *mocks.MockOperationTypeDirectionsProcessorInterface) {
+				mockProc.EXPECT().
+					Directions(mock.Anything).
+					Return(nil, errors.New("database error")).
+					Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Failed to retrieve operation type directions")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockOperationTypeDirectionsProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewOperationTypeDirectionsHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/operation-types/directions", nil)
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateResp != nil {
+				tt.validateResp(t, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// parseIntQueryParam reads an optional integer query parameter
+// An absent or empty value yields def; a present value that is not an integer of at least min is reported as invalid
+func parseIntQueryParam(r *http.Request, name string, def, min int64) (int64, bool) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return def, true
+	}
+
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || value < min {
+		return 0, false
+	}
+	return value, true
+}
+
+// parsePaginationParams reads limit and offset with the same rules for both:
+// empty means "use the default", anything present must be a valid value or the request is rejected
+// A zero limit stands for "use the default page size" and is left to NormalizePagination
+func parsePaginationParams(w http.ResponseWriter, r *http.Request) (limit, offset int64, ok bool) {
+	limit, ok = parseIntQueryParam(r, "limit", 0, 1)
+	if !ok {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid limit")
+		return 0, 0, false
+	}
+
+	offset, ok = parseIntQueryParam(r, "offset", 0, 0)
+	if !ok {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid offset")
+		return 0, 0, false
+	}
+
+	return limit, offset, true
+}
+
+// parseAccountIDParam reads the accountId URL parameter as an int64
+// Parsing with a fixed bit size keeps large IDs working the same on 32- and 64-bit platforms
+func parseAccountIDParam(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "accountId"), 10, 64)
+}
+
+// parseTransactionIDParam reads the transactionId URL parameter as an int64
+func parseTransactionIDParam(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "transactionId"), 10, 64)
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type ReadinessHandler struct {
+	processor processors.ReadinessProcessorInterface
+}
+
+func NewReadinessHandler(processor processors.ReadinessProcessorInterface) *ReadinessHandler {
+	return &ReadinessHandler{
+		processor: processor,
+	}
+}
+
+// Handle reports every readiness check; degraded still answers 200 so the instance keeps receiving traffic,
+// while unhealthy answers 503 so orchestrators take it out of rotation
+func (h *ReadinessHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	report, err := h.processor.Process(r.Context())
+	if err != nil {
+		respondWithError(w, r, http.StatusServiceUnavailable, "Failed to check readiness")
+		return
+	}
+
+	status := http.StatusOK
+	if report.Status == domain.HealthStatusUnhealthy {
+		status = http.StatusServiceUnavailable
+	}
+
+	respondWithJSON(w, r, status, report)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReadinessHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(*mocks.MockReadinessProcessorInterface)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "healthy",
+			setupMock: func(mockProc *mocks.MockReadinessProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything).Return(domain.NewReadinessReport([]domain.HealthCheck{
+					{Name: "database", Status: domain.HealthStatusHealthy},
+					{Name: "migrations", Status: domain.HealthStatusHealthy, Detail: "version 10 of 10"},
+				}), nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"status": "healthy", "checks": [
+				{"name": "database", "status": "healthy"},
+				{"name": "migrations", "status": "healthy", "detail": "version 10 of 10"}
+			]}`,
+		},
+		{
+			name: "degraded keeps serving",
+			setupMock: func(mockProc *mocks.MockReadinessProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything).Return(domain.NewReadinessReport([]domain.HealthCheck{
+					{Name: "database", Status: domain.HealthStatusHealthy},
+					{Name: "wal", Status: domain.HealthStatusDegraded, Detail: "20 bytes, above 10"},
+				}), nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"status": "degraded", "checks": [
+				{"name": "database", "status": "healthy"},
+				{"name": "wal", "status": "degraded", "detail": "20 bytes, above 10"}
+			]}`,
+		},
+		{
+			name: "unhealthy",
+			setupMock: func(mockProc *mocks.MockReadinessProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything).Return(domain.NewReadinessReport([]domain.HealthCheck{
+					{Name: "database", Status: domain.HealthStatusUnhealthy, Detail: "sql: database is closed"},
+				}), nil).Once()
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: `{"status": "unhealthy", "checks": [
+				{"name": "database", "status": "unhealthy", "detail": "sql: database is closed"}
+			]}`,
+		},
+		{
+			name: "processor error",
+			setupMock: func(mockProc *mocks.MockReadinessProcessorInterface) {
+				mockProc.EXPECT().Process(mock.Anything).Return(nil, errors.New("boom")).Once()
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"error": "Service Unavailable", "message": "Failed to check readiness"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockReadinessProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewReadinessHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodGet, "/health/ready", nil)
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
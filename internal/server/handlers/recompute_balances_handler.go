@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type RecomputeBalancesHandler struct {
+	processor processors.RecomputeBalancesProcessorInterface
+}
+
+func NewRecomputeBalancesHandler(processor processors.RecomputeBalancesProcessorInterface) *RecomputeBalancesHandler {
+	return &RecomputeBalancesHandler{
+		processor: processor,
+	}
+}
+
+// Handle rebuilds the account balances cache; after_account_id resumes an interrupted run
+func (h *RecomputeBalancesHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	var req domain.RecomputeBalancesRequest
+
+	if afterStr := r.URL.Query().Get("after_account_id"); afterStr != "" {
+		after, err := strconv.ParseInt(afterStr, 10, 64)
+		if err != nil || after < 0 {
+			respondWithError(w, r, http.StatusBadRequest, "Invalid after_account_id")
+			return
+		}
+		req.AfterAccountID = after
+	}
+
+	if batchStr := r.URL.Query().Get("batch_size"); batchStr != "" {
+		batchSize, err := strconv.ParseInt(batchStr, 10, 64)
+		if err != nil || batchSize <= 0 || batchSize > domain.MaxRecomputeBatchSize {
+			respondWithError(w, r, http.StatusBadRequest, fmt.Sprintf("batch_size must be between 1 and %d", domain.MaxRecomputeBatchSize))
+			return
+		}
+		req.BatchSize = batchSize
+	}
+
+	response, err := h.processor.Process(r.Context(), req)
+	if err != nil {
+		log.Printf("balance recompute failed: %v", err)
+
+		// Tell the operator where to resume instead of starting over
+		message := "Failed to recompute balances"
+		if response != nil {
+			message = fmt.Sprintf("%s; resume with after_account_id=%d", message, response.LastAccountID)
+		}
+		respondWithError(w, r, http.StatusInternalServerError, message)
+		return
+	}
+
+	log.Printf("recomputed balances of %d accounts in %d batches (last account %d)", response.AccountsProcessed, response.Batches, response.LastAccountID)
+	respondWithJSON(w, r, http.StatusOK, response)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRecomputeBalancesHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		setupMock      func(*mocks.MockRecomputeBalancesProcessorInterface)
+		expectedStatus int
+		validateResp   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "recomputes every account",
+			queryParams: "",
+			setupMock: func(mockProc *mocks.MockRecomputeBalancesProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.RecomputeBalancesRequest{}).
+					Return(&domain.RecomputeBalancesResponse{AccountsProcessed: 1200, Batches: 3, LastAccountID: 1200}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"accounts_processed":1200,"batches":3,"last_account_id":1200}`, w.Body.String())
+			},
+		},
+		{
+			name:        "resumes with a cursor and batch size",
+			queryParams: "?after_account_id=500&batch_size=100",
+			setupMock: func(mockProc *mocks.MockRecomputeBalancesProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.RecomputeBalancesRequest{AfterAccountID: 500, BatchSize: 100}).
+					Return(&domain.RecomputeBalancesResponse{AccountsProcessed: 10, Batches: 1, LastAccountID: 510}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid cursor",
+			queryParams:    "?after_account_id=-1",
+			setupMock:      func(mockProc *mocks.MockRecomputeBalancesProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Invalid after_account_id")
+			},
+		},
+		{
+			name:           "batch size above the maximum",
+			queryParams:    "?batch_size=5001",
+			setupMock:      func(mockProc *mocks.MockRecomputeBalancesProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "batch_size must be between 1 and 5000")
+			},
+		},
+		{
+			name:        "failure tells where to resume",
+			queryParams: "",
+			setupMock: func(mockProc *mocks.MockRecomputeBalancesProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.RecomputeBalancesRequest{}).
+					Return(&domain.RecomputeBalancesResponse{AccountsProcessed: 500, Batches: 1, LastAccountID: 500}, errors.New("disk I/O error")).
+					Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "resume with after_account_id=500")
+				assert.NotContains(t, w.Body.String(), "disk I/O error")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockRecomputeBalancesProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewRecomputeBalancesHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/recompute-balances"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateResp != nil {
+				tt.validateResp(t, w)
+			}
+		})
+	}
+}
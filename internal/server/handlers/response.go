@@ -1,35 +1,118 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	customMiddleware "github.com/larissamartinsss/simple-banking-api/internal/server/middleware"
 )
 
+// ErrorResponse is the body of every error response
+// RequestID echoes the ID assigned by the RequestID middleware, so clients can quote it in support tickets
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
+	Error     string       `json:"error"`
+	Message   string       `json:"message,omitempty"`
+	Fields    []FieldError `json:"fields,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
 }
 
 // respondWithError sends an error response
-func respondWithError(w http.ResponseWriter, code int, message string) {
-	respondWithJSON(w, code, ErrorResponse{
-		Error:   http.StatusText(code),
-		Message: message,
+func respondWithError(w http.ResponseWriter, r *http.Request, code int, message string) {
+	respondWithJSON(w, r, code, ErrorResponse{
+		Error:     http.StatusText(code),
+		Message:   message,
+		RequestID: middleware.GetReqID(r.Context()),
 	})
 }
 
-// respondWithJSON sends a JSON response
-func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+// respondWithValidationError sends a 400 listing every field-level violation when available
+func respondWithValidationError(w http.ResponseWriter, r *http.Request, err error) {
+	var validationErrs ValidationErrors
+	if errors.As(err, &validationErrs) {
+		respondWithJSON(w, r, http.StatusBadRequest, ErrorResponse{
+			Error:     http.StatusText(http.StatusBadRequest),
+			Message:   validationErrs.Error(),
+			Fields:    validationErrs,
+			RequestID: middleware.GetReqID(r.Context()),
+		})
+		return
+	}
+	respondWithError(w, r, http.StatusBadRequest, err.Error())
+}
+
+// serviceUnavailableRetryAfter is the Retry-After (in seconds) sent when the database is saturated
+const serviceUnavailableRetryAfter = "1"
+
+// respondWithServiceUnavailable asks the client to back off and retry later
+func respondWithServiceUnavailable(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Retry-After", serviceUnavailableRetryAfter)
+	respondWithError(w, r, http.StatusServiceUnavailable, domain.ErrServiceUnavailable.Error())
+}
+
+// prettyQueryParam asks for indented JSON (e.g. ?pretty=true), handy when debugging with curl
+const prettyQueryParam = "pretty"
+
+// wantsPrettyJSON reports whether the request asked for indented JSON; an invalid value keeps it compact
+func wantsPrettyJSON(r *http.Request) bool {
+	pretty, err := strconv.ParseBool(r.URL.Query().Get(prettyQueryParam))
+	return err == nil && pretty
+}
+
+// errResponseTooLarge aborts a response whose body exceeds the size set by middleware.MaxResponseBytes
+var errResponseTooLarge = errors.New("response too large")
+
+// limitedBuffer is a bytes.Buffer that refuses writes taking it past max bytes (0 for no limit)
+type limitedBuffer struct {
+	bytes.Buffer
+	max int64
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.max > 0 && int64(b.Len()+len(p)) > b.max {
+		return 0, errResponseTooLarge
+	}
+	return b.Buffer.Write(p)
+}
+
+// respondWithJSON sends a JSON response, compact unless the request asked for ?pretty=true
+// The payload is encoded before the status is written, so an encoding failure is still sent as a 500,
+// as is a body larger than the maximum set by middleware.MaxResponseBytes
+func respondWithJSON(w http.ResponseWriter, r *http.Request, code int, payload interface{}) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
 
-	if payload != nil {
-		if err := json.NewEncoder(w).Encode(payload); err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(ErrorResponse{
-				Error:   "Internal Server Error",
-				Message: "Failed to encode response",
-			})
+	if payload == nil {
+		w.WriteHeader(code)
+		return
+	}
+
+	body := &limitedBuffer{max: customMiddleware.GetMaxResponseBytes(r.Context())}
+	encoder := json.NewEncoder(body)
+	if wantsPrettyJSON(r) {
+		encoder.SetIndent("", "  ")
+	}
+	if err := encoder.Encode(payload); err != nil {
+		message := "Failed to encode response"
+		if errors.Is(err, errResponseTooLarge) {
+			log.Printf("response to %s %s exceeds the maximum of %d bytes (request_id=%s)", r.Method, r.URL.Path, body.max, middleware.GetReqID(r.Context()))
+			message = fmt.Sprintf("Response exceeds the maximum size of %d bytes; request fewer items", body.max)
 		}
+		// Written directly, since the error body could itself exceed a very small maximum
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   message,
+			RequestID: middleware.GetReqID(r.Context()),
+		})
+		return
 	}
+
+	w.WriteHeader(code)
+	w.Write(body.Bytes())
 }
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	customMiddleware "github.com/larissamartinsss/simple-banking-api/internal/server/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRespondWithError_IncludesRequestID(t *testing.T) {
+	handler := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respondWithError(w, r, http.StatusNotFound, "account not found")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/accounts/1", nil)
+	req.Header.Set(middleware.RequestIDHeader, "support-ticket-42")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	var body ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "support-ticket-42", body.RequestID)
+	assert.Equal(t, "account not found", body.Message)
+}
+
+func TestRespondWithError_GeneratedRequestID(t *testing.T) {
+	var assigned string
+	handler := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assigned = middleware.GetReqID(r.Context())
+		respondWithValidationError(w, r, ValidationErrors{{Field: "amount", Message: "amount is required"}})
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/transactions", nil))
+
+	var body ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.NotEmpty(t, assigned)
+	assert.Equal(t, assigned, body.RequestID)
+}
+
+func TestRespondWithError_OmitsMissingRequestID(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	respondWithError(w, httptest.NewRequest(http.MethodGet, "/", nil), http.StatusBadRequest, "bad")
+
+	assert.NotContains(t, w.Body.String(), "request_id")
+}
+
+func TestRespondWithJSON_PrettyPrinting(t *testing.T) {
+	payload := map[string]any{"id": 1, "document_number": "12345678900"}
+
+	tests := []struct {
+		name     string
+		query    string
+		wantBody string
+	}{
+		{
+			name:     "compact by default",
+			query:    "",
+			wantBody: "{\"document_number\":\"12345678900\",\"id\":1}\n",
+		},
+		{
+			name:     "indented when requested",
+			query:    "?pretty=true",
+			wantBody: "{\n  \"document_number\": \"12345678900\",\n  \"id\": 1\n}\n",
+		},
+		{
+			name:     "compact when disabled",
+			query:    "?pretty=false",
+			wantBody: "{\"document_number\":\"12345678900\",\"id\":1}\n",
+		},
+		{
+			name:     "compact on an invalid value",
+			query:    "?pretty=please",
+			wantBody: "{\"document_number\":\"12345678900\",\"id\":1}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			respondWithJSON(w, httptest.NewRequest(http.MethodGet, "/v1/accounts/1"+tt.query, nil), http.StatusOK, payload)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func TestRespondWithJSON_EncodingFailure(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	respondWithJSON(w, httptest.NewRequest(http.MethodGet, "/", nil), http.StatusOK, map[string]any{"bad": make(chan int)})
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "Failed to encode response")
+}
+
+func TestRespondWithJSON_MaxResponseBytes(t *testing.T) {
+	payload := map[string]any{"items": []string{"a long enough item", "another long enough item"}}
+
+	tests := []struct {
+		name       string
+		maxBytes   int64
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "body within the maximum", maxBytes: 1024, wantStatus: http.StatusOK, wantBody: `"another long enough item"`},
+		{name: "body over the maximum", maxBytes: 32, wantStatus: http.StatusInternalServerError, wantBody: "Response exceeds the maximum size of 32 bytes"},
+		{name: "no maximum", maxBytes: 0, wantStatus: http.StatusOK, wantBody: `"a long enough item"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := customMiddleware.MaxResponseBytes(tt.maxBytes)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				respondWithJSON(w, r, http.StatusOK, payload)
+			}))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/admin/transactions", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+		})
+	}
+}
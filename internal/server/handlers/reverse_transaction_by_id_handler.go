@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type ReverseTransactionByIDHandler struct {
+	processor processors.ReverseTransactionProcessorInterface
+}
+
+func NewReverseTransactionByIDHandler(processor processors.ReverseTransactionProcessorInterface) *ReverseTransactionByIDHandler {
+	return &ReverseTransactionByIDHandler{
+		processor: processor,
+	}
+}
+
+// Handle reverses the transaction identified by the transactionId URL parameter
+func (h *ReverseTransactionByIDHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	transactionID, err := parseTransactionIDParam(r)
+	if err != nil || transactionID <= 0 {
+		respondWithError(w, r, http.StatusBadRequest, "Invalid transaction ID")
+		return
+	}
+
+	response, err := h.processor.ProcessByID(r.Context(), transactionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTransactionNotFound), errors.Is(err, domain.ErrAccountNotFound):
+			respondWithError(w, r, http.StatusNotFound, err.Error())
+		case errors.Is(err, domain.ErrTransactionAlreadyReversed), errors.Is(err, domain.ErrBalanceChanged):
+			respondWithError(w, r, http.StatusConflict, err.Error())
+		case errors.Is(err, domain.ErrInsufficientLimit):
+			respondWithError(w, r, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, domain.ErrReversalNotReversible):
+			respondWithError(w, r, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, domain.ErrServiceUnavailable):
+			respondWithServiceUnavailable(w, r)
+		default:
+			respondWithError(w, r, http.StatusInternalServerError, "Failed to reverse transaction")
+		}
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusCreated, response)
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReverseTransactionByIDHandler_Handle(t *testing.T) {
+	eventDate := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		transactionID  string
+		setupMock      func(*mocks.MockReverseTransactionProcessorInterface)
+		expectedStatus int
+		validateResp   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:          "reverses the transaction",
+			transactionID: "10",
+			setupMock: func(mockProc *mocks.MockReverseTransactionProcessorInterface) {
+				mockProc.EXPECT().
+					ProcessByID(mock.Anything, int64(10)).
+					Return(&domain.ReverseTransactionResponse{
+						CreateTransactionResponse: domain.CreateTransactionResponse{
+							TransactionID:   11,
+							AccountID:       1,
+							OperationTypeID: 1,
+							Amount:          50.0,
+							EventDate:       eventDate,
+						},
+						ReversedTransactionID: 10,
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusCreated,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{
+					"transaction_id": 11,
+					"account_id": 1,
+					"operation_type_id": 1,
+					"amount": 50,
+					"event_date": "2025-01-02T10:00:00Z",
+					"reversed_transaction_id": 10
+				}`, w.Body.String())
+			},
+		},
+		{
+			name:          "unknown transaction",
+			transactionID: "999",
+			setupMock: func(mockProc *mocks.MockReverseTransactionProcessorInterface) {
+				mockProc.EXPECT().ProcessByID(mock.Anything, int64(999)).Return(nil, domain.ErrTransactionNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), domain.ErrTransactionNotFound.Error())
+			},
+		},
+		{
+			name:          "closed account",
+			transactionID: "10",
+			setupMock: func(mockProc *mocks.MockReverseTransactionProcessorInterface) {
+				mockProc.EXPECT().ProcessByID(mock.Anything, int64(10)).Return(nil, domain.ErrAccountNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), domain.ErrAccountNotFound.Error())
+			},
+		},
+		{
+			name:          "credit reversal over the credit limit",
+			transactionID: "10",
+			setupMock: func(mockProc *mocks.MockReverseTransactionProcessorInterface) {
+				mockProc.EXPECT().ProcessByID(mock.Anything, int64(10)).Return(nil, domain.ErrInsufficientLimit).Once()
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), domain.ErrInsufficientLimit.Error())
+			},
+		},
+		{
+			name:          "already reversed",
+			transactionID: "10",
+			setupMock: func(mockProc *mocks.MockReverseTransactionProcessorInterface) {
+				mockProc.EXPECT().ProcessByID(mock.Anything, int64(10)).Return(nil, domain.ErrTransactionAlreadyReversed).Once()
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:          "reversal of a reversal",
+			transactionID: "11",
+			setupMock: func(mockProc *mocks.MockReverseTransactionProcessorInterface) {
+				mockProc.EXPECT().ProcessByID(mock.Anything, int64(11)).Return(nil, domain.ErrReversalNotReversible).Once()
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "invalid transaction ID",
+			transactionID:  "abc",
+			setupMock:      func(mockProc *mocks.MockReverseTransactionProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "non-positive transaction ID",
+			transactionID:  "0",
+			setupMock:      func(mockProc *mocks.MockReverseTransactionProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "processor error",
+			transactionID: "10",
+			setupMock: func(mockProc *mocks.MockReverseTransactionProcessorInterface) {
+				mockProc.EXPECT().ProcessByID(mock.Anything, int64(10)).Return(nil, errors.New("db down")).Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockReverseTransactionProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewReverseTransactionByIDHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions/"+tt.transactionID+"/reversal", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("transactionId", tt.transactionID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateResp != nil {
+				tt.validateResp(t, w)
+			}
+		})
+	}
+}
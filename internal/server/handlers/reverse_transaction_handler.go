@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors"
+)
+
+type ReverseTransactionHandler struct {
+	processor processors.ReverseTransactionProcessorInterface
+}
+
+func NewReverseTransactionHandler(processor processors.ReverseTransactionProcessorInterface) *ReverseTransactionHandler {
+	return &ReverseTransactionHandler{
+		processor: processor,
+	}
+}
+
+// Handle reverses the transaction the account created with the given idempotency key
+func (h *ReverseTransactionHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	var req domain.ReverseTransactionRequest
+
+	if err := decodeJSON(w, r, &req); err != nil {
+		respondWithDecodeError(w, r, err)
+		return
+	}
+
+	if err := validateStruct(req); err != nil {
+		respondWithValidationError(w, r, err)
+		return
+	}
+
+	response, err := h.processor.Process(r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrIdempotencyKeyNotFound), errors.Is(err, domain.ErrAccountNotFound):
+			respondWithError(w, r, http.StatusNotFound, err.Error())
+		case errors.Is(err, domain.ErrTransactionAlreadyReversed), errors.Is(err, domain.ErrBalanceChanged):
+			respondWithError(w, r, http.StatusConflict, err.Error())
+		case errors.Is(err, domain.ErrInsufficientLimit):
+			respondWithError(w, r, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, domain.ErrServiceUnavailable):
+			respondWithServiceUnavailable(w, r)
+		default:
+			respondWithError(w, r, http.StatusInternalServerError, "Failed to reverse transaction")
+		}
+		return
+	}
+
+	respondWithJSON(w, r, http.StatusCreated, response)
+}
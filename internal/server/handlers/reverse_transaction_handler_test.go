@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReverseTransactionHandler_Handle(t *testing.T) {
+	eventDate := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*mocks.MockReverseTransactionProcessorInterface)
+		expectedStatus int
+		validateResp   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "known key",
+			body: `{"account_id": 1, "idempotency_key": "key-1"}`,
+			setupMock: func(mockProc *mocks.MockReverseTransactionProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ReverseTransactionRequest{AccountID: 1, IdempotencyKey: "key-1"}).
+					Return(&domain.ReverseTransactionResponse{
+						CreateTransactionResponse: domain.CreateTransactionResponse{
+							TransactionID:   11,
+							AccountID:       1,
+							OperationTypeID: 1,
+							Amount:          50.0,
+							EventDate:       eventDate,
+						},
+						ReversedTransactionID: 10,
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusCreated,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{
+					"transaction_id": 11,
+					"account_id": 1,
+					"operation_type_id": 1,
+					"amount": 50,
+					"event_date": "2025-01-02T10:00:00Z",
+					"reversed_transaction_id": 10
+				}`, w.Body.String())
+			},
+		},
+		{
+			name: "unknown key",
+			body: `{"account_id": 1, "idempotency_key": "missing"}`,
+			setupMock: func(mockProc *mocks.MockReverseTransactionProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ReverseTransactionRequest{AccountID: 1, IdempotencyKey: "missing"}).
+					Return(nil, domain.ErrIdempotencyKeyNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), domain.ErrIdempotencyKeyNotFound.Error())
+			},
+		},
+		{
+			name: "closed account",
+			body: `{"account_id": 1, "idempotency_key": "key-1"}`,
+			setupMock: func(mockProc *mocks.MockReverseTransactionProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, domain.ReverseTransactionRequest{AccountID: 1, IdempotencyKey: "key-1"}).
+					Return(nil, domain.ErrAccountNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResp: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), domain.ErrAccountNotFound.Error())
+			},
+		},
+		{
+			name: "already reversed",
+			body: `{"account_id": 1, "idempotency_key": "key-1"}`,
+			setupMock: func(mockProc *mocks.MockReverseTransactionProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, mock.Anything).
+					Return(nil, domain.ErrTransactionAlreadyReversed).
+					Once()
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "missing idempotency key",
+			body:           `{"account_id": 1}`,
+			setupMock:      func(mockProc *mocks.MockReverseTransactionProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid account ID",
+			body:           `{"account_id": 0, "idempotency_key": "key-1"}`,
+			setupMock:      func(mockProc *mocks.MockReverseTransactionProcessorInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "processor error",
+			body: `{"account_id": 1, "idempotency_key": "key-1"}`,
+			setupMock: func(mockProc *mocks.MockReverseTransactionProcessorInterface) {
+				mockProc.EXPECT().
+					Process(mock.Anything, mock.Anything).
+					Return(nil, errors.New("db down")).
+					Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProc := mocks.NewMockReverseTransactionProcessorInterface(t)
+			tt.setupMock(mockProc)
+
+			handler := NewReverseTransactionHandler(mockProc)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions/reverse-by-key", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateResp != nil {
+				tt.validateResp(t, w)
+			}
+		})
+	}
+}
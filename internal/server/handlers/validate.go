@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// digitsPattern backs the "numeric" rule
+var digitsPattern = regexp.MustCompile(`^\d+$`)
+
+// FieldError describes a single struct tag rule violated by a request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors lists every field that failed its `validate` tag
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, len(v))
+	for i, fieldErr := range v {
+		messages[i] = fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// validateStruct checks the simple, declarative rules in `validate` struct tags
+// Supported rules: required, numeric, len=N|M (string length), gt, gte, lt, lte (numbers)
+// Each field reports its first violated rule; fields are named after their json tag
+// Complex rules (formats, cross-field checks) stay in the domain Validate methods
+func validateStruct(s interface{}) error {
+	value := reflect.Indirect(reflect.ValueOf(s))
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("validateStruct: expected a struct, got %s", value.Kind())
+	}
+
+	var errs ValidationErrors
+	structType := value.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag := field.Tag.Get("validate")
+		if tag == "" || !field.IsExported() {
+			continue
+		}
+
+		if fieldErr := validateField(jsonFieldName(field), value.Field(i), strings.Split(tag, ",")); fieldErr != nil {
+			errs = append(errs, *fieldErr)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func validateField(name string, value reflect.Value, rules []string) *FieldError {
+	// Optional fields are only validated when present
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			if hasRule(rules, "required") {
+				return &FieldError{Field: name, Rule: "required", Message: name + " is required"}
+			}
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	for _, rule := range rules {
+		ruleName, param, _ := strings.Cut(rule, "=")
+
+		var ok bool
+		var message string
+
+		switch ruleName {
+		case "required":
+			ok = !value.IsZero()
+			message = name + " is required"
+		case "numeric":
+			ok = value.Kind() != reflect.String || value.Len() == 0 || digitsPattern.MatchString(value.String())
+			message = name + " must contain only digits"
+		case "len":
+			ok = lengthMatches(value, strings.Split(param, "|"))
+			message = fmt.Sprintf("%s must have %s characters", name, strings.Join(strings.Split(param, "|"), " or "))
+		case "gt", "gte", "lt", "lte":
+			ok = compareNumber(value, ruleName, param)
+			message = boundMessage(name, ruleName, param, rules)
+		default:
+			panic(fmt.Sprintf("validateStruct: unknown rule %q on field %s", ruleName, name))
+		}
+
+		if !ok {
+			return &FieldError{Field: name, Rule: rule, Message: message}
+		}
+	}
+
+	return nil
+}
+
+func lengthMatches(value reflect.Value, lengths []string) bool {
+	for _, l := range lengths {
+		if n, err := strconv.Atoi(l); err == nil && value.Len() == n {
+			return true
+		}
+	}
+	return false
+}
+
+func compareNumber(value reflect.Value, op, param string) bool {
+	bound, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		panic(fmt.Sprintf("validateStruct: invalid bound %q", param))
+	}
+
+	var n float64
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		n = value.Float()
+	default:
+		panic(fmt.Sprintf("validateStruct: %s is not a number", value.Kind()))
+	}
+
+	switch op {
+	case "gt":
+		return n > bound
+	case "gte":
+		return n >= bound
+	case "lt":
+		return n < bound
+	default:
+		return n <= bound
+	}
+}
+
+// boundMessage describes a numeric bound, merging gte and lte into a single range
+func boundMessage(name, op, param string, rules []string) string {
+	lower, hasLower := ruleParam(rules, "gte")
+	upper, hasUpper := ruleParam(rules, "lte")
+	if hasLower && hasUpper {
+		return fmt.Sprintf("%s must be between %s and %s", name, lower, upper)
+	}
+
+	switch op {
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", name, param)
+	case "gte":
+		return fmt.Sprintf("%s must be greater than or equal to %s", name, param)
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s", name, param)
+	default:
+		return fmt.Sprintf("%s must be less than or equal to %s", name, param)
+	}
+}
+
+func hasRule(rules []string, name string) bool {
+	_, ok := ruleParam(rules, name)
+	return ok
+}
+
+func ruleParam(rules []string, name string) (string, bool) {
+	for _, rule := range rules {
+		ruleName, param, _ := strings.Cut(rule, "=")
+		if ruleName == name {
+			return param, true
+		}
+	}
+	return "", false
+}
+
+// jsonFieldName returns the field's JSON name so errors match the request body
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+)
+
+func TestValidateStruct(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          interface{}
+		expectedFields []FieldError
+	}{
+		{
+			name:  "valid account request",
+			input: domain.CreateAccountRequest{DocumentNumber: "12345678900"},
+		},
+		{
+			name:  "valid cnpj account request",
+			input: &domain.CreateAccountRequest{DocumentNumber: "12345678000190"},
+		},
+		{
+			name:  "missing document number",
+			input: domain.CreateAccountRequest{},
+			expectedFields: []FieldError{
+				{Field: "document_number", Rule: "required", Message: "document_number is required"},
+			},
+		},
+		{
+			name:  "document number with unsupported length",
+			input: domain.CreateAccountRequest{DocumentNumber: "1234567890123"},
+			expectedFields: []FieldError{
+				{Field: "document_number", Rule: "len=11|14", Message: "document_number must have 11 or 14 characters"},
+			},
+		},
+		{
+			name:  "document number with letters",
+			input: domain.CreateAccountRequest{DocumentNumber: "1234567890a"},
+			expectedFields: []FieldError{
+				{Field: "document_number", Rule: "numeric", Message: "document_number must contain only digits"},
+			},
+		},
+		{
+			name:  "valid transaction request",
+			input: domain.CreateTransactionRequest{AccountID: 1, OperationTypeID: 4, Amount: 10},
+		},
+		{
+			name:  "every invalid transaction field is reported",
+			input: domain.CreateTransactionRequest{AccountID: 0, OperationTypeID: 0, Amount: 10},
+			expectedFields: []FieldError{
+				{Field: "account_id", Rule: "gt=0", Message: "account_id must be greater than 0"},
+				{Field: "operation_type_id", Rule: "gt=0", Message: "operation_type_id must be greater than 0"},
+			},
+		},
+		{
+			name:  "negative operation type",
+			input: domain.CreateTransactionRequest{AccountID: 1, OperationTypeID: -1, Amount: 10},
+			expectedFields: []FieldError{
+				{Field: "operation_type_id", Rule: "gt=0", Message: "operation_type_id must be greater than 0"},
+			},
+		},
+		{
+			name:  "operation types beyond the seeded ones are left to the processor",
+			input: domain.CreateTransactionRequest{AccountID: 1, OperationTypeID: 5, Amount: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStruct(tt.input)
+
+			if tt.expectedFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErrs ValidationErrors
+			require.ErrorAs(t, err, &validationErrs)
+			assert.Equal(t, tt.expectedFields, []FieldError(validationErrs))
+		})
+	}
+}
+
+func TestValidateStruct_OptionalPointerFields(t *testing.T) {
+	type request struct {
+		Limit *int64  `json:"limit" validate:"gt=0"`
+		Name  *string `json:"name" validate:"required"`
+	}
+
+	zero := int64(0)
+	name := "x"
+
+	err := validateStruct(request{Name: &name})
+	assert.NoError(t, err, "nil optional field should be skipped")
+
+	err = validateStruct(request{Limit: &zero})
+	var validationErrs ValidationErrors
+	require.ErrorAs(t, err, &validationErrs)
+	assert.Equal(t, []FieldError{
+		{Field: "limit", Rule: "gt=0", Message: "limit must be greater than 0"},
+		{Field: "name", Rule: "required", Message: "name is required"},
+	}, []FieldError(validationErrs))
+}
+
+func TestValidateStruct_RejectsNonStruct(t *testing.T) {
+	assert.Error(t, validateStruct("not a struct"))
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+)
+
+// AdminTokenHeader carries the shared secret required by admin endpoints
+const AdminTokenHeader = "X-Admin-Token"
+
+// AdminOnly restricts access to requests presenting the configured admin token
+// When no token is configured, admin endpoints are disabled entirely
+func AdminOnly(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				writeJSONError(w, http.StatusForbidden, "admin endpoints are disabled")
+				return
+			}
+
+			provided := r.Header.Get(AdminTokenHeader)
+			if provided == "" {
+				writeJSONError(w, http.StatusUnauthorized, AdminTokenHeader+" header is required")
+				return
+			}
+
+			if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				writeJSONError(w, http.StatusForbidden, "invalid admin token")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// writeJSONError writes an error body matching the handlers' ErrorResponse shape
+func writeJSONError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   http.StatusText(code),
+		"message": message,
+	})
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminOnly(t *testing.T) {
+	tests := []struct {
+		name           string
+		configured     string
+		provided       string
+		expectedStatus int
+		wantCalled     bool
+	}{
+		{
+			name:           "valid token",
+			configured:     "secret",
+			provided:       "secret",
+			expectedStatus: http.StatusOK,
+			wantCalled:     true,
+		},
+		{
+			name:           "missing token",
+			configured:     "secret",
+			provided:       "",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "wrong token",
+			configured:     "secret",
+			provided:       "guess",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "admin disabled when no token configured",
+			configured:     "",
+			provided:       "anything",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := AdminOnly(tt.configured)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			if tt.provided != "" {
+				req.Header.Set(AdminTokenHeader, tt.provided)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.wantCalled, called)
+			if !tt.wantCalled {
+				assert.Contains(t, rec.Body.String(), `"error"`)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultAPIVersion is used when the Accept header does not request a specific version
+const DefaultAPIVersion = 1
+
+// vendorMediaType matches the versioned media type, e.g. application/vnd.banking.v1+json
+var vendorMediaType = regexp.MustCompile(`^application/vnd\.banking\.v(\d+)\+json$`)
+
+type apiVersionKey struct{}
+
+// APIVersion negotiates the API version from the Accept header and stores it in the request context
+// Requests without a versioned media type get DefaultAPIVersion; unsupported versions get 406
+func APIVersion(supported ...int) func(http.Handler) http.Handler {
+	if len(supported) == 0 {
+		supported = []int{DefaultAPIVersion}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			version, requested := requestedAPIVersion(r.Header.Get("Accept"))
+			if !requested {
+				version = DefaultAPIVersion
+			} else if !containsVersion(supported, version) {
+				writeJSONError(w, http.StatusNotAcceptable, fmt.Sprintf("API version %d is not supported", version))
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), apiVersionKey{}, version)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// APIVersionFromContext returns the negotiated API version, or DefaultAPIVersion when none was negotiated
+func APIVersionFromContext(ctx context.Context) int {
+	if version, ok := ctx.Value(apiVersionKey{}).(int); ok {
+		return version
+	}
+	return DefaultAPIVersion
+}
+
+// requestedAPIVersion returns the version of the first versioned media type in the Accept header
+func requestedAPIVersion(accept string) (int, bool) {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		matches := vendorMediaType.FindStringSubmatch(mediaType)
+		if matches == nil {
+			continue
+		}
+
+		version, err := strconv.Atoi(matches[1])
+		if err != nil {
+			continue
+		}
+		return version, true
+	}
+	return 0, false
+}
+
+func containsVersion(versions []int, version int) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
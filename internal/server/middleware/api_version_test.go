@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIVersion(t *testing.T) {
+	tests := []struct {
+		name           string
+		accept         string
+		expectedStatus int
+		wantVersion    int
+	}{
+		{
+			name:           "no accept header defaults to v1",
+			accept:         "",
+			expectedStatus: http.StatusOK,
+			wantVersion:    1,
+		},
+		{
+			name:           "plain json defaults to v1",
+			accept:         "application/json",
+			expectedStatus: http.StatusOK,
+			wantVersion:    1,
+		},
+		{
+			name:           "explicit v1",
+			accept:         "application/vnd.banking.v1+json",
+			expectedStatus: http.StatusOK,
+			wantVersion:    1,
+		},
+		{
+			name:           "explicit v1 among other media types",
+			accept:         "text/html, application/vnd.banking.v1+json; q=0.9, */*",
+			expectedStatus: http.StatusOK,
+			wantVersion:    1,
+		},
+		{
+			name:           "unsupported version",
+			accept:         "application/vnd.banking.v2+json",
+			expectedStatus: http.StatusNotAcceptable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotVersion int
+			called := false
+			handler := APIVersion()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				gotVersion = APIVersionFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/accounts/1", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.True(t, called)
+				assert.Equal(t, tt.wantVersion, gotVersion)
+			} else {
+				assert.False(t, called)
+				assert.Contains(t, rec.Body.String(), "API version 2 is not supported")
+			}
+		})
+	}
+}
+
+func TestAPIVersion_SupportsConfiguredVersions(t *testing.T) {
+	var gotVersion int
+	handler := APIVersion(1, 2)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotVersion = APIVersionFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/accounts/1", nil)
+	req.Header.Set("Accept", "application/vnd.banking.v2+json")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 2, gotVersion)
+}
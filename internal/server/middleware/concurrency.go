@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// DefaultMaxConcurrentBatches is the default number of batch requests served at once
+const DefaultMaxConcurrentBatches = 1
+
+// concurrencyRetryAfter is the Retry-After (in seconds) sent when every slot is taken
+const concurrencyRetryAfter = "1"
+
+// MaxConcurrent serves at most limit requests at once through the routes it wraps, sharing one set of
+// slots between them; a request arriving while all slots are taken gets 429 instead of waiting
+// Wrap only batch routes with it, so they cannot monopolize the database writer while single writes go through
+// A non-positive limit falls back to DefaultMaxConcurrentBatches
+func MaxConcurrent(limit int) func(http.Handler) http.Handler {
+	if limit <= 0 {
+		limit = DefaultMaxConcurrentBatches
+	}
+	slots := make(chan struct{}, limit)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			default:
+				w.Header().Set("Retry-After", concurrencyRetryAfter)
+				writeJSONError(w, http.StatusTooManyRequests, "too many batch requests in progress, retry later")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
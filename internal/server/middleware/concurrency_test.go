@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxConcurrent_ThrottlesConcurrentBatches(t *testing.T) {
+	const limit = 2
+
+	started := make(chan struct{}, limit)
+	release := make(chan struct{})
+	handler := MaxConcurrent(limit)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Fill every slot with a batch that stays in flight
+	var wg sync.WaitGroup
+	codes := make(chan int, limit)
+	for i := 0; i < limit; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/transactions/import", nil))
+			codes <- w.Code
+		}()
+	}
+	for i := 0; i < limit; i++ {
+		<-started
+	}
+
+	// Further batches are turned away while the slots are taken
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/transactions/import", nil))
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		assert.JSONEq(t, `{"error":"Too Many Requests","message":"too many batch requests in progress, retry later"}`, w.Body.String())
+	}
+
+	close(release)
+	wg.Wait()
+	close(codes)
+	for code := range codes {
+		assert.Equal(t, http.StatusOK, code)
+	}
+
+	// Finished batches free their slots
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/transactions/import", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestMaxConcurrent_DefaultsToOneBatch(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	handler := MaxConcurrent(0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+	}()
+	<-started
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	close(release)
+	<-done
+}
@@ -2,15 +2,120 @@ package middleware
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"log/slog"
 	"net/http"
 	"sync"
 	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
 )
 
+// IdempotencyKeyHeader is the header a client deduplicates a request with
+// Read it through r.Header.Get, so every casing of the name is found; its value is the key exactly as
+// sent, never lowercased or trimmed, so "abc" and "ABC" are distinct keys
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// DefaultIdempotencyTTL is how long a successful response is replayed for its Idempotency-Key
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// DefaultIdempotencyMaxBodyBytes caps the body of a request made with an Idempotency-Key (10MB)
+// The whole body is hashed, with the method and target, to tell a replay from a different request, so a larger one is rejected with 413
+const DefaultIdempotencyMaxBodyBytes = 10 << 20
+
+// idempotencyConfig holds the optional settings of the idempotency middleware
+type idempotencyConfig struct {
+	ttl                time.Duration
+	failureGracePeriod time.Duration
+	reuseWindow        time.Duration
+	maxBodyBytes       int64
+	metrics            ports.IdempotencyMetrics
+	logger             *slog.Logger
+	now                func() time.Time
+}
+
+// IdempotencyOption configures optional behavior of the idempotency middleware
+type IdempotencyOption func(*idempotencyConfig)
+
+// WithTTL replays successful responses for the given period (DefaultIdempotencyTTL by default);
+// a request arriving later with the same key is processed as new. Zero keeps responses forever
+func WithTTL(ttl time.Duration) IdempotencyOption {
+	return func(c *idempotencyConfig) {
+		c.ttl = ttl
+	}
+}
+
+// WithFailureGracePeriod remembers server errors (5xx) for an idempotency key during the given period,
+// so rapid retries get the same error instead of re-running a write whose outcome is unknown
+// Zero (the default) disables it and failed requests may be retried immediately
+func WithFailureGracePeriod(period time.Duration) IdempotencyOption {
+	return func(c *idempotencyConfig) {
+		c.failureGracePeriod = period
+	}
+}
+
+// WithReuseWindow rejects a key whose first request is older than window with 409, instead of replaying it
+// or processing the request as new, to catch clients reusing a key long after, likely for an unrelated request
+// It applies whether or not the stored response has expired; zero (the default) disables it
+func WithReuseWindow(window time.Duration) IdempotencyOption {
+	return func(c *idempotencyConfig) {
+		c.reuseWindow = window
+	}
+}
+
+// WithIdempotencyMetrics counts requests replayed from a stored response (hits) and requests processed (misses)
+func WithIdempotencyMetrics(metrics ports.IdempotencyMetrics) IdempotencyOption {
+	return func(c *idempotencyConfig) {
+		c.metrics = metrics
+	}
+}
+
+// WithIdempotencyLogger reports responses that could not be recorded (slog.Default by default)
+func WithIdempotencyLogger(logger *slog.Logger) IdempotencyOption {
+	return func(c *idempotencyConfig) {
+		c.logger = logger
+	}
+}
+
+// withMaxBodyBytes replaces DefaultIdempotencyMaxBodyBytes, so tests can exceed it with small bodies
+func withMaxBodyBytes(n int64) IdempotencyOption {
+	return func(c *idempotencyConfig) {
+		c.maxBodyBytes = n
+	}
+}
+
+// withClock replaces time.Now, so tests can move past the TTL without waiting
+func withClock(now func() time.Time) IdempotencyOption {
+	return func(c *idempotencyConfig) {
+		c.now = now
+	}
+}
+
 // IdempotencyMiddleware ensures requests with the same Idempotency-Key return the same response
-func IdempotencyMiddleware() func(http.Handler) http.Handler {
-	// Simple thread-safe in-memory cache
-	cache := &sync.Map{}
+// Responses are kept in store, so they survive restarts and are shared by every replica using it
+func IdempotencyMiddleware(store ports.IdempotencyStore, opts ...IdempotencyOption) func(http.Handler) http.Handler {
+	config := &idempotencyConfig{
+		ttl:          DefaultIdempotencyTTL,
+		maxBodyBytes: DefaultIdempotencyMaxBodyBytes,
+		logger:       slog.Default(),
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	h := &idempotencyHandler{
+		store:    store,
+		config:   config,
+		inFlight: &sync.Map{},
+	}
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -20,71 +125,240 @@ func IdempotencyMiddleware() func(http.Handler) http.Handler {
 				return
 			}
 
-			// Get Idempotency-Key header
-			key := r.Header.Get("Idempotency-Key")
+			key := r.Header.Get(IdempotencyKeyHeader)
 			if key == "" {
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			// Check if already processed
-			if cached, ok := cache.Load(key); ok {
-				// Check if it's a completed response or still processing
-				if resp, ok := cached.(*cachedResponse); ok {
-					w.WriteHeader(resp.status)
-					w.Write(resp.body)
+			h.serve(w, r, next, key)
+		})
+	}
+}
+
+// idempotencyHandler deduplicates requests through the store
+// inFlight tracks the keys processed by this process, so concurrent duplicates wait for the response
+// instead of being turned away; keys processed by another replica are reported as in progress
+type idempotencyHandler struct {
+	store    ports.IdempotencyStore
+	config   *idempotencyConfig
+	inFlight *sync.Map
+}
+
+func (h *idempotencyHandler) serve(w http.ResponseWriter, r *http.Request, next http.Handler, key string) {
+	ctx := r.Context()
+
+	if r.ContentLength > h.config.maxBodyBytes {
+		writeBodyTooLarge(w)
+		return
+	}
+	// Both the replay check and the processed request read the body through the same limit
+	r.Body = http.MaxBytesReader(w, r.Body, h.config.maxBodyBytes)
+
+	// The first pass may find the key released or expired; the retry then claims it or sees who did
+	for attempt := 0; attempt < 2; attempt++ {
+		// Still processing here, wait
+		if processing, ok := h.inFlight.Load(key); ok {
+			<-processing.(*processingMarker).done
+		}
+
+		record, err := h.store.Get(ctx, key)
+		if err != nil {
+			writeStoreUnavailable(w)
+			return
+		}
+
+		if record != nil {
+			now := h.config.now()
+			switch {
+			case record.StaleProcessing(now):
+				// The request holding the key is presumed gone (a crash or a killed request), so it is taken over
+			case record.Status == domain.IdempotencyStatusProcessing:
+				// Another replica (or a request that just started here) holds the key
+				if attempt == 0 {
+					continue
+				}
+				w.Header().Set("Retry-After", "1")
+				writeJSONError(w, http.StatusConflict, "a request with this Idempotency-Key is already in progress")
+				return
+			case h.config.reuseWindow > 0 && !now.Before(record.CreatedAt.Add(h.config.reuseWindow)):
+				writeJSONError(w, http.StatusConflict, "idempotency key expired, use a new key")
+				return
+			case !record.Expired(now):
+				requestHash, err := hashRequest(r)
+				if err != nil {
+					var maxBytesErr *http.MaxBytesError
+					if errors.As(err, &maxBytesErr) {
+						writeBodyTooLarge(w)
+						return
+					}
+					writeJSONError(w, http.StatusBadRequest, "could not read request body")
 					return
 				}
-				// Still processing, wait
-				processing := cached.(*processingMarker)
-				<-processing.done
-				// Now get the actual response
-				if cached, ok := cache.Load(key); ok {
-					resp := cached.(*cachedResponse)
-					w.WriteHeader(resp.status)
-					w.Write(resp.body)
+				// A reused key must not hand the response of one request to a different one, e.g. on another resource
+				if !record.MatchesRequest(requestHash) {
+					writeJSONError(w, http.StatusConflict, "Idempotency-Key was already used with a different request")
 					return
 				}
-			}
-
-			// Use LoadOrStore to atomically mark as processing
-			marker := &processingMarker{done: make(chan struct{})}
-			actual, loaded := cache.LoadOrStore(key, marker)
-
-			if loaded {
-				// Another goroutine is already processing this key
-				processing := actual.(*processingMarker)
-				<-processing.done
-
-				// Get the cached response
-				if cached, ok := cache.Load(key); ok {
-					resp := cached.(*cachedResponse)
-					w.WriteHeader(resp.status)
-					w.Write(resp.body)
+				h.replay(w, record)
+				return
+			default:
+				// The stored response expired, so the request is processed as new
+				if err := h.store.Delete(ctx, key); err != nil {
+					writeStoreUnavailable(w)
 					return
 				}
 			}
+		}
 
-			// This goroutine won the race - process the request
-			rec := &recorder{ResponseWriter: w, body: &bytes.Buffer{}, status: http.StatusOK}
-			next.ServeHTTP(rec, r)
-
-			// Cache only successful responses (2xx)
-			if rec.status >= 200 && rec.status < 300 {
-				cache.Store(key, &cachedResponse{
-					status: rec.status,
-					body:   rec.body.Bytes(),
-					time:   time.Now(),
-				})
-			} else {
-				// Remove marker for error responses (don't cache errors)
-				cache.Delete(key)
+		// Mark as processing here first, so duplicates in this process wait on it
+		marker := &processingMarker{done: make(chan struct{})}
+		if _, loaded := h.inFlight.LoadOrStore(key, marker); loaded {
+			continue
+		}
+
+		claimed, err := h.store.SetProcessing(ctx, key)
+		if err != nil || !claimed {
+			h.release(key, marker)
+			if err != nil {
+				writeStoreUnavailable(w)
+				return
 			}
+			continue
+		}
 
-			// Signal that processing is complete
-			close(marker.done)
-		})
+		h.process(w, r, next, key, marker)
+		return
+	}
+
+	w.Header().Set("Retry-After", "1")
+	writeJSONError(w, http.StatusConflict, "a request with this Idempotency-Key is already in progress")
+}
+
+// process runs the request that claimed the key and stores its response
+func (h *idempotencyHandler) process(w http.ResponseWriter, r *http.Request, next http.Handler, key string, marker *processingMarker) {
+	defer h.release(key, marker)
+
+	if h.config.metrics != nil {
+		h.config.metrics.Miss()
+	}
+	// The body is hashed as the handler streams it, so large bodies are never buffered
+	body := &hashingBody{ReadCloser: r.Body, hash: newRequestHash(r)}
+	r.Body = body
+	rec := &recorder{ResponseWriter: w, body: &bytes.Buffer{}, status: http.StatusOK}
+	next.ServeHTTP(rec, r)
+
+	// The response is already sent, so store it even if the client has gone away
+	ctx := context.WithoutCancel(r.Context())
+
+	requestHash, hashed := body.sum()
+	result := &domain.IdempotencyRecord{
+		Key:            key,
+		RequestHash:    requestHash,
+		ResponseStatus: rec.status,
+		ResponseBody:   rec.body.Bytes(),
+	}
+
+	var err error
+	if !hashed {
+		// Without the hash the record would be replayed to any request with the key, so none is kept
+		h.config.logger.LogAttrs(ctx, slog.LevelError, "Idempotency request body could not be hashed, the response is not stored",
+			slog.String("request_id", chiMiddleware.GetReqID(ctx)),
+			slog.String("key", key),
+		)
+		err = h.store.Delete(ctx, key)
+	} else if rec.status >= 200 && rec.status < 300 {
+		// Cache only successful responses (2xx), until the TTL passes
+		if h.config.ttl > 0 {
+			expiresAt := h.config.now().Add(h.config.ttl)
+			result.ExpiresAt = &expiresAt
+		}
+		err = h.store.SetResult(ctx, result)
+	} else if rec.status >= 500 && h.config.failureGracePeriod > 0 {
+		// Remember server errors briefly so rapid retries don't re-run an ambiguous write
+		expiresAt := h.config.now().Add(h.config.failureGracePeriod)
+		result.ExpiresAt = &expiresAt
+		err = h.store.SetResult(ctx, result)
+	} else {
+		// Release the key for error responses (don't cache errors)
+		err = h.store.Delete(ctx, key)
+	}
+	if err != nil {
+		h.config.logger.LogAttrs(ctx, slog.LevelError, "Failed to record the idempotent response",
+			slog.String("request_id", chiMiddleware.GetReqID(ctx)),
+			slog.String("key", key),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// replay writes a stored response
+func (h *idempotencyHandler) replay(w http.ResponseWriter, record *domain.IdempotencyRecord) {
+	if h.config.metrics != nil {
+		h.config.metrics.Hit()
 	}
+	w.WriteHeader(record.ResponseStatus)
+	w.Write(record.ResponseBody)
+}
+
+// release removes the local processing marker and wakes the requests waiting on it
+func (h *idempotencyHandler) release(key string, marker *processingMarker) {
+	h.inFlight.CompareAndDelete(key, marker)
+	close(marker.done)
+}
+
+// writeStoreUnavailable answers when the idempotency store cannot be reached
+// The request is not processed, since it could not be deduplicated
+func writeStoreUnavailable(w http.ResponseWriter) {
+	w.Header().Set("Retry-After", "1")
+	writeJSONError(w, http.StatusServiceUnavailable, domain.ErrServiceUnavailable.Error())
+}
+
+// writeBodyTooLarge answers a request whose body is too large to be hashed
+func writeBodyTooLarge(w http.ResponseWriter) {
+	writeJSONError(w, http.StatusRequestEntityTooLarge, "request body too large for an Idempotency-Key request")
+}
+
+// newRequestHash starts the hash identifying a request with its method and target (path and query), so
+// requests without a body, e.g. closing two different accounts, never hash the same; the body follows
+func newRequestHash(r *http.Request) hash.Hash {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.RequestURI()+"\n")
+	return hash
+}
+
+// hashRequest returns the hex SHA-256 of the request's method, target and what is left to read of its body
+func hashRequest(r *http.Request) (string, error) {
+	hash := newRequestHash(r)
+	if _, err := io.Copy(hash, r.Body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// hashingBody hashes a request body as it is read, after the method and target newRequestHash wrote
+type hashingBody struct {
+	io.ReadCloser
+	hash   hash.Hash
+	failed bool
+}
+
+func (b *hashingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.hash.Write(p[:n])
+	if err != nil && err != io.EOF {
+		b.failed = true
+	}
+	return n, err
+}
+
+// sum reads whatever the handler left unread and returns the hex SHA-256 of the whole request
+// It reports false when the body could not be read in full, e.g. beyond the size limit
+func (b *hashingBody) sum() (string, bool) {
+	if _, err := io.Copy(io.Discard, b); err != nil || b.failed {
+		return "", false
+	}
+	return hex.EncodeToString(b.hash.Sum(nil)), true
 }
 
 // processingMarker indicates a request is currently being processed
@@ -92,13 +366,6 @@ type processingMarker struct {
 	done chan struct{}
 }
 
-// cachedResponse stores an HTTP response
-type cachedResponse struct {
-	status int
-	body   []byte
-	time   time.Time
-}
-
 // recorder captures status code and response body
 type recorder struct {
 	http.ResponseWriter
@@ -1,13 +1,29 @@
 package middleware
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIdempotencyMiddleware(t *testing.T) {
@@ -61,7 +77,7 @@ func TestIdempotencyMiddleware(t *testing.T) {
 			})
 
 			// Wrap with idempotency middleware
-			middleware := IdempotencyMiddleware()
+			middleware := IdempotencyMiddleware(newMemoryIdempotencyStore())
 			wrappedHandler := middleware(handler)
 
 			// Make multiple requests
@@ -106,7 +122,7 @@ func TestIdempotencyMiddleware_CachesOnlySuccessfulResponses(t *testing.T) {
 		}
 	})
 
-	middleware := IdempotencyMiddleware()
+	middleware := IdempotencyMiddleware(newMemoryIdempotencyStore())
 	wrappedHandler := middleware(handler)
 
 	// First request - fails (500)
@@ -133,7 +149,7 @@ func TestIdempotencyMiddleware_ReturnsIdenticalResponse(t *testing.T) {
 		w.Write([]byte(`{"id":123,"amount":100.50}`))
 	})
 
-	middleware := IdempotencyMiddleware()
+	middleware := IdempotencyMiddleware(newMemoryIdempotencyStore())
 	wrappedHandler := middleware(handler)
 
 	// First request
@@ -152,3 +168,797 @@ func TestIdempotencyMiddleware_ReturnsIdenticalResponse(t *testing.T) {
 	assert.Equal(t, rec1.Code, rec2.Code, "Status codes should match")
 	assert.Equal(t, rec1.Body.String(), rec2.Body.String(), "Response bodies should match")
 }
+
+// rawIdempotentRequest parses a request as it arrives on the wire, with the header name spelled as the client sent it
+func rawIdempotentRequest(t *testing.T, headerName, key string) *http.Request {
+	body := `{"test":"data"}`
+	raw := "POST /test HTTP/1.1\r\nHost: example.com\r\n" +
+		headerName + ": " + key + "\r\n" +
+		"Content-Length: " + strconv.Itoa(len(body)) + "\r\n\r\n" + body
+	req, err := http.ReadRequest(bufio.NewReader(strings.NewReader(raw)))
+	require.NoError(t, err)
+	return req
+}
+
+func TestIdempotencyMiddleware_HeaderNameCasing(t *testing.T) {
+	var calls int
+	handler := IdempotencyMiddleware(newMemoryIdempotencyStore())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id":%d}`, calls)
+	}))
+
+	for _, headerName := range []string{"Idempotency-Key", "idempotency-key", "Idempotency-key", "IDEMPOTENCY-KEY"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, rawIdempotentRequest(t, headerName, "casing-key"))
+
+		assert.Equal(t, http.StatusCreated, rec.Code, headerName)
+		assert.JSONEq(t, `{"id":1}`, rec.Body.String(), "%s replays the first response", headerName)
+	}
+	assert.Equal(t, 1, calls, "Every casing of the header name is the same key")
+}
+
+func TestIdempotencyMiddleware_KeyValueIsCaseSensitive(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	var calls int
+	handler := IdempotencyMiddleware(store)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id":%d}`, calls)
+	}))
+
+	for i, key := range []string{"order-abc", "ORDER-ABC", "Order-Abc"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, rawIdempotentRequest(t, "idempotency-key", key))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, fmt.Sprintf(`{"id":%d}`, i+1), rec.Body.String(), "%s is its own key", key)
+	}
+	assert.Equal(t, 3, calls)
+
+	// Stored under the exact value sent
+	for _, key := range []string{"order-abc", "ORDER-ABC", "Order-Abc"} {
+		record, err := store.Get(context.Background(), key)
+		require.NoError(t, err)
+		require.NotNil(t, record, key)
+		assert.Equal(t, key, record.Key)
+	}
+}
+
+func TestIdempotencyMiddleware_FailureGracePeriod(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []IdempotencyOption
+		wait          time.Duration
+		wantStatus    int
+		wantCallCount int
+	}{
+		{
+			name:          "disabled by default - retry is processed",
+			wantStatus:    http.StatusCreated,
+			wantCallCount: 2,
+		},
+		{
+			name:          "enabled - rapid retry replays the failure",
+			opts:          []IdempotencyOption{WithFailureGracePeriod(time.Minute)},
+			wantStatus:    http.StatusInternalServerError,
+			wantCallCount: 1,
+		},
+		{
+			name:          "enabled - retry after the grace period is processed",
+			opts:          []IdempotencyOption{WithFailureGracePeriod(20 * time.Millisecond)},
+			wait:          50 * time.Millisecond,
+			wantStatus:    http.StatusCreated,
+			wantCallCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callCount := 0
+
+			// Handler that fails first time, succeeds second time
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				callCount++
+				if callCount == 1 {
+					w.WriteHeader(http.StatusInternalServerError)
+					w.Write([]byte(`{"error":"server error"}`))
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id":1,"status":"created"}`))
+			})
+
+			wrappedHandler := IdempotencyMiddleware(newMemoryIdempotencyStore(), tt.opts...)(handler)
+
+			req1 := httptest.NewRequest("POST", "/test", strings.NewReader(`{"test":"data"}`))
+			req1.Header.Set("Idempotency-Key", "grace-key")
+			rec1 := httptest.NewRecorder()
+			wrappedHandler.ServeHTTP(rec1, req1)
+			assert.Equal(t, http.StatusInternalServerError, rec1.Code)
+
+			time.Sleep(tt.wait)
+
+			req2 := httptest.NewRequest("POST", "/test", strings.NewReader(`{"test":"data"}`))
+			req2.Header.Set("Idempotency-Key", "grace-key")
+			rec2 := httptest.NewRecorder()
+			wrappedHandler.ServeHTTP(rec2, req2)
+
+			assert.Equal(t, tt.wantStatus, rec2.Code)
+			assert.Equal(t, tt.wantCallCount, callCount)
+			if tt.wantStatus == http.StatusInternalServerError {
+				assert.Equal(t, rec1.Body.String(), rec2.Body.String())
+			}
+		})
+	}
+}
+
+func TestIdempotencyMiddleware_FailureGracePeriodIgnoresClientErrors(t *testing.T) {
+	callCount := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	wrappedHandler := IdempotencyMiddleware(newMemoryIdempotencyStore(), WithFailureGracePeriod(time.Minute))(handler)
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("POST", "/test", strings.NewReader(`{}`))
+		req.Header.Set("Idempotency-Key", "client-error-key")
+		wrappedHandler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, 2, callCount)
+}
+
+func TestIdempotencyMiddleware_RecordsHitsAndMisses(t *testing.T) {
+	metrics := mocks.NewMockIdempotencyMetrics(t)
+	metrics.EXPECT().Miss().Once()
+	metrics.EXPECT().Hit().Once()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"transaction_id":1}`))
+	})
+	wrapped := IdempotencyMiddleware(newMemoryIdempotencyStore(), WithIdempotencyMetrics(metrics))(handler)
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/v1/transactions", strings.NewReader(`{}`))
+		req.Header.Set("Idempotency-Key", "metrics-key")
+		w := httptest.NewRecorder()
+		wrapped.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	// Requests without a key are not deduplicated, so they are neither hits nor misses
+	req := httptest.NewRequest(http.MethodPost, "/v1/transactions", strings.NewReader(`{}`))
+	wrapped.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestIdempotencyMiddleware_SharesKeysThroughTheStore(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	callCount := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"transaction_id":1}`))
+	})
+
+	// Two middleware instances stand for a restarted process or a second replica
+	for _, wrapped := range []http.Handler{
+		IdempotencyMiddleware(store)(handler),
+		IdempotencyMiddleware(store)(handler),
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/transactions", strings.NewReader(`{}`))
+		req.Header.Set("Idempotency-Key", "shared-key")
+		w := httptest.NewRecorder()
+		wrapped.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, `{"transaction_id":1}`, w.Body.String())
+	}
+
+	assert.Equal(t, 1, callCount)
+}
+
+func TestIdempotencyMiddleware_ConcurrentDuplicatesWaitForTheFirst(t *testing.T) {
+	var callCount atomic.Int32
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount.Add(1)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"transaction_id":1}`))
+	})
+	wrapped := IdempotencyMiddleware(newMemoryIdempotencyStore())(handler)
+
+	const requests = 5
+	codes := make(chan int, requests)
+	for i := 0; i < requests; i++ {
+		go func() {
+			req := httptest.NewRequest(http.MethodPost, "/v1/transactions", strings.NewReader(`{}`))
+			req.Header.Set("Idempotency-Key", "concurrent-key")
+			w := httptest.NewRecorder()
+			wrapped.ServeHTTP(w, req)
+			codes <- w.Code
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < requests; i++ {
+		assert.Equal(t, http.StatusCreated, <-codes)
+	}
+	assert.Equal(t, int32(1), callCount.Load())
+}
+
+func TestIdempotencyMiddleware_KeyInProgressElsewhere(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	claimed, err := store.SetProcessing(context.Background(), "remote-key")
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("A key processed by another replica must not be processed again")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/transactions", strings.NewReader(`{}`))
+	req.Header.Set("Idempotency-Key", "remote-key")
+	w := httptest.NewRecorder()
+	IdempotencyMiddleware(store)(handler).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+}
+
+func TestIdempotencyMiddleware_TakesOverStaleProcessingKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		age           time.Duration
+		wantStatus    int
+		wantCallCount int
+	}{
+		{name: "key still within the processing window", age: domain.IdempotencyStaleProcessingAfter - time.Minute, wantStatus: http.StatusConflict, wantCallCount: 0},
+		{name: "key abandoned by a crash", age: time.Hour, wantStatus: http.StatusCreated, wantCallCount: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryIdempotencyStore()
+			store.records["abandoned-key"] = &domain.IdempotencyRecord{
+				Key:       "abandoned-key",
+				Status:    domain.IdempotencyStatusProcessing,
+				CreatedAt: time.Now().Add(-tt.age),
+			}
+
+			callCount := 0
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				callCount++
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"transaction_id":1}`))
+			})
+			wrapped := IdempotencyMiddleware(store)(handler)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/transactions", strings.NewReader(`{}`))
+			req.Header.Set("Idempotency-Key", "abandoned-key")
+			w := httptest.NewRecorder()
+			wrapped.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantCallCount, callCount)
+			if tt.wantStatus == http.StatusConflict {
+				assert.Contains(t, w.Body.String(), "already in progress")
+				return
+			}
+
+			// The response of the request that took the key over is replayed
+			req = httptest.NewRequest(http.MethodPost, "/v1/transactions", strings.NewReader(`{}`))
+			req.Header.Set("Idempotency-Key", "abandoned-key")
+			w = httptest.NewRecorder()
+			wrapped.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusCreated, w.Code)
+			assert.Equal(t, `{"transaction_id":1}`, w.Body.String())
+			assert.Equal(t, 1, callCount)
+		})
+	}
+}
+
+func TestIdempotencyMiddleware_StoreUnavailable(t *testing.T) {
+	store := mocks.NewMockIdempotencyStore(t)
+	store.EXPECT().Get(mock.Anything, "key").Return(nil, errors.New("database is locked")).Once()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("A request that cannot be deduplicated must not be processed")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/transactions", strings.NewReader(`{}`))
+	req.Header.Set("Idempotency-Key", "key")
+	w := httptest.NewRecorder()
+	IdempotencyMiddleware(store)(handler).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestIdempotencyMiddleware_TTL(t *testing.T) {
+	tests := []struct {
+		name          string
+		ttl           time.Duration
+		elapsed       time.Duration
+		wantCallCount int
+	}{
+		{name: "replayed within the TTL", ttl: 24 * time.Hour, elapsed: 23 * time.Hour, wantCallCount: 1},
+		{name: "processed as new after the TTL", ttl: 24 * time.Hour, elapsed: 25 * time.Hour, wantCallCount: 2},
+		{name: "zero TTL keeps responses forever", ttl: 0, elapsed: 365 * 24 * time.Hour, wantCallCount: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2025, 11, 16, 14, 0, 0, 0, time.UTC)
+			clock := func() time.Time { return now }
+
+			callCount := 0
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				callCount++
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"transaction_id":1}`))
+			})
+			wrapped := IdempotencyMiddleware(newMemoryIdempotencyStore(), WithTTL(tt.ttl), withClock(clock))(handler)
+
+			for i := 0; i < 2; i++ {
+				req := httptest.NewRequest(http.MethodPost, "/v1/transactions", strings.NewReader(`{}`))
+				req.Header.Set("Idempotency-Key", "ttl-key")
+				w := httptest.NewRecorder()
+				wrapped.ServeHTTP(w, req)
+				assert.Equal(t, http.StatusCreated, w.Code)
+
+				now = now.Add(tt.elapsed)
+			}
+
+			assert.Equal(t, tt.wantCallCount, callCount)
+		})
+	}
+}
+
+func TestIdempotencyMiddleware_RequestBodyMustMatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		replayBody    string
+		wantStatus    int
+		wantCallCount int
+	}{
+		{
+			name:          "identical body replays the stored response",
+			replayBody:    `{"account_id":1,"amount":50}`,
+			wantStatus:    http.StatusCreated,
+			wantCallCount: 1,
+		},
+		{
+			name:          "different body is rejected",
+			replayBody:    `{"account_id":1,"amount":500}`,
+			wantStatus:    http.StatusConflict,
+			wantCallCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callCount := 0
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				callCount++
+				// Only part of the body is read; the rest is still hashed
+				io.ReadFull(r.Body, make([]byte, 5))
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"transaction_id":1}`))
+			})
+			wrapped := IdempotencyMiddleware(newMemoryIdempotencyStore())(handler)
+
+			var responses []*httptest.ResponseRecorder
+			for _, body := range []string{`{"account_id":1,"amount":50}`, tt.replayBody} {
+				req := httptest.NewRequest(http.MethodPost, "/v1/transactions", strings.NewReader(body))
+				req.Header.Set("Idempotency-Key", "body-key")
+				w := httptest.NewRecorder()
+				wrapped.ServeHTTP(w, req)
+				responses = append(responses, w)
+			}
+
+			assert.Equal(t, http.StatusCreated, responses[0].Code)
+			assert.Equal(t, tt.wantStatus, responses[1].Code)
+			assert.Equal(t, tt.wantCallCount, callCount)
+			if tt.wantStatus == http.StatusConflict {
+				assert.Contains(t, responses[1].Body.String(), "different request")
+			} else {
+				assert.Equal(t, responses[0].Body.String(), responses[1].Body.String())
+			}
+		})
+	}
+}
+
+func TestIdempotencyMiddleware_RequestTargetMustMatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		target        string
+		wantStatus    int
+		wantCallCount int
+	}{
+		{
+			name:          "same method and path replays the stored response",
+			method:        http.MethodDelete,
+			target:        "/v1/accounts/1",
+			wantStatus:    http.StatusNoContent,
+			wantCallCount: 1,
+		},
+		{
+			name:          "another path is rejected",
+			method:        http.MethodDelete,
+			target:        "/v1/accounts/2",
+			wantStatus:    http.StatusConflict,
+			wantCallCount: 1,
+		},
+		{
+			name:          "another query is rejected",
+			method:        http.MethodDelete,
+			target:        "/v1/accounts/1?force=true",
+			wantStatus:    http.StatusConflict,
+			wantCallCount: 1,
+		},
+		{
+			name:          "another method is rejected",
+			method:        http.MethodPost,
+			target:        "/v1/accounts/1",
+			wantStatus:    http.StatusConflict,
+			wantCallCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callCount := 0
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				callCount++
+				w.WriteHeader(http.StatusNoContent)
+			})
+			wrapped := IdempotencyMiddleware(newMemoryIdempotencyStore())(handler)
+
+			// Neither request has a body, so only the method and target tell them apart
+			first := httptest.NewRequest(http.MethodDelete, "/v1/accounts/1", nil)
+			first.Header.Set("Idempotency-Key", "target-key")
+			w := httptest.NewRecorder()
+			wrapped.ServeHTTP(w, first)
+			require.Equal(t, http.StatusNoContent, w.Code)
+
+			replay := httptest.NewRequest(tt.method, tt.target, nil)
+			replay.Header.Set("Idempotency-Key", "target-key")
+			w = httptest.NewRecorder()
+			wrapped.ServeHTTP(w, replay)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantCallCount, callCount, "A request on another resource must not be answered with the first response")
+			if tt.wantStatus == http.StatusConflict {
+				assert.Contains(t, w.Body.String(), "different request")
+			}
+		})
+	}
+}
+
+// chunkedIdempotentRequest is a request whose body size is not declared, so only reading it finds its length
+func chunkedIdempotentRequest(key string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/transactions/import", body)
+	req.ContentLength = -1
+	req.Header.Set("Idempotency-Key", key)
+	return req
+}
+
+func TestIdempotencyMiddleware_BodyTooLarge(t *testing.T) {
+	const limit = 16
+	small := `{"amount":1}`
+	large := strings.Repeat("x", limit+1)
+
+	tests := []struct {
+		name          string
+		stored        bool
+		request       func() *http.Request
+		wantStatus    int
+		wantCallCount int
+	}{
+		{
+			name: "declared length over the limit is rejected before processing",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/v1/transactions/import", strings.NewReader(large))
+				req.Header.Set("Idempotency-Key", "large-key")
+				return req
+			},
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:   "undeclared length over the limit is rejected on replay",
+			stored: true,
+			request: func() *http.Request {
+				return chunkedIdempotentRequest("large-key", strings.NewReader(large))
+			},
+			wantStatus:    http.StatusRequestEntityTooLarge,
+			wantCallCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callCount := 0
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				callCount++
+				w.WriteHeader(http.StatusCreated)
+			})
+			wrapped := IdempotencyMiddleware(newMemoryIdempotencyStore(), withMaxBodyBytes(limit))(handler)
+
+			if tt.stored {
+				w := httptest.NewRecorder()
+				wrapped.ServeHTTP(w, chunkedIdempotentRequest("large-key", strings.NewReader(small)))
+				require.Equal(t, http.StatusCreated, w.Code)
+			}
+
+			w := httptest.NewRecorder()
+			wrapped.ServeHTTP(w, tt.request())
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantCallCount, callCount)
+		})
+	}
+}
+
+// failingReader returns its data, then err
+type failingReader struct {
+	data string
+	err  error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, r.err
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestIdempotencyMiddleware_UnhashedBodyIsNotStored(t *testing.T) {
+	const limit = 16
+
+	tests := []struct {
+		name string
+		body func() io.Reader
+	}{
+		{
+			name: "body left unread beyond the limit",
+			body: func() io.Reader { return strings.NewReader(strings.Repeat("x", limit+1)) },
+		},
+		{
+			name: "body read failure",
+			body: func() io.Reader { return &failingReader{data: `{"amount":1}`, err: errors.New("connection reset")} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callCount := 0
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				callCount++
+				// The body is not read, so only the middleware finds it cannot be hashed
+				w.WriteHeader(http.StatusCreated)
+			})
+			store := newMemoryIdempotencyStore()
+			wrapped := IdempotencyMiddleware(store, withMaxBodyBytes(limit))(handler)
+
+			w := httptest.NewRecorder()
+			wrapped.ServeHTTP(w, chunkedIdempotentRequest("unhashed-key", tt.body()))
+			assert.Equal(t, http.StatusCreated, w.Code)
+
+			record, err := store.Get(context.Background(), "unhashed-key")
+			require.NoError(t, err)
+			assert.Nil(t, record, "A record without the request hash would match any request")
+
+			// So a different request with the key is processed instead of replayed
+			w = httptest.NewRecorder()
+			wrapped.ServeHTTP(w, chunkedIdempotentRequest("unhashed-key", strings.NewReader(`{"amount":2}`)))
+			assert.Equal(t, http.StatusCreated, w.Code)
+			assert.Equal(t, 2, callCount)
+		})
+	}
+}
+
+func TestIdempotencyMiddleware_LogsUnrecordedResponses(t *testing.T) {
+	tests := []struct {
+		name      string
+		store     func(t *testing.T) *mocks.MockIdempotencyStore
+		body      io.Reader
+		wantMsg   string
+		wantError string
+	}{
+		{
+			name: "store failure",
+			store: func(t *testing.T) *mocks.MockIdempotencyStore {
+				store := mocks.NewMockIdempotencyStore(t)
+				store.EXPECT().Get(mock.Anything, "log-key").Return(nil, nil).Once()
+				store.EXPECT().SetProcessing(mock.Anything, "log-key").Return(true, nil).Once()
+				store.EXPECT().SetResult(mock.Anything, mock.Anything).Return(errors.New("database is locked")).Once()
+				return store
+			},
+			body:      strings.NewReader(`{}`),
+			wantMsg:   "Failed to record the idempotent response",
+			wantError: "database is locked",
+		},
+		{
+			name: "unhashed body",
+			store: func(t *testing.T) *mocks.MockIdempotencyStore {
+				store := mocks.NewMockIdempotencyStore(t)
+				store.EXPECT().Get(mock.Anything, "log-key").Return(nil, nil).Once()
+				store.EXPECT().SetProcessing(mock.Anything, "log-key").Return(true, nil).Once()
+				store.EXPECT().Delete(mock.Anything, "log-key").Return(nil).Once()
+				return store
+			},
+			body:    &failingReader{data: `{}`, err: errors.New("connection reset")},
+			wantMsg: "Idempotency request body could not be hashed, the response is not stored",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&logs, nil))
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+			})
+			wrapped := chiMiddleware.RequestID(IdempotencyMiddleware(tt.store(t), WithIdempotencyLogger(logger))(handler))
+
+			req := chunkedIdempotentRequest("log-key", tt.body)
+			req.Header.Set(chiMiddleware.RequestIDHeader, "req-123")
+			w := httptest.NewRecorder()
+			wrapped.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusCreated, w.Code, "The response is sent whether or not it is recorded")
+
+			var record map[string]any
+			require.NoError(t, json.Unmarshal(logs.Bytes(), &record), "One JSON record for the unrecorded response")
+			assert.Equal(t, "ERROR", record["level"])
+			assert.Equal(t, tt.wantMsg, record["msg"])
+			assert.Equal(t, "req-123", record["request_id"])
+			assert.Equal(t, "log-key", record["key"])
+			if tt.wantError != "" {
+				assert.Equal(t, tt.wantError, record["error"])
+			}
+		})
+	}
+}
+
+// memoryIdempotencyStore is an in-memory ports.IdempotencyStore
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*domain.IdempotencyRecord
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: map[string]*domain.IdempotencyRecord{}}
+}
+
+func (s *memoryIdempotencyStore) Get(_ context.Context, key string) (*domain.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok {
+		return nil, nil
+	}
+	copied := *record
+	return &copied, nil
+}
+
+func (s *memoryIdempotencyStore) GetMetadata(ctx context.Context, key string) (*domain.IdempotencyRecord, error) {
+	record, err := s.Get(ctx, key)
+	if record != nil {
+		record.ResponseBody = nil
+	}
+	return record, err
+}
+
+func (s *memoryIdempotencyStore) SetProcessing(_ context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Like the repository, a key left processing for too long is taken over
+	if record, ok := s.records[key]; ok && !record.StaleProcessing(time.Now()) {
+		return false, nil
+	}
+	s.records[key] = &domain.IdempotencyRecord{Key: key, Status: domain.IdempotencyStatusProcessing, CreatedAt: time.Now()}
+	return true, nil
+}
+
+func (s *memoryIdempotencyStore) SetResult(_ context.Context, result *domain.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[result.Key]
+	if !ok {
+		return nil
+	}
+	record.Status = domain.IdempotencyStatusCompleted
+	record.RequestHash = result.RequestHash
+	record.ResponseStatus = result.ResponseStatus
+	record.ResponseBody = append([]byte(nil), result.ResponseBody...)
+	record.ExpiresAt = result.ExpiresAt
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+func (s *memoryIdempotencyStore) DeleteExpired(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for key, record := range s.records {
+		if record.Expired(before) || record.StaleProcessing(before) {
+			delete(s.records, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func TestIdempotencyMiddleware_ReuseWindow(t *testing.T) {
+	tests := []struct {
+		name          string
+		window        time.Duration
+		age           time.Duration
+		ttl           time.Duration
+		wantStatus    int
+		wantCallCount int
+	}{
+		{name: "replayed within the window", window: time.Hour, age: 59 * time.Minute, ttl: 24 * time.Hour, wantStatus: http.StatusCreated, wantCallCount: 0},
+		{name: "rejected beyond the window", window: time.Hour, age: 2 * time.Hour, ttl: 24 * time.Hour, wantStatus: http.StatusConflict, wantCallCount: 0},
+		{name: "rejected beyond the window after the TTL", window: time.Hour, age: 48 * time.Hour, ttl: 24 * time.Hour, wantStatus: http.StatusConflict, wantCallCount: 0},
+		{name: "disabled window replays old keys", window: 0, age: 23 * time.Hour, ttl: 24 * time.Hour, wantStatus: http.StatusCreated, wantCallCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2025, 11, 16, 14, 0, 0, 0, time.UTC)
+			createdAt := now.Add(-tt.age)
+			expiresAt := createdAt.Add(tt.ttl)
+
+			store := newMemoryIdempotencyStore()
+			store.records["reuse-key"] = &domain.IdempotencyRecord{
+				Key:            "reuse-key",
+				Status:         domain.IdempotencyStatusCompleted,
+				ResponseStatus: http.StatusCreated,
+				ResponseBody:   []byte(`{"transaction_id":1}`),
+				CreatedAt:      createdAt,
+				ExpiresAt:      &expiresAt,
+			}
+
+			callCount := 0
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				callCount++
+				w.WriteHeader(http.StatusCreated)
+			})
+			wrapped := IdempotencyMiddleware(store, WithTTL(tt.ttl), WithReuseWindow(tt.window), withClock(func() time.Time { return now }))(handler)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/transactions", strings.NewReader(`{}`))
+			req.Header.Set("Idempotency-Key", "reuse-key")
+			w := httptest.NewRecorder()
+			wrapped.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantCallCount, callCount)
+			if tt.wantStatus == http.StatusConflict {
+				assert.Contains(t, w.Body.String(), "idempotency key expired, use a new key")
+			} else {
+				assert.Equal(t, `{"transaction_id":1}`, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// unmatchedRoute labels requests that matched no route, so unknown paths cannot grow the label set
+const unmatchedRoute = "unmatched"
+
+// Metrics records every request with its chi route pattern (e.g. /v1/accounts/{accountId}) rather than
+// the raw path, keeping label cardinality bounded; a nil metrics records nothing
+// Mount it before Recoverer so recovered panics are counted as the 500 they answer
+func Metrics(metrics ports.HTTPMetrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if metrics == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			defer func() {
+				// A handler that never writes a header answers 200
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+
+				metrics.RequestServed(r.Method, routePattern(r), status, time.Since(start))
+			}()
+
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
+
+// routePattern returns the pattern chi matched once routing is done
+func routePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return unmatchedRoute
+	}
+
+	pattern := rctx.RoutePattern()
+	if pattern == "" {
+		return unmatchedRoute
+	}
+	return pattern
+}
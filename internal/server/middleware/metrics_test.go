@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMetrics_RecordsRoutePattern(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		wantRoute   string
+		wantStatus  int
+		handlerCode int
+	}{
+		{name: "matched route", path: "/v1/accounts/42", wantRoute: "/v1/accounts/{accountId}", wantStatus: http.StatusOK},
+		{name: "handler status", path: "/v1/accounts/7", wantRoute: "/v1/accounts/{accountId}", wantStatus: http.StatusNotFound, handlerCode: http.StatusNotFound},
+		{name: "unmatched path", path: "/unknown/123", wantRoute: unmatchedRoute, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpMetrics := mocks.NewMockHTTPMetrics(t)
+			httpMetrics.EXPECT().
+				RequestServed(http.MethodGet, tt.wantRoute, tt.wantStatus, mock.AnythingOfType("time.Duration")).
+				Once()
+
+			router := chi.NewRouter()
+			router.Use(Metrics(httpMetrics))
+			router.Route("/v1/accounts", func(r chi.Router) {
+				r.Get("/{accountId}", func(w http.ResponseWriter, r *http.Request) {
+					if tt.handlerCode != 0 {
+						w.WriteHeader(tt.handlerCode)
+					}
+				})
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestMetrics_Disabled(t *testing.T) {
+	handler := Metrics(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestMetrics_ObservesDuration(t *testing.T) {
+	httpMetrics := mocks.NewMockHTTPMetrics(t)
+	httpMetrics.EXPECT().
+		RequestServed(http.MethodPost, "/slow", http.StatusCreated, mock.MatchedBy(func(d time.Duration) bool {
+			return d >= 10*time.Millisecond
+		})).
+		Once()
+
+	router := chi.NewRouter()
+	router.Use(Metrics(httpMetrics))
+	router.Post("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/slow", nil))
+}
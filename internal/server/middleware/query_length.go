@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// DefaultMaxQueryLength is the default limit for the raw query string (8KB)
+const DefaultMaxQueryLength = 8 * 1024
+
+// MaxQueryLength rejects requests whose raw query string is longer than maxLength bytes
+// A non-positive maxLength falls back to DefaultMaxQueryLength
+func MaxQueryLength(maxLength int) func(http.Handler) http.Handler {
+	if maxLength <= 0 {
+		maxLength = DefaultMaxQueryLength
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(r.URL.RawQuery) > maxLength {
+				writeJSONError(w, http.StatusRequestURITooLong, fmt.Sprintf("query string must not be longer than %d bytes", maxLength))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
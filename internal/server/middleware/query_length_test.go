@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxQueryLength(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxLength      int
+		query          string
+		expectedStatus int
+		wantCalled     bool
+	}{
+		{
+			name:           "short query passes",
+			maxLength:      64,
+			query:          "limit=10&offset=0",
+			expectedStatus: http.StatusOK,
+			wantCalled:     true,
+		},
+		{
+			name:           "query at the limit passes",
+			maxLength:      10,
+			query:          strings.Repeat("a", 10),
+			expectedStatus: http.StatusOK,
+			wantCalled:     true,
+		},
+		{
+			name:           "oversized query is rejected",
+			maxLength:      64,
+			query:          "fields=" + strings.Repeat("a,", 100),
+			expectedStatus: http.StatusRequestURITooLong,
+		},
+		{
+			name:           "default limit applies when unset",
+			maxLength:      0,
+			query:          "account_ids=" + strings.Repeat("1,", DefaultMaxQueryLength),
+			expectedStatus: http.StatusRequestURITooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := MaxQueryLength(tt.maxLength)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/accounts/1/transactions?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.wantCalled, called)
+			if !tt.wantCalled {
+				assert.Contains(t, rec.Body.String(), "query string must not be longer than")
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitIdleTimeout is how long a client IP may stay silent before its limiter is dropped
+const rateLimitIdleTimeout = 3 * time.Minute
+
+// clientLimiter is the token bucket of one client IP
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// rateLimiter holds one token bucket per client IP, sweeping idle ones at most once per idle timeout
+type rateLimiter struct {
+	rps   rate.Limit
+	burst int
+	now   func() time.Time
+
+	mu          sync.Mutex
+	clients     map[string]*clientLimiter
+	lastCleanup time.Time
+}
+
+// RateLimitMiddleware allows each client IP rps requests per second with bursts of up to burst requests;
+// a request over the limit gets 429 with a Retry-After header
+// The client IP is the request's RemoteAddr, so mount it after chi's RealIP
+// A non-positive rps disables the limit, and a non-positive burst falls back to rps
+func RateLimitMiddleware(rps int, burst int) func(http.Handler) http.Handler {
+	return newRateLimiter(rps, burst, time.Now).middleware
+}
+
+func newRateLimiter(rps int, burst int, now func() time.Time) *rateLimiter {
+	if burst <= 0 {
+		burst = rps
+	}
+
+	return &rateLimiter{
+		rps:         rate.Limit(rps),
+		burst:       burst,
+		now:         now,
+		clients:     make(map[string]*clientLimiter),
+		lastCleanup: now(),
+	}
+}
+
+func (l *rateLimiter) middleware(next http.Handler) http.Handler {
+	if l.rps <= 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if retryAfter, ok := l.allow(clientIP(r)); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			writeJSONError(w, http.StatusTooManyRequests, "rate limit exceeded, retry later")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allow takes a token from the IP's bucket, or reports in whole seconds when the next one is available
+func (l *rateLimiter) allow(ip string) (int, bool) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.cleanup(now)
+
+	client, ok := l.clients[ip]
+	if !ok {
+		client = &clientLimiter{limiter: rate.NewLimiter(l.rps, l.burst)}
+		l.clients[ip] = client
+	}
+	client.lastSeen = now
+
+	reservation := client.limiter.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if delay == 0 {
+		return 0, true
+	}
+
+	// Give the token back, the request is turned away rather than delayed
+	reservation.CancelAt(now)
+	return int(math.Ceil(delay.Seconds())), false
+}
+
+// cleanup drops the limiters of IPs idle for longer than rateLimitIdleTimeout; the caller holds mu
+func (l *rateLimiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < rateLimitIdleTimeout {
+		return
+	}
+
+	for ip, client := range l.clients {
+		if now.Sub(client.lastSeen) >= rateLimitIdleTimeout {
+			delete(l.clients, ip)
+		}
+	}
+	l.lastCleanup = now
+}
+
+// clientIP returns the host part of RemoteAddr, which RealIP may already have replaced by a bare IP
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
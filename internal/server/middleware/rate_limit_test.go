@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rateLimitRequest(remoteAddr string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/v1/accounts/1", nil)
+	req.RemoteAddr = remoteAddr
+	return req
+}
+
+func TestRateLimitMiddleware_RejectsRequestsOverTheBurst(t *testing.T) {
+	const burst = 5
+
+	handler := RateLimitMiddleware(1, burst)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for i := 0; i < burst; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, rateLimitRequest("203.0.113.7:40000"))
+		assert.Equal(t, http.StatusOK, w.Code, "request %d is within the burst", i+1)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, rateLimitRequest("203.0.113.7:40001"))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"error":"Too Many Requests","message":"rate limit exceeded, retry later"}`, w.Body.String())
+
+	// Another client has its own bucket
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, rateLimitRequest("198.51.100.1:40000"))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRateLimitMiddleware_RefillsOverTime(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(2, 1, func() time.Time { return now })
+
+	_, ok := limiter.allow("203.0.113.7")
+	assert.True(t, ok)
+
+	retryAfter, ok := limiter.allow("203.0.113.7")
+	assert.False(t, ok)
+	assert.Equal(t, 1, retryAfter, "a partial second rounds up")
+
+	now = now.Add(500 * time.Millisecond)
+	_, ok = limiter.allow("203.0.113.7")
+	assert.True(t, ok)
+}
+
+func TestRateLimitMiddleware_KeysByRealIP(t *testing.T) {
+	handler := RateLimitMiddleware(1, 1)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// RealIP leaves a bare IP without a port
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, rateLimitRequest("203.0.113.7"))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, rateLimitRequest("203.0.113.7"))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
+
+func TestRateLimitMiddleware_Disabled(t *testing.T) {
+	handler := RateLimitMiddleware(0, 0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for i := 0; i < 100; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, rateLimitRequest("203.0.113.7:40000"))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
+
+func TestRateLimitMiddleware_CleansUpIdleClients(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(1, 1, func() time.Time { return now })
+
+	limiter.allow("203.0.113.7")
+	now = now.Add(time.Minute)
+	limiter.allow("198.51.100.1")
+	assert.Len(t, limiter.clients, 2)
+
+	// Only the client silent for the whole idle timeout is dropped
+	now = now.Add(rateLimitIdleTimeout - time.Minute)
+	limiter.allow("192.0.2.10")
+	assert.Len(t, limiter.clients, 2)
+	assert.NotContains(t, limiter.clients, "203.0.113.7")
+	assert.Contains(t, limiter.clients, "198.51.100.1")
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"runtime/debug"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// Recoverer turns a panic into a JSON 500 response
+// The panic and its stack trace are logged with the request ID; the client only sees a generic message
+func Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rvr := recover()
+			if rvr == nil {
+				return
+			}
+
+			// Let the server abort the response as it would without this middleware
+			if rvr == http.ErrAbortHandler {
+				panic(rvr)
+			}
+
+			requestID := chiMiddleware.GetReqID(r.Context())
+			log.Printf("panic serving %s %s (request_id=%s): %v\n%s", r.Method, r.URL.Path, requestID, rvr, debug.Stack())
+
+			// Upgraded connections have no response to write to
+			if r.Header.Get("Connection") == "Upgrade" {
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{
+				"error":      http.StatusText(http.StatusInternalServerError),
+				"message":    "an unexpected error occurred",
+				"request_id": requestID,
+			})
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverer(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	panicking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("database handle is nil")
+	})
+	handler := chiMiddleware.RequestID(Recoverer(panicking))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/accounts/1", nil)
+	req.Header.Set(chiMiddleware.RequestIDHeader, "req-123")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "Internal Server Error", body["error"])
+	assert.Equal(t, "req-123", body["request_id"])
+	assert.NotEmpty(t, body["message"])
+
+	assert.NotContains(t, w.Body.String(), "database handle is nil", "panic value must not leak")
+	assert.NotContains(t, w.Body.String(), "goroutine", "stack trace must not leak")
+	assert.NotContains(t, w.Body.String(), ".go:", "stack trace must not leak")
+
+	assert.Contains(t, logs.String(), "database handle is nil")
+	assert.Contains(t, logs.String(), "request_id=req-123")
+	assert.Contains(t, logs.String(), "goroutine", "stack trace is logged internally")
+}
+
+func TestRecoverer_PassesThroughWithoutPanic(t *testing.T) {
+	handler := Recoverer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestRecoverer_RepanicsOnAbortHandler(t *testing.T) {
+	handler := Recoverer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// RequestLogger emits one structured record per request with its request ID, method, path, status,
+// bytes written and latency; it must run after chi's RequestID so the ID is available
+// The status is read from a wrapping writer, which keeps the Flusher the streaming exports rely on
+func RequestLogger(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			defer func() {
+				// A handler that never writes a header answers 200
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+
+				logger.LogAttrs(r.Context(), slog.LevelInfo, "request",
+					slog.String("request_id", chiMiddleware.GetReqID(r.Context())),
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.Int("status", status),
+					slog.Int("bytes", ww.BytesWritten()),
+					slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+				)
+			}()
+
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
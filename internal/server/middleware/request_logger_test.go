@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLogger(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus float64
+		wantBytes  float64
+	}{
+		{
+			name: "explicit status",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"account_id":1}`))
+			},
+			wantStatus: http.StatusCreated,
+			wantBytes:  16,
+		},
+		{
+			name:       "no header written",
+			handler:    func(w http.ResponseWriter, r *http.Request) {},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&logs, nil))
+			handler := chiMiddleware.RequestID(RequestLogger(logger)(tt.handler))
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/accounts?verbose=1", nil)
+			req.Header.Set(chiMiddleware.RequestIDHeader, "req-123")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			var record map[string]any
+			require.NoError(t, json.Unmarshal(logs.Bytes(), &record), "One JSON record per request")
+			assert.Equal(t, "INFO", record["level"])
+			assert.Equal(t, "request", record["msg"])
+			assert.Equal(t, "req-123", record["request_id"])
+			assert.Equal(t, "POST", record["method"])
+			assert.Equal(t, "/v1/accounts", record["path"], "The query string is left out")
+			assert.Equal(t, tt.wantStatus, record["status"])
+			assert.Equal(t, tt.wantBytes, record["bytes"])
+			assert.Contains(t, record, "latency_ms")
+			assert.Contains(t, record, "time")
+		})
+	}
+}
+
+func TestRequestLogger_KeepsFlusher(t *testing.T) {
+	flushed := false
+	handler := RequestLogger(slog.New(slog.DiscardHandler))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+			flushed = true
+		}
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/accounts/1/transactions.jsonl", nil))
+
+	assert.True(t, flushed, "Streaming handlers can still flush")
+	assert.True(t, w.Flushed)
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+)
+
+type maxResponseBytesKey struct{}
+
+// MaxResponseBytes caps the JSON bodies the handlers write for the requests it wraps, so a misconfigured
+// limit on a listing cannot produce an unbounded response; a larger body is replaced by a 500
+// A non-positive limit disables the guard
+func MaxResponseBytes(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limit <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), maxResponseBytesKey{}, limit)))
+		})
+	}
+}
+
+// GetMaxResponseBytes returns the cap set by MaxResponseBytes for the request, or 0 when there is none
+func GetMaxResponseBytes(ctx context.Context) int64 {
+	limit, _ := ctx.Value(maxResponseBytesKey{}).(int64)
+	return limit
+}
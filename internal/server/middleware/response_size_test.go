@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxResponseBytes(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int64
+		want  int64
+	}{
+		{name: "limit is passed to the handler", limit: 1024, want: 1024},
+		{name: "zero disables the guard", limit: 0, want: 0},
+		{name: "negative disables the guard", limit: -1, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got int64
+			handler := MaxResponseBytes(tt.limit)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = GetMaxResponseBytes(r.Context())
+			}))
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/accounts", nil))
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
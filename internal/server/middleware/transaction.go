@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+)
+
+// Transactional runs each request in a database transaction bound to its context, committed when the handler
+// answers 2xx and rolled back on any other status or a panic, which is then passed on to Recoverer
+// The response is held back until the commit, so a failed commit is still reported as a 500
+// Only wrap routes whose repositories query through the context's transaction: the SQLite pool has a single
+// connection, so a query made outside of it would wait for the transaction forever
+// A nil provider disables it
+func Transactional(provider ports.TxProvider, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if provider == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, tx, err := provider.Begin(r.Context())
+			if err != nil {
+				logger.Error("Failed to begin database transaction", "method", r.Method, "path", r.URL.Path, "error", err)
+				if errors.Is(err, domain.ErrServiceUnavailable) {
+					w.Header().Set("Retry-After", "1")
+					writeJSONError(w, http.StatusServiceUnavailable, domain.ErrServiceUnavailable.Error())
+					return
+				}
+				writeJSONError(w, http.StatusInternalServerError, "failed to begin database transaction")
+				return
+			}
+
+			finished := false
+			defer func() {
+				if !finished {
+					tx.Rollback()
+				}
+			}()
+
+			buffered := &bufferedResponse{ResponseWriter: w}
+			next.ServeHTTP(buffered, r.WithContext(ctx))
+
+			finished = true
+			// A handler that wrote nothing answers 200
+			if buffered.status == 0 {
+				buffered.status = http.StatusOK
+			}
+			if buffered.status < 200 || buffered.status >= 300 {
+				if err := tx.Rollback(); err != nil {
+					logger.Error("Failed to roll back database transaction", "method", r.Method, "path", r.URL.Path, "error", err)
+				}
+				buffered.flush()
+				return
+			}
+
+			if err := tx.Commit(); err != nil {
+				logger.Error("Failed to commit database transaction", "method", r.Method, "path", r.URL.Path, "error", err)
+				writeJSONError(w, http.StatusInternalServerError, "failed to commit database transaction")
+				return
+			}
+			buffered.flush()
+		})
+	}
+}
+
+// bufferedResponse holds the status and body of a response until flush; headers go straight to the
+// underlying writer, which only sends them with the status
+type bufferedResponse struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) WriteHeader(code int) {
+	if b.status == 0 {
+		b.status = code
+	}
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+	return b.body.Write(p)
+}
+
+// flush sends the held response
+func (b *bufferedResponse) flush() {
+	b.ResponseWriter.WriteHeader(b.status)
+	b.ResponseWriter.Write(b.body.Bytes())
+}
@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type txContextKey struct{}
+
+func TestTransactional(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        http.HandlerFunc
+		setupTx        func(*mocks.MockTx)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "2xx response is committed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"ok":true}`))
+			},
+			setupTx: func(tx *mocks.MockTx) {
+				tx.EXPECT().Commit().Return(nil).Once()
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"ok":true}`,
+		},
+		{
+			name:    "handler that writes nothing is committed",
+			handler: func(w http.ResponseWriter, r *http.Request) {},
+			setupTx: func(tx *mocks.MockTx) {
+				tx.EXPECT().Commit().Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "error response is rolled back",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(`{"error":"rejected"}`))
+			},
+			setupTx: func(tx *mocks.MockTx) {
+				tx.EXPECT().Rollback().Return(nil).Once()
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"rejected"}`,
+		},
+		{
+			name: "failed commit is reported instead of the response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"ok":true}`))
+			},
+			setupTx: func(tx *mocks.MockTx) {
+				tx.EXPECT().Commit().Return(errors.New("disk full")).Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "failed to commit database transaction",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := mocks.NewMockTxProvider(t)
+			tx := mocks.NewMockTx(t)
+			tt.setupTx(tx)
+			provider.EXPECT().
+				Begin(mock.Anything).
+				RunAndReturn(func(ctx context.Context) (context.Context, ports.Tx, error) {
+					return context.WithValue(ctx, txContextKey{}, tx), tx, nil
+				}).
+				Once()
+
+			handler := Transactional(provider, slog.New(slog.DiscardHandler))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tx, r.Context().Value(txContextKey{}), "The handler runs with the transaction's context")
+				tt.handler(w, r)
+			}))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/transactions", nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestTransactional_PanicRollsBack(t *testing.T) {
+	provider := mocks.NewMockTxProvider(t)
+	tx := mocks.NewMockTx(t)
+	provider.EXPECT().Begin(mock.Anything).Return(context.Background(), tx, nil).Once()
+	tx.EXPECT().Rollback().Return(nil).Once()
+
+	handler := Transactional(provider, slog.New(slog.DiscardHandler))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	assert.PanicsWithValue(t, "boom", func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/transactions", nil))
+	})
+}
+
+func TestTransactional_BeginFailure(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "database busy", err: domain.ErrServiceUnavailable, expectedStatus: http.StatusServiceUnavailable},
+		{name: "other error", err: errors.New("database is closed"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := mocks.NewMockTxProvider(t)
+			provider.EXPECT().Begin(mock.Anything).Return(nil, nil, tt.err).Once()
+
+			handler := Transactional(provider, slog.New(slog.DiscardHandler))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Fatal("handler must not run without a transaction")
+			}))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/transactions", nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestTransactional_NilProviderIsDisabled(t *testing.T) {
+	handler := Transactional(nil, slog.New(slog.DiscardHandler))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/v1/accounts/1", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
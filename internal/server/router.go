@@ -1,30 +1,107 @@
 package server
 
 import (
+	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/ports"
 	"github.com/larissamartinsss/simple-banking-api/internal/server/handlers"
 	customMiddleware "github.com/larissamartinsss/simple-banking-api/internal/server/middleware"
 )
 
+// Config holds server configuration
+type Config struct {
+	// AdminToken is required in the X-Admin-Token header by admin endpoints (empty disables them)
+	AdminToken string
+
+	// MaxQueryLength caps the raw query string in bytes (0 uses the middleware default)
+	MaxQueryLength int
+
+	// MaxResponseBytes caps the JSON response bodies; a larger one is replaced by a 500 (0 disables)
+	MaxResponseBytes int64
+
+	// MaxConcurrentBatches caps the batch requests (imports) served at once (0 uses the middleware default)
+	MaxConcurrentBatches int
+
+	// RateLimitRPS allows each client IP this many requests per second; more get 429 (0 disables)
+	RateLimitRPS int
+
+	// RateLimitBurst is the number of requests a client IP may send at once (0 uses RateLimitRPS)
+	RateLimitBurst int
+
+	// IdempotencyStore persists the responses of requests made with an Idempotency-Key
+	IdempotencyStore ports.IdempotencyStore
+
+	// IdempotencyTTL replays successful responses for an idempotency key during this period (0 keeps them forever)
+	IdempotencyTTL time.Duration
+
+	// IdempotencyFailureGracePeriod replays server errors for an idempotency key during this period (0 disables)
+	IdempotencyFailureGracePeriod time.Duration
+
+	// IdempotencyReuseWindow rejects an idempotency key first used longer ago than this (0 disables)
+	IdempotencyReuseWindow time.Duration
+
+	// IdempotencyMetrics counts deduplicated and processed idempotent requests (nil disables)
+	IdempotencyMetrics ports.IdempotencyMetrics
+
+	// TxProvider runs the check-then-write routes (account close, reversals) in one database transaction (nil disables)
+	TxProvider ports.TxProvider
+
+	// HTTPMetrics counts served requests and their latency by route pattern (nil disables)
+	HTTPMetrics ports.HTTPMetrics
+
+	// Logger receives one structured record per request (nil uses slog.Default)
+	Logger *slog.Logger
+}
+
+// Handlers groups the HTTP handlers mounted by the server
+type Handlers struct {
+	CreateAccount           *handlers.CreateAccountHandler
+	GetAccount              *handlers.GetAccountHandler
+	ListAccounts            *handlers.ListAccountsHandler
+	CloseAccount            *handlers.CloseAccountHandler
+	ForceCloseAccount       *handlers.CloseAccountHandler
+	CreateTransaction       *handlers.CreateTransactionHandler
+	GetTransactions         *handlers.GetTransactionsHandler
+	ExportTransactionsJSONL *handlers.ExportTransactionsJSONLHandler
+	ExportTransactionsOFX   *handlers.ExportTransactionsOFXHandler
+	ExportTransactionsCSV   *handlers.ExportTransactionsCSVHandler
+	GetRecentTransactions   *handlers.GetRecentTransactionsHandler
+	GetStatement            *handlers.GetStatementHandler
+	ListOperationTypes      *handlers.ListOperationTypesHandler
+	OperationTypeDirections *handlers.OperationTypeDirectionsHandler
+	RecomputeBalances       *handlers.RecomputeBalancesHandler
+	CanDebit                *handlers.CanDebitHandler
+	GetAccountBalance       *handlers.GetAccountBalanceHandler
+	GetDailyTotals          *handlers.GetDailyTotalsHandler
+	GetActivityRange        *handlers.GetActivityRangeHandler
+	GetBalanceTrend         *handlers.GetBalanceTrendHandler
+	ListTransactions        *handlers.ListTransactionsHandler
+	CountAccounts           *handlers.CountAccountsHandler
+	GetIdempotencyKey       *handlers.GetIdempotencyKeyHandler
+	ReverseTransaction      *handlers.ReverseTransactionHandler
+	ReverseTransactionByID  *handlers.ReverseTransactionByIDHandler
+	Readiness               *handlers.ReadinessHandler
+	ImportTransactions      *handlers.ImportTransactionsHandler
+
+	// Metrics serves the Prometheus metrics (nil disables /metrics)
+	Metrics http.Handler
+}
+
 type Server struct {
-	router                   *chi.Mux
-	createAccountHandler     *handlers.CreateAccountHandler
-	getAccountHandler        *handlers.GetAccountHandler
-	createTransactionHandler *handlers.CreateTransactionHandler
-	getTransactionHandler    *handlers.GetTransactionsHandler
+	router   *chi.Mux
+	config   Config
+	handlers Handlers
 }
 
-func NewServer(createAccountHandler *handlers.CreateAccountHandler, getAccountHandler *handlers.GetAccountHandler, createTransactionHandler *handlers.CreateTransactionHandler, getTransactionHandler *handlers.GetTransactionsHandler) *Server {
+func NewServer(config Config, handlers Handlers) *Server {
 	s := &Server{
-		router:                   chi.NewRouter(),
-		createAccountHandler:     createAccountHandler,
-		getAccountHandler:        getAccountHandler,
-		createTransactionHandler: createTransactionHandler,
-		getTransactionHandler:    getTransactionHandler,
+		router:   chi.NewRouter(),
+		config:   config,
+		handlers: handlers,
 	}
 
 	s.setupMiddleware()
@@ -37,11 +114,23 @@ func NewServer(createAccountHandler *handlers.CreateAccountHandler, getAccountHa
 func (s *Server) setupMiddleware() {
 	s.router.Use(middleware.RequestID)
 	s.router.Use(middleware.RealIP)
-	s.router.Use(middleware.Logger)
-	s.router.Use(middleware.Recoverer)
+	s.router.Use(customMiddleware.RequestLogger(s.logger()))
+	s.router.Use(customMiddleware.Metrics(s.config.HTTPMetrics))
+	s.router.Use(customMiddleware.Recoverer)
+	s.router.Use(customMiddleware.RateLimitMiddleware(s.config.RateLimitRPS, s.config.RateLimitBurst))
+	s.router.Use(customMiddleware.MaxQueryLength(s.config.MaxQueryLength))
+	s.router.Use(customMiddleware.MaxResponseBytes(s.config.MaxResponseBytes))
+	s.router.Use(customMiddleware.APIVersion())
 	s.router.Use(middleware.Timeout(60 * time.Second))
 	s.router.Use(middleware.SetHeader("Content-Type", "application/json"))
-	s.router.Use(customMiddleware.IdempotencyMiddleware())
+	s.router.Use(customMiddleware.IdempotencyMiddleware(
+		s.config.IdempotencyStore,
+		customMiddleware.WithTTL(s.config.IdempotencyTTL),
+		customMiddleware.WithFailureGracePeriod(s.config.IdempotencyFailureGracePeriod),
+		customMiddleware.WithReuseWindow(s.config.IdempotencyReuseWindow),
+		customMiddleware.WithIdempotencyMetrics(s.config.IdempotencyMetrics),
+		customMiddleware.WithIdempotencyLogger(s.logger()),
+	))
 }
 
 // setupRoutes configures all RESTful routes
@@ -50,20 +139,66 @@ func (s *Server) setupRoutes() {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{"status":"healthy"}`))
 	})
+	s.router.Get("/health/ready", s.handlers.Readiness.Handle)
+
+	if s.handlers.Metrics != nil {
+		s.router.Handle("/metrics", s.handlers.Metrics)
+	}
+
+	// Shared by every batch route so together they hold at most MaxConcurrentBatches slots
+	batchLimit := customMiddleware.MaxConcurrent(s.config.MaxConcurrentBatches)
+	// Inside the idempotency middleware, so a stored response is only recorded once it is committed
+	transactional := customMiddleware.Transactional(s.config.TxProvider, s.logger())
 
 	s.router.Route("/v1", func(r chi.Router) {
 		r.Route("/accounts", func(r chi.Router) {
-			r.Post("/", s.createAccountHandler.Handle)
-			r.Get("/{accountId}", s.getAccountHandler.Handle)
-			r.Get("/{accountId}/transactions", s.getTransactionHandler.Handle)
+			r.Post("/", s.handlers.CreateAccount.Handle)
+			r.Get("/", s.handlers.ListAccounts.Handle)
+			r.Get("/{accountId}", s.handlers.GetAccount.Handle)
+			r.With(transactional).Delete("/{accountId}", s.handlers.CloseAccount.Handle)
+			r.Get("/{accountId}/transactions", s.handlers.GetTransactions.Handle)
+			r.Get("/{accountId}/transactions.jsonl", s.handlers.ExportTransactionsJSONL.Handle)
+			r.Get("/{accountId}/transactions.ofx", s.handlers.ExportTransactionsOFX.Handle)
+			r.Get("/{accountId}/transactions/export", s.handlers.ExportTransactionsCSV.Handle)
+			r.Get("/{accountId}/statement", s.handlers.GetStatement.Handle)
+			r.Get("/{accountId}/can-debit", s.handlers.CanDebit.Handle)
+			r.Get("/{accountId}/balance", s.handlers.GetAccountBalance.Handle)
+			r.Get("/{accountId}/daily", s.handlers.GetDailyTotals.Handle)
+			r.Get("/{accountId}/activity-range", s.handlers.GetActivityRange.Handle)
+			r.Get("/{accountId}/trend", s.handlers.GetBalanceTrend.Handle)
 		})
 
 		r.Route("/transactions", func(r chi.Router) {
-			r.Post("/", s.createTransactionHandler.Handle)
+			r.Post("/", s.handlers.CreateTransaction.Handle)
+			r.With(transactional).Post("/reverse-by-key", s.handlers.ReverseTransaction.Handle)
+			r.With(transactional).Post("/{transactionId}/reversal", s.handlers.ReverseTransactionByID.Handle)
+			r.With(batchLimit).Post("/import", s.handlers.ImportTransactions.Handle)
+		})
+
+		r.Get("/operation-types", s.handlers.ListOperationTypes.Handle)
+		r.Get("/operation-types/directions", s.handlers.OperationTypeDirections.Handle)
+
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(customMiddleware.AdminOnly(s.config.AdminToken))
+
+			r.Get("/accounts/count", s.handlers.CountAccounts.Handle)
+			r.With(transactional).Delete("/accounts/{accountId}", s.handlers.ForceCloseAccount.Handle)
+			r.Get("/idempotency/{key}", s.handlers.GetIdempotencyKey.Handle)
+			r.Get("/transactions", s.handlers.ListTransactions.Handle)
+			r.Get("/transactions/recent", s.handlers.GetRecentTransactions.Handle)
+			r.Post("/recompute-balances", s.handlers.RecomputeBalances.Handle)
 		})
 	})
 }
 
+// logger returns the configured request logger, falling back to the default one
+func (s *Server) logger() *slog.Logger {
+	if s.config.Logger != nil {
+		return s.config.Logger
+	}
+	return slog.Default()
+}
+
 func (s *Server) GetRouter() http.Handler {
 	return s.router
 }
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/larissamartinsss/simple-banking-api/internal/adapters/metrics"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/domain"
+	portmocks "github.com/larissamartinsss/simple-banking-api/internal/core/ports/mocks"
+	"github.com/larissamartinsss/simple-banking-api/internal/core/services/processors/mocks"
+	"github.com/larissamartinsss/simple-banking-api/internal/server/handlers"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_MountsAccountTransactions(t *testing.T) {
+	mockProc := mocks.NewMockGetTransactionsProcessorInterface(t)
+	mockProc.EXPECT().
+		Process(mock.Anything, domain.GetTransactionsRequest{AccountID: 1, Limit: domain.DefaultPageSize}).
+		Return(&domain.GetTransactionsResponse{
+			Transactions: []*domain.AccountTransaction{},
+			Pagination:   domain.NewPaginationMetadata(0, domain.DefaultPageSize, 0),
+		}, nil).
+		Once()
+
+	router := NewServer(Config{}, Handlers{
+		GetTransactions: handlers.NewGetTransactionsHandler(mockProc),
+	}).GetRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/accounts/1/transactions", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"transactions":[]`)
+
+	// Routes are mounted under /v1 only, matching the startup log and the README
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/accounts/1/transactions", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestServer_ThrottlesBatchesButNotSingleCreates(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	importProc := mocks.NewMockImportTransactionsProcessorInterface(t)
+	importProc.EXPECT().Process(mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, func() (*domain.ImportLine, error)) (*domain.ImportTransactionsResponse, error) {
+			close(started)
+			<-release
+			return domain.NewImportTransactionsResponse(), nil
+		}).
+		Once()
+
+	createProc := mocks.NewMockCreateTransactionProcessorInterface(t)
+	createProc.EXPECT().Process(mock.Anything, mock.Anything).
+		Return(&domain.CreateTransactionResponse{TransactionID: 1}, nil).
+		Once()
+
+	idempotencyStore := portmocks.NewMockIdempotencyStore(t)
+	idempotencyStore.EXPECT().Get(mock.Anything, "single-create").Return(nil, nil).Once()
+	idempotencyStore.EXPECT().SetProcessing(mock.Anything, "single-create").Return(true, nil).Once()
+	idempotencyStore.EXPECT().
+		SetResult(mock.Anything, mock.MatchedBy(func(record *domain.IdempotencyRecord) bool {
+			return record.Key == "single-create" && record.ResponseStatus == http.StatusCreated && record.RequestHash != ""
+		})).
+		Return(nil).
+		Once()
+
+	router := NewServer(Config{MaxConcurrentBatches: 1, IdempotencyStore: idempotencyStore}, Handlers{
+		ImportTransactions: handlers.NewImportTransactionsHandler(importProc),
+		CreateTransaction:  handlers.NewCreateTransactionHandler(createProc),
+	}).GetRouter()
+
+	importRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/v1/transactions/import", strings.NewReader(""))
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		return req
+	}
+
+	firstImport := make(chan int, 1)
+	go func() {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, importRequest())
+		firstImport <- w.Code
+	}()
+	<-started
+
+	// A second batch is turned away while the first holds the only slot
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, importRequest())
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	// Interactive creates are not limited by batches
+	req := httptest.NewRequest(http.MethodPost, "/v1/transactions", strings.NewReader(`{"account_id": 1, "operation_type_id": 1, "amount": 10}`))
+	req.Header.Set("Idempotency-Key", "single-create")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	close(release)
+	assert.Equal(t, http.StatusOK, <-firstImport)
+}
+
+func TestServer_RateLimitsPerForwardedClientIP(t *testing.T) {
+	const burst = 3
+
+	router := NewServer(Config{RateLimitRPS: 1, RateLimitBurst: burst}, Handlers{}).GetRouter()
+
+	healthRequest := func(clientIP string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.Header.Set("X-Forwarded-For", clientIP)
+		return req
+	}
+
+	for i := 0; i < burst; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, healthRequest("203.0.113.7"))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, healthRequest("203.0.113.7"))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	// Clients behind the same proxy are limited separately
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, healthRequest("198.51.100.1"))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestServer_ExposesRequestMetricsByRoutePattern(t *testing.T) {
+	mockProc := mocks.NewMockGetAccountProcessorInterface(t)
+	mockProc.EXPECT().
+		Process(mock.Anything, domain.GetAccountRequest{AccountID: 42}).
+		Return(&domain.GetAccountResponse{Account: &domain.Account{ID: 42, DocumentNumber: "12345678909"}}, nil).
+		Once()
+
+	registry := prometheus.NewRegistry()
+	router := NewServer(Config{HTTPMetrics: metrics.NewHTTPMetrics(registry)}, Handlers{
+		GetAccount: handlers.NewGetAccountHandler(mockProc),
+		Metrics:    promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
+	}).GetRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/accounts/42", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	scrape := w.Body.String()
+	assert.Contains(t, scrape, `http_requests_total{method="GET",path="/v1/accounts/{accountId}",status="200"} 1`)
+	assert.Contains(t, scrape, `http_request_duration_seconds_count{method="GET",path="/v1/accounts/{accountId}"} 1`)
+	assert.NotContains(t, scrape, `path="/v1/accounts/42"`, "Raw paths would explode the label cardinality")
+}